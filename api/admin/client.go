@@ -6,9 +6,13 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/api/ratelimit"
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/rpc"
 )
@@ -29,6 +33,24 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (bool, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	ListWebhooks(ctx context.Context, options ...rpc.Option) ([]indexer.WebhookStatus, error)
+	PauseWebhook(ctx context.Context, name string, options ...rpc.Option) (bool, error)
+	ResumeWebhook(ctx context.Context, name string, options ...rpc.Option) (bool, error)
+	ReplayWebhook(ctx context.Context, name string, startIndex uint64, options ...rpc.Option) (bool, error)
+	Reindex(ctx context.Context, chain string, options ...rpc.Option) (bool, error)
+	GetReindexStatus(ctx context.Context, options ...rpc.Option) ([]indexer.ReindexStatus, error)
+	ExportIndex(ctx context.Context, args *ExportIndexArgs, options ...rpc.Option) (bool, error)
+	GetExportStatus(ctx context.Context, options ...rpc.Option) ([]indexer.ExportStatus, error)
+	ReloadAPIRateLimits(ctx context.Context, options ...rpc.Option) (bool, error)
+	GetAPIRateLimits(ctx context.Context, options ...rpc.Option) (ratelimit.Config, error)
+	BanNodeID(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) (bool, error)
+	UnbanNodeID(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (bool, error)
+	BanIPs(ctx context.Context, ips string, duration time.Duration, options ...rpc.Option) (bool, error)
+	UnbanIPs(ctx context.Context, ips string, options ...rpc.Option) (bool, error)
+	DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (bool, error)
+	PinPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) (bool, error)
+	UnpinPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (bool, error)
+	GetPeerFilter(ctx context.Context, options ...rpc.Option) (*GetPeerFilterReply, error)
 }
 
 // Client implementation for the Axia Platform Info API Endpoint
@@ -161,3 +183,148 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) ListWebhooks(ctx context.Context, options ...rpc.Option) ([]indexer.WebhookStatus, error) {
+	res := &ListWebhooksReply{}
+	err := c.requester.SendRequest(ctx, "listWebhooks", struct{}{}, res, options...)
+	return res.Webhooks, err
+}
+
+func (c *client) PauseWebhook(ctx context.Context, name string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "pauseWebhook", &WebhookArgs{
+		Name: name,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) ResumeWebhook(ctx context.Context, name string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "resumeWebhook", &WebhookArgs{
+		Name: name,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) ReplayWebhook(ctx context.Context, name string, startIndex uint64, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "replayWebhook", &ReplayWebhookArgs{
+		Name:       name,
+		StartIndex: json.Uint64(startIndex),
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) Reindex(ctx context.Context, chain string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "reindex", &ReindexArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetReindexStatus(ctx context.Context, options ...rpc.Option) ([]indexer.ReindexStatus, error) {
+	res := &GetReindexStatusReply{}
+	err := c.requester.SendRequest(ctx, "getReindexStatus", struct{}{}, res, options...)
+	return res.Reindexes, err
+}
+
+func (c *client) ExportIndex(ctx context.Context, args *ExportIndexArgs, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "exportIndex", args, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetExportStatus(ctx context.Context, options ...rpc.Option) ([]indexer.ExportStatus, error) {
+	res := &GetExportStatusReply{}
+	err := c.requester.SendRequest(ctx, "getExportStatus", struct{}{}, res, options...)
+	return res.Exports, err
+}
+
+func (c *client) ReloadAPIRateLimits(ctx context.Context, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "reloadAPIRateLimits", struct{}{}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetAPIRateLimits(ctx context.Context, options ...rpc.Option) (ratelimit.Config, error) {
+	res := &GetAPIRateLimitsReply{}
+	err := c.requester.SendRequest(ctx, "getAPIRateLimits", struct{}{}, res, options...)
+	return res.RateLimits, err
+}
+
+// BanNodeID bans [nodeID] for [duration], or until it's unbanned if
+// [duration] is 0
+func (c *client) BanNodeID(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "banNodeID", &BanNodeIDArgs{
+		NodeID:   nodeID,
+		Duration: formatBanDuration(duration),
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) UnbanNodeID(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "unbanNodeID", &NodeIDArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	return res.Success, err
+}
+
+// BanIPs bans the IP or CIDR [ips] for [duration], or until it's unbanned if
+// [duration] is 0
+func (c *client) BanIPs(ctx context.Context, ips string, duration time.Duration, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "banIPs", &BanIPsArgs{
+		IPs:      ips,
+		Duration: formatBanDuration(duration),
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) UnbanIPs(ctx context.Context, ips string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "unbanIPs", &UnbanIPsArgs{
+		IPs: ips,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "disconnectPeer", &NodeIDArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) PinPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "pinPeer", &PinPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) UnpinPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "unpinPeer", &NodeIDArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetPeerFilter(ctx context.Context, options ...rpc.Option) (*GetPeerFilterReply, error) {
+	res := &GetPeerFilterReply{}
+	err := c.requester.SendRequest(ctx, "getPeerFilter", struct{}{}, res, options...)
+	return res, err
+}
+
+func formatBanDuration(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+	return duration.String()
+}
@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/api/ratelimit"
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/rpc"
 )
@@ -67,6 +70,21 @@ func (mc *mockClient) SendRequest(ctx context.Context, method string, params int
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
+	case *ListWebhooksReply:
+		response := mc.response.(*ListWebhooksReply)
+		*p = *response
+	case *GetReindexStatusReply:
+		response := mc.response.(*GetReindexStatusReply)
+		*p = *response
+	case *GetExportStatusReply:
+		response := mc.response.(*GetExportStatusReply)
+		*p = *response
+	case *GetAPIRateLimitsReply:
+		response := mc.response.(*GetAPIRateLimitsReply)
+		*p = *response
+	case *GetPeerFilterReply:
+		response := mc.response.(*GetPeerFilterReply)
+		*p = *response
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
@@ -414,3 +432,231 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestListWebhooks(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := []indexer.WebhookStatus{{Name: "explorer", Active: true}}
+		mockClient := client{requester: NewMockClient(&ListWebhooksReply{
+			Webhooks: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.ListWebhooks(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListWebhooksReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ListWebhooks(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestReplayWebhook(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.ReplayWebhook(context.Background(), "explorer", 5)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestReindex(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.Reindex(context.Background(), "X")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetReindexStatus(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := []indexer.ReindexStatus{{Chain: "X", Active: true, Reindexed: 5}}
+		mockClient := client{requester: NewMockClient(&GetReindexStatusReply{
+			Reindexes: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetReindexStatus(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetReindexStatusReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetReindexStatus(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestExportIndex(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.ExportIndex(context.Background(), &ExportIndexArgs{Chain: "X"})
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetExportStatus(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := []indexer.ExportStatus{{Chain: "X", Active: true, Exported: 5}}
+		mockClient := client{requester: NewMockClient(&GetExportStatusReply{
+			Exports: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetExportStatus(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetExportStatusReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetExportStatus(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestReloadAPIRateLimits(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.ReloadAPIRateLimits(context.Background())
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetAPIRateLimits(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := ratelimit.Config{
+			KeyBy:  ratelimit.KeyByIP,
+			Limits: []ratelimit.Limit{{Method: "avm.getUTXOs", RequestsPerSecond: 1, Burst: 5}},
+		}
+		mockClient := client{requester: NewMockClient(&GetAPIRateLimitsReply{
+			RateLimits: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetAPIRateLimits(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetAPIRateLimitsReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetAPIRateLimits(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestPeerFilterMethods(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	methods := map[string]func(c Client) (bool, error){
+		"BanNodeID": func(c Client) (bool, error) {
+			return c.BanNodeID(context.Background(), nodeID, time.Hour)
+		},
+		"UnbanNodeID": func(c Client) (bool, error) {
+			return c.UnbanNodeID(context.Background(), nodeID)
+		},
+		"BanIPs": func(c Client) (bool, error) {
+			return c.BanIPs(context.Background(), "1.2.3.0/24", 0)
+		},
+		"UnbanIPs": func(c Client) (bool, error) {
+			return c.UnbanIPs(context.Background(), "1.2.3.0/24")
+		},
+		"DisconnectPeer": func(c Client) (bool, error) {
+			return c.DisconnectPeer(context.Background(), nodeID)
+		},
+		"PinPeer": func(c Client) (bool, error) {
+			return c.PinPeer(context.Background(), nodeID, "1.2.3.4:9651")
+		},
+		"UnpinPeer": func(c Client) (bool, error) {
+			return c.UnpinPeer(context.Background(), nodeID)
+		},
+	}
+	for name, method := range methods {
+		t.Run(name, func(t *testing.T) {
+			for _, test := range GetSuccessResponseTests() {
+				mockClient := &client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+				success, err := method(mockClient)
+				if test.Err != nil {
+					assert.Error(t, err)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, test.Success, success)
+			}
+		})
+	}
+}
+
+func TestGetPeerFilter(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expiry := time.Unix(1000, 0)
+		expectedReply := &GetPeerFilterReply{
+			NodeIDBans: []NodeIDBan{{NodeID: ids.GenerateTestNodeID(), Expiry: &expiry}},
+			IPBans:     []IPBan{{IPs: "1.2.3.0/24"}},
+			Pins:       []Pin{{NodeID: ids.GenerateTestNodeID(), IP: "1.2.3.4:9651"}},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetPeerFilter(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetPeerFilterReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetPeerFilter(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
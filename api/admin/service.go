@@ -7,15 +7,22 @@ import (
 	"errors"
 	"net/http"
 	"path"
-
-	"github.com/gorilla/rpc/v2"
+	"time"
 
 	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/api/ratelimit"
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/api/server"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer"
+	"github.com/sankar-boro/axia-network-v2/indexer/export"
+	"github.com/sankar-boro/axia-network-v2/network"
+	"github.com/sankar-boro/axia-network-v2/network/peerfilter"
 	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/json"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/perms"
@@ -34,6 +41,11 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+
+	errRateLimitsDisabled = errors.New("API rate limits aren't configured")
+	errNoRateLimitsFile   = errors.New("API rate limits weren't read from a file, so they can't be reloaded")
+
+	errNotConnected = errors.New("not connected to the peer")
 )
 
 type Config struct {
@@ -45,6 +57,17 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Webhooks     indexer.WebhookManager
+	Reindexer    indexer.Reindexer
+	Exporter     indexer.Exporter
+	// Limits the rate of API calls, or nil if API rate limits aren't
+	// configured
+	APIRateLimiter ratelimit.Limiter
+	// File that the API rate limits are reloaded from, or empty if they
+	// weren't read from a file
+	APIRateLimitsFile string
+	Network           network.Network
+	PeerFilter        peerfilter.Filter
 }
 
 // Admin is the API service for node admin management
@@ -56,7 +79,7 @@ type Admin struct {
 // NewService returns a new admin API service.
 // All of the fields in [config] must be set.
 func NewService(config Config) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := schema.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -304,3 +327,388 @@ func (service *Admin) LoadVMs(_ *http.Request, _ *struct{}, reply *LoadVMsReply)
 	reply.NewVMs, err = ids.GetRelevantAliases(service.VMManager, loadedVMs)
 	return err
 }
+
+// ListWebhooksReply contains the response metadata for ListWebhooks
+type ListWebhooksReply struct {
+	Webhooks []indexer.WebhookStatus `json:"webhooks"`
+}
+
+// ListWebhooks returns the status of the webhooks of the indexer.
+func (service *Admin) ListWebhooks(_ *http.Request, _ *struct{}, reply *ListWebhooksReply) error {
+	service.Log.Debug("Admin: ListWebhooks called")
+
+	reply.Webhooks = service.Webhooks.Webhooks()
+	return nil
+}
+
+// WebhookArgs are the arguments for calling PauseWebhook and ResumeWebhook
+type WebhookArgs struct {
+	Name string `json:"name"`
+}
+
+// PauseWebhook stops deliveries by a webhook until it is resumed.
+func (service *Admin) PauseWebhook(_ *http.Request, args *WebhookArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: PauseWebhook called with Name: %s", args.Name)
+
+	if err := service.Webhooks.PauseWebhook(args.Name); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// ResumeWebhook resumes deliveries by a paused webhook.
+func (service *Admin) ResumeWebhook(_ *http.Request, args *WebhookArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: ResumeWebhook called with Name: %s", args.Name)
+
+	if err := service.Webhooks.ResumeWebhook(args.Name); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// ReplayWebhookArgs are the arguments for calling ReplayWebhook
+type ReplayWebhookArgs struct {
+	Name       string      `json:"name"`
+	StartIndex json.Uint64 `json:"startIndex"`
+}
+
+// ReplayWebhook causes a webhook to deliver again the containers accepted from
+// the given index onward.
+func (service *Admin) ReplayWebhook(_ *http.Request, args *ReplayWebhookArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: ReplayWebhook called with Name: %s, StartIndex: %d", args.Name, args.StartIndex)
+
+	if err := service.Webhooks.ReplayWebhook(args.Name, uint64(args.StartIndex)); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// ReindexArgs are the arguments for calling Reindex
+type ReindexArgs struct {
+	Chain string `json:"chain"`
+}
+
+// Reindex starts rebuilding the indices of a chain from the containers the
+// chain has accepted.
+func (service *Admin) Reindex(_ *http.Request, args *ReindexArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: Reindex called with Chain: %s", args.Chain)
+
+	if err := service.Reindexer.Reindex(args.Chain); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetReindexStatusReply contains the response metadata for GetReindexStatus
+type GetReindexStatusReply struct {
+	Reindexes []indexer.ReindexStatus `json:"reindexes"`
+}
+
+// GetReindexStatus returns the progress of the reindexes started since the
+// node started.
+func (service *Admin) GetReindexStatus(_ *http.Request, _ *struct{}, reply *GetReindexStatusReply) error {
+	service.Log.Debug("Admin: GetReindexStatus called")
+
+	reply.Reindexes = service.Reindexer.ReindexStatuses()
+	return nil
+}
+
+// ExportIndexArgs are the arguments for calling ExportIndex
+type ExportIndexArgs struct {
+	Chain string `json:"chain"`
+	// Formats of the files the txs are written to. Defaults to csv and ndjson.
+	Formats []export.Format `json:"formats"`
+	// Number of heights covered by each file
+	PartitionSize json.Uint64 `json:"partitionSize"`
+	// Encoding of the bytes of the txs
+	Encoding formatting.Encoding `json:"encoding"`
+	// Index of the first container whose txs are exported
+	StartIndex json.Uint64 `json:"startIndex"`
+	// Index of the last container whose txs are exported. Defaults to the
+	// last accepted container.
+	EndIndex json.Uint64 `json:"endIndex"`
+}
+
+// ExportIndex starts writing the txs accepted by a chain to files in the
+// export directory of the node.
+func (service *Admin) ExportIndex(_ *http.Request, args *ExportIndexArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: ExportIndex called with Chain: %s, Formats: %v", args.Chain, args.Formats)
+
+	err := service.Exporter.Export(indexer.ExportConfig{
+		Chain:         args.Chain,
+		Formats:       args.Formats,
+		PartitionSize: uint64(args.PartitionSize),
+		Encoding:      args.Encoding,
+		StartIndex:    uint64(args.StartIndex),
+		EndIndex:      uint64(args.EndIndex),
+	})
+	if err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetExportStatusReply contains the response metadata for GetExportStatus
+type GetExportStatusReply struct {
+	Exports []indexer.ExportStatus `json:"exports"`
+}
+
+// GetExportStatus returns the progress of the exports started since the node
+// started.
+func (service *Admin) GetExportStatus(_ *http.Request, _ *struct{}, reply *GetExportStatusReply) error {
+	service.Log.Debug("Admin: GetExportStatus called")
+
+	reply.Exports = service.Exporter.ExportStatuses()
+	return nil
+}
+
+// ReloadAPIRateLimits reads the API rate limits from their file again and
+// replaces the limits in use with them.
+func (service *Admin) ReloadAPIRateLimits(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: ReloadAPIRateLimits called")
+
+	if service.APIRateLimiter == nil {
+		return errRateLimitsDisabled
+	}
+	if service.APIRateLimitsFile == "" {
+		return errNoRateLimitsFile
+	}
+	config, err := ratelimit.ReadConfig(service.APIRateLimitsFile)
+	if err != nil {
+		return err
+	}
+	if err := service.APIRateLimiter.Update(config); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetAPIRateLimitsReply contains the response metadata for GetAPIRateLimits
+type GetAPIRateLimitsReply struct {
+	RateLimits ratelimit.Config `json:"rateLimits"`
+}
+
+// GetAPIRateLimits returns the API rate limits in use
+func (service *Admin) GetAPIRateLimits(_ *http.Request, _ *struct{}, reply *GetAPIRateLimitsReply) error {
+	service.Log.Debug("Admin: GetAPIRateLimits called")
+
+	if service.APIRateLimiter == nil {
+		return errRateLimitsDisabled
+	}
+	reply.RateLimits = service.APIRateLimiter.Config()
+	return nil
+}
+
+// BanNodeIDArgs are the arguments for calling BanNodeID
+type BanNodeIDArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Duration of the ban, such as "1h30m". The ban doesn't end until it's
+	// removed if empty.
+	Duration string `json:"duration"`
+}
+
+// BanNodeID forbids connections to a node and disconnects from it
+func (service *Admin) BanNodeID(_ *http.Request, args *BanNodeIDArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: BanNodeID called with NodeID: %s, Duration: %q", args.NodeID, args.Duration)
+
+	duration, err := parseBanDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	if err := service.Network.BanNodeID(args.NodeID, duration); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// NodeIDArgs are the arguments for calling the peer management methods that
+// only take a node ID
+type NodeIDArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// UnbanNodeID allows connections to a banned node again
+func (service *Admin) UnbanNodeID(_ *http.Request, args *NodeIDArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: UnbanNodeID called with NodeID: %s", args.NodeID)
+
+	if err := service.Network.UnbanNodeID(args.NodeID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// BanIPsArgs are the arguments for calling BanIPs
+type BanIPsArgs struct {
+	// IPs is an IP, such as "1.2.3.4", or a CIDR, such as "1.2.3.0/24"
+	IPs string `json:"ips"`
+	// Duration of the ban, such as "1h30m". The ban doesn't end until it's
+	// removed if empty.
+	Duration string `json:"duration"`
+}
+
+// BanIPs forbids connections to a range of IPs and disconnects from the peers
+// in it
+func (service *Admin) BanIPs(_ *http.Request, args *BanIPsArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: BanIPs called with IPs: %s, Duration: %q", args.IPs, args.Duration)
+
+	ipNet, err := peerfilter.ParseIPs(args.IPs)
+	if err != nil {
+		return err
+	}
+	duration, err := parseBanDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	if err := service.Network.BanIPs(ipNet, duration); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// UnbanIPsArgs are the arguments for calling UnbanIPs
+type UnbanIPsArgs struct {
+	IPs string `json:"ips"`
+}
+
+// UnbanIPs allows connections to a banned range of IPs again
+func (service *Admin) UnbanIPs(_ *http.Request, args *UnbanIPsArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: UnbanIPs called with IPs: %s", args.IPs)
+
+	ipNet, err := peerfilter.ParseIPs(args.IPs)
+	if err != nil {
+		return err
+	}
+	if err := service.Network.UnbanIPs(ipNet); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// DisconnectPeer closes the connection to a peer. The node may reconnect to it
+// later.
+func (service *Admin) DisconnectPeer(_ *http.Request, args *NodeIDArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: DisconnectPeer called with NodeID: %s", args.NodeID)
+
+	if !service.Network.Disconnect(args.NodeID) {
+		return errNotConnected
+	}
+	reply.Success = true
+	return nil
+}
+
+// PinPeerArgs are the arguments for calling PinPeer
+type PinPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// PinPeer makes the node always attempt to be connected to a peer, including
+// after restarts
+func (service *Admin) PinPeer(_ *http.Request, args *PinPeerArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: PinPeer called with NodeID: %s, IP: %s", args.NodeID, args.IP)
+
+	ip, err := ips.ToIPPort(args.IP)
+	if err != nil {
+		return err
+	}
+	if err := service.Network.Pin(args.NodeID, ip); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// UnpinPeer stops the node from always attempting to be connected to a peer
+func (service *Admin) UnpinPeer(_ *http.Request, args *NodeIDArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: UnpinPeer called with NodeID: %s", args.NodeID)
+
+	if err := service.Network.Unpin(args.NodeID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// NodeIDBan is a banned node. Expiry is omitted if the ban doesn't end until
+// it's removed.
+type NodeIDBan struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// IPBan is a banned range of IPs. Expiry is omitted if the ban doesn't end
+// until it's removed.
+type IPBan struct {
+	IPs    string     `json:"ips"`
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// Pin is a peer that the node always attempts to be connected to
+type Pin struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// GetPeerFilterReply contains the response metadata for GetPeerFilter
+type GetPeerFilterReply struct {
+	NodeIDBans []NodeIDBan `json:"nodeIDBans"`
+	IPBans     []IPBan     `json:"ipBans"`
+	Pins       []Pin       `json:"pins"`
+}
+
+// GetPeerFilter returns the banned nodes and IPs and the pinned peers
+func (service *Admin) GetPeerFilter(_ *http.Request, _ *struct{}, reply *GetPeerFilterReply) error {
+	service.Log.Debug("Admin: GetPeerFilter called")
+
+	nodeIDBans := service.PeerFilter.NodeIDBans()
+	reply.NodeIDBans = make([]NodeIDBan, len(nodeIDBans))
+	for i, ban := range nodeIDBans {
+		reply.NodeIDBans[i] = NodeIDBan{
+			NodeID: ban.NodeID,
+			Expiry: banExpiry(ban.Expiry),
+		}
+	}
+
+	ipBans := service.PeerFilter.IPBans()
+	reply.IPBans = make([]IPBan, len(ipBans))
+	for i, ban := range ipBans {
+		reply.IPBans[i] = IPBan{
+			IPs:    ban.IPs.String(),
+			Expiry: banExpiry(ban.Expiry),
+		}
+	}
+
+	pins := service.PeerFilter.Pins()
+	reply.Pins = make([]Pin, len(pins))
+	for i, pin := range pins {
+		reply.Pins[i] = Pin{
+			NodeID: pin.NodeID,
+			IP:     pin.IP.String(),
+		}
+	}
+	return nil
+}
+
+func parseBanDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func banExpiry(expiry time.Time) *time.Time {
+	if expiry.IsZero() {
+		return nil
+	}
+	return &expiry
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sankar-boro/axia-network-v2/api/auth"
+	"github.com/sankar-boro/axia-network-v2/api/server"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+)
+
+// Name of the audit log file, without its extension
+const fileName = "audit"
+
+var (
+	// DefaultMethods are the privileged methods that are audited by default.
+	// A method ending in ".*" stands for every method of the service.
+	// Note that the methods of the platform chain are served as core.*.
+	DefaultMethods = []string{
+		"admin.*",
+		"auth.*",
+		"ipcs.*",
+		"keystore.*",
+		"avm.exportKey",
+		"core.exportKey",
+	}
+
+	errEmptyMethod = errors.New("audited methods can't be empty")
+
+	_ Log = &log{}
+)
+
+// Log records calls to privileged methods of the HTTP API in an append-only
+// file. Calls that can't be recorded are rejected.
+// gRPC calls aren't recorded.
+type Log interface {
+	server.Wrapper
+
+	// Close closes the file of the audit log
+	Close() error
+}
+
+type log struct {
+	log   logging.Logger
+	clock mockable.Clock
+	// Audited services and methods, as given by calls
+	services map[string]struct{}
+	methods  map[string]struct{}
+
+	lock   sync.Mutex
+	writer io.WriteCloser
+	// Hash of the last entry written
+	prevHash string
+}
+
+// New returns a Log that records calls to [methods] in the file audit.log in
+// [dir]. New entries continue the chain of hashes of the entries that were
+// already written to the log, including the entries of rotated files.
+//
+// Once the file reaches [maxSize] megabytes it is renamed with a timestamp and
+// a new file is started. Rotated files are never compressed or deleted, so
+// that no entry is ever lost; operators must archive them.
+func New(logger logging.Logger, dir string, maxSize int, methods []string) (Log, error) {
+	prevHash, err := readLastHash(dir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the audit log: %w", err)
+	}
+	// Leaving MaxFiles and MaxAge unset keeps every rotated file.
+	config := logging.RotatingWriterConfig{
+		MaxSize:   maxSize,
+		Directory: dir,
+	}
+	l, err := newLog(logger, logging.NewRotatingWriter(config, fileName), methods)
+	if err != nil {
+		return nil, err
+	}
+	l.prevHash = prevHash
+	logger.Info("recording calls to %v in the audit log", methods)
+	return l, nil
+}
+
+func newLog(logger logging.Logger, writer io.WriteCloser, methods []string) (*log, error) {
+	l := &log{
+		log:      logger,
+		services: make(map[string]struct{}),
+		methods:  make(map[string]struct{}),
+		writer:   writer,
+	}
+	for _, method := range methods {
+		switch {
+		case method == "":
+			return nil, errEmptyMethod
+		case strings.HasSuffix(method, ".*"):
+			l.services[strings.TrimSuffix(method, ".*")] = struct{}{}
+		default:
+			l.methods[normalizeMethod(method)] = struct{}{}
+		}
+	}
+	return l, nil
+}
+
+// readLastHash returns the hash of the last entry of the audit log in [dir],
+// or the empty string if it has no entries. If the current file has no
+// entries, because it was just rotated, the newest rotated file with entries
+// is read instead.
+func readLastHash(dir string) (string, error) {
+	prevHash, err := readFileLastHash(filepath.Join(dir, fileName+".log"))
+	if err != nil || prevHash != "" {
+		return prevHash, err
+	}
+
+	// Rotated files are named after the UTC time they were rotated at, so
+	// the newest file sorts last.
+	rotated, err := filepath.Glob(filepath.Join(dir, fileName+"-*.log"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(rotated)
+	for i := len(rotated) - 1; i >= 0; i-- {
+		prevHash, err := readFileLastHash(rotated[i])
+		if err != nil || prevHash != "" {
+			return prevHash, err
+		}
+	}
+	return "", nil
+}
+
+// readFileLastHash returns the hash of the last entry in the file at [path],
+// or the empty string if the file doesn't exist or has no entries.
+func readFileLastHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return lastHash(file)
+}
+
+type call struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+func (l *log) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls, err := readCalls(w, r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		tokenID, _ := auth.TokenID(r.Context())
+		for _, call := range calls {
+			if !l.audited(call.Method) {
+				continue
+			}
+			entry := Entry{
+				RemoteAddr: r.RemoteAddr,
+				TokenID:    tokenID,
+				Endpoint:   r.URL.Path,
+				Method:     call.Method,
+				Params:     summarize(call.Params),
+			}
+			if err := l.write(entry); err != nil {
+				l.log.Error("couldn't record the call to %s in the audit log: %s", call.Method, err)
+				writeErrorResponse(w, http.StatusInternalServerError, "couldn't record the call in the audit log")
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// audited returns true if calls to [method] are recorded
+func (l *log) audited(method string) bool {
+	method = normalizeMethod(method)
+	if _, ok := l.methods[method]; ok {
+		return true
+	}
+	if i := strings.LastIndex(method, "."); i >= 0 {
+		_, ok := l.services[method[:i]]
+		return ok
+	}
+	return false
+}
+
+// write completes [entry] with the time and the chain of hashes and appends
+// it to the audit log.
+func (l *log) write(entry Entry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entry.Timestamp = l.clock.Time().UTC()
+	entry.PrevHash = l.prevHash
+	hash, err := entry.computeHash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.writer.Write(append(entryBytes, '\n')); err != nil {
+		return err
+	}
+	l.prevHash = hash
+	return nil
+}
+
+func (l *log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.writer.Close()
+}
+
+// normalizeMethod returns [method] with the first letter of the method's name
+// lowercased. utils/json only serves "service.method", but calls to
+// "service.Method" are still recorded as attempts to call it, and audited
+// methods may be configured either way.
+func normalizeMethod(method string) string {
+	i := strings.LastIndex(method, ".")
+	if i < 0 || i == len(method)-1 {
+		return method
+	}
+	firstRune, runeLen := utf8.DecodeRuneInString(method[i+1:])
+	return method[:i+1] + string(unicode.ToLower(firstRune)) + method[i+1+runeLen:]
+}
+
+// readCalls returns the JSON-RPC calls that [r] makes, or nil if [r] isn't a
+// JSON-RPC request. The body of [r] is replaced so that it can be read again.
+// Bodies larger than [server.MaxRequestBodySize] are rejected.
+func readCalls(w http.ResponseWriter, r *http.Request) ([]call, error) {
+	if r.Body == nil || r.Method != http.MethodPost {
+		return nil, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, server.MaxRequestBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Body.Close(); err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A batch of calls is an array of calls.
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		calls := []call{}
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return nil, nil
+		}
+		return calls, nil
+	}
+
+	singleCall := call{}
+	if err := json.Unmarshal(body, &singleCall); err != nil || singleCall.Method == "" {
+		return nil, nil
+	}
+	return []call{singleCall}, nil
+}
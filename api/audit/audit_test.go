@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/api/server"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+)
+
+func makeCall(t *testing.T, handler http.Handler, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/ext/admin", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func readEntries(t *testing.T, path string) []Entry {
+	fileBytes, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	entries := []Entry{}
+	for _, line := range bytes.Split(bytes.TrimSpace(fileBytes), []byte("\n")) {
+		entry := Entry{}
+		assert.NoError(t, json.Unmarshal(line, &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLog(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, fileName+".log")
+
+	l, err := New(logging.NoLog{}, dir, 8, DefaultMethods)
+	assert.NoError(err)
+
+	served := 0
+	serve := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+	})
+	handler := l.WrapHandler(serve)
+	assert.Equal(http.StatusOK, makeCall(t, handler, `{"jsonrpc":"2.0","method":"admin.lockProfile","params":{},"id":1}`))
+	assert.Equal(http.StatusOK, makeCall(t, handler, `{"jsonrpc":"2.0","method":"info.getNodeID","params":{},"id":1}`))
+	assert.Equal(http.StatusOK, makeCall(t, handler, `[
+		{"jsonrpc":"2.0","method":"keystore.createUser","params":{"username":"bob","password":"hunter2"},"id":1},
+		{"jsonrpc":"2.0","method":"avm.ExportKey","params":[{"address":"Swap-local1","password":"hunter2"}],"id":2}
+	]`))
+	assert.Equal(3, served)
+	assert.NoError(l.Close())
+
+	entries := readEntries(t, path)
+	assert.Len(entries, 3)
+	assert.Equal("admin.lockProfile", entries[0].Method)
+	assert.Equal("/ext/admin", entries[0].Endpoint)
+	assert.Equal("", entries[0].PrevHash)
+	assert.Equal("keystore.createUser", entries[1].Method)
+	assert.JSONEq(`{"username":"bob","password":"[redacted]"}`, string(entries[1].Params))
+	assert.Equal("avm.ExportKey", entries[2].Method)
+	assert.JSONEq(`[{"address":"Swap-local1","password":"[redacted]"}]`, string(entries[2].Params))
+
+	file, err := os.Open(path)
+	assert.NoError(err)
+	lastHash, err := Verify(file, "")
+	assert.NoError(err)
+	assert.NoError(file.Close())
+	assert.Equal(entries[2].Hash, lastHash)
+
+	// Entries written after a restart continue the chain
+	l, err = New(logging.NoLog{}, dir, 8, DefaultMethods)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, makeCall(t, l.WrapHandler(serve), `{"method":"auth.newToken","params":{"password":"hunter2"}}`))
+	assert.NoError(l.Close())
+	assert.Equal(4, served)
+
+	entries = readEntries(t, path)
+	assert.Len(entries, 4)
+	assert.Equal(lastHash, entries[3].PrevHash)
+
+	file, err = os.Open(path)
+	assert.NoError(err)
+	_, err = Verify(file, "")
+	assert.NoError(err)
+	assert.NoError(file.Close())
+}
+
+// Rotated files of the audit log are kept
+func TestLogRotation(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	l, err := New(logging.NoLog{}, dir, 1, DefaultMethods)
+	assert.NoError(err)
+
+	handler := l.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	calls := make([]string, 1000)
+	for i := range calls {
+		calls[i] = `{"method":"admin.lockProfile","params":{}}`
+	}
+	body := "[" + strings.Join(calls, ",") + "]"
+
+	numCalls := 0
+	files := []os.DirEntry{}
+	for i := 0; i < 100 && len(files) < 2; i++ {
+		assert.Equal(http.StatusOK, makeCall(t, handler, body))
+		numCalls += len(calls)
+
+		files, err = os.ReadDir(dir)
+		assert.NoError(err)
+	}
+	assert.NoError(l.Close())
+	assert.Len(files, 2)
+
+	numEntries := 0
+	for _, file := range files {
+		numEntries += len(readEntries(t, filepath.Join(dir, file.Name())))
+	}
+	assert.Equal(numCalls, numEntries)
+}
+
+// Entries written after a restart continue the chain of the newest rotated
+// file if the current file has no entries
+func TestLogRotationRestart(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, fileName+".log")
+	serve := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	body := `{"method":"admin.lockProfile","params":{}}`
+
+	for _, rotatedName := range []string{"audit-2022-01-01T00-00-00.000.log", "audit-2022-02-01T00-00-00.000.log"} {
+		l, err := New(logging.NoLog{}, dir, 8, DefaultMethods)
+		assert.NoError(err)
+		assert.Equal(http.StatusOK, makeCall(t, l.WrapHandler(serve), body))
+		assert.NoError(l.Close())
+		assert.NoError(os.Rename(path, filepath.Join(dir, rotatedName)))
+	}
+	rotatedEntries := readEntries(t, filepath.Join(dir, "audit-2022-02-01T00-00-00.000.log"))
+	assert.Len(rotatedEntries, 1)
+
+	// The current file was created by the rotation but has no entries yet.
+	assert.NoError(os.WriteFile(path, nil, 0o600))
+
+	l, err := New(logging.NoLog{}, dir, 8, DefaultMethods)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, makeCall(t, l.WrapHandler(serve), body))
+	assert.NoError(l.Close())
+
+	entries := readEntries(t, path)
+	assert.Len(entries, 1)
+	assert.Equal(rotatedEntries[0].Hash, entries[0].PrevHash)
+}
+
+// Calls are rejected if their body is too large to be read
+func TestLogBodyTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := New(logging.NoLog{}, t.TempDir(), 8, DefaultMethods)
+	assert.NoError(err)
+
+	served := false
+	handler := l.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		served = true
+	}))
+	body := strings.Repeat(" ", server.MaxRequestBodySize) + `{"method":"admin.lockProfile","params":{}}`
+	assert.Equal(http.StatusBadRequest, makeCall(t, handler, body))
+	assert.False(served)
+	assert.NoError(l.Close())
+}
+
+func TestVerifyTampered(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := &bytes.Buffer{}
+	l, err := newLog(logging.NoLog{}, nopCloser{buf}, []string{"admin.*"})
+	assert.NoError(err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(l.write(Entry{Method: "admin.lockProfile"}))
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(lines, 3)
+
+	lastHash, err := Verify(strings.NewReader(buf.String()), "")
+	assert.NoError(err)
+	assert.Equal(l.prevHash, lastHash)
+
+	// Modifying an entry is detected
+	modified := strings.Replace(buf.String(), "admin.lockProfile", "admin.stopProfile", 1)
+	_, err = Verify(strings.NewReader(modified), "")
+	assert.ErrorIs(err, errWrongHash)
+
+	// Removing an entry is detected
+	removed := lines[0] + "\n" + lines[2] + "\n"
+	_, err = Verify(strings.NewReader(removed), "")
+	assert.ErrorIs(err, errWrongPrevHash)
+
+	// The first entry must follow the given hash
+	_, err = Verify(strings.NewReader(lines[1]+"\n"), lastHash)
+	assert.ErrorIs(err, errWrongPrevHash)
+}
+
+func TestAudited(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := newLog(logging.NoLog{}, nopCloser{&bytes.Buffer{}}, DefaultMethods)
+	assert.NoError(err)
+	assert.True(l.audited("admin.lockProfile"))
+	assert.True(l.audited("admin.LockProfile"))
+	assert.True(l.audited("core.exportKey"))
+	assert.True(l.audited("avm.ExportKey"))
+	assert.False(l.audited("avm.getBalance"))
+	assert.False(l.audited("administrator.lockProfile"))
+	assert.False(l.audited(""))
+
+	_, err = newLog(logging.NoLog{}, nopCloser{&bytes.Buffer{}}, []string{""})
+	assert.ErrorIs(err, errEmptyMethod)
+}
+
+func TestSummarize(t *testing.T) {
+	assert := assert.New(t)
+
+	longString := strings.Repeat("a", maxStringLen+1)
+	longArray := make([]int, maxArrayLen+2)
+	longArrayBytes, err := json.Marshal(longArray)
+	assert.NoError(err)
+
+	assert.Nil(summarize(nil))
+	assert.Nil(summarize([]byte("not json")))
+	assert.JSONEq(
+		`{"token":"[redacted]","tokenID":"id","privateKey":"[redacted]","newPassword":"[redacted]","amount":12345678901234567890}`,
+		string(summarize([]byte(`{"token":"t","tokenID":"id","privateKey":"k","newPassword":"p","amount":12345678901234567890}`))),
+	)
+	assert.JSONEq(
+		`{"user":"`+longString[:maxStringLen]+`... (129 bytes)"}`,
+		string(summarize([]byte(`{"user":"`+longString+`"}`))),
+	)
+	assert.JSONEq(
+		`{"a":{"b":{"c":{"d":"{...}"}}}}`,
+		string(summarize([]byte(`{"a":{"b":{"c":{"d":{"e":1}}}}}`))),
+	)
+
+	summary := []interface{}{}
+	assert.NoError(json.Unmarshal(summarize(longArrayBytes), &summary))
+	assert.Len(summary, maxArrayLen+1)
+	assert.Equal("... 2 more", summary[maxArrayLen])
+}
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
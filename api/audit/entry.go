@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+)
+
+// Entries are written one per line, so lines are at most as long as an entry
+const maxLineLen = 1024 * 1024
+
+var (
+	errWrongPrevHash = errors.New("entry doesn't follow the previous entry")
+	errWrongHash     = errors.New("entry's hash doesn't match its contents")
+)
+
+// Entry is a call to a method recorded in the audit log.
+// Each entry contains the hash of the entry before it, so that removing or
+// modifying an entry breaks the chain of hashes that follows it.
+type Entry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	RemoteAddr string    `json:"remoteAddr"`
+	// ID of the auth token that authorized the call, if any
+	TokenID  string `json:"tokenID,omitempty"`
+	Endpoint string `json:"endpoint"`
+	Method   string `json:"method"`
+	// Summary of the params of the call, with secrets redacted. It's kept
+	// as raw JSON so that the entry is marshalled the same way when its hash
+	// is verified.
+	Params json.RawMessage `json:"params,omitempty"`
+	// Hash of the entry before this one, or empty if this is the first entry
+	PrevHash string `json:"prevHash"`
+	// Hash of this entry, computed with Hash empty
+	Hash string `json:"hash,omitempty"`
+}
+
+// computeHash returns the hex encoded hash of [e] without its Hash
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	entryBytes, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hashing.ComputeHash256(entryBytes)), nil
+}
+
+// Verify checks that the entries read from [r] form an unbroken chain of
+// hashes. If [prevHash] isn't empty, the first entry must follow the entry
+// whose hash is [prevHash], which is how an audit log continues a rotated
+// file. Returns the hash of the last entry, or [prevHash] if there are none.
+func Verify(r io.Reader, prevHash string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLen)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return "", fmt.Errorf("couldn't parse line %d: %w", line, err)
+		}
+		if prevHash != "" && entry.PrevHash != prevHash {
+			return "", fmt.Errorf("line %d: %w", line, errWrongPrevHash)
+		}
+		hash, err := entry.computeHash()
+		if err != nil {
+			return "", err
+		}
+		if entry.Hash != hash {
+			return "", fmt.Errorf("line %d: %w", line, errWrongHash)
+		}
+		prevHash = hash
+	}
+	return prevHash, scanner.Err()
+}
+
+// lastHash returns the hash of the last entry read from [r], or the empty
+// string if there are none.
+func lastHash(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLen)
+	var lastLine []byte
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			lastLine = append(lastLine[:0], scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil || lastLine == nil {
+		return "", err
+	}
+	entry := Entry{}
+	if err := json.Unmarshal(lastLine, &entry); err != nil {
+		return "", fmt.Errorf("couldn't parse the last entry: %w", err)
+	}
+	return entry.Hash, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	redacted = "[redacted]"
+
+	// Longer strings are truncated
+	maxStringLen = 128
+	// Only the first [maxArrayLen] elements of arrays are kept
+	maxArrayLen = 16
+	// Values nested deeper are elided
+	maxDepth = 4
+)
+
+// sensitiveParams are parts of the names of params whose values are redacted.
+// Names are compared in lower case.
+var sensitiveParams = []string{
+	"password",
+	"privatekey",
+	"secret",
+	"mnemonic",
+}
+
+// isSensitive returns true if the value of the param [name] is redacted
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	// The token param is an auth token, whereas tokenID only identifies one
+	if name == "token" {
+		return true
+	}
+	for _, sensitive := range sensitiveParams {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// summarize returns a summary of the JSON [params] with the values of
+// sensitive params redacted and long values shortened. Returns nil if [params]
+// isn't JSON.
+func summarize(params []byte) json.RawMessage {
+	if len(params) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	summary, err := json.Marshal(summarizeValue(value, 0))
+	if err != nil {
+		return nil
+	}
+	return summary
+}
+
+func summarizeValue(value interface{}, depth int) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if depth >= maxDepth {
+			return "{...}"
+		}
+		summary := make(map[string]interface{}, len(value))
+		for name, field := range value {
+			if isSensitive(name) {
+				summary[name] = redacted
+				continue
+			}
+			summary[name] = summarizeValue(field, depth+1)
+		}
+		return summary
+	case []interface{}:
+		if depth >= maxDepth {
+			return "[...]"
+		}
+		summary := make([]interface{}, 0, maxArrayLen+1)
+		for i, elem := range value {
+			if i == maxArrayLen {
+				summary = append(summary, fmt.Sprintf("... %d more", len(value)-maxArrayLen))
+				break
+			}
+			summary = append(summary, summarizeValue(elem, depth+1))
+		}
+		return summary
+	case string:
+		if len(value) > maxStringLen {
+			return fmt.Sprintf("%s... (%d bytes)", value[:maxStringLen], len(value))
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+)
+
+type responseErr struct {
+	Code    rpc.ErrorCode `json:"code"`
+	Message string        `json:"message"`
+}
+
+type responseBody struct {
+	Version string      `json:"jsonrpc"`
+	Err     responseErr `json:"error"`
+	ID      interface{} `json:"id"`
+}
+
+// Write a JSON-RPC formatted response with header [status] and the error
+// [message].
+// Errors while writing are ignored.
+func writeErrorResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	// There isn't anything to do with the returned error, so it is dropped.
+	_ = json.NewEncoder(w).Encode(responseBody{
+		Version: rpc.Version,
+		Err: responseErr{
+			Code:    rpc.E_SERVER,
+			Message: message,
+		},
+	})
+}
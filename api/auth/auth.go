@@ -4,20 +4,31 @@
 package auth
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	stdjson "encoding/json"
+
 	"github.com/golang-jwt/jwt"
 
-	"github.com/gorilla/rpc/v2"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/sankar-boro/axia-network-v2/api/schema"
+	"github.com/sankar-boro/axia-network-v2/api/server"
+	"github.com/sankar-boro/axia-network-v2/chains/atomic"
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
 	"github.com/sankar-boro/axia-network-v2/utils/json"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/password"
@@ -35,6 +46,7 @@ const (
 	defaultTokenLifespan = time.Hour * 12
 
 	maxEndpoints = 128
+	maxMethods   = 128
 )
 
 var (
@@ -47,29 +59,58 @@ var (
 	errInvalidSigningMethod        = errors.New("auth token didn't specify the HS256 signing method correctly")
 	errTokenRevoked                = errors.New("the provided auth token was revoked")
 	errTokenInsufficientPermission = errors.New("the provided auth token does not allow access to this endpoint")
+	errTokenMethodNotAllowed       = errors.New("the provided auth token does not allow calling this method")
+	errUnknownToken                = errors.New("unknown auth token")
+	errNotJSONRPC                  = errors.New("the provided auth token only allows JSON-RPC calls")
 	errWrongPassword               = errors.New("incorrect password")
 	errSamePassword                = errors.New("new password can't be same as old password")
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errTooManyMethods              = fmt.Errorf("can only name at most %d methods", maxMethods)
+	errNonPositiveLifespan         = errors.New("token lifespan must be positive")
+
+	singletonPrefix = []byte("singleton")
+	tokenPrefix     = []byte("token")
+
+	passwordKey = []byte("password")
 
 	_ Auth = &auth{}
 )
 
+// tokenIDKey is the key of the ID of the auth token that authorized a request
+// in the request's context
+type tokenIDKey struct{}
+
+// TokenID returns the ID of the auth token that authorized the request whose
+// context is [ctx], or false if the request wasn't authorized with a token.
+func TokenID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tokenIDKey{}).(string)
+	return id, ok
+}
+
 type Auth interface {
 	// Create and return a new token that allows access to each API endpoint for
 	// [duration] such that the API's path ends with an element of [endpoints].
 	// If one of the elements of [endpoints] is "*", all APIs are accessible.
-	NewToken(pw string, duration time.Duration, endpoints []string) (string, error)
+	// If [methods] is non-empty, the token only allows calling the JSON-RPC
+	// methods in [methods], e.g. "platform.getHeight".
+	NewToken(pw string, duration time.Duration, endpoints, methods []string) (string, error)
 
 	// Revokes [token]; it will not be accepted as authorization for future API
-	// calls. If the token is invalid, this is a no-op.  If a token is revoked
-	// and then the password is changed, and then changed back to the current
-	// password, the token will be un-revoked. Therefore, passwords shouldn't be
-	// re-used before previously revoked tokens have expired.
-	RevokeToken(pw, token string) error
+	// calls. Revocations persist across restarts.
+	RevokeToken(token, pw string) error
 
-	// Authenticates [token] for access to [url].
+	// Revokes the token whose ID is [tokenID].
+	RevokeTokenByID(tokenID, pw string) error
+
+	// ListTokens returns the tokens that haven't expired yet, including the
+	// revoked ones.
+	ListTokens(pw string) ([]TokenInfo, error)
+
+	// Authenticates [token] for access to [url]. The methods that the token
+	// allows calling are only checked by the handlers returned by WrapHandler
+	// and by AuthorizeGRPC.
 	AuthenticateToken(token, url string) error
 
 	// Change the password required to create and revoke tokens.
@@ -86,6 +127,12 @@ type Auth interface {
 	// WrapHandler wraps an http.Handler. Before passing a request to the
 	// provided handler, the auth token is authenticated.
 	WrapHandler(h http.Handler) http.Handler
+
+	// AuthorizeGRPC authenticates the auth token in the metadata of the gRPC
+	// call with context [ctx] for access to [url] and for calling the JSON-RPC
+	// method [method], which mirrors the called gRPC method. The returned
+	// context holds the ID of the token, as returned by TokenID.
+	AuthorizeGRPC(ctx context.Context, url, method string) (context.Context, error)
 }
 
 type auth struct {
@@ -98,29 +145,63 @@ type auth struct {
 	lock sync.RWMutex
 	// Can be changed via API call.
 	password password.Hash
-	// Set of token IDs that have been revoked
-	revoked map[string]struct{}
+	// The parameters that the password is hashed with when it is changed.
+	hashParams password.Params
+
+	// Key: passwordKey
+	// Value: The hash of the password
+	singletonDB database.Database
+	// Key: token ID
+	// Value: The tokenRecord of the token
+	tokenDB database.Database
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
-	a := &auth{
-		log:      log,
-		endpoint: endpoint,
-		revoked:  make(map[string]struct{}),
+// New returns an Auth whose password is [pw], hashed with [hashParams], and
+// whose tokens are persisted in [db]. If the password that was persisted in
+// [db] isn't [pw], the persisted tokens are removed.
+func New(log logging.Logger, endpoint, pw string, hashParams password.Params, db database.Database) (Auth, error) {
+	a := newAuth(log, endpoint, hashParams, db)
+
+	passwordHash, err := a.getPassword()
+	if err != nil {
+		return nil, err
 	}
-	return a, a.password.Set(pw)
+	if passwordHash == nil || !passwordHash.Check(pw) {
+		return a, a.setPassword(pw)
+	}
+
+	// The tokens that were issued under [pw] remain valid, as tokens aren't
+	// signed with the password hash.
+	a.password = *passwordHash
+	if !passwordHash.NeedsUpgrade(hashParams) {
+		return a, nil
+	}
+	if err := a.password.SetWithParams(pw, hashParams); err != nil {
+		return nil, err
+	}
+	return a, a.putPassword(a.singletonDB)
+}
+
+// NewFromHash returns an Auth whose password is [pw] and whose tokens are
+// persisted in [db]. Passwords that it's changed to are hashed with the same
+// parameters as [pw].
+func NewFromHash(log logging.Logger, endpoint string, pw password.Hash, db database.Database) Auth {
+	a := newAuth(log, endpoint, pw.Params, db)
+	a.password = pw
+	return a
 }
 
-func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
+func newAuth(log logging.Logger, endpoint string, hashParams password.Params, db database.Database) *auth {
 	return &auth{
-		log:      log,
-		endpoint: endpoint,
-		password: pw,
-		revoked:  make(map[string]struct{}),
+		log:         log,
+		endpoint:    endpoint,
+		hashParams:  hashParams,
+		singletonDB: prefixdb.New(singletonPrefix, db),
+		tokenDB:     prefixdb.New(tokenPrefix, db),
 	}
 }
 
-func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
+func (a *auth) NewToken(pw string, duration time.Duration, endpoints, methods []string) (string, error) {
 	if pw == "" {
 		return "", errNoPassword
 	}
@@ -129,9 +210,15 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	} else if l > maxEndpoints {
 		return "", errTooManyEndpoints
 	}
+	if len(methods) > maxMethods {
+		return "", errTooManyMethods
+	}
+	if duration <= 0 {
+		return "", errNonPositiveLifespan
+	}
 
-	a.lock.RLock()
-	defer a.lock.RUnlock()
+	a.lock.Lock()
+	defer a.lock.Unlock()
 
 	if !a.password.Check(pw) {
 		return "", errWrongPassword
@@ -151,19 +238,35 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	}
 	id := base64.URLEncoding.EncodeToString(idBytes[:])
 
+	now := a.clock.Time()
+	record := tokenRecord{
+		Methods:   methods,
+		IssuedAt:  uint64(now.Unix()),
+		ExpiresAt: uint64(now.Add(duration).Unix()),
+	}
+	if _, err := rand.Read(record.Secret[:]); err != nil {
+		return "", fmt.Errorf("failed to generate the token secret due to %w", err)
+	}
+	if canAccessAll {
+		record.Endpoints = []string{"*"}
+	} else {
+		record.Endpoints = endpoints
+	}
+	if err := a.putToken(id, &record); err != nil {
+		return "", err
+	}
+
 	claims := endpointClaims{
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: a.clock.Time().Add(duration).Unix(),
+			ExpiresAt: int64(record.ExpiresAt),
+			IssuedAt:  int64(record.IssuedAt),
 			Id:        id,
 		},
-	}
-	if canAccessAll {
-		claims.Endpoints = []string{"*"}
-	} else {
-		claims.Endpoints = endpoints
+		Endpoints: record.Endpoints,
+		Methods:   record.Methods,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	return token.SignedString(record.Secret[:]) // Sign the token and return its string repr.
 }
 
 func (a *auth) RevokeToken(tokenStr, pw string) error {
@@ -182,52 +285,142 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 	}
 
 	// See if token is well-formed and signature is right
-	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
+	claims, record, err := a.parseToken(tokenStr)
 	if err != nil {
 		return err
 	}
+	record.Revoked = true
+	return a.putToken(claims.Id, record)
+}
 
-	// If the token isn't valid, it has essentially already been revoked.
-	if !token.Valid {
-		return nil
+func (a *auth) RevokeTokenByID(tokenID, pw string) error {
+	if tokenID == "" {
+		return errNoToken
+	}
+	if pw == "" {
+		return errNoPassword
 	}
 
-	claims, ok := token.Claims.(*endpointClaims)
-	if !ok {
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return errWrongPassword
 	}
-	a.revoked[claims.Id] = struct{}{}
-	return nil
+
+	record, err := a.getToken(tokenID)
+	if err != nil {
+		return err
+	}
+	record.Revoked = true
+	return a.putToken(tokenID, record)
+}
+
+func (a *auth) ListTokens(pw string) ([]TokenInfo, error) {
+	if pw == "" {
+		return nil, errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return nil, errWrongPassword
+	}
+
+	it := a.tokenDB.NewIterator()
+	defer it.Release()
+
+	// Expired tokens are removed while listing the tokens
+	now := uint64(a.clock.Unix())
+	batch := a.tokenDB.NewBatch()
+	tokens := []TokenInfo{}
+	for it.Next() {
+		record := tokenRecord{}
+		if _, err := c.Unmarshal(it.Value(), &record); err != nil {
+			return nil, err
+		}
+		if record.ExpiresAt <= now {
+			if err := batch.Delete(it.Key()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		tokens = append(tokens, TokenInfo{
+			ID:        string(it.Key()),
+			Endpoints: record.Endpoints,
+			Methods:   record.Methods,
+			IssuedAt:  json.Uint64(record.IssuedAt),
+			ExpiresAt: json.Uint64(record.ExpiresAt),
+			Revoked:   record.Revoked,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].IssuedAt != tokens[j].IssuedAt {
+			return tokens[i].IssuedAt < tokens[j].IssuedAt
+		}
+		return tokens[i].ID < tokens[j].ID
+	})
+	return tokens, nil
 }
 
 func (a *auth) AuthenticateToken(tokenStr, url string) error {
+	_, err := a.authenticateToken(tokenStr, url)
+	return err
+}
+
+// authenticateToken authenticates [tokenStr] for access to [url] and returns
+// its claims.
+func (a *auth) authenticateToken(tokenStr, url string) (*endpointClaims, error) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
-	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
+	claims, record, err := a.parseToken(tokenStr)
 	if err != nil { // Probably because signature wrong
-		return err
+		return nil, err
+	}
+	if record.Revoked {
+		return nil, errTokenRevoked
 	}
 
 	// Make sure this token gives access to the requested endpoint
-	claims, ok := token.Claims.(*endpointClaims)
-	if !ok {
-		// Error is intentionally dropped here as there is nothing left to do
-		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+	for _, endpoint := range claims.Endpoints {
+		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
+			return claims, nil
+		}
 	}
+	return nil, errTokenInsufficientPermission
+}
 
-	_, revoked := a.revoked[claims.Id]
-	if revoked {
-		return errTokenRevoked
+// authorizeMethods returns nil if [claims] allow calling every method in
+// [methods].
+func authorizeMethods(claims *endpointClaims, methods []string) error {
+	if len(claims.Methods) == 0 {
+		return nil
 	}
-
-	for _, endpoint := range claims.Endpoints {
-		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
-			return nil
+	if len(methods) == 0 {
+		return errNotJSONRPC
+	}
+	for _, method := range methods {
+		allowed := false
+		for _, allowedMethod := range claims.Methods {
+			if method == allowedMethod {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %q", errTokenMethodNotAllowed, method)
 		}
 	}
-	return errTokenInsufficientPermission
+	return nil
 }
 
 func (a *auth) ChangePassword(oldPW, newPW string) error {
@@ -244,18 +437,13 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 	if err := password.IsValid(newPW, password.OK); err != nil {
 		return err
 	}
-	if err := a.password.Set(newPW); err != nil {
-		return err
-	}
 
-	// All the revoked tokens are now invalid; no need to mark specifically as
-	// revoked.
-	a.revoked = make(map[string]struct{})
-	return nil
+	// All the issued tokens are now invalid.
+	return a.setPassword(newPW)
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
-	server := rpc.NewServer()
+	server := schema.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -273,34 +461,205 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		// Should be "Bearer AUTH.TOKEN.HERE"
-		rawHeader := r.Header.Get(headerKey)
-		if rawHeader == "" {
-			writeUnauthorizedResponse(w, errNoToken)
-			return
-		}
-		if !strings.HasPrefix(rawHeader, headerValStart) {
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			writeUnauthorizedResponse(w, errAuthHeaderNotParsable)
+		tokenStr, err := parseHeader(r.Header.Get(headerKey))
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
 			return
 		}
-		// Returns actual auth token. Slice guaranteed to not go OOB
-		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		claims, err := a.authenticateToken(tokenStr, r.URL.Path)
+		if err != nil {
 			writeUnauthorizedResponse(w, err)
 			return
 		}
 
-		h.ServeHTTP(w, r)
+		// The body is only read if the token is limited to specific methods,
+		// so that other requests are streamed to [h].
+		if len(claims.Methods) > 0 {
+			methods, err := readMethods(w, r)
+			if err != nil {
+				writeUnauthorizedResponse(w, err)
+				return
+			}
+			if err := authorizeMethods(claims, methods); err != nil {
+				writeUnauthorizedResponse(w, err)
+				return
+			}
+		}
+
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenIDKey{}, claims.Id)))
 	})
 }
 
-// getTokenKey returns the key to use when making and parsing tokens
-func (a *auth) getTokenKey(t *jwt.Token) (interface{}, error) {
-	if t.Method != jwt.SigningMethodHS256 {
-		return nil, errInvalidSigningMethod
+func (a *auth) AuthorizeGRPC(ctx context.Context, url, method string) (context.Context, error) {
+	rawHeader := ""
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(headerKey); len(values) > 0 {
+		rawHeader = values[0]
+	}
+	tokenStr, err := parseHeader(rawHeader)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := a.authenticateToken(tokenStr, url)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeMethods(claims, []string{method}); err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, tokenIDKey{}, claims.Id), nil
+}
+
+// parseHeader returns the auth token in [rawHeader], which should be
+// "Bearer AUTH.TOKEN.HERE".
+func parseHeader(rawHeader string) (string, error) {
+	if rawHeader == "" {
+		return "", errNoToken
+	}
+	if !strings.HasPrefix(rawHeader, headerValStart) {
+		return "", errAuthHeaderNotParsable
+	}
+	// Returns actual auth token. Slice guaranteed to not go OOB
+	return rawHeader[len(headerValStart):], nil
+}
+
+// readMethods returns the JSON-RPC methods that [r] calls, or nil if [r]
+// isn't a JSON-RPC request. The body of [r] is replaced so that it can be read
+// again. Bodies larger than [server.MaxRequestBodySize] are rejected.
+func readMethods(w http.ResponseWriter, r *http.Request) ([]string, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, server.MaxRequestBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Body.Close(); err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	type call struct {
+		Method string `json:"method"`
+	}
+
+	// A batch of calls is an array of calls.
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		calls := []call{}
+		if err := stdjson.Unmarshal(body, &calls); err != nil {
+			return nil, nil
+		}
+		methods := make([]string, len(calls))
+		for i, call := range calls {
+			methods[i] = call.Method
+		}
+		return methods, nil
+	}
+
+	singleCall := call{}
+	if err := stdjson.Unmarshal(body, &singleCall); err != nil || singleCall.Method == "" {
+		return nil, nil
+	}
+	return []string{singleCall.Method}, nil
+}
+
+// parseToken returns the claims of [tokenStr] and the record of the token.
+// Assumes [a.lock] is held.
+func (a *auth) parseToken(tokenStr string) (*endpointClaims, *tokenRecord, error) {
+	var record *tokenRecord
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, errInvalidSigningMethod
+		}
+		claims, ok := t.Claims.(*endpointClaims)
+		if !ok {
+			return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", t.Claims)
+		}
+		var err error
+		record, err = a.getToken(claims.Id)
+		if err != nil {
+			return nil, err
+		}
+		// Each token is signed with its own secret.
+		return record.Secret[:], nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		// Error is intentionally dropped here as there is nothing left to do
+		// with it.
+		return nil, nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+	}
+	return claims, record, nil
+}
+
+// getToken returns the record of the token whose ID is [id].
+// Assumes [a.lock] is held.
+func (a *auth) getToken(id string) (*tokenRecord, error) {
+	recordBytes, err := a.tokenDB.Get([]byte(id))
+	if err == database.ErrNotFound {
+		return nil, errUnknownToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	record := &tokenRecord{}
+	_, err = c.Unmarshal(recordBytes, record)
+	return record, err
+}
+
+// putToken persists [record] as the record of the token whose ID is [id].
+// Assumes [a.lock] is held.
+func (a *auth) putToken(id string, record *tokenRecord) error {
+	recordBytes, err := c.Marshal(codecVersion, record)
+	if err != nil {
+		return err
+	}
+	return a.tokenDB.Put([]byte(id), recordBytes)
+}
+
+// getPassword returns the persisted password hash, or nil if there isn't one.
+func (a *auth) getPassword() (*password.Hash, error) {
+	passwordBytes, err := a.singletonDB.Get(passwordKey)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	passwordHash := &password.Hash{}
+	_, err = c.Unmarshal(passwordBytes, passwordHash)
+	return passwordHash, err
+}
+
+// putPassword writes the password hash to [w].
+func (a *auth) putPassword(w database.KeyValueWriter) error {
+	passwordBytes, err := c.Marshal(codecVersion, &a.password)
+	if err != nil {
+		return err
+	}
+	return w.Put(passwordKey, passwordBytes)
+}
+
+// setPassword sets the password to [pw] and removes every issued token.
+// Assumes [a.lock] is held, if [a] is in use.
+func (a *auth) setPassword(pw string) error {
+	if err := a.password.SetWithParams(pw, a.hashParams); err != nil {
+		return err
+	}
+
+	singletonBatch := a.singletonDB.NewBatch()
+	if err := a.putPassword(singletonBatch); err != nil {
+		return err
+	}
+	tokenBatch := a.tokenDB.NewBatch()
+	if err := tokenBatch.DeleteRange(nil, nil); err != nil {
+		return err
 	}
-	return a.password.Password[:], nil
+	return atomic.WriteAll(singletonBatch, tokenBatch)
 }
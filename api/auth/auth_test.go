@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -18,6 +19,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"google.golang.org/grpc/metadata"
+
+	"context"
+	"github.com/sankar-boro/axia-network-v2/api/server"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/password"
 )
@@ -38,31 +44,35 @@ func init() {
 var dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
 
 func TestNewTokenWrongPassword(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
-	_, err := auth.NewToken("", defaultTokenLifespan, []string{"endpoint1, endpoint2"})
+	_, err := auth.NewToken("", defaultTokenLifespan, []string{"endpoint1, endpoint2"}, nil)
 	assert.Error(t, err, "should have failed because password is wrong")
 
-	_, err = auth.NewToken("notThePassword", defaultTokenLifespan, []string{"endpoint1, endpoint2"})
+	_, err = auth.NewToken("notThePassword", defaultTokenLifespan, []string{"endpoint1, endpoint2"}, nil)
 	assert.Error(t, err, "should have failed because password is wrong")
 }
 
 func TestNewTokenHappyPath(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
 
 	now := time.Now()
 	auth.clock.Set(now)
 
 	// Make a token
 	endpoints := []string{"endpoint1", "endpoint2", "endpoint3"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	// Parse the token
-	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(*jwt.Token) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(t *jwt.Token) (interface{}, error) {
 		auth.lock.RLock()
 		defer auth.lock.RUnlock()
-		return auth.password.Password[:], nil
+		record, err := auth.getToken(t.Claims.(*endpointClaims).Id)
+		if err != nil {
+			return nil, err
+		}
+		return record.Secret[:], nil
 	})
 	assert.NoError(t, err, "couldn't parse new token")
 
@@ -75,11 +85,11 @@ func TestNewTokenHappyPath(t *testing.T) {
 }
 
 func TestTokenHasWrongSig(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
 
 	// Make a token
 	endpoints := []string{"endpoint1", "endpoint2", "endpoint3"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	// Try to parse the token using the wrong password
@@ -100,7 +110,7 @@ func TestTokenHasWrongSig(t *testing.T) {
 }
 
 func TestChangePassword(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
 
 	password2 := "fejhkefjhefjhefhje" // #nosec G101
 	var err error
@@ -128,24 +138,27 @@ func TestChangePassword(t *testing.T) {
 }
 
 func TestRevokeToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	err = auth.RevokeToken(tokenStr, testPassword)
 	assert.NoError(t, err, "should have succeeded")
-	assert.Len(t, auth.revoked, 1, "revoked token list is incorrect")
+	tokens, err := auth.ListTokens(testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1, "token list is incorrect")
+	assert.True(t, tokens[0].Revoked, "token should have been revoked")
 }
 
 func TestWrapHandlerHappyPath(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -159,12 +172,31 @@ func TestWrapHandlerHappyPath(t *testing.T) {
 	}
 }
 
+func TestWrapHandlerTokenID(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
+
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"}, nil)
+	assert.NoError(t, err)
+	tokens, err := auth.ListTokens(testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+
+	tokenID := ""
+	wrappedHandler := auth.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenID, _ = TokenID(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/info", strings.NewReader(""))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+	wrappedHandler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, tokens[0].ID, tokenID)
+}
+
 func TestWrapHandlerRevokedToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	err = auth.RevokeToken(tokenStr, testPassword)
@@ -184,13 +216,13 @@ func TestWrapHandlerRevokedToken(t *testing.T) {
 }
 
 func TestWrapHandlerExpiredToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
 
 	auth.clock.Set(time.Now().Add(-2 * defaultTokenLifespan))
 
 	// Make a token that expired well in the past
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -207,7 +239,7 @@ func TestWrapHandlerExpiredToken(t *testing.T) {
 }
 
 func TestWrapHandlerNoAuthToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -222,11 +254,11 @@ func TestWrapHandlerNoAuthToken(t *testing.T) {
 }
 
 func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	unauthorizedEndpoints := []string{"/ext/bc/Swap", "/ext/metrics", "", "/foo", "/ext/info/foo"}
@@ -244,11 +276,11 @@ func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
 }
 
 func TestWrapHandlerAuthEndpoint(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics", "", "/foo", "/ext/info/foo"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -260,11 +292,11 @@ func TestWrapHandlerAuthEndpoint(t *testing.T) {
 }
 
 func TestWrapHandlerAccessAll(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	// Make a token that allows access to all endpoints
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics", "", "/foo", "/ext/foo/info"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"})
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"}, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -285,11 +317,11 @@ func TestWriteUnauthorizedResponse(t *testing.T) {
 }
 
 func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	err = auth.RevokeToken(tokenStr, testPassword)
@@ -309,7 +341,7 @@ func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
 }
 
 func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/Swap", "/ext/metrics"}
@@ -344,3 +376,130 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		assert.Regexp(t, unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+func TestWrapHandlerMethods(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
+
+	// Make a token that only allows calling platform.getHeight
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"}, []string{"platform.getHeight"})
+	assert.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The body must still be readable by the wrapped handler
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "platform.getHeight")
+	}))
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{body: `{"jsonrpc":"2.0","id":1,"method":"platform.getHeight","params":{}}`, code: http.StatusOK},
+		{body: `[{"jsonrpc":"2.0","id":1,"method":"platform.getHeight"},{"jsonrpc":"2.0","id":2,"method":"platform.getHeight"}]`, code: http.StatusOK},
+		{body: `{"jsonrpc":"2.0","id":1,"method":"platform.exportKey","params":{}}`, code: http.StatusUnauthorized},
+		{body: `[{"jsonrpc":"2.0","id":1,"method":"platform.getHeight"},{"jsonrpc":"2.0","id":2,"method":"platform.exportKey"}]`, code: http.StatusUnauthorized},
+		{body: ``, code: http.StatusUnauthorized},
+		{body: `not json`, code: http.StatusUnauthorized},
+		{body: strings.Repeat(" ", server.MaxRequestBodySize) + `{"jsonrpc":"2.0","id":1,"method":"platform.getHeight","params":{}}`, code: http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/bc/P", strings.NewReader(test.body))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		assert.Equal(t, test.code, rr.Code, test.body)
+	}
+}
+
+func TestAuthorizeGRPC(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
+
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"/ext/bc/P"}, []string{"platform.getHeight"})
+	assert.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", fmt.Sprintf("Bearer %s", tokenStr)))
+
+	authorizedCtx, err := auth.AuthorizeGRPC(ctx, "/ext/bc/P", "platform.getHeight")
+	assert.NoError(t, err)
+	_, ok := TokenID(authorizedCtx)
+	assert.True(t, ok)
+
+	_, err = auth.AuthorizeGRPC(ctx, "/ext/bc/P", "platform.exportKey")
+	assert.ErrorIs(t, err, errTokenMethodNotAllowed)
+
+	_, err = auth.AuthorizeGRPC(ctx, "/ext/info", "platform.getHeight")
+	assert.ErrorIs(t, err, errTokenInsufficientPermission)
+
+	_, err = auth.AuthorizeGRPC(context.Background(), "/ext/bc/P", "platform.getHeight")
+	assert.ErrorIs(t, err, errNoToken)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", tokenStr))
+	_, err = auth.AuthorizeGRPC(ctx, "/ext/bc/P", "platform.getHeight")
+	assert.ErrorIs(t, err, errAuthHeaderNotParsable)
+}
+
+func TestTokensPersist(t *testing.T) {
+	db := memdb.New()
+	params := password.Params{Time: 1, Memory: 8 * 1024, Threads: 1}
+
+	auth, err := New(logging.NoLog{}, "auth", testPassword, params, db)
+	assert.NoError(t, err)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"}, nil)
+	assert.NoError(t, err)
+	revokedTokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, auth.RevokeToken(revokedTokenStr, testPassword))
+
+	// Restarting with the same password keeps the tokens and revocations
+	auth, err = New(logging.NoLog{}, "auth", testPassword, params, db)
+	assert.NoError(t, err)
+	assert.NoError(t, auth.AuthenticateToken(tokenStr, "/ext/info"))
+	err = auth.AuthenticateToken(revokedTokenStr, "/ext/info")
+	assert.ErrorIs(t, err, errTokenRevoked)
+
+	// Restarting with different hash parameters keeps the tokens
+	auth, err = New(logging.NoLog{}, "auth", testPassword, password.Params{Time: 2, Memory: 8 * 1024, Threads: 1}, db)
+	assert.NoError(t, err)
+	assert.NoError(t, auth.AuthenticateToken(tokenStr, "/ext/info"))
+
+	// Restarting with a different password removes the tokens
+	auth, err = New(logging.NoLog{}, "auth", "fejhkefjhefjhefhje", params, db)
+	assert.NoError(t, err)
+	assert.Error(t, auth.AuthenticateToken(tokenStr, "/ext/info"))
+	tokens, err := auth.ListTokens("fejhkefjhefjhefhje")
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestListTokens(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New()).(*auth)
+
+	now := time.Now()
+	auth.clock.Set(now)
+
+	_, err := auth.NewToken(testPassword, time.Minute, []string{"/ext/info"}, nil)
+	assert.NoError(t, err)
+	auth.clock.Set(now.Add(time.Second))
+	_, err = auth.NewToken(testPassword, time.Hour, []string{"/ext/bc/P"}, []string{"platform.getHeight"})
+	assert.NoError(t, err)
+
+	_, err = auth.ListTokens("notThePassword")
+	assert.ErrorIs(t, err, errWrongPassword)
+
+	tokens, err := auth.ListTokens(testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, []string{"/ext/info"}, tokens[0].Endpoints)
+	assert.EqualValues(t, now.Add(time.Minute).Unix(), tokens[0].ExpiresAt)
+	assert.Equal(t, []string{"platform.getHeight"}, tokens[1].Methods)
+
+	assert.NoError(t, auth.RevokeTokenByID(tokens[1].ID, testPassword))
+	assert.ErrorIs(t, auth.RevokeTokenByID("unknown", testPassword), errUnknownToken)
+
+	// Expired tokens aren't listed
+	auth.clock.Set(now.Add(2 * time.Minute))
+	tokens, err = auth.ListTokens(testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.True(t, tokens[0].Revoked)
+}
@@ -15,4 +15,8 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Each element is a JSON-RPC method, e.g. "platform.getHeight", that the
+	// token allows calling. If methods is empty, all methods may be called.
+	Methods []string `json:"methods,omitempty"`
 }
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"github.com/sankar-boro/axia-network-v2/codec"
+	"github.com/sankar-boro/axia-network-v2/codec/linearcodec"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+)
+
+const (
+	maxPackerSize = 64 * units.KiB // max size, in bytes, of something being marshalled by Marshal()
+
+	codecVersion = 0
+)
+
+var c codec.Manager
+
+func init() {
+	lc := linearcodec.NewDefault()
+	c = codec.NewManager(maxPackerSize)
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
@@ -5,8 +5,10 @@ package auth
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
 )
 
 // Service that serves the Auth API functionality.
@@ -26,32 +28,64 @@ type NewTokenArgs struct {
 	// allows access to all API endpoints. [Endpoints] must have between 1 and
 	// [maxEndpoints] elements
 	Endpoints []string `json:"endpoints"`
+	// Methods that may be called with this token e.g. if methods is
+	// ["platform.getHeight"] then the token holder can only call
+	// platform.getHeight. If [Methods] is empty, all methods may be called.
+	// [Methods] must have at most [maxMethods] elements
+	Methods []string `json:"methods"`
+	// Lifespan is the number of seconds until the token expires. If zero, the
+	// token expires in [defaultTokenLifespan].
+	Lifespan json.Uint64 `json:"lifespan"`
 }
 
 type Token struct {
-	Token string `json:"token"` // The new token. Expires in [TokenLifespan].
+	Token string `json:"token"` // The new token. Expires in [Lifespan].
 }
 
 func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
 	s.auth.log.Debug("Auth: NewToken called")
 
+	lifespan := defaultTokenLifespan
+	if args.Lifespan != 0 {
+		lifespan = time.Duration(args.Lifespan) * time.Second
+	}
+
 	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	reply.Token, err = s.auth.NewToken(args.Password.Password, lifespan, args.Endpoints, args.Methods)
 	return err
 }
 
 type RevokeTokenArgs struct {
 	Password
 	Token
+	// TokenID is the ID of the token to revoke, as returned by ListTokens.
+	// Only used if [Token] is empty.
+	TokenID string `json:"tokenID"`
 }
 
 func (s *Service) RevokeToken(_ *http.Request, args *RevokeTokenArgs, reply *api.SuccessResponse) error {
 	s.auth.log.Debug("Auth: RevokeToken called")
 
 	reply.Success = true
+	if args.Token.Token == "" && args.TokenID != "" {
+		return s.auth.RevokeTokenByID(args.TokenID, args.Password.Password)
+	}
 	return s.auth.RevokeToken(args.Token.Token, args.Password.Password)
 }
 
+type ListTokensReply struct {
+	Tokens []TokenInfo `json:"tokens"`
+}
+
+// ListTokens returns the tokens that haven't expired yet
+func (s *Service) ListTokens(_ *http.Request, args *Password, reply *ListTokensReply) error {
+	s.auth.log.Debug("Auth: ListTokens called")
+
+	var err error
+	reply.Tokens, err = s.auth.ListTokens(args.Password)
+	return err
+}
+
 type ChangePasswordArgs struct {
 	OldPassword string `json:"oldPassword"` // Current authorization password
 	NewPassword string `json:"newPassword"` // New authorization password
@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+)
+
+// number of bytes of the secret that a token is signed with
+const tokenSecretLen = 32
+
+// tokenRecord is what is persisted about an issued token
+type tokenRecord struct {
+	// Secret that the token is signed with
+	Secret    [tokenSecretLen]byte `serialize:"true"`
+	Endpoints []string             `serialize:"true"`
+	Methods   []string             `serialize:"true"`
+	// Unix times that the token was issued at and that it expires at
+	IssuedAt  uint64 `serialize:"true"`
+	ExpiresAt uint64 `serialize:"true"`
+	Revoked   bool   `serialize:"true"`
+}
+
+// TokenInfo describes an issued token, without the token itself
+type TokenInfo struct {
+	ID        string      `json:"id"`
+	Endpoints []string    `json:"endpoints"`
+	Methods   []string    `json:"methods"`
+	IssuedAt  json.Uint64 `json:"issuedAt"`
+	ExpiresAt json.Uint64 `json:"expiresAt"`
+	Revoked   bool        `json:"revoked"`
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+
+	stdjson "encoding/json"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+
+	healthpb "github.com/sankar-boro/axia-network-v2/proto/pb/health"
+)
+
+var _ healthpb.HealthServer = &grpcServer{}
+
+// NewGRPCServer returns a gRPC server that mirrors the health JSON-RPC API
+func NewGRPCServer(log logging.Logger, reporter Reporter) healthpb.HealthServer {
+	return &grpcServer{
+		service: &Service{
+			log:    log,
+			health: reporter,
+		},
+	}
+}
+
+// grpcServer serves the health API over gRPC by calling into [service]
+type grpcServer struct {
+	healthpb.UnsafeHealthServer
+	service *Service
+}
+
+func (s *grpcServer) Readiness(context.Context, *emptypb.Empty) (*healthpb.HealthResponse, error) {
+	reply := APIHealthReply{}
+	if err := s.service.Readiness(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return newHealthResponse(&reply)
+}
+
+func (s *grpcServer) Health(context.Context, *emptypb.Empty) (*healthpb.HealthResponse, error) {
+	reply := APIHealthReply{}
+	if err := s.service.Health(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return newHealthResponse(&reply)
+}
+
+func (s *grpcServer) Liveness(context.Context, *emptypb.Empty) (*healthpb.HealthResponse, error) {
+	reply := APIHealthReply{}
+	if err := s.service.Liveness(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return newHealthResponse(&reply)
+}
+
+func newHealthResponse(reply *APIHealthReply) (*healthpb.HealthResponse, error) {
+	checks := make(map[string]*healthpb.Result, len(reply.Checks))
+	for name, result := range reply.Checks {
+		details, err := stdjson.Marshal(result.Details)
+		if err != nil {
+			return nil, err
+		}
+		pbResult := &healthpb.Result{
+			Details:            details,
+			Timestamp:          timestamppb.New(result.Timestamp),
+			Duration:           durationpb.New(result.Duration),
+			ContiguousFailures: result.ContiguousFailures,
+		}
+		if result.Error != nil {
+			pbResult.Error = *result.Error
+		}
+		if result.TimeOfFirstFailure != nil {
+			pbResult.TimeOfFirstFailure = timestamppb.New(*result.TimeOfFirstFailure)
+		}
+		checks[name] = pbResult
+	}
+	return &healthpb.HealthResponse{
+		Checks:  checks,
+		Healthy: reply.Healthy,
+	}, nil
+}
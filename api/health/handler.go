@@ -8,8 +8,7 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/utils/json"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 )
@@ -17,7 +16,7 @@ import (
 // NewGetAndPostHandler returns a health handler that supports GET and jsonrpc
 // POST requests.
 func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer := schema.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -42,7 +41,7 @@ func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler,
 		},
 		"health",
 	)
-	return handler, err
+	return schema.NewHandler(handler, newServer), err
 }
 
 // NewGetHandler return a health handler that supports GET requests reporting
@@ -21,6 +21,7 @@ type Client interface {
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	PeerDetails(context.Context, []ids.NodeID, ...rpc.Option) ([]PeerDetail, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
@@ -84,6 +85,14 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) PeerDetails(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]PeerDetail, error) {
+	res := &PeerDetailsReply{}
+	err := c.requester.SendRequest(ctx, "peerDetails", &PeersArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res.Peers, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "isBootstrapped", &IsBootstrappedArgs{
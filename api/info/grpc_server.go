@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	infopb "github.com/sankar-boro/axia-network-v2/proto/pb/info"
+)
+
+var _ infopb.InfoServer = &grpcServer{}
+
+// grpcServer serves the info API over gRPC by calling into [service]
+type grpcServer struct {
+	infopb.UnsafeInfoServer
+	service *Info
+}
+
+func (s *grpcServer) GetNodeVersion(context.Context, *emptypb.Empty) (*infopb.GetNodeVersionResponse, error) {
+	reply := GetNodeVersionReply{}
+	if err := s.service.GetNodeVersion(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNodeVersionResponse{
+		Version:         reply.Version,
+		DatabaseVersion: reply.DatabaseVersion,
+		GitCommit:       reply.GitCommit,
+		VmVersions:      reply.VMVersions,
+	}, nil
+}
+
+func (s *grpcServer) GetNodeID(context.Context, *emptypb.Empty) (*infopb.GetNodeIDResponse, error) {
+	reply := GetNodeIDReply{}
+	if err := s.service.GetNodeID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNodeIDResponse{
+		NodeId: reply.NodeID[:],
+	}, nil
+}
+
+func (s *grpcServer) GetNodeIP(context.Context, *emptypb.Empty) (*infopb.GetNodeIPResponse, error) {
+	reply := GetNodeIPReply{}
+	if err := s.service.GetNodeIP(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNodeIPResponse{
+		Ip: reply.IP,
+	}, nil
+}
+
+func (s *grpcServer) GetNetworkID(context.Context, *emptypb.Empty) (*infopb.GetNetworkIDResponse, error) {
+	reply := GetNetworkIDReply{}
+	if err := s.service.GetNetworkID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNetworkIDResponse{
+		NetworkId: uint32(reply.NetworkID),
+	}, nil
+}
+
+func (s *grpcServer) GetNetworkName(context.Context, *emptypb.Empty) (*infopb.GetNetworkNameResponse, error) {
+	reply := GetNetworkNameReply{}
+	if err := s.service.GetNetworkName(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNetworkNameResponse{
+		NetworkName: reply.NetworkName,
+	}, nil
+}
+
+func (s *grpcServer) GetBlockchainID(_ context.Context, req *infopb.GetBlockchainIDRequest) (*infopb.GetBlockchainIDResponse, error) {
+	reply := GetBlockchainIDReply{}
+	if err := s.service.GetBlockchainID(nil, &GetBlockchainIDArgs{Alias: req.Alias}, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetBlockchainIDResponse{
+		BlockchainId: reply.BlockchainID[:],
+	}, nil
+}
+
+func (s *grpcServer) IsBootstrapped(_ context.Context, req *infopb.IsBootstrappedRequest) (*infopb.IsBootstrappedResponse, error) {
+	reply := IsBootstrappedResponse{}
+	if err := s.service.IsBootstrapped(nil, &IsBootstrappedArgs{Chain: req.Chain}, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.IsBootstrappedResponse{
+		IsBootstrapped: reply.IsBootstrapped,
+	}, nil
+}
+
+func (s *grpcServer) GetTxFee(context.Context, *emptypb.Empty) (*infopb.GetTxFeeResponse, error) {
+	reply := GetTxFeeResponse{}
+	if err := s.service.GetTxFee(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetTxFeeResponse{
+		TxFee:                 uint64(reply.TxFee),
+		CreateAssetTxFee:      uint64(reply.CreateAssetTxFee),
+		CreateAllychainTxFee:  uint64(reply.CreateAllychainTxFee),
+		CreateBlockchainTxFee: uint64(reply.CreateBlockchainTxFee),
+	}, nil
+}
+
+func (s *grpcServer) Uptime(context.Context, *emptypb.Empty) (*infopb.UptimeResponse, error) {
+	reply := UptimeResponse{}
+	if err := s.service.Uptime(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.UptimeResponse{
+		RewardingStakePercentage:  float64(reply.RewardingStakePercentage),
+		WeightedAveragePercentage: float64(reply.WeightedAveragePercentage),
+	}, nil
+}
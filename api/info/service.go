@@ -8,14 +8,14 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/network"
 	"github.com/sankar-boro/axia-network-v2/network/peer"
 	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/benchlist"
+	"github.com/sankar-boro/axia-network-v2/snow/networking/router"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
@@ -23,6 +23,8 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/version"
 	"github.com/sankar-boro/axia-network-v2/vms"
+
+	infopb "github.com/sankar-boro/axia-network-v2/proto/pb/info"
 )
 
 var (
@@ -54,7 +56,8 @@ type Parameters struct {
 	VMManager             vms.Manager
 }
 
-// NewService returns a new admin API service
+// NewService returns a new admin API service and the gRPC service that mirrors
+// it
 func NewService(
 	parameters Parameters,
 	log logging.Logger,
@@ -65,12 +68,8 @@ func NewService(
 	versionParser version.ApplicationParser,
 	validators validators.Set,
 	benchlist benchlist.Manager,
-) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
-	codec := json.NewCodec()
-	newServer.RegisterCodec(codec, "application/json")
-	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	if err := newServer.RegisterService(&Info{
+) (*common.HTTPHandler, *common.GRPCService, error) {
+	service := &Info{
 		Parameters:    parameters,
 		log:           log,
 		chainManager:  chainManager,
@@ -80,10 +79,19 @@ func NewService(
 		versionParser: versionParser,
 		validators:    validators,
 		benchlist:     benchlist,
-	}, "info"); err != nil {
-		return nil, err
 	}
-	return &common.HTTPHandler{Handler: newServer}, nil
+	newServer := schema.NewServer()
+	codec := json.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	if err := newServer.RegisterService(service, "info"); err != nil {
+		return nil, nil, err
+	}
+	return &common.HTTPHandler{Handler: newServer}, &common.GRPCService{
+		Name: "info",
+		Desc: &infopb.Info_ServiceDesc,
+		Impl: &grpcServer{service: service},
+	}, nil
 }
 
 // GetNodeVersionReply are the results from calling GetNodeVersion
@@ -218,6 +226,58 @@ func (service *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply)
 	return nil
 }
 
+// PeerDetail is a peer and the traffic exchanged with it since it connected
+type PeerDetail struct {
+	Peer
+
+	// Traffic exchanged with the peer, by op
+	Traffic map[string]peer.Traffic `json:"traffic"`
+	// TotalTraffic is the sum of [Traffic]
+	TotalTraffic peer.Traffic `json:"totalTraffic"`
+	// ChainTraffic is the traffic the peer sent, by chain ID. The traffic sent
+	// to chains that this node doesn't run is under "unknown".
+	ChainTraffic map[string]router.ChainTraffic `json:"chainTraffic"`
+}
+
+// PeerDetailsReply are the results from calling PeerDetails
+type PeerDetailsReply struct {
+	// Number of elements in [Peers]
+	NumPeers json.Uint64 `json:"numPeers"`
+	// Each element is a peer
+	Peers []PeerDetail `json:"peers"`
+}
+
+// PeerDetails returns the current peers and the traffic exchanged with each of
+// them
+func (service *Info) PeerDetails(_ *http.Request, args *PeersArgs, reply *PeerDetailsReply) error {
+	service.log.Debug("Info: PeerDetails called")
+
+	chainRouter := service.chainManager.Router()
+	peers := service.networking.PeerDetails(args.NodeIDs)
+	reply.Peers = make([]PeerDetail, len(peers))
+	for i, p := range peers {
+		traffic := make(map[string]peer.Traffic, len(p.Traffic))
+		for op, opTraffic := range p.Traffic {
+			traffic[op.String()] = opTraffic
+		}
+		var chainTraffic map[string]router.ChainTraffic
+		if chainRouter != nil {
+			chainTraffic = chainRouter.ChainTraffic(p.ID)
+		}
+		reply.Peers[i] = PeerDetail{
+			Peer: Peer{
+				Info:    p.Info,
+				Benched: service.benchlist.GetBenched(p.ID),
+			},
+			Traffic:      traffic,
+			TotalTraffic: peer.TotalTraffic(p.Traffic),
+			ChainTraffic: chainTraffic,
+		}
+	}
+	reply.NumPeers = json.Uint64(len(reply.Peers))
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
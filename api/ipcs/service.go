@@ -7,9 +7,8 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/api/server"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/ids"
@@ -37,7 +36,7 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer serv
 		ipcs: ipcs,
 	}
 
-	newServer := rpc.NewServer()
+	newServer := schema.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -20,6 +20,10 @@ type BlockchainKeystore interface {
 	// values. This Database will not perform any encrypting or decrypting of
 	// values and is not recommended to be used when implementing a VM.
 	GetRawDatabase(username, password string) (database.Database, error)
+
+	// GetKeyParams returns the parameters that the encryption key of the
+	// database is derived from the password with.
+	GetKeyParams(username, password string) (encdb.KeyParams, error)
 }
 
 type blockchainKeystore struct {
@@ -38,3 +42,9 @@ func (bks *blockchainKeystore) GetRawDatabase(username, password string) (databa
 
 	return bks.ks.GetRawDatabase(bks.blockchainID, username, password)
 }
+
+func (bks *blockchainKeystore) GetKeyParams(username, password string) (encdb.KeyParams, error) {
+	bks.ks.log.Debug("Keystore: GetKeyParams called with %s from %s", username, bks.blockchainID)
+
+	return bks.ks.GetKeyParams(username, password)
+}
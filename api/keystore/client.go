@@ -24,6 +24,8 @@ type Client interface {
 	ImportUser(ctx context.Context, importTo api.UserPass, exportedUser []byte, options ...rpc.Option) (bool, error)
 	// Delete the given user
 	DeleteUser(context.Context, api.UserPass, ...rpc.Option) (bool, error)
+	// Change the password of the given user to [newPassword]
+	ChangeUserPassword(ctx context.Context, user api.UserPass, newPassword string, options ...rpc.Option) (bool, error)
 }
 
 // Client implementation for Axia Keystore API Endpoint
@@ -81,3 +83,12 @@ func (c *client) DeleteUser(ctx context.Context, user api.UserPass, options ...r
 	err := c.requester.SendRequest(ctx, "deleteUser", &user, res, options...)
 	return res.Success, err
 }
+
+func (c *client) ChangeUserPassword(ctx context.Context, user api.UserPass, newPassword string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "changeUserPassword", &ChangeUserPasswordArgs{
+		UserPass:    user,
+		NewPassword: newPassword,
+	}, res, options...)
+	return res.Success, err
+}
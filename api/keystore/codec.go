@@ -14,6 +14,10 @@ const (
 	maxSliceLength = 256 * 1024
 
 	codecVersion = 0
+	// paramsCodecVersion is the codec version of password hashes that include
+	// the parameters of the hash, and of exported users that also include the
+	// parameters of their encryption key.
+	paramsCodecVersion = 1
 )
 
 var c codec.Manager
@@ -24,4 +28,7 @@ func init() {
 	if err := c.RegisterCodec(codecVersion, lc); err != nil {
 		panic(err)
 	}
+	if err := c.RegisterCodec(paramsCodecVersion, lc); err != nil {
+		panic(err)
+	}
 }
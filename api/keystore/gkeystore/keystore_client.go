@@ -31,27 +31,41 @@ func NewClient(client keystorepb.KeystoreClient) *Client {
 }
 
 func (c *Client) GetDatabase(username, password string) (*encdb.Database, error) {
-	bcDB, err := c.GetRawDatabase(username, password)
+	bcDB, keyParams, err := c.getDatabase(username, password)
 	if err != nil {
 		return nil, err
 	}
-	return encdb.New([]byte(password), bcDB)
+	return encdb.NewWithParams([]byte(password), keyParams, bcDB)
 }
 
 func (c *Client) GetRawDatabase(username, password string) (database.Database, error) {
+	bcDB, _, err := c.getDatabase(username, password)
+	return bcDB, err
+}
+
+func (c *Client) GetKeyParams(username, password string) (encdb.KeyParams, error) {
+	_, keyParams, err := c.getDatabase(username, password)
+	return keyParams, err
+}
+
+func (c *Client) getDatabase(username, password string) (database.Database, encdb.KeyParams, error) {
 	resp, err := c.client.GetDatabase(context.Background(), &keystorepb.GetDatabaseRequest{
 		Username: username,
 		Password: password,
 	})
 	if err != nil {
-		return nil, err
+		return nil, encdb.KeyParams{}, err
 	}
 
 	clientConn, err := grpcutils.Dial(resp.ServerAddr)
 	if err != nil {
-		return nil, err
+		return nil, encdb.KeyParams{}, err
 	}
 
 	dbClient := rpcdb.NewClient(rpcdbpb.NewDatabaseClient(clientConn))
-	return dbClient, err
+	keyParams := encdb.KeyParams{
+		KDF:  encdb.KDF(resp.Kdf),
+		Salt: resp.Salt,
+	}
+	return dbClient, keyParams, err
 }
@@ -40,6 +40,10 @@ func (s *Server) GetDatabase(
 	if err != nil {
 		return nil, err
 	}
+	keyParams, err := s.ks.GetKeyParams(req.Username, req.Password)
+	if err != nil {
+		return nil, err
+	}
 
 	closer := dbCloser{Database: db}
 
@@ -60,7 +64,11 @@ func (s *Server) GetDatabase(
 		rpcdbpb.RegisterDatabaseServer(server, db)
 		return server
 	})
-	return &keystorepb.GetDatabaseResponse{ServerAddr: serverAddr}, nil
+	return &keystorepb.GetDatabaseResponse{
+		ServerAddr: serverAddr,
+		Kdf:        uint32(keyParams.KDF),
+		Salt:       keyParams.Salt,
+	}, nil
 }
 
 type dbCloser struct {
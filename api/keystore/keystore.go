@@ -9,17 +9,18 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/chains/atomic"
 	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/encdb"
 	"github.com/sankar-boro/axia-network-v2/database/manager"
 	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/utils/json"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/password"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
 )
 
 const (
@@ -30,9 +31,11 @@ const (
 var (
 	errEmptyUsername = errors.New("empty username")
 	errUserMaxLength = fmt.Errorf("username exceeds maximum length of %d chars", maxUserLen)
+	errCostlyHash    = errors.New("password hash parameters exceed the parameters of this node")
 
-	usersPrefix = []byte("users")
-	bcsPrefix   = []byte("bcs")
+	usersPrefix     = []byte("users")
+	bcsPrefix       = []byte("bcs")
+	keyParamsPrefix = []byte("keyParams")
 
 	_ Keystore = &keystore{}
 )
@@ -54,6 +57,10 @@ type Keystore interface {
 	// values and is not recommended to be used when implementing a VM.
 	GetRawDatabase(bID ids.ID, username, password string) (database.Database, error)
 
+	// GetKeyParams returns the parameters that the encryption key of the
+	// databases of [username] is derived from its password with.
+	GetKeyParams(username, password string) (encdb.KeyParams, error)
+
 	// CreateUser attempts to register this username and password as a new user
 	// of the keystore.
 	CreateUser(username, pw string) error
@@ -62,15 +69,21 @@ type Keystore interface {
 	// from the keystore.
 	DeleteUser(username, pw string) error
 
+	// ChangeUserPassword changes the password of the provided username to
+	// [newPW] and re-encrypts all of its data with a key derived from [newPW].
+	ChangeUserPassword(username, oldPW, newPW string) error
+
 	// ListUsers returns all the users that currently exist in this keystore.
 	ListUsers() ([]string, error)
 
 	// ImportUser imports a serialized encoding of a user's information complete
-	// with encrypted database values. The password is integrity checked.
+	// with encrypted database values and the parameters of their encryption
+	// key. The password is integrity checked.
 	ImportUser(username, pw string, user []byte) error
 
 	// ExportUser exports a serialized encoding of a user's information complete
-	// with encrypted database values.
+	// with encrypted database values and the parameters of their encryption
+	// key.
 	ExportUser(username, pw string) ([]byte, error)
 
 	// Get the password that is used by [username]. If [username] doesn't exist,
@@ -83,16 +96,32 @@ type kvPair struct {
 	Value []byte `serialize:"true"`
 }
 
-// user describes the full content of a user
+// user describes the full content of a user, in the format that users were
+// exported in before [paramsCodecVersion]. Its values are encrypted with the
+// zero value of encdb.KeyParams.
 type user struct {
+	password.LegacyHash `serialize:"true"`
+	Data                []kvPair `serialize:"true"`
+}
+
+// userWithParams describes the full content of a user, along with the
+// parameters that its password is hashed with and that its values are
+// encrypted with.
+type userWithParams struct {
 	password.Hash `serialize:"true"`
-	Data          []kvPair `serialize:"true"`
+	Data          []kvPair        `serialize:"true"`
+	KeyParams     encdb.KeyParams `serialize:"true"`
 }
 
 type keystore struct {
 	lock sync.Mutex
 	log  logging.Logger
 
+	// The parameters that new password hashes are derived with. Hashes that
+	// were derived with other parameters are upgraded once their password is
+	// provided.
+	hashParams password.Params
+
 	// Key: username
 	// Value: The hash of that user's password
 	usernameToPassword map[string]*password.Hash
@@ -100,27 +129,36 @@ type keystore struct {
 	// Used to persist users and their data
 	userDB database.Database
 	bcDB   database.Database
-	//           BaseDB
-	//          /      \
-	//    UserDB        BlockchainDB
-	//                 /      |     \
-	//               Usr     Usr    Usr
-	//             /  |  \
-	//          BID  BID  BID
+	//               BaseDB
+	//          /      |       \
+	//    UserDB  KeyParamsDB   BlockchainDB
+	//                         /      |     \
+	//                       Usr     Usr    Usr
+	//                     /  |  \
+	//                  BID  BID  BID
+
+	// Key: username
+	// Value: The parameters that the key of the user's databases is derived
+	// with. Users without parameters use the zero value of encdb.KeyParams.
+	keyParamsDB database.Database
 }
 
-func New(log logging.Logger, dbManager manager.Manager) Keystore {
+// New returns a keystore that stores its users in [dbManager] and hashes their
+// passwords with [hashParams].
+func New(log logging.Logger, dbManager manager.Manager, hashParams password.Params) Keystore {
 	currentDB := dbManager.Current()
 	return &keystore{
 		log:                log,
+		hashParams:         hashParams,
 		usernameToPassword: make(map[string]*password.Hash),
 		userDB:             prefixdb.New(usersPrefix, currentDB.Database),
 		bcDB:               prefixdb.New(bcsPrefix, currentDB.Database),
+		keyParamsDB:        prefixdb.New(keyParamsPrefix, currentDB.Database),
 	}
 }
 
 func (ks *keystore) CreateHandler() (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer := schema.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -137,12 +175,25 @@ func (ks *keystore) NewBlockchainKeyStore(blockchainID ids.ID) BlockchainKeystor
 	}
 }
 
-func (ks *keystore) GetDatabase(bID ids.ID, username, password string) (*encdb.Database, error) {
-	bcDB, err := ks.GetRawDatabase(bID, username, password)
+func (ks *keystore) GetDatabase(bID ids.ID, username, pw string) (*encdb.Database, error) {
+	if username == "" {
+		return nil, errEmptyUsername
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := ks.checkPassword(username, pw); err != nil {
+		return nil, err
+	}
+	keyParams, err := ks.getKeyParams(username)
 	if err != nil {
 		return nil, err
 	}
-	return encdb.New([]byte(password), bcDB)
+
+	userDB := prefixdb.New([]byte(username), ks.bcDB)
+	bcDB := prefixdb.NewNested(bID[:], userDB)
+	return encdb.NewWithParams([]byte(pw), keyParams, bcDB)
 }
 
 func (ks *keystore) GetRawDatabase(bID ids.ID, username, pw string) (database.Database, error) {
@@ -153,19 +204,29 @@ func (ks *keystore) GetRawDatabase(bID ids.ID, username, pw string) (database.Da
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
-	passwordHash, err := ks.getPassword(username)
-	if err != nil {
+	if err := ks.checkPassword(username, pw); err != nil {
 		return nil, err
 	}
-	if passwordHash == nil || !passwordHash.Check(pw) {
-		return nil, fmt.Errorf("incorrect password for user %q", username)
-	}
 
 	userDB := prefixdb.New([]byte(username), ks.bcDB)
 	bcDB := prefixdb.NewNested(bID[:], userDB)
 	return bcDB, nil
 }
 
+func (ks *keystore) GetKeyParams(username, pw string) (encdb.KeyParams, error) {
+	if username == "" {
+		return encdb.KeyParams{}, errEmptyUsername
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := ks.checkPassword(username, pw); err != nil {
+		return encdb.KeyParams{}, err
+	}
+	return ks.getKeyParams(username)
+}
+
 func (ks *keystore) CreateUser(username, pw string) error {
 	if username == "" {
 		return errEmptyUsername
@@ -190,16 +251,34 @@ func (ks *keystore) CreateUser(username, pw string) error {
 	}
 
 	passwordHash = &password.Hash{}
-	if err := passwordHash.Set(pw); err != nil {
+	if err := passwordHash.SetWithParams(pw, ks.hashParams); err != nil {
 		return err
 	}
 
-	passwordBytes, err := c.Marshal(codecVersion, passwordHash)
+	passwordBytes, err := c.Marshal(paramsCodecVersion, passwordHash)
 	if err != nil {
 		return err
 	}
 
-	if err := ks.userDB.Put([]byte(username), passwordBytes); err != nil {
+	keyParams, err := encdb.DefaultKeyParams()
+	if err != nil {
+		return err
+	}
+	keyParamsBytes, err := c.Marshal(codecVersion, &keyParams)
+	if err != nil {
+		return err
+	}
+
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put([]byte(username), passwordBytes); err != nil {
+		return err
+	}
+	keyParamsBatch := ks.keyParamsDB.NewBatch()
+	if err := keyParamsBatch.Put([]byte(username), keyParamsBytes); err != nil {
+		return err
+	}
+
+	if err := atomic.WriteAll(userBatch, keyParamsBatch); err != nil {
 		return err
 	}
 	ks.usernameToPassword[username] = passwordHash
@@ -234,6 +313,10 @@ func (ks *keystore) DeleteUser(username, pw string) error {
 	if err := userBatch.Delete(userNameBytes); err != nil {
 		return err
 	}
+	keyParamsBatch := ks.keyParamsDB.NewBatch()
+	if err := keyParamsBatch.Delete(userNameBytes); err != nil {
+		return err
+	}
 
 	userDataDB := prefixdb.New(userNameBytes, ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
@@ -251,7 +334,7 @@ func (ks *keystore) DeleteUser(username, pw string) error {
 		return err
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := atomic.WriteAll(dataBatch, userBatch, keyParamsBatch); err != nil {
 		return err
 	}
 
@@ -260,6 +343,92 @@ func (ks *keystore) DeleteUser(username, pw string) error {
 	return nil
 }
 
+func (ks *keystore) ChangeUserPassword(username, oldPW, newPW string) error {
+	if username == "" {
+		return errEmptyUsername
+	}
+	if len(username) > maxUserLen {
+		return errUserMaxLength
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := ks.checkPassword(username, oldPW); err != nil {
+		return err
+	}
+	if err := password.IsValid(newPW, password.OK); err != nil {
+		return err
+	}
+
+	oldKeyParams, err := ks.getKeyParams(username)
+	if err != nil {
+		return err
+	}
+	newKeyParams, err := encdb.DefaultKeyParams()
+	if err != nil {
+		return err
+	}
+
+	// The values of every blockchain of the user are stored under the user's
+	// prefix, so they can be re-encrypted without knowing the blockchains.
+	// The re-encrypted values are staged in a versiondb so that they are
+	// written atomically with the new password.
+	userDataDB := prefixdb.New([]byte(username), ks.bcDB)
+	oldDB, err := encdb.NewWithParams([]byte(oldPW), oldKeyParams, userDataDB)
+	if err != nil {
+		return err
+	}
+	vdb := versiondb.New(userDataDB)
+	newDB, err := encdb.NewWithParams([]byte(newPW), newKeyParams, vdb)
+	if err != nil {
+		return err
+	}
+
+	it := oldDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if err := newDB.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("couldn't decrypt the data of user %q: %w", username, err)
+	}
+
+	passwordHash := &password.Hash{}
+	if err := passwordHash.SetWithParams(newPW, ks.hashParams); err != nil {
+		return err
+	}
+	passwordBytes, err := c.Marshal(paramsCodecVersion, passwordHash)
+	if err != nil {
+		return err
+	}
+	keyParamsBytes, err := c.Marshal(codecVersion, &newKeyParams)
+	if err != nil {
+		return err
+	}
+
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put([]byte(username), passwordBytes); err != nil {
+		return err
+	}
+	keyParamsBatch := ks.keyParamsDB.NewBatch()
+	if err := keyParamsBatch.Put([]byte(username), keyParamsBytes); err != nil {
+		return err
+	}
+	dataBatch, err := vdb.CommitBatch()
+	if err != nil {
+		return err
+	}
+
+	if err := atomic.WriteAll(dataBatch, userBatch, keyParamsBatch); err != nil {
+		return err
+	}
+	ks.usernameToPassword[username] = passwordHash
+	return nil
+}
+
 func (ks *keystore) ListUsers() ([]string, error) {
 	users := []string{}
 
@@ -293,15 +462,30 @@ func (ks *keystore) ImportUser(username, pw string, userBytes []byte) error {
 		return fmt.Errorf("user already exists: %s", username)
 	}
 
-	userData := user{}
-	if _, err := c.Unmarshal(userBytes, &userData); err != nil {
+	userData, err := parseUser(userBytes)
+	if err != nil {
 		return err
 	}
+	// The parameters of an imported hash are chosen by the caller, so they
+	// must not make checking the password more costly than it is for the
+	// users of this node.
+	if userData.Hash.Params != password.LegacyParams && userData.Hash.Params.Exceeds(ks.hashParams) {
+		return errCostlyHash
+	}
 	if !userData.Hash.Check(pw) {
 		return fmt.Errorf("incorrect password for user %q", username)
 	}
+	if userData.Hash.NeedsUpgrade(ks.hashParams) {
+		if err := userData.Hash.SetWithParams(pw, ks.hashParams); err != nil {
+			return err
+		}
+	}
 
-	usrBytes, err := c.Marshal(codecVersion, &userData.Hash)
+	usrBytes, err := c.Marshal(paramsCodecVersion, &userData.Hash)
+	if err != nil {
+		return err
+	}
+	keyParamsBytes, err := c.Marshal(codecVersion, &userData.KeyParams)
 	if err != nil {
 		return err
 	}
@@ -310,6 +494,10 @@ func (ks *keystore) ImportUser(username, pw string, userBytes []byte) error {
 	if err := userBatch.Put([]byte(username), usrBytes); err != nil {
 		return err
 	}
+	keyParamsBatch := ks.keyParamsDB.NewBatch()
+	if err := keyParamsBatch.Put([]byte(username), keyParamsBytes); err != nil {
+		return err
+	}
 
 	userDataDB := prefixdb.New([]byte(username), ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
@@ -319,7 +507,7 @@ func (ks *keystore) ImportUser(username, pw string, userBytes []byte) error {
 		}
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := atomic.WriteAll(dataBatch, userBatch, keyParamsBatch); err != nil {
 		return err
 	}
 	ks.usernameToPassword[username] = &userData.Hash
@@ -337,17 +525,24 @@ func (ks *keystore) ExportUser(username, pw string) ([]byte, error) {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
+	if err := ks.checkPassword(username, pw); err != nil {
+		return nil, err
+	}
 	passwordHash, err := ks.getPassword(username)
 	if err != nil {
 		return nil, err
 	}
-	if passwordHash == nil || !passwordHash.Check(pw) {
-		return nil, fmt.Errorf("incorrect password for user %q", username)
+	keyParams, err := ks.getKeyParams(username)
+	if err != nil {
+		return nil, err
 	}
 
 	userDB := prefixdb.New([]byte(username), ks.bcDB)
 
-	userData := user{Hash: *passwordHash}
+	userData := userWithParams{
+		Hash:      *passwordHash,
+		KeyParams: keyParams,
+	}
 	it := userDB.NewIterator()
 	defer it.Release()
 	for it.Next() {
@@ -361,7 +556,74 @@ func (ks *keystore) ExportUser(username, pw string) ([]byte, error) {
 	}
 
 	// Return the byte representation of the user
-	return c.Marshal(codecVersion, &userData)
+	return c.Marshal(paramsCodecVersion, &userData)
+}
+
+// parseUser parses a user that was exported in the [user] or the
+// [userWithParams] format.
+func parseUser(userBytes []byte) (*userWithParams, error) {
+	p := wrappers.Packer{Bytes: userBytes}
+	if version := p.UnpackShort(); p.Err == nil && version == paramsCodecVersion {
+		userData := &userWithParams{}
+		_, err := c.Unmarshal(userBytes, userData)
+		return userData, err
+	}
+
+	userData := user{}
+	if _, err := c.Unmarshal(userBytes, &userData); err != nil {
+		return nil, err
+	}
+	return &userWithParams{
+		Hash: userData.LegacyHash.Hash(),
+		Data: userData.Data,
+	}, nil
+}
+
+// checkPassword returns an error if [username] doesn't exist or if [pw]
+// isn't its password. If the password hash of [username] wasn't derived with
+// the keystore's parameters, it is re-derived with them.
+func (ks *keystore) checkPassword(username, pw string) error {
+	passwordHash, err := ks.getPassword(username)
+	if err != nil {
+		return err
+	}
+	if passwordHash == nil || !passwordHash.Check(pw) {
+		return fmt.Errorf("incorrect password for user %q", username)
+	}
+	if !passwordHash.NeedsUpgrade(ks.hashParams) {
+		return nil
+	}
+
+	upgradedHash := &password.Hash{}
+	if err := upgradedHash.SetWithParams(pw, ks.hashParams); err != nil {
+		return err
+	}
+	passwordBytes, err := c.Marshal(paramsCodecVersion, upgradedHash)
+	if err != nil {
+		return err
+	}
+	if err := ks.userDB.Put([]byte(username), passwordBytes); err != nil {
+		return err
+	}
+	ks.usernameToPassword[username] = upgradedHash
+	ks.log.Debug("upgraded the password hash of user %q", username)
+	return nil
+}
+
+// getKeyParams returns the parameters that the key of the databases of
+// [username] is derived with.
+func (ks *keystore) getKeyParams(username string) (encdb.KeyParams, error) {
+	keyParams := encdb.KeyParams{}
+	keyParamsBytes, err := ks.keyParamsDB.Get([]byte(username))
+	if err == database.ErrNotFound {
+		// The user was created before key parameters were recorded
+		return keyParams, nil
+	}
+	if err != nil {
+		return keyParams, err
+	}
+	_, err = c.Unmarshal(keyParamsBytes, &keyParams)
+	return keyParams, err
 }
 
 func (ks *keystore) getPassword(username string) (*password.Hash, error) {
@@ -382,7 +644,18 @@ func (ks *keystore) getPassword(username string) (*password.Hash, error) {
 		return nil, err
 	}
 
-	passwordHash = &password.Hash{}
-	_, err = c.Unmarshal(userBytes, passwordHash)
-	return passwordHash, err
+	// Users created before [paramsCodecVersion] have legacy hashes
+	p := wrappers.Packer{Bytes: userBytes}
+	if version := p.UnpackShort(); p.Err == nil && version == paramsCodecVersion {
+		passwordHash = &password.Hash{}
+		_, err = c.Unmarshal(userBytes, passwordHash)
+		return passwordHash, err
+	}
+
+	legacyHash := password.LegacyHash{}
+	if _, err := c.Unmarshal(userBytes, &legacyHash); err != nil {
+		return nil, err
+	}
+	hash := legacyHash.Hash()
+	return &hash, nil
 }
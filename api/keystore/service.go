@@ -12,6 +12,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
 	"github.com/sankar-boro/axia-network-v2/utils/formatting"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/password"
 	"github.com/sankar-boro/axia-network-v2/version"
 )
 
@@ -33,6 +34,20 @@ func (s *service) DeleteUser(_ *http.Request, args *api.UserPass, reply *api.Suc
 	return s.ks.DeleteUser(args.Username, args.Password)
 }
 
+type ChangeUserPasswordArgs struct {
+	// The username and current password of the user
+	api.UserPass
+	// The new password of the user
+	NewPassword string `json:"newPassword"`
+}
+
+func (s *service) ChangeUserPassword(_ *http.Request, args *ChangeUserPasswordArgs, reply *api.SuccessResponse) error {
+	s.ks.log.Debug("Keystore: ChangeUserPassword called with %s", args.Username)
+
+	reply.Success = true
+	return s.ks.ChangeUserPassword(args.Username, args.Password, args.NewPassword)
+}
+
 type ListUsersReply struct {
 	Users []string `json:"users"`
 }
@@ -109,5 +124,5 @@ func CreateTestKeystore() (Keystore, error) {
 	if err != nil {
 		return nil, err
 	}
-	return New(logging.NoLog{}, dbManager), nil
+	return New(logging.NoLog{}, dbManager, password.DefaultParams), nil
 }
@@ -10,9 +10,14 @@ import (
 	"reflect"
 	"testing"
 
+	"errors"
 	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/database/encdb"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/password"
 )
 
 // strongPassword defines a password used for the following tests that
@@ -427,3 +432,291 @@ func TestServiceDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceChangeUserPassword(t *testing.T) {
+	newPassword := strongPassword + "new"
+
+	ks, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := service{ks: ks.(*keystore)}
+
+	if err := s.CreateUser(nil, &api.UserPass{Username: "bob", Password: strongPassword}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write values to the databases of two blockchains
+	bIDs := []ids.ID{{1}, {2}}
+	for _, bID := range bIDs {
+		db, err := ks.GetDatabase(bID, "bob", strongPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put([]byte("hello"), bID[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldKeyParams, err := ks.GetKeyParams("bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	{
+		reply := api.SuccessResponse{}
+		err := s.ChangeUserPassword(nil, &ChangeUserPasswordArgs{
+			UserPass:    api.UserPass{Username: "bob", Password: "wrong"},
+			NewPassword: newPassword,
+		}, &reply)
+		if err == nil {
+			t.Fatal("Should have errored due to incorrect password")
+		}
+	}
+
+	{
+		reply := api.SuccessResponse{}
+		err := s.ChangeUserPassword(nil, &ChangeUserPasswordArgs{
+			UserPass:    api.UserPass{Username: "bob", Password: strongPassword},
+			NewPassword: "weak",
+		}, &reply)
+		if err == nil {
+			t.Fatal("Should have errored due to a weak password")
+		}
+	}
+
+	{
+		reply := api.SuccessResponse{}
+		if err := s.ChangeUserPassword(nil, &ChangeUserPasswordArgs{
+			UserPass:    api.UserPass{Username: "bob", Password: strongPassword},
+			NewPassword: newPassword,
+		}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if !reply.Success {
+			t.Fatal("Password should have been changed successfully")
+		}
+	}
+
+	if _, err := ks.GetDatabase(bIDs[0], "bob", strongPassword); err == nil {
+		t.Fatal("Should have errored due to the old password")
+	}
+	newKeyParams, err := ks.GetKeyParams("bob", newPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(oldKeyParams.Salt, newKeyParams.Salt) {
+		t.Fatal("The key should have been derived with a new salt")
+	}
+	for _, bID := range bIDs {
+		db, err := ks.GetDatabase(bID, "bob", newPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val, err := db.Get([]byte("hello")); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(val, bID[:]) {
+			t.Fatalf("Should have read %s from the db", bID)
+		}
+	}
+
+	// The password change persists across restarts
+	delete(s.ks.usernameToPassword, "bob")
+	db, err := ks.GetDatabase(bIDs[1], "bob", newPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, err := db.Get([]byte("hello")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(val, bIDs[1][:]) {
+		t.Fatalf("Should have read %s from the db", bIDs[1])
+	}
+}
+
+func TestServiceChangeUserPasswordLegacyUser(t *testing.T) {
+	newPassword := strongPassword + "new"
+
+	ks, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := service{ks: ks.(*keystore)}
+
+	// Users created before key parameters were recorded have none
+	if err := s.CreateUser(nil, &api.UserPass{Username: "bob", Password: strongPassword}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ks.keyParamsDB.Delete([]byte("bob")); err != nil {
+		t.Fatal(err)
+	}
+	rawDB, err := ks.GetRawDatabase(ids.Empty, "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyDB, err := encdb.New([]byte(strongPassword), rawDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := legacyDB.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ks.ChangeUserPassword("bob", strongPassword, newPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := ks.GetDatabase(ids.Empty, "bob", newPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, err := db.Get([]byte("hello")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(val, []byte("world")) {
+		t.Fatalf("Should have read '%s' from the db", "world")
+	}
+}
+
+func TestServiceImportLegacyUser(t *testing.T) {
+	ks, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := service{ks: ks.(*keystore)}
+
+	// Users exported before key parameters were recorded are encrypted with
+	// the zero value of the parameters
+	passwordHash := password.Hash{}
+	if err := passwordHash.SetWithParams(strongPassword, password.LegacyParams); err != nil {
+		t.Fatal(err)
+	}
+	rawDB := memdb.New()
+	legacyDB, err := encdb.New([]byte(strongPassword), prefixdb.NewNested(ids.Empty[:], rawDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := legacyDB.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	legacyUser := user{
+		LegacyHash: password.LegacyHash{
+			Password: passwordHash.Password,
+			Salt:     passwordHash.Salt,
+		},
+	}
+	it := rawDB.NewIterator()
+	for it.Next() {
+		legacyUser.Data = append(legacyUser.Data, kvPair{
+			Key:   it.Key(),
+			Value: it.Value(),
+		})
+	}
+	it.Release()
+	userBytes, err := c.Marshal(codecVersion, &legacyUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userStr, err := formatting.EncodeWithChecksum(formatting.Hex, userBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply := api.SuccessResponse{}
+	if err := s.ImportUser(nil, &ImportUserArgs{
+		UserPass: api.UserPass{Username: "bob", Password: strongPassword},
+		User:     userStr,
+		Encoding: formatting.Hex,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, err := db.Get([]byte("hello")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(val, []byte("world")) {
+		t.Fatalf("Should have read '%s' from the db", "world")
+	}
+}
+
+func TestServiceImportCostlyHash(t *testing.T) {
+	ks, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hash is never derived, so its parameters only need to be more
+	// costly than the parameters of the keystore.
+	userData := userWithParams{
+		Hash: password.Hash{
+			Version: password.Argon2id,
+			Params: password.Params{
+				Time:    password.DefaultParams.Time + 1,
+				Memory:  password.DefaultParams.Memory,
+				Threads: password.DefaultParams.Threads,
+			},
+		},
+	}
+	userBytes, err := c.Marshal(paramsCodecVersion, &userData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.ImportUser("bob", strongPassword, userBytes); !errors.Is(err, errCostlyHash) {
+		t.Fatalf("expected %v but got %v", errCostlyHash, err)
+	}
+}
+
+func TestServiceUpgradePasswordHash(t *testing.T) {
+	ks, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := service{ks: ks.(*keystore)}
+
+	if err := s.CreateUser(nil, &api.UserPass{Username: "bob", Password: strongPassword}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the hash with one of the legacy format
+	passwordHash := password.Hash{}
+	if err := passwordHash.SetWithParams(strongPassword, password.LegacyParams); err != nil {
+		t.Fatal(err)
+	}
+	legacyBytes, err := c.Marshal(codecVersion, &password.LegacyHash{
+		Password: passwordHash.Password,
+		Salt:     passwordHash.Salt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ks.userDB.Put([]byte("bob"), legacyBytes); err != nil {
+		t.Fatal(err)
+	}
+	delete(s.ks.usernameToPassword, "bob")
+
+	if _, err := ks.GetDatabase(ids.Empty, "bob", "wrong"); err == nil {
+		t.Fatal("Should have errored due to incorrect password")
+	}
+	if hash, err := ks.getPassword("bob"); err != nil {
+		t.Fatal(err)
+	} else if hash.Params != password.LegacyParams {
+		t.Fatal("An incorrect password shouldn't upgrade the hash")
+	}
+
+	if _, err := ks.GetDatabase(ids.Empty, "bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	// The upgraded hash is persisted
+	delete(s.ks.usernameToPassword, "bob")
+	hash, err := ks.getPassword("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash.Params != password.DefaultParams {
+		t.Fatalf("Hash should have been upgraded to %+v but has %+v", password.DefaultParams, hash.Params)
+	}
+	if !hash.Check(strongPassword) {
+		t.Fatal("Upgraded hash should have verified the password")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// KeyByIP limits the calls of each client IP separately
+	KeyByIP KeyType = "ip"
+	// KeyByToken limits the calls made with each auth token separately. Calls
+	// that weren't authorized with an auth token are limited by client IP.
+	KeyByToken KeyType = "token"
+)
+
+var (
+	errUnknownKeyType     = errors.New("unknown rate limit key type")
+	errNonPositiveRate    = errors.New("rate limit must allow a positive number of requests per second")
+	errNonPositiveBurst   = errors.New("rate limit must allow a positive burst")
+	errDuplicateRateLimit = errors.New("duplicate rate limit")
+)
+
+// KeyType describes how clients are told apart
+type KeyType string
+
+// Config describes the limits on the rate of API calls
+type Config struct {
+	// How clients are told apart. Defaults to KeyByIP.
+	KeyBy KeyType `json:"keyBy"`
+	// Each call is limited by the most specific limit it matches. Limits that
+	// name a method are more specific than limits that only name an endpoint.
+	// Calls that don't match any limit aren't limited.
+	Limits []Limit `json:"limits"`
+}
+
+// Limit is a token bucket that each client has for the calls that match it
+type Limit struct {
+	// Suffix of the path of the endpoints this limit applies to, such as
+	// "bc/X". Aliases are resolved, so a limit on "bc/X" also applies to
+	// calls to "bc/[chain ID]". If empty, the limit applies to every endpoint.
+	Endpoint string `json:"endpoint"`
+	// JSON-RPC method this limit applies to, such as "avm.getUTXOs". If
+	// empty, the limit applies to every method.
+	Method string `json:"method"`
+	// Rate that each client's bucket is refilled at
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Size of each client's bucket
+	Burst int `json:"burst"`
+}
+
+// String returns a description of the calls [l] applies to
+func (l *Limit) String() string {
+	endpoint, method := l.Endpoint, l.Method
+	if endpoint == "" {
+		endpoint = "*"
+	}
+	if method == "" {
+		method = "*"
+	}
+	return fmt.Sprintf("%s:%s", endpoint, method)
+}
+
+// Verify returns an error if [c] can't be used to limit calls
+func (c *Config) Verify() error {
+	switch c.KeyBy {
+	case "", KeyByIP, KeyByToken:
+	default:
+		return fmt.Errorf("%w: %q", errUnknownKeyType, c.KeyBy)
+	}
+	limits := make(map[string]struct{}, len(c.Limits))
+	for _, limit := range c.Limits {
+		name := limit.String()
+		switch {
+		case limit.RequestsPerSecond <= 0:
+			return fmt.Errorf("%w: %s", errNonPositiveRate, name)
+		case limit.Burst <= 0:
+			return fmt.Errorf("%w: %s", errNonPositiveBurst, name)
+		}
+		if _, ok := limits[name]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateRateLimit, name)
+		}
+		limits[name] = struct{}{}
+	}
+	return nil
+}
+
+// ParseConfig returns the config that [configBytes] is the JSON of
+func ParseConfig(configBytes []byte) (Config, error) {
+	config := Config{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return Config{}, fmt.Errorf("problem unmarshaling rate limits: %w", err)
+	}
+	return config, config.Verify()
+}
+
+// ReadConfig returns the config in the JSON file at [path]
+func ReadConfig(path string) (Config, error) {
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("couldn't read rate limits: %w", err)
+	}
+	return ParseConfig(configBytes)
+}
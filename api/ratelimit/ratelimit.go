@@ -0,0 +1,402 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sankar-boro/axia-network-v2/api/auth"
+	"github.com/sankar-boro/axia-network-v2/api/server"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+)
+
+// How often the buckets of clients that haven't made calls recently are removed
+const cleanupFrequency = time.Minute
+
+var _ Limiter = &limiter{}
+
+// Limiter limits the rate that each client can call the API at. HTTP calls
+// that exceed their limit are rejected with http.StatusTooManyRequests and
+// gRPC calls with codes.ResourceExhausted.
+type Limiter interface {
+	server.Wrapper
+	server.GRPCWrapper
+	server.AliasWrapper
+
+	// Update replaces the limits with the limits of [config]. The buckets of
+	// every client are refilled.
+	Update(config Config) error
+
+	// Config returns the limits in use
+	Config() Config
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+type limit struct {
+	Limit
+	name string
+	// Time it takes an empty bucket to be refilled
+	refillTime time.Duration
+	// Client key --> The client's bucket
+	buckets map[string]*bucket
+}
+
+// bucket returns the bucket of the client with [key], creating it if needed.
+// Assumes the lock of the limiter is held.
+func (l *limit) bucket(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{
+			limiter: rate.NewLimiter(rate.Limit(l.RequestsPerSecond), l.Burst),
+		}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b
+}
+
+type limiter struct {
+	log     logging.Logger
+	metrics metrics
+	clock   mockable.Clock
+
+	lock   sync.Mutex
+	config Config
+	limits []*limit
+	// True if any limit names a method, in which case the bodies of requests
+	// are read to find the methods they call
+	limitsMethods bool
+	lastCleanup   time.Time
+	// Alias URL --> The URL of the endpoint it aliases
+	aliases map[string]string
+}
+
+// New returns a Limiter that limits calls as described by [config]. Its
+// metrics are registered with [registerer].
+func New(log logging.Logger, registerer prometheus.Registerer, config Config) (Limiter, error) {
+	l := &limiter{
+		log:     log,
+		aliases: make(map[string]string),
+	}
+	if err := l.metrics.Initialize(registerer); err != nil {
+		return nil, err
+	}
+	return l, l.Update(config)
+}
+
+func (l *limiter) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.lock.Lock()
+		limitsMethods := l.limitsMethods
+		l.lock.Unlock()
+
+		// The body is only read if a limit names a method, so that other
+		// requests are streamed to [h].
+		methods := []string{""}
+		if limitsMethods {
+			var err error
+			methods, err = readMethods(w, r)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if retryAfter, limitName, ok := l.allow(r.Context(), r.RemoteAddr, r.URL.Path, methods); !ok {
+			l.log.Verbo("rejecting API call to %s from %s as it exceeds the rate limit %s", r.URL.Path, r.RemoteAddr, limitName)
+			writeTooManyRequestsResponse(w, retryAfter, "rate limit exceeded for "+limitName)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (l *limiter) AuthorizeGRPC(ctx context.Context, url, method string) (context.Context, error) {
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+	if retryAfter, limitName, ok := l.allow(ctx, remoteAddr, url, []string{method}); !ok {
+		l.log.Verbo("rejecting gRPC call to %s from %s as it exceeds the rate limit %s", url, remoteAddr, limitName)
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, retry after %s", limitName, retryAfter)
+	}
+	return ctx, nil
+}
+
+func (l *limiter) AliasEndpoint(endpoint string, aliases ...string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	url := endpointURL(endpoint)
+	for _, alias := range aliases {
+		l.aliases[endpointURL(alias)] = url
+	}
+}
+
+// allow takes a token from the bucket of the client at [remoteAddr], whose
+// call has context [ctx], for each of [methods] of the endpoint at [path]. If a
+// bucket is empty, no tokens are taken and the time until the bucket has a
+// token is returned along with the name of its limit.
+func (l *limiter) allow(ctx context.Context, remoteAddr, path string, methods []string) (time.Duration, string, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Time()
+	l.cleanup(now)
+
+	key := l.clientKey(ctx, remoteAddr)
+	reservations := make([]*rate.Reservation, 0, len(methods))
+	allowed := make([]*limit, 0, len(methods))
+	for _, method := range methods {
+		lim := l.match(path, method)
+		if lim == nil {
+			continue
+		}
+		b := lim.bucket(key, now)
+		l.metrics.clients.WithLabelValues(lim.name).Set(float64(len(lim.buckets)))
+		reservation := b.limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			for _, reservation := range reservations {
+				reservation.CancelAt(now)
+			}
+			l.metrics.rejected.WithLabelValues(lim.name).Inc()
+			return delay, lim.name, false
+		}
+		reservations = append(reservations, reservation)
+		allowed = append(allowed, lim)
+	}
+	for _, lim := range allowed {
+		l.metrics.allowed.WithLabelValues(lim.name).Inc()
+	}
+	return 0, "", true
+}
+
+// match returns the most specific limit of calls to [method] of the endpoint
+// at [path], or nil if there is no such limit. Aliases of endpoints are
+// resolved, so that a limit can't be bypassed by calling an endpoint through
+// another alias.
+// Assumes [l.lock] is held.
+func (l *limiter) match(path, method string) *limit {
+	var (
+		best      *limit
+		bestScore = -1
+		resolved  = l.resolve(path)
+	)
+	for _, lim := range l.limits {
+		if lim.Endpoint != "" &&
+			!strings.HasSuffix(path, lim.Endpoint) &&
+			!strings.HasSuffix(resolved, l.resolve(endpointURL(lim.Endpoint))) {
+			continue
+		}
+		if lim.Method != "" && lim.Method != method {
+			continue
+		}
+		score := 0
+		if lim.Method != "" {
+			score += 2
+		}
+		if lim.Endpoint != "" {
+			score++
+		}
+		if score > bestScore {
+			best = lim
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// resolve returns [path] with the endpoint alias it starts with, if any,
+// replaced by the endpoint it aliases.
+// Assumes [l.lock] is held.
+func (l *limiter) resolve(path string) string {
+	longestAlias := ""
+	for alias := range l.aliases {
+		if len(alias) > len(longestAlias) && (path == alias || strings.HasPrefix(path, alias+"/")) {
+			longestAlias = alias
+		}
+	}
+	if longestAlias == "" {
+		return path
+	}
+	return l.aliases[longestAlias] + path[len(longestAlias):]
+}
+
+// clientKey returns the key of the buckets of the client at [remoteAddr] whose
+// call has context [ctx]. Only tokens that were already validated by auth are
+// used as keys, so that a client can't get a new bucket by sending a new token
+// with each call.
+// Assumes [l.lock] is held.
+func (l *limiter) clientKey(ctx context.Context, remoteAddr string) string {
+	if l.config.KeyBy == KeyByToken {
+		if tokenID, ok := auth.TokenID(ctx); ok {
+			return "token:" + tokenID
+		}
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+// endpointURL returns the URL that [endpoint], such as "bc/X", is served at
+func endpointURL(endpoint string) string {
+	return "/ext/" + strings.Trim(endpoint, "/")
+}
+
+// cleanup removes the buckets that are full because they haven't been used
+// recently.
+// Assumes [l.lock] is held.
+func (l *limiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < cleanupFrequency {
+		return
+	}
+	l.lastCleanup = now
+	for _, lim := range l.limits {
+		for key, b := range lim.buckets {
+			if now.Sub(b.lastUsed) >= lim.refillTime {
+				delete(lim.buckets, key)
+			}
+		}
+		l.metrics.clients.WithLabelValues(lim.name).Set(float64(len(lim.buckets)))
+	}
+}
+
+func (l *limiter) Update(config Config) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+	if config.KeyBy == "" {
+		config.KeyBy = KeyByIP
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.config = config
+	l.limits = make([]*limit, len(config.Limits))
+	l.limitsMethods = false
+	l.metrics.clients.Reset()
+	for i, configLimit := range config.Limits {
+		l.limits[i] = &limit{
+			Limit:      configLimit,
+			name:       configLimit.String(),
+			refillTime: time.Duration(float64(configLimit.Burst) / configLimit.RequestsPerSecond * float64(time.Second)),
+			buckets:    make(map[string]*bucket),
+		}
+		l.limitsMethods = l.limitsMethods || configLimit.Method != ""
+	}
+	l.log.Info("limiting the rate of API calls by client %s with %d limits", config.KeyBy, len(config.Limits))
+	return nil
+}
+
+func (l *limiter) Config() Config {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.config
+}
+
+type metrics struct {
+	allowed, rejected *prometheus.CounterVec
+	clients           *prometheus.GaugeVec
+}
+
+func (m *metrics) Initialize(registerer prometheus.Registerer) error {
+	m.allowed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "calls_allowed",
+			Help: "Number of calls that were within their rate limit",
+		},
+		[]string{"limit"},
+	)
+	m.rejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "calls_rejected",
+			Help: "Number of calls that were rejected for exceeding their rate limit",
+		},
+		[]string{"limit"},
+	)
+	m.clients = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clients",
+			Help: "Number of clients that have a bucket of the rate limit",
+		},
+		[]string{"limit"},
+	)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.allowed),
+		registerer.Register(m.rejected),
+		registerer.Register(m.clients),
+	)
+	return errs.Err
+}
+
+// readMethods returns the JSON-RPC methods that [r] calls. If [r] isn't a
+// JSON-RPC request, a single empty method is returned. The body of [r] is
+// replaced so that it can be read again. Bodies larger than
+// [server.MaxRequestBodySize] are rejected.
+func readMethods(w http.ResponseWriter, r *http.Request) ([]string, error) {
+	if r.Body == nil {
+		return []string{""}, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, server.MaxRequestBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Body.Close(); err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	type call struct {
+		Method string `json:"method"`
+	}
+
+	// A batch of calls is an array of calls.
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		calls := []call{}
+		if err := json.Unmarshal(body, &calls); err != nil || len(calls) == 0 {
+			return []string{""}, nil
+		}
+		methods := make([]string, len(calls))
+		for i, call := range calls {
+			methods[i] = call.Method
+		}
+		return methods, nil
+	}
+
+	singleCall := call{}
+	if err := json.Unmarshal(body, &singleCall); err != nil {
+		return []string{""}, nil
+	}
+	return []string{singleCall.Method}, nil
+}
@@ -0,0 +1,278 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sankar-boro/axia-network-v2/api/auth"
+	"github.com/sankar-boro/axia-network-v2/api/server"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/password"
+)
+
+func newTestLimiter(t *testing.T, config Config) (*limiter, http.Handler) {
+	l, err := New(logging.NoLog{}, prometheus.NewRegistry(), config)
+	assert.NoError(t, err)
+	return l.(*limiter), l.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func serveTest(h http.Handler, path, remoteAddr, token, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	request.RemoteAddr = remoteAddr
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestLimiterMostSpecificLimit(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{Limits: []Limit{
+		{Endpoint: "bc/X", RequestsPerSecond: 1, Burst: 3},
+		{Endpoint: "bc/X", Method: "avm.getUTXOs", RequestsPerSecond: 1, Burst: 1},
+	}})
+	now := time.Now()
+	l.clock.Set(now)
+
+	getUTXOs := `{"jsonrpc":"2.0","method":"avm.getUTXOs","id":1}`
+	getTx := `{"jsonrpc":"2.0","method":"avm.getTx","id":1}`
+
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", getUTXOs).Code)
+	recorder := serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", getUTXOs)
+	assert.Equal(http.StatusTooManyRequests, recorder.Code)
+	assert.Equal("1", recorder.Header().Get("Retry-After"))
+	assert.Contains(recorder.Body.String(), "bc/X:avm.getUTXOs")
+
+	// Other methods of the endpoint have their own bucket
+	for i := 0; i < 3; i++ {
+		assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", getTx).Code)
+	}
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", getTx).Code)
+
+	// Other clients have their own buckets
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "5.6.7.8:1", "", getUTXOs).Code)
+
+	// Other endpoints aren't limited
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/P", "1.2.3.4:1", "", getUTXOs).Code)
+
+	// The bucket is refilled over time
+	l.clock.Set(now.Add(time.Second))
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", getUTXOs).Code)
+}
+
+func TestLimiterBatch(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{Limits: []Limit{
+		{Method: "avm.getTx", RequestsPerSecond: 1, Burst: 3},
+	}})
+	l.clock.Set(time.Now())
+
+	batch := `[
+		{"jsonrpc":"2.0","method":"avm.getTx","id":1},
+		{"jsonrpc":"2.0","method":"avm.getTx","id":2}
+	]`
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", batch).Code)
+	// Each call of a batch takes a token, and a batch that exceeds the limit
+	// doesn't take any tokens.
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", batch).Code)
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", `{"method":"avm.getTx"}`).Code)
+}
+
+func TestLimiterKeyByToken(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{
+		KeyBy:  KeyByToken,
+		Limits: []Limit{{RequestsPerSecond: 1, Burst: 1}},
+	})
+	l.clock.Set(time.Now())
+
+	// The limiter runs inside of auth, so calls only reach it with a token
+	// that auth validated.
+	hashedPassword := password.Hash{}
+	assert.NoError(hashedPassword.Set("password"))
+	a := auth.NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
+	h = a.WrapHandler(h)
+
+	token1, err := a.NewToken("password", time.Hour, []string{"*"}, nil)
+	assert.NoError(err)
+	token2, err := a.NewToken("password", time.Hour, []string{"*"}, nil)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "1.2.3.4:1", token1, "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/info", "5.6.7.8:1", token1, "").Code)
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "1.2.3.4:1", token2, "").Code)
+	// Calls that don't need a token are limited by IP
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/auth", "1.2.3.4:1", "", "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/auth", "1.2.3.4:2", "", "").Code)
+}
+
+func TestLimiterKeyByTokenUnvalidated(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{
+		KeyBy:  KeyByToken,
+		Limits: []Limit{{RequestsPerSecond: 1, Burst: 1}},
+	})
+	l.clock.Set(time.Now())
+
+	// Tokens that auth didn't validate are ignored, so sending a new token with
+	// each call doesn't give the client a new bucket.
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "1.2.3.4:1", "token1", "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/info", "1.2.3.4:1", "token2", "").Code)
+	assert.Len(l.limits[0].buckets, 1)
+}
+
+func TestLimiterAliases(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{Limits: []Limit{
+		{Endpoint: "bc/X", RequestsPerSecond: 1, Burst: 1},
+		{Endpoint: "bc/P", RequestsPerSecond: 1, Burst: 1},
+	}})
+	l.clock.Set(time.Now())
+	l.AliasEndpoint("bc/chainX", "bc/X", "X")
+	l.AliasEndpoint("bc/chainP", "bc/P")
+
+	// A limit on an alias applies to every alias of the same endpoint.
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/bc/chainX", "1.2.3.4:1", "", "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/X", "1.2.3.4:1", "", "").Code)
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/chainP", "1.2.3.4:1", "", "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/bc/P", "1.2.3.4:1", "", "").Code)
+}
+
+func TestLimiterGRPC(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{
+		KeyBy:  KeyByToken,
+		Limits: []Limit{{Endpoint: "bc/X", Method: "avm.issueTx", RequestsPerSecond: 1, Burst: 1}},
+	})
+	l.clock.Set(time.Now())
+	l.AliasEndpoint("bc/chainX", "bc/X")
+
+	hashedPassword := password.Hash{}
+	assert.NoError(hashedPassword.Set("password"))
+	a := auth.NewFromHash(logging.NoLog{}, "auth", hashedPassword, memdb.New())
+	h = a.WrapHandler(h)
+	token, err := a.NewToken("password", time.Hour, []string{"*"}, nil)
+	assert.NoError(err)
+
+	// gRPC calls share their buckets with HTTP calls.
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/bc/X", "1.2.3.4:1", token, `{"method":"avm.issueTx"}`).Code)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 2}})
+	ctx, err = a.AuthorizeGRPC(ctx, "/ext/bc/chainX", "avm.issueTx")
+	assert.NoError(err)
+	_, err = l.AuthorizeGRPC(ctx, "/ext/bc/chainX", "avm.issueTx")
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// Other methods aren't limited.
+	_, err = l.AuthorizeGRPC(ctx, "/ext/bc/chainX", "avm.getTx")
+	assert.NoError(err)
+}
+
+func TestLimiterBodyTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	_, h := newTestLimiter(t, Config{Limits: []Limit{
+		{Method: "avm.getTx", RequestsPerSecond: 1, Burst: 1},
+	}})
+
+	body := strings.Repeat(" ", server.MaxRequestBodySize+1)
+	assert.Equal(http.StatusBadRequest, serveTest(h, "/ext/bc/X", "1.2.3.4:1", "", body).Code)
+}
+
+func TestLimiterCleanupAndUpdate(t *testing.T) {
+	assert := assert.New(t)
+	l, h := newTestLimiter(t, Config{Limits: []Limit{
+		{Endpoint: "info", RequestsPerSecond: 1, Burst: 1},
+	}})
+	now := time.Now()
+	l.clock.Set(now)
+
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "1.2.3.4:1", "", "").Code)
+	assert.Len(l.limits[0].buckets, 1)
+
+	l.clock.Set(now.Add(cleanupFrequency))
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "5.6.7.8:1", "", "").Code)
+	assert.Len(l.limits[0].buckets, 1)
+	assert.Contains(l.limits[0].buckets, "ip:5.6.7.8")
+
+	newConfig := Config{Limits: []Limit{
+		{Endpoint: "info", RequestsPerSecond: 1, Burst: 2},
+	}}
+	assert.NoError(l.Update(newConfig))
+	newConfig.KeyBy = KeyByIP
+	assert.Equal(newConfig, l.Config())
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "5.6.7.8:1", "", "").Code)
+	assert.Equal(http.StatusOK, serveTest(h, "/ext/info", "5.6.7.8:1", "", "").Code)
+	assert.Equal(http.StatusTooManyRequests, serveTest(h, "/ext/info", "5.6.7.8:1", "", "").Code)
+
+	// Invalid configs aren't applied
+	assert.ErrorIs(l.Update(Config{KeyBy: "cookie"}), errUnknownKeyType)
+	assert.Equal(newConfig, l.Config())
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		err    error
+	}{
+		{
+			name: "valid",
+			config: Config{Limits: []Limit{
+				{RequestsPerSecond: 1, Burst: 1},
+				{Method: "avm.getTx", RequestsPerSecond: 0.5, Burst: 1},
+			}},
+		},
+		{
+			name:   "unknown key type",
+			config: Config{KeyBy: "cookie"},
+			err:    errUnknownKeyType,
+		},
+		{
+			name:   "zero rate",
+			config: Config{Limits: []Limit{{Burst: 1}}},
+			err:    errNonPositiveRate,
+		},
+		{
+			name:   "zero burst",
+			config: Config{Limits: []Limit{{RequestsPerSecond: 1}}},
+			err:    errNonPositiveBurst,
+		},
+		{
+			name: "duplicate",
+			config: Config{Limits: []Limit{
+				{Endpoint: "info", RequestsPerSecond: 1, Burst: 1},
+				{Endpoint: "info", RequestsPerSecond: 2, Burst: 2},
+			}},
+			err: errDuplicateRateLimit,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ErrorIs(t, test.config.Verify(), test.err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+)
+
+type responseErr struct {
+	Code    rpc.ErrorCode `json:"code"`
+	Message string        `json:"message"`
+}
+
+type responseBody struct {
+	Version string      `json:"jsonrpc"`
+	Err     responseErr `json:"error"`
+	ID      interface{} `json:"id"`
+}
+
+// Write a JSON-RPC formatted response saying that the API call exceeded its
+// rate limit. The response has header http.StatusTooManyRequests and tells the
+// client to retry after [retryAfter].
+// Errors while writing are ignored.
+func writeTooManyRequestsResponse(w http.ResponseWriter, retryAfter time.Duration, message string) {
+	// Retry-After is given in whole seconds
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	writeErrorResponse(w, http.StatusTooManyRequests, message)
+}
+
+// Write a JSON-RPC formatted response with header [status] and the error
+// [message].
+// Errors while writing are ignored.
+func writeErrorResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	// There isn't anything to do with the returned error, so it is dropped.
+	_ = json.NewEncoder(w).Encode(responseBody{
+		Version: rpc.Version,
+		Err: responseErr{
+			Code:    rpc.E_SERVER,
+			Message: message,
+		},
+	})
+}
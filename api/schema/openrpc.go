@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OpenRPCVersion is the version of the OpenRPC specification that documents
+// follow
+const OpenRPCVersion = "1.2.6"
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	requestType = reflect.TypeOf((*http.Request)(nil))
+)
+
+// Document is an OpenRPC document that describes JSON-RPC methods.
+// See https://spec.open-rpc.org
+type Document struct {
+	OpenRPC    string     `json:"openrpc"`
+	Info       Info       `json:"info"`
+	Methods    []*Method  `json:"methods"`
+	Components Components `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Endpoint is a URL that methods are served at
+type Endpoint struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type Method struct {
+	Name string `json:"name"`
+	// Endpoints that serve the method
+	Servers []Endpoint `json:"servers"`
+	// Params are given as the fields of an object
+	ParamStructure string               `json:"paramStructure"`
+	Params         []*ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor   `json:"result"`
+}
+
+type ContentDescriptor struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type endpointServices struct {
+	url      string
+	services []Service
+}
+
+// Generator builds an OpenRPC document of the JSON-RPC services of the
+// handlers added to it
+type Generator struct {
+	info Info
+
+	lock      sync.Mutex
+	endpoints []endpointServices
+	// Document of the services of [endpoints], or nil if it needs to be
+	// rebuilt
+	document *Document
+}
+
+// NewGenerator returns a Generator whose documents have the title [title] and
+// the version [version]
+func NewGenerator(title, version string) *Generator {
+	return &Generator{info: Info{
+		Title:   title,
+		Version: version,
+	}}
+}
+
+// AddHandler documents the services of [handler] as being served at [url].
+// Handlers that don't implement Documented are ignored.
+func (g *Generator) AddHandler(url string, handler http.Handler) {
+	documented, ok := handler.(Documented)
+	if !ok {
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.endpoints = append(g.endpoints, endpointServices{
+		url:      url,
+		services: documented.Services(),
+	})
+	g.document = nil
+}
+
+// Document returns the OpenRPC document of the services of the handlers added
+// to the generator
+func (g *Generator) Document() *Document {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.document != nil {
+		return g.document
+	}
+
+	r := newReflector()
+	type methodKey struct {
+		name     string
+		receiver reflect.Type
+	}
+	methods := make(map[methodKey]*Method)
+	document := &Document{
+		OpenRPC:    OpenRPCVersion,
+		Info:       g.info,
+		Methods:    []*Method{},
+		Components: Components{Schemas: r.components},
+	}
+	for _, endpoint := range g.endpoints {
+		for _, service := range endpoint.services {
+			receiverType := reflect.TypeOf(service.Receiver)
+			for i := 0; i < receiverType.NumMethod(); i++ {
+				argsType, replyType, ok := rpcMethodTypes(receiverType.Method(i))
+				if !ok {
+					continue
+				}
+				key := methodKey{
+					name:     service.Name + "." + lowercaseFirst(receiverType.Method(i).Name),
+					receiver: receiverType,
+				}
+				method, ok := methods[key]
+				if !ok {
+					method = &Method{
+						Name:           key.name,
+						ParamStructure: "by-name",
+						Params:         r.params(argsType),
+						Result: &ContentDescriptor{
+							Name:   "result",
+							Schema: r.schema(replyType),
+						},
+					}
+					methods[key] = method
+					document.Methods = append(document.Methods, method)
+				}
+				method.Servers = append(method.Servers, Endpoint{
+					Name: endpoint.url,
+					URL:  endpoint.url,
+				})
+			}
+		}
+	}
+	sort.SliceStable(document.Methods, func(i, j int) bool {
+		return document.Methods[i].Name < document.Methods[j].Name
+	})
+	g.document = document
+	return document
+}
+
+// params returns the params of a method whose arguments are of type [t]. The
+// fields of struct arguments are given as params by name.
+func (r *reflector) params(t reflect.Type) []*ContentDescriptor {
+	if t.Kind() != reflect.Struct {
+		return []*ContentDescriptor{{
+			Name:   "args",
+			Schema: r.schema(t),
+		}}
+	}
+	params := []*ContentDescriptor{}
+	for _, field := range fields(t) {
+		if field.embedded {
+			params = append(params, r.params(field.typ)...)
+			continue
+		}
+		params = append(params, &ContentDescriptor{
+			Name:   field.name,
+			Schema: r.schema(field.typ),
+		})
+	}
+	return params
+}
+
+// rpcMethodTypes returns the types of the arguments and the reply of [method]
+// if it's served by rpc.Server, which requires the signature
+// func (*http.Request, *Args, *Reply) error
+func rpcMethodTypes(method reflect.Method) (reflect.Type, reflect.Type, bool) {
+	methodType := method.Type
+	switch {
+	case method.PkgPath != "",
+		methodType.NumIn() != 4,
+		methodType.In(1) != requestType,
+		methodType.In(2).Kind() != reflect.Ptr,
+		methodType.In(3).Kind() != reflect.Ptr,
+		methodType.NumOut() != 1,
+		methodType.Out(0) != errorType:
+		return nil, nil, false
+	default:
+		return methodType.In(2).Elem(), methodType.In(3).Elem(), true
+	}
+}
+
+// lowercaseFirst returns [s] with its first letter lowercased, which is how
+// methods are called over JSON-RPC
+func lowercaseFirst(s string) string {
+	firstRune, runeLen := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(firstRune)) + s[runeLen:]
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+)
+
+type TestPage struct {
+	Limit json.Uint64 `json:"limit"`
+}
+
+type TestArgs struct {
+	TestPage
+	TxID     ids.ID              `json:"txID"`
+	Encoding formatting.Encoding `json:"encoding"`
+	Ignored  string              `json:"-"`
+	ignored  string
+}
+
+type TestTree struct {
+	Children []*TestTree       `json:"children"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	NodeID   ids.NodeID
+	Bytes    []byte      `json:"bytes"`
+	Any      interface{} `json:"any"`
+}
+
+type testService struct{}
+
+func (*testService) GetTree(_ *http.Request, _ *TestArgs, _ *TestTree) error { return nil }
+
+func (*testService) GetCount(_ *http.Request, _ *int, _ *json.Uint32) error { return nil }
+
+// Methods that rpc.Server doesn't serve aren't documented
+func (*testService) NotServed(_ *TestArgs) error { return nil }
+
+func newTestGenerator(t *testing.T) *Generator {
+	server := NewServer()
+	assert.NoError(t, server.RegisterService(&testService{}, "test"))
+
+	g := NewGenerator("test", "v1.0.0")
+	g.AddHandler("/ext/test/1", server)
+	g.AddHandler("/ext/test/2", NewHandler(http.NotFoundHandler(), server))
+	// Handlers that aren't documented are ignored
+	g.AddHandler("/ext/other", http.NotFoundHandler())
+	return g
+}
+
+func TestGeneratorDocument(t *testing.T) {
+	assert := assert.New(t)
+	document := newTestGenerator(t).Document()
+
+	assert.Equal(OpenRPCVersion, document.OpenRPC)
+	assert.Equal(Info{Title: "test", Version: "v1.0.0"}, document.Info)
+	assert.Len(document.Methods, 2)
+
+	servers := []Endpoint{
+		{Name: "/ext/test/1", URL: "/ext/test/1"},
+		{Name: "/ext/test/2", URL: "/ext/test/2"},
+	}
+
+	getCount := document.Methods[0]
+	assert.Equal("test.getCount", getCount.Name)
+	assert.Equal(servers, getCount.Servers)
+	assert.Equal([]*ContentDescriptor{{
+		Name:   "args",
+		Schema: &Schema{Type: "integer"},
+	}}, getCount.Params)
+	assert.Equal("string", getCount.Result.Schema.Type)
+	assert.Equal("^[0-9]+$", getCount.Result.Schema.Pattern)
+
+	getTree := document.Methods[1]
+	assert.Equal("test.getTree", getTree.Name)
+	assert.Equal(servers, getTree.Servers)
+	assert.Equal("by-name", getTree.ParamStructure)
+	paramNames := make([]string, len(getTree.Params))
+	for i, param := range getTree.Params {
+		paramNames[i] = param.Name
+	}
+	// The fields of embedded structs are params
+	assert.Equal([]string{"limit", "txID", "encoding"}, paramNames)
+	assert.Equal("string", getTree.Params[0].Schema.Type)
+	assert.Equal("string", getTree.Params[1].Schema.Type)
+	assert.Equal([]string{"cb58", "hex", "json"}, getTree.Params[2].Schema.Enum)
+
+	assert.Equal(&Schema{Ref: componentsPrefix + "schema.TestTree"}, getTree.Result.Schema)
+	tree := document.Components.Schemas["schema.TestTree"]
+	assert.Equal("object", tree.Type)
+	// Recursive types refer to their component
+	assert.Equal(&Schema{
+		Type:  "array",
+		Items: &Schema{Ref: componentsPrefix + "schema.TestTree"},
+	}, tree.Properties["children"])
+	assert.Equal(&Schema{
+		Type:                 "object",
+		AdditionalProperties: &Schema{Type: "string"},
+	}, tree.Properties["labels"])
+	assert.Equal("^"+ids.NodeIDPrefix, tree.Properties["NodeID"].Pattern)
+	assert.Equal(&Schema{Type: "string", Format: "byte"}, tree.Properties["bytes"])
+	assert.Equal(&Schema{}, tree.Properties["any"])
+}
+
+func TestCreateHandler(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGenerator(t)
+	handler, err := CreateHandler(g.Document)
+	assert.NoError(err)
+
+	// GET requests are responded to with the document
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(http.StatusOK, recorder.Code)
+	document := Document{}
+	assert.NoError(stdjson.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Len(document.Methods, 2)
+
+	// The document can be fetched with rpc.discover
+	request := httptest.NewRequest(
+		http.MethodPost,
+		"/",
+		bytes.NewBufferString(`{"jsonrpc":"2.0","method":"rpc.discover","params":{},"id":1}`),
+	)
+	request.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(http.StatusOK, recorder.Code)
+	response := struct {
+		Result Document `json:"result"`
+	}{}
+	assert.NoError(stdjson.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(OpenRPCVersion, response.Result.OpenRPC)
+	assert.Len(response.Result.Methods, 2)
+
+	// The handler documents rpc.discover
+	g.AddHandler("/ext/schema", handler)
+	methods := g.Document().Methods
+	assert.Len(methods, 3)
+	assert.Equal("rpc.discover", methods[0].Name)
+}
@@ -0,0 +1,298 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*stdjson.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	customTypesLock sync.RWMutex
+	customTypes     = map[reflect.Type]*Schema{}
+)
+
+// Schema is a JSON schema that describes the JSON form of a Go type
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+func init() {
+	uintSchema := func(bits int) *Schema {
+		return &Schema{
+			Type:        "string",
+			Pattern:     "^[0-9]+$",
+			Description: fmt.Sprintf("%d bit unsigned integer in base 10", bits),
+		}
+	}
+	floatSchema := &Schema{
+		Type:        "string",
+		Pattern:     "^-?[0-9]+(\\.[0-9]+)?$",
+		Description: "decimal number",
+	}
+	idSchema := &Schema{
+		Type:        "string",
+		Description: "32 byte ID encoded in CB58",
+	}
+	shortIDSchema := &Schema{
+		Type:        "string",
+		Description: "20 byte ID encoded in CB58",
+	}
+	nodeIDSchema := &Schema{
+		Type:        "string",
+		Pattern:     "^" + ids.NodeIDPrefix,
+		Description: "node ID encoded in CB58 with the prefix " + ids.NodeIDPrefix,
+	}
+
+	RegisterType(json.Uint8(0), uintSchema(8))
+	RegisterType(json.Uint16(0), uintSchema(16))
+	RegisterType(json.Uint32(0), uintSchema(32))
+	RegisterType(json.Uint64(0), uintSchema(64))
+	RegisterType(json.Float32(0), floatSchema)
+	RegisterType(json.Float64(0), floatSchema)
+	RegisterType(ids.ID{}, idSchema)
+	RegisterType(ids.ShortID{}, shortIDSchema)
+	RegisterType(ids.NodeID{}, nodeIDSchema)
+	RegisterType(ids.Set{}, &Schema{Type: "array", Items: idSchema})
+	RegisterType(ids.ShortSet{}, &Schema{Type: "array", Items: shortIDSchema})
+	RegisterType(ids.NodeIDSet{}, &Schema{Type: "array", Items: nodeIDSchema})
+	RegisterType(formatting.CB58, &Schema{
+		Type:        "string",
+		Enum:        []string{formatting.CB58.String(), formatting.Hex.String(), formatting.JSON.String()},
+		Description: "encoding of bytes",
+	})
+	RegisterType(time.Time{}, &Schema{
+		Type:   "string",
+		Format: "date-time",
+	})
+	RegisterType(big.Int{}, &Schema{Type: "integer"})
+	RegisterType(stdjson.RawMessage{}, &Schema{})
+}
+
+// RegisterType sets the schema of the type of [value]. Types whose JSON form
+// can't be found by reflection, such as types that implement json.Marshaler,
+// should be registered.
+func RegisterType(value interface{}, schema *Schema) {
+	customTypesLock.Lock()
+	defer customTypesLock.Unlock()
+
+	customTypes[reflect.TypeOf(value)] = schema
+}
+
+func customType(t reflect.Type) (*Schema, bool) {
+	customTypesLock.RLock()
+	defer customTypesLock.RUnlock()
+
+	schema, ok := customTypes[t]
+	return schema, ok
+}
+
+// reflector builds the schemas of Go types. Named struct types are added to
+// [components] and referenced by name, so that recursive types have finite
+// schemas.
+type reflector struct {
+	components map[string]*Schema
+	// Named struct type --> Name of its schema in [components]
+	names map[reflect.Type]string
+}
+
+func newReflector() *reflector {
+	return &reflector{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// schema returns the schema of the JSON form of values of type [t]
+func (r *reflector) schema(t reflect.Type) *Schema {
+	if schema, ok := customType(t); ok {
+		return schema
+	}
+	if t.Kind() == reflect.Ptr {
+		return r.schema(t.Elem())
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return marshaledSchema(t)
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded in base64
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		return r.structSchema(t)
+	default:
+		// Interfaces can hold values of any type
+		return &Schema{}
+	}
+}
+
+// structSchema returns the schema of the struct type [t]. If [t] is named, a
+// reference to its schema in the components is returned.
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.objectSchema(t)
+	}
+	if name, ok := r.names[t]; ok {
+		return &Schema{Ref: componentsPrefix + name}
+	}
+
+	name := r.componentName(t)
+	r.names[t] = name
+	// The component is reserved before the fields are reflected so that
+	// recursive references to [t] resolve to it.
+	r.components[name] = &Schema{}
+	*r.components[name] = *r.objectSchema(t)
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+// componentName returns the name of the schema of the named type [t], which
+// is the name of its package followed by the name of the type. If another
+// type has the same name, the full path of the package is used instead.
+func (r *reflector) componentName(t reflect.Type) string {
+	name := fmt.Sprintf("%s.%s", path.Base(t.PkgPath()), t.Name())
+	if _, ok := r.components[name]; !ok {
+		return name
+	}
+	return strings.ReplaceAll(fmt.Sprintf("%s.%s", t.PkgPath(), t.Name()), "/", ".")
+}
+
+// objectSchema returns the schema of the fields of the struct type [t]
+func (r *reflector) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	r.addFields(schema, t)
+	return schema
+}
+
+// addFields adds the fields of the struct type [t] to the properties of
+// [schema]. The fields of embedded structs without a JSON name are added as
+// if they were fields of [t], as they are by encoding/json.
+func (r *reflector) addFields(schema *Schema, t reflect.Type) {
+	for _, field := range fields(t) {
+		if field.embedded {
+			r.addFields(schema, field.typ)
+			continue
+		}
+		schema.Properties[field.name] = r.schema(field.typ)
+	}
+}
+
+type field struct {
+	name     string
+	typ      reflect.Type
+	embedded bool
+}
+
+// fields returns the fields of the struct type [t] that appear in its JSON
+// form
+func fields(t reflect.Type) []field {
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := structField.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if structField.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if _, ok := customType(fieldType); !ok {
+				fields = append(fields, field{typ: fieldType, embedded: true})
+				continue
+			}
+		}
+		if structField.PkgPath != "" {
+			// Unexported fields aren't marshalled
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		fields = append(fields, field{name: name, typ: structField.Type})
+	}
+	return fields
+}
+
+// marshaledSchema returns the schema of the JSON type that the zero value of
+// [t] is marshalled to by its MarshalJSON method
+func marshaledSchema(t reflect.Type) (schema *Schema) {
+	defer func() {
+		// MarshalJSON may not handle zero values
+		if r := recover(); r != nil {
+			schema = &Schema{}
+		}
+	}()
+
+	valueBytes, err := stdjson.Marshal(reflect.New(t).Interface())
+	if err != nil {
+		return &Schema{}
+	}
+	var value interface{}
+	if err := stdjson.Unmarshal(valueBytes, &value); err != nil {
+		return &Schema{}
+	}
+	switch value.(type) {
+	case string:
+		return &Schema{Type: "string"}
+	case float64:
+		return &Schema{Type: "number"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	case []interface{}:
+		return &Schema{Type: "array"}
+	case map[string]interface{}:
+		return &Schema{Type: "object"}
+	default:
+		return &Schema{}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"net/http"
+
+	"github.com/gorilla/rpc/v2"
+)
+
+var (
+	_ Documented = &Server{}
+	_ Documented = &documentedHandler{}
+)
+
+// Service is a receiver whose methods are served over JSON-RPC as
+// [Name].[method]
+type Service struct {
+	Name     string
+	Receiver interface{}
+}
+
+// Documented is implemented by HTTP handlers that serve JSON-RPC services
+type Documented interface {
+	http.Handler
+
+	// Services returns the services served by the handler
+	Services() []Service
+}
+
+// Server is an rpc.Server that keeps track of the services registered with
+// it, so that they can be documented
+type Server struct {
+	*rpc.Server
+	services []Service
+}
+
+// NewServer returns a new Server
+func NewServer() *Server {
+	return &Server{Server: rpc.NewServer()}
+}
+
+// RegisterService adds the methods of [receiver] to the server as
+// [name].[method]. See rpc.Server.
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+	s.services = append(s.services, Service{
+		Name:     name,
+		Receiver: receiver,
+	})
+	return nil
+}
+
+func (s *Server) Services() []Service { return s.services }
+
+type documentedHandler struct {
+	http.Handler
+	services func() []Service
+}
+
+// NewHandler returns [handler] documented as serving the services of
+// [documented]. It's used for handlers that wrap a Server.
+func NewHandler(handler http.Handler, documented Documented) Documented {
+	return &documentedHandler{
+		Handler:  handler,
+		services: documented.Services,
+	}
+}
+
+func (h *documentedHandler) Services() []Service { return h.services() }
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"net/http"
+
+	stdjson "encoding/json"
+
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+)
+
+// Discover is the API service that serves the OpenRPC document of the node
+type Discover struct {
+	document func() *Document
+}
+
+// Discover returns the OpenRPC document of the JSON-RPC services of the node.
+// It's called as rpc.discover, as named by the OpenRPC specification.
+func (d *Discover) Discover(_ *http.Request, _ *struct{}, reply *Document) error {
+	*reply = *d.document()
+	return nil
+}
+
+// CreateHandler returns a handler that serves the document returned by
+// [document]. GET requests are responded to with the document, and it can be
+// fetched over JSON-RPC with rpc.discover.
+func CreateHandler(document func() *Document) (http.Handler, error) {
+	newServer := NewServer()
+	codec := json.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	if err := newServer.RegisterService(&Discover{document: document}, "rpc"); err != nil {
+		return nil, err
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			newServer.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// There isn't anything to do with the returned error, so it is
+		// dropped.
+		_ = stdjson.NewEncoder(w).Encode(document())
+	})
+	return NewHandler(handler, newServer), nil
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/sankar-boro/axia-network-v2/utils/metric"
+)
+
+// DefaultMaxBatchSize is the maximum number of calls in a JSON-RPC batch, if
+// not given
+const DefaultMaxBatchSize = 100
+
+var (
+	_ http.Handler        = &batchHandler{}
+	_ http.ResponseWriter = &batchResponseWriter{}
+)
+
+// batchHandler serves JSON-RPC 2.0 batches, which are arrays of calls, by
+// passing each call of the batch to [handler] as its own request. The
+// responses to the calls are written back as an array. Requests that aren't
+// batches are passed to [handler] as is.
+type batchHandler struct {
+	handler http.Handler
+	// Maximum number of calls in a batch. If 0, batches are rejected.
+	maxBatchSize int
+}
+
+// batchErrorResponse is the response to a batch, or to a call of a batch,
+// that couldn't be handled
+type batchErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	Error   *json2.Error    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func (b *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestBodySize))
+	if err != nil {
+		writeBatchError(w, nil, json2.E_PARSE, fmt.Sprintf("couldn't read request body: %s", err))
+		return
+	}
+	if err := r.Body.Close(); err != nil {
+		writeBatchError(w, nil, json2.E_PARSE, fmt.Sprintf("couldn't close request body: %s", err))
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	trimmedBody := bytes.TrimSpace(body)
+	if len(trimmedBody) == 0 || trimmedBody[0] != '[' {
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+
+	calls := []json.RawMessage{}
+	if err := json.Unmarshal(trimmedBody, &calls); err != nil {
+		writeBatchError(w, nil, json2.E_PARSE, fmt.Sprintf("couldn't parse batch: %s", err))
+		return
+	}
+	switch {
+	case len(calls) == 0:
+		writeBatchError(w, nil, json2.E_INVALID_REQ, "batch is empty")
+		return
+	case len(calls) > b.maxBatchSize:
+		writeBatchError(w, nil, json2.E_INVALID_REQ, fmt.Sprintf("batch has %d calls, which is more than the maximum of %d", len(calls), b.maxBatchSize))
+		return
+	}
+
+	// The calls are handled one after the other so that each call grabs and
+	// releases the lock of its chain like a call that isn't batched.
+	ctx := metric.WithBatchedRequest(r.Context())
+	responses := make([]json.RawMessage, 0, len(calls))
+	for _, call := range calls {
+		if response := b.serveCall(r.Clone(ctx), call); response != nil {
+			responses = append(responses, response)
+		}
+	}
+
+	// Notifications aren't responded to. If every call is a notification,
+	// nothing is written back.
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeBatchResponse(w, responses)
+}
+
+// serveCall passes [call] to the handler as the body of [r] and returns the
+// response, or nil if [call] is a notification
+func (b *batchHandler) serveCall(r *http.Request, call json.RawMessage) json.RawMessage {
+	r.Body = io.NopCloser(bytes.NewReader(call))
+	r.ContentLength = int64(len(call))
+
+	writer := newBatchResponseWriter()
+	b.handler.ServeHTTP(writer, r)
+
+	response := bytes.TrimSpace(writer.body.Bytes())
+	if len(response) == 0 {
+		return nil
+	}
+	if json.Valid(response) {
+		return response
+	}
+
+	// The handler rejected the call without a JSON-RPC response, so the error
+	// it wrote is wrapped in one.
+	request := struct {
+		ID json.RawMessage `json:"id"`
+	}{}
+	_ = json.Unmarshal(call, &request)
+	errResponse, err := json.Marshal(newBatchErrorResponse(
+		request.ID,
+		json2.E_SERVER,
+		fmt.Sprintf("%d %s: %s", writer.status, http.StatusText(writer.status), response),
+	))
+	if err != nil {
+		return nil
+	}
+	return errResponse
+}
+
+func newBatchErrorResponse(id json.RawMessage, code json2.ErrorCode, message string) *batchErrorResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &batchErrorResponse{
+		Version: json2.Version,
+		Error: &json2.Error{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	}
+}
+
+func writeBatchError(w http.ResponseWriter, id json.RawMessage, code json2.ErrorCode, message string) {
+	writeBatchResponse(w, newBatchErrorResponse(id, code, message))
+}
+
+func writeBatchResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	// Doesn't matter if there's an error while writing. The caller will get a
+	// malformed response.
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// batchResponseWriter holds the response to a call of a batch
+type batchResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBatchResponseWriter() *batchResponseWriter {
+	return &batchResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (w *batchResponseWriter) Header() http.Header { return w.header }
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+
+func (w *batchResponseWriter) WriteHeader(status int) { w.status = status }
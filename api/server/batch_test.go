@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/utils/metric"
+)
+
+var errTestFail = errors.New("fail")
+
+type testService struct{}
+
+type TestArgs struct {
+	Fail bool `json:"fail"`
+}
+
+type TestReply struct {
+	Echo string `json:"echo"`
+}
+
+func (*testService) Echo(r *http.Request, args *TestArgs, reply *TestReply) error {
+	if args.Fail {
+		return errTestFail
+	}
+	reply.Echo = "echo"
+	return nil
+}
+
+func newTestBatchHandler(t *testing.T, maxBatchSize int) (*batchHandler, *prometheus.Registry) {
+	registry := prometheus.NewRegistry()
+	interceptor, err := metric.NewAPIInterceptor("test", registry)
+	assert.NoError(t, err)
+
+	rpcServer := rpc.NewServer()
+	rpcServer.RegisterCodec(json2.NewCodec(), "application/json")
+	rpcServer.RegisterInterceptFunc(interceptor.InterceptRequest)
+	rpcServer.RegisterAfterFunc(interceptor.AfterRequest)
+	assert.NoError(t, rpcServer.RegisterService(&testService{}, "test"))
+
+	return &batchHandler{
+		handler:      rpcServer,
+		maxBatchSize: maxBatchSize,
+	}, registry
+}
+
+// gatherCounts returns the sum of the values of each counter in [registry]
+func gatherCounts(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	counts := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			counts[family.GetName()] += m.GetCounter().GetValue()
+		}
+	}
+	return counts
+}
+
+func serveBatchTest(h http.Handler, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestBatchHandlerBatch(t *testing.T) {
+	assert := assert.New(t)
+	h, registry := newTestBatchHandler(t, 3)
+
+	recorder := serveBatchTest(h, `[
+		{"jsonrpc":"2.0","method":"test.Echo","params":{},"id":1},
+		{"jsonrpc":"2.0","method":"test.Echo","params":{}},
+		{"jsonrpc":"2.0","method":"test.Echo","params":{"fail":true},"id":"3"}
+	]`)
+	assert.Equal(http.StatusOK, recorder.Code)
+
+	responses := []struct {
+		Result *TestReply      `json:"result"`
+		Error  *json2.Error    `json:"error"`
+		ID     json.RawMessage `json:"id"`
+	}{}
+	assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &responses))
+	// The notification isn't responded to
+	assert.Len(responses, 2)
+	assert.Equal("echo", responses[0].Result.Echo)
+	assert.Equal("1", string(responses[0].ID))
+	assert.Nil(responses[1].Result)
+	assert.Equal(errTestFail.Error(), responses[1].Error.Message)
+	assert.Equal(`"3"`, string(responses[1].ID))
+
+	// Each call is recorded as its own request
+	counts := gatherCounts(t, registry)
+	assert.Equal(3.0, counts["test_request_duration_count"])
+	assert.Equal(3.0, counts["test_request_batched_count"])
+	assert.Equal(1.0, counts["test_request_error_count"])
+}
+
+func TestBatchHandlerSingleCall(t *testing.T) {
+	assert := assert.New(t)
+	h, registry := newTestBatchHandler(t, 0)
+
+	recorder := serveBatchTest(h, `{"jsonrpc":"2.0","method":"test.Echo","params":{},"id":1}`)
+	assert.Equal(http.StatusOK, recorder.Code)
+	response := struct {
+		Result TestReply `json:"result"`
+	}{}
+	assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal("echo", response.Result.Echo)
+	counts := gatherCounts(t, registry)
+	assert.Equal(1.0, counts["test_request_duration_count"])
+	assert.Zero(counts["test_request_batched_count"])
+}
+
+func TestBatchHandlerInvalidBatch(t *testing.T) {
+	h, _ := newTestBatchHandler(t, 1)
+
+	tests := []struct {
+		name string
+		body string
+		code json2.ErrorCode
+	}{
+		{
+			name: "malformed",
+			body: `[{"jsonrpc":"2.0"`,
+			code: json2.E_PARSE,
+		},
+		{
+			name: "empty",
+			body: `[]`,
+			code: json2.E_INVALID_REQ,
+		},
+		{
+			name: "too large",
+			body: `[{"jsonrpc":"2.0","method":"test.Echo","id":1},{"jsonrpc":"2.0","method":"test.Echo","id":2}]`,
+			code: json2.E_INVALID_REQ,
+		},
+		{
+			name: "body too large",
+			body: strings.Repeat(" ", MaxRequestBodySize) + `[{"jsonrpc":"2.0","method":"test.Echo","id":1}]`,
+			code: json2.E_PARSE,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			recorder := serveBatchTest(h, test.body)
+			response := batchErrorResponse{}
+			assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(test.code, response.Error.Code)
+			assert.Equal("null", string(response.ID))
+		})
+	}
+}
+
+func TestBatchHandlerNonJSONResponse(t *testing.T) {
+	assert := assert.New(t)
+	h := &batchHandler{
+		handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("API call rejected"))
+		}),
+		maxBatchSize: 1,
+	}
+
+	recorder := serveBatchTest(h, `[{"jsonrpc":"2.0","method":"test.Echo","id":7}]`)
+	responses := []batchErrorResponse{}
+	assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &responses))
+	assert.Len(responses, 1)
+	assert.Equal(json2.E_SERVER, responses[0].Error.Code)
+	assert.Contains(responses[0].Error.Message, "API call rejected")
+	assert.Equal("7", string(responses[0].ID))
+}
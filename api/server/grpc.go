@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
+)
+
+// EndpointMetadataKey is the gRPC metadata key that selects the endpoint, e.g.
+// "bc/X", that a call is routed to. It may be omitted if the called service is
+// only served at one endpoint.
+const EndpointMetadataKey = "endpoint"
+
+var (
+	errUnknownGRPCService   = errors.New("unknown gRPC service")
+	errUnknownGRPCEndpoint  = errors.New("gRPC service isn't served at the endpoint")
+	errAmbiguousGRPCService = fmt.Errorf("gRPC service is served at multiple endpoints, %q must be specified", EndpointMetadataKey)
+)
+
+type GRPCAdder interface {
+	// AddGRPCService registers a gRPC service that is routed to by [base]
+	AddGRPCService(service *common.GRPCService, lock *sync.RWMutex, base string) error
+}
+
+// GRPCWrapper is implemented by wrappers that also apply to gRPC calls.
+type GRPCWrapper interface {
+	// AuthorizeGRPC returns an error if a gRPC call to the API at [url] should
+	// be rejected. [ctx] is the context of the call, which holds its metadata
+	// and peer. [method] is the JSON-RPC method that mirrors the called gRPC
+	// method. The returned context is passed to the next wrapper and to the
+	// called service. If the error is a gRPC status, it is returned to the
+	// caller as is.
+	AuthorizeGRPC(ctx context.Context, url, method string) (context.Context, error)
+}
+
+type grpcRoute struct {
+	service       *common.GRPCService
+	before, after func()
+	// ctx is the context of the chain the service belongs to, or nil if the
+	// service doesn't belong to a chain.
+	ctx *snow.ConsensusContext
+}
+
+// intercept grabs and releases the lock of the route around the handling of a
+// call.
+func (r *grpcRoute) intercept(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if r.before != nil {
+		r.before()
+	}
+	if r.after != nil {
+		defer r.after()
+	}
+	return handler(ctx, req)
+}
+
+// grpcRouter routes gRPC calls to the services registered with it. Services are
+// dispatched to by hand, rather than being registered with the gRPC server, so
+// that they can be added after the server starts serving.
+type grpcRouter struct {
+	lock     sync.RWMutex
+	routes   map[string]map[string]*grpcRoute // Maps service names to bases to routes
+	aliases  map[string]string                // Maps aliases to bases
+	wrappers []GRPCWrapper
+}
+
+func newGRPCRouter(wrappers []GRPCWrapper) *grpcRouter {
+	return &grpcRouter{
+		routes:   make(map[string]map[string]*grpcRoute),
+		aliases:  make(map[string]string),
+		wrappers: wrappers,
+	}
+}
+
+func (r *grpcRouter) AddRoute(service *common.GRPCService, ctx *snow.ConsensusContext, before, after func(), base string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	name := service.Desc.ServiceName
+	bases := r.routes[name]
+	if bases == nil {
+		bases = make(map[string]*grpcRoute)
+		r.routes[name] = bases
+	}
+	if _, exists := bases[base]; exists {
+		return fmt.Errorf("gRPC service %s is already routed to by %s", name, base)
+	}
+	bases[base] = &grpcRoute{
+		service: service,
+		before:  before,
+		after:   after,
+		ctx:     ctx,
+	}
+	return nil
+}
+
+func (r *grpcRouter) AddAlias(base string, aliases ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, alias := range aliases {
+		r.aliases[alias] = base
+	}
+}
+
+// getRoute returns the route of [serviceName] that [md] selects and the
+// endpoint it was selected by.
+func (r *grpcRouter) getRoute(serviceName string, md metadata.MD) (string, *grpcRoute, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	bases, exists := r.routes[serviceName]
+	if !exists {
+		return "", nil, fmt.Errorf("%w: %s", errUnknownGRPCService, serviceName)
+	}
+
+	endpoints := md.Get(EndpointMetadataKey)
+	if len(endpoints) == 0 {
+		if len(bases) != 1 {
+			return "", nil, errAmbiguousGRPCService
+		}
+		for base, route := range bases {
+			return base, route, nil
+		}
+	}
+
+	endpoint := strings.TrimPrefix(endpoints[0], baseURL)
+	endpoint = strings.Trim(endpoint, "/")
+	base := endpoint
+	if aliased, exists := r.aliases[endpoint]; exists {
+		base = aliased
+	}
+	route, exists := bases[base]
+	if !exists {
+		return "", nil, fmt.Errorf("%w: %s", errUnknownGRPCEndpoint, endpoint)
+	}
+	return endpoint, route, nil
+}
+
+// handleStream handles every call made to the gRPC server.
+func (r *grpcRouter) handleStream(_ interface{}, stream grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "couldn't determine the called method")
+	}
+	// [fullMethod] is of the form "/[service]/[method]"
+	serviceName, methodName, ok := cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "malformed method %q", fullMethod)
+	}
+
+	ctx := stream.Context()
+	md, _ := metadata.FromIncomingContext(ctx)
+	endpoint, route, err := r.getRoute(serviceName, md)
+	if err != nil {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+
+	var method *grpc.MethodDesc
+	for i := range route.service.Desc.Methods {
+		if route.service.Desc.Methods[i].MethodName == methodName {
+			method = &route.service.Desc.Methods[i]
+			break
+		}
+	}
+	if method == nil {
+		return status.Errorf(codes.Unimplemented, "unknown method %s of gRPC service %s", methodName, serviceName)
+	}
+
+	// The wrappers are applied from the outermost to the innermost, the same
+	// order that HTTP requests pass through them in.
+	url := fmt.Sprintf("%s/%s", baseURL, endpoint)
+	jsonMethod := fmt.Sprintf("%s.%s", route.service.Name, lowerFirst(methodName))
+	for i := len(r.wrappers) - 1; i >= 0; i-- {
+		ctx, err = r.wrappers[i].AuthorizeGRPC(ctx, url, jsonMethod)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+	}
+
+	// Reject calls to the chain before it finishes bootstrapping
+	if route.ctx != nil && route.ctx.GetState() != snow.NormalOp {
+		return status.Error(codes.Unavailable, "API call rejected because chain is not done bootstrapping")
+	}
+
+	reply, err := method.Handler(route.service.Impl, ctx, stream.RecvMsg, route.intercept)
+	if err != nil {
+		return err
+	}
+	return stream.SendMsg(reply)
+}
+
+// isGRPCRequest returns true if [r] is a gRPC call.
+func isGRPCRequest(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// cut slices [s] around the first instance of [sep].
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
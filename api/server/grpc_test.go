@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
+
+	healthpb "github.com/sankar-boro/axia-network-v2/proto/pb/health"
+)
+
+var errTestUnauthorized = errors.New("unauthorized")
+
+type testHealthServer struct {
+	healthpb.UnsafeHealthServer
+	healthy bool
+}
+
+func (s *testHealthServer) Readiness(context.Context, *emptypb.Empty) (*healthpb.HealthResponse, error) {
+	return &healthpb.HealthResponse{Healthy: s.healthy}, nil
+}
+
+func (s *testHealthServer) Health(context.Context, *emptypb.Empty) (*healthpb.HealthResponse, error) {
+	return &healthpb.HealthResponse{Healthy: s.healthy}, nil
+}
+
+func (s *testHealthServer) Liveness(context.Context, *emptypb.Empty) (*healthpb.HealthResponse, error) {
+	return &healthpb.HealthResponse{Healthy: s.healthy}, nil
+}
+
+type testGRPCWrapper struct {
+	url, method string
+}
+
+func (w *testGRPCWrapper) AuthorizeGRPC(ctx context.Context, url, method string) (context.Context, error) {
+	w.url = url
+	w.method = method
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, errTestUnauthorized
+	}
+	return ctx, nil
+}
+
+func newTestHealthService(healthy bool) *common.GRPCService {
+	return &common.GRPCService{
+		LockOptions: common.NoLock,
+		Name:        "health",
+		Desc:        &healthpb.Health_ServiceDesc,
+		Impl:        &testHealthServer{healthy: healthy},
+	}
+}
+
+// serveGRPCRouter serves [r] and returns a client of the health service.
+func serveGRPCRouter(t *testing.T, r *grpcRouter) healthpb.HealthClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.UnknownServiceHandler(r.handleStream))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestGRPCRouterSingleEndpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	wrapper := &testGRPCWrapper{}
+	r := newGRPCRouter([]GRPCWrapper{wrapper})
+	assert.NoError(r.AddRoute(newTestHealthService(true), nil, nil, nil, "health"))
+	assert.Error(r.AddRoute(newTestHealthService(true), nil, nil, nil, "health"))
+	client := serveGRPCRouter(t, r)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+	reply, err := client.Health(ctx, &emptypb.Empty{})
+	assert.NoError(err)
+	assert.True(reply.Healthy)
+	assert.Equal("/ext/health", wrapper.url)
+	assert.Equal("health.health", wrapper.method)
+
+	_, err = client.Readiness(context.Background(), &emptypb.Empty{})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+	assert.Equal("health.readiness", wrapper.method)
+}
+
+func TestGRPCRouterEndpoints(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newGRPCRouter(nil)
+	assert.NoError(r.AddRoute(newTestHealthService(true), nil, nil, nil, "bc/healthy"))
+	assert.NoError(r.AddRoute(newTestHealthService(false), nil, nil, nil, "bc/unhealthy"))
+	r.AddAlias("bc/healthy", "bc/H")
+	client := serveGRPCRouter(t, r)
+
+	// The endpoint must be specified if the service is served at multiple
+	// endpoints.
+	_, err := client.Health(context.Background(), &emptypb.Empty{})
+	assert.Equal(codes.Unimplemented, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), EndpointMetadataKey, "/ext/bc/unhealthy")
+	reply, err := client.Health(ctx, &emptypb.Empty{})
+	assert.NoError(err)
+	assert.False(reply.Healthy)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), EndpointMetadataKey, "bc/H")
+	reply, err = client.Health(ctx, &emptypb.Empty{})
+	assert.NoError(err)
+	assert.True(reply.Healthy)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), EndpointMetadataKey, "bc/unknown")
+	_, err = client.Health(ctx, &emptypb.Empty{})
+	assert.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestGRPCRouterLock(t *testing.T) {
+	assert := assert.New(t)
+
+	service := newTestHealthService(true)
+	service.LockOptions = common.WriteLock
+	calls := int64(0)
+	inc := func() { atomic.AddInt64(&calls, 1) }
+	r := newGRPCRouter(nil)
+	assert.NoError(r.AddRoute(service, nil, inc, inc, "health"))
+	client := serveGRPCRouter(t, r)
+
+	_, err := client.Liveness(context.Background(), &emptypb.Empty{})
+	assert.NoError(err)
+	assert.EqualValues(2, atomic.LoadInt64(&calls))
+}
+
+type testLimitingGRPCWrapper struct{}
+
+func (testLimitingGRPCWrapper) AuthorizeGRPC(context.Context, string, string) (context.Context, error) {
+	return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+}
+
+func TestGRPCRouterWrapperStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newGRPCRouter([]GRPCWrapper{testLimitingGRPCWrapper{}})
+	assert.NoError(r.AddRoute(newTestHealthService(true), nil, nil, nil, "health"))
+	client := serveGRPCRouter(t, r)
+
+	_, err := client.Health(context.Background(), &emptypb.Empty{})
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+}
@@ -10,6 +10,7 @@ import (
 	sync "sync"
 	time "time"
 
+	schema "github.com/sankar-boro/axia-network-v2/api/schema"
 	ids "github.com/sankar-boro/axia-network-v2/ids"
 	snow "github.com/sankar-boro/axia-network-v2/snow"
 	common "github.com/sankar-boro/axia-network-v2/snow/engine/common"
@@ -204,6 +205,20 @@ func (mr *MockServerMockRecorder) AddChainRoute(handler, ctx, base, endpoint int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChainRoute", reflect.TypeOf((*MockServer)(nil).AddChainRoute), handler, ctx, base, endpoint)
 }
 
+// AddGRPCService mocks base method.
+func (m *MockServer) AddGRPCService(service *common.GRPCService, lock *sync.RWMutex, base string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGRPCService", service, lock, base)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGRPCService indicates an expected call of AddGRPCService.
+func (mr *MockServerMockRecorder) AddGRPCService(service, lock, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGRPCService", reflect.TypeOf((*MockServer)(nil).AddGRPCService), service, lock, base)
+}
+
 // AddRoute mocks base method.
 func (m *MockServer) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string) error {
 	m.ctrl.T.Helper()
@@ -260,6 +275,18 @@ func (mr *MockServerMockRecorder) DispatchTLS(certBytes, keyBytes interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), certBytes, keyBytes)
 }
 
+// EnableGRPC mocks base method.
+func (m *MockServer) EnableGRPC(port uint16) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableGRPC", port)
+}
+
+// EnableGRPC indicates an expected call of EnableGRPC.
+func (mr *MockServerMockRecorder) EnableGRPC(port interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableGRPC", reflect.TypeOf((*MockServer)(nil).EnableGRPC), port)
+}
+
 // Initialize mocks base method.
 func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, host string, port uint16, allowedOrigins []string, shutdownTimeout time.Duration, nodeID ids.NodeID, wrappers ...Wrapper) {
 	m.ctrl.T.Helper()
@@ -289,6 +316,32 @@ func (mr *MockServerMockRecorder) RegisterChain(chainName, engine interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterChain", reflect.TypeOf((*MockServer)(nil).RegisterChain), chainName, engine)
 }
 
+// Schema mocks base method.
+func (m *MockServer) Schema() *schema.Document {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schema")
+	ret0, _ := ret[0].(*schema.Document)
+	return ret0
+}
+
+// Schema indicates an expected call of Schema.
+func (mr *MockServerMockRecorder) Schema() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schema", reflect.TypeOf((*MockServer)(nil).Schema))
+}
+
+// SetMaxBatchSize mocks base method.
+func (m *MockServer) SetMaxBatchSize(maxBatchSize int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxBatchSize", maxBatchSize)
+}
+
+// SetMaxBatchSize indicates an expected call of SetMaxBatchSize.
+func (mr *MockServerMockRecorder) SetMaxBatchSize(maxBatchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxBatchSize", reflect.TypeOf((*MockServer)(nil).SetMaxBatchSize), maxBatchSize)
+}
+
 // Shutdown mocks base method.
 func (m *MockServer) Shutdown() error {
 	m.ctrl.T.Helper()
@@ -19,15 +19,29 @@ import (
 
 	"github.com/rs/cors"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/grpc"
+
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/snow"
 	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+	"github.com/sankar-boro/axia-network-v2/version"
 )
 
-const baseURL = "/ext"
+const (
+	baseURL = "/ext"
+
+	// MaxRequestBodySize is the maximum size of the body of a request that is
+	// read in full before the request is handled
+	MaxRequestBodySize = 16 * units.MiB
+)
 
 var (
 	errUnknownLockOption = errors.New("invalid lock options")
@@ -58,6 +72,7 @@ type PathAdderWithReadLock interface {
 type Server interface {
 	PathAdder
 	PathAdderWithReadLock
+	GRPCAdder
 	// Initialize creates the API server at the provided host and port
 	Initialize(log logging.Logger,
 		factory logging.Factory,
@@ -67,6 +82,14 @@ type Server interface {
 		shutdownTimeout time.Duration,
 		nodeID ids.NodeID,
 		wrappers ...Wrapper)
+	// EnableGRPC makes the API server serve its gRPC services. If [port] is 0,
+	// they're served on the API port. Otherwise, they're served on [port]. Must
+	// be called before the API server is dispatched.
+	EnableGRPC(port uint16)
+	// SetMaxBatchSize sets the maximum number of calls in a JSON-RPC batch.
+	// If [maxBatchSize] is 0, batches are rejected. Must be called before the
+	// API server is dispatched.
+	SetMaxBatchSize(maxBatchSize int)
 	// Dispatch starts the API server
 	Dispatch() error
 	// DispatchTLS starts the API server with the provided TLS certificate
@@ -85,6 +108,9 @@ type Server interface {
 		ctx *snow.ConsensusContext,
 		base, endpoint string,
 	) error
+	// Schema returns the OpenRPC document of the JSON-RPC services of the
+	// routes added to this server
+	Schema() *schema.Document
 	// Shutdown this server
 	Shutdown() error
 }
@@ -104,6 +130,20 @@ type server struct {
 
 	// Maps endpoints to handlers
 	router *router
+	// Splits JSON-RPC batches into calls that are passed to [router]
+	batchHandler *batchHandler
+	// Documents the JSON-RPC services of the routes
+	schema *schema.Generator
+
+	// Wrappers that are told about the aliases of endpoints
+	aliasWrappers []AliasWrapper
+
+	// Routes gRPC calls to services
+	grpcRouter  *grpcRouter
+	grpcServer  *grpc.Server
+	grpcEnabled bool
+	// Port that gRPC is served on, or 0 if it is served on [listenPort]
+	grpcPort uint16
 
 	srv *http.Server
 }
@@ -129,15 +169,23 @@ func (s *server) Initialize(
 	s.listenPort = port
 	s.shutdownTimeout = shutdownTimeout
 	s.router = newRouter()
+	s.batchHandler = &batchHandler{
+		handler:      s.router,
+		maxBatchSize: DefaultMaxBatchSize,
+	}
+	s.schema = schema.NewGenerator(
+		fmt.Sprintf("%s node APIs", constants.PlatformName),
+		version.CurrentApp.String(),
+	)
 
 	s.log.Info("API created with allowed origins: %v", allowedOrigins)
 
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   allowedOrigins,
 		AllowCredentials: true,
-	}).Handler(s.router)
+	}).Handler(s.batchHandler)
 	gzipHandler := gziphandler.GzipHandler(corsHandler)
-	s.handler = http.HandlerFunc(
+	var httpHandler http.Handler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// Attach this node's ID as a header
 			w.Header().Set("node-id", nodeID.String())
@@ -145,9 +193,39 @@ func (s *server) Initialize(
 		},
 	)
 
+	grpcWrappers := []GRPCWrapper(nil)
 	for _, wrapper := range wrappers {
-		s.handler = wrapper.WrapHandler(s.handler)
+		httpHandler = wrapper.WrapHandler(httpHandler)
+		if grpcWrapper, ok := wrapper.(GRPCWrapper); ok {
+			grpcWrappers = append(grpcWrappers, grpcWrapper)
+		}
+		if aliasWrapper, ok := wrapper.(AliasWrapper); ok {
+			s.aliasWrappers = append(s.aliasWrappers, aliasWrapper)
+		}
 	}
+
+	s.grpcRouter = newGRPCRouter(grpcWrappers)
+	s.grpcServer = grpc.NewServer(grpc.UnknownServiceHandler(s.grpcRouter.handleStream))
+	s.handler = http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			// gRPC calls are authorized by the gRPC router, so they bypass
+			// the HTTP wrappers.
+			if s.grpcEnabled && s.grpcPort == 0 && isGRPCRequest(r) {
+				s.grpcServer.ServeHTTP(w, r)
+				return
+			}
+			httpHandler.ServeHTTP(w, r)
+		},
+	)
+}
+
+func (s *server) EnableGRPC(port uint16) {
+	s.grpcEnabled = true
+	s.grpcPort = port
+}
+
+func (s *server) SetMaxBatchSize(maxBatchSize int) {
+	s.batchHandler.maxBatchSize = maxBatchSize
 }
 
 func (s *server) Dispatch() error {
@@ -157,6 +235,22 @@ func (s *server) Dispatch() error {
 		return err
 	}
 
+	handler := s.handler
+	if s.grpcEnabled {
+		if s.grpcPort == 0 {
+			// gRPC requires HTTP/2, which is only negotiated over TLS, so
+			// HTTP/2 without TLS must be accepted as well.
+			handler = h2c.NewHandler(handler, &http2.Server{})
+		} else {
+			grpcListener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.listenHost, s.grpcPort))
+			if err != nil {
+				_ = listener.Close()
+				return err
+			}
+			s.serveGRPC(grpcListener)
+		}
+	}
+
 	ipPort, err := ips.ToIPPort(listener.Addr().String())
 	if err != nil {
 		s.log.Info("HTTP API server listening on %q", listenAddress)
@@ -164,7 +258,7 @@ func (s *server) Dispatch() error {
 		s.log.Info("HTTP API server listening on \"%s:%d\"", s.listenHost, ipPort.Port)
 	}
 
-	s.srv = &http.Server{Handler: s.handler}
+	s.srv = &http.Server{Handler: handler}
 	return s.srv.Serve(listener)
 }
 
@@ -177,6 +271,7 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 	config := &tls.Config{
 		MinVersion:   tls.VersionTLS12,
 		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
 	}
 
 	listener, err := tls.Listen("tcp", listenAddress, config)
@@ -184,6 +279,17 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 		return err
 	}
 
+	if s.grpcEnabled && s.grpcPort != 0 {
+		grpcConfig := config.Clone()
+		grpcConfig.NextProtos = []string{"h2"}
+		grpcListener, err := tls.Listen("tcp", fmt.Sprintf("%s:%d", s.listenHost, s.grpcPort), grpcConfig)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+		s.serveGRPC(grpcListener)
+	}
+
 	ipPort, err := ips.ToIPPort(listener.Addr().String())
 	if err != nil {
 		s.log.Info("HTTPS API server listening on %q", listenAddress)
@@ -195,6 +301,22 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 	return s.srv.Serve(listener)
 }
 
+// serveGRPC serves gRPC on [listener] in a separate goroutine.
+func (s *server) serveGRPC(listener net.Listener) {
+	ipPort, err := ips.ToIPPort(listener.Addr().String())
+	if err != nil {
+		s.log.Info("gRPC API server listening on %q", listener.Addr())
+	} else {
+		s.log.Info("gRPC API server listening on \"%s:%d\"", s.listenHost, ipPort.Port)
+	}
+
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil {
+			s.log.Error("gRPC API server stopped serving: %s", err)
+		}
+	}()
+}
+
 func (s *server) RegisterChain(chainName string, engine common.Engine) {
 	go s.registerChain(chainName, engine)
 }
@@ -206,8 +328,9 @@ func (s *server) registerChain(chainName string, engine common.Engine) {
 	)
 
 	ctx := engine.Context()
+	vm := engine.GetVM()
 	ctx.Lock.Lock()
-	handlers, err = engine.GetVM().CreateHandlers()
+	handlers, err = vm.CreateHandlers()
 	ctx.Lock.Unlock()
 	if err != nil {
 		s.log.Error("failed to create %s handlers: %s", chainName, err)
@@ -231,6 +354,24 @@ func (s *server) registerChain(chainName string, engine common.Engine) {
 			s.log.Error("error adding route: %s", err)
 		}
 	}
+
+	creator, ok := vm.(common.GRPCServiceCreator)
+	if !s.grpcEnabled || !ok {
+		return
+	}
+
+	ctx.Lock.Lock()
+	services, err := creator.CreateGRPCServices()
+	ctx.Lock.Unlock()
+	if err != nil {
+		s.log.Error("failed to create %s gRPC services: %s", chainName, err)
+		return
+	}
+	for _, service := range services {
+		if err := s.addChainGRPCService(service, ctx, defaultEndpoint); err != nil {
+			s.log.Error("error adding gRPC service: %s", err)
+		}
+	}
 }
 
 func (s *server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.ConsensusContext, base, endpoint string) error {
@@ -243,7 +384,11 @@ func (s *server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.ConsensusC
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
-	return s.router.AddRouter(url, endpoint, h)
+	if err := s.router.AddRouter(url, endpoint, h); err != nil {
+		return err
+	}
+	s.schema.AddHandler(url+endpoint, handler.Handler)
+	return nil
 }
 
 func (s *server) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string) error {
@@ -264,28 +409,59 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
-	return s.router.AddRouter(url, endpoint, h)
+	if err := s.router.AddRouter(url, endpoint, h); err != nil {
+		return err
+	}
+	s.schema.AddHandler(url+endpoint, handler.Handler)
+	return nil
+}
+
+func (s *server) AddGRPCService(service *common.GRPCService, lock *sync.RWMutex, base string) error {
+	s.log.Info("adding gRPC service %s at %s/%s", service.Desc.ServiceName, baseURL, base)
+	before, after, err := lockFuncs(service.LockOptions, lock)
+	if err != nil {
+		return err
+	}
+	return s.grpcRouter.AddRoute(service, nil, before, after, base)
+}
+
+func (s *server) addChainGRPCService(service *common.GRPCService, ctx *snow.ConsensusContext, base string) error {
+	s.log.Info("adding gRPC service %s at %s/%s", service.Desc.ServiceName, baseURL, base)
+	before, after, err := lockFuncs(service.LockOptions, &ctx.Lock)
+	if err != nil {
+		return err
+	}
+	return s.grpcRouter.AddRoute(service, ctx, before, after, base)
 }
 
 // Wraps a handler by grabbing and releasing a lock before calling the handler.
 func lockMiddleware(handler http.Handler, lockOption common.LockOption, lock *sync.RWMutex) (http.Handler, error) {
+	before, after, err := lockFuncs(lockOption, lock)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return handler, nil
+	}
+	return middlewareHandler{
+		before:  before,
+		after:   after,
+		handler: handler,
+	}, nil
+}
+
+// lockFuncs returns the functions that grab and release [lock] as specified by
+// [lockOption]. If no lock should be held, nil functions are returned.
+func lockFuncs(lockOption common.LockOption, lock *sync.RWMutex) (func(), func(), error) {
 	switch lockOption {
 	case common.WriteLock:
-		return middlewareHandler{
-			before:  lock.Lock,
-			after:   lock.Unlock,
-			handler: handler,
-		}, nil
+		return lock.Lock, lock.Unlock, nil
 	case common.ReadLock:
-		return middlewareHandler{
-			before:  lock.RLock,
-			after:   lock.RUnlock,
-			handler: handler,
-		}, nil
+		return lock.RLock, lock.RUnlock, nil
 	case common.NoLock:
-		return handler, nil
+		return nil, nil, nil
 	default:
-		return nil, errUnknownLockOption
+		return nil, nil, errUnknownLockOption
 	}
 }
 
@@ -309,7 +485,14 @@ func (s *server) AddAliases(endpoint string, aliases ...string) error {
 	for i, alias := range aliases {
 		endpoints[i] = fmt.Sprintf("%s/%s", baseURL, alias)
 	}
-	return s.router.AddAlias(url, endpoints...)
+	if err := s.router.AddAlias(url, endpoints...); err != nil {
+		return err
+	}
+	s.grpcRouter.AddAlias(endpoint, aliases...)
+	for _, wrapper := range s.aliasWrappers {
+		wrapper.AliasEndpoint(endpoint, aliases...)
+	}
+	return nil
 }
 
 func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) error {
@@ -322,6 +505,10 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 	return s.AddAliases(endpoint, aliases...)
 }
 
+func (s *server) Schema() *schema.Document {
+	return s.schema.Document()
+}
+
 func (s *server) Shutdown() error {
 	if s.srv == nil {
 		return nil
@@ -333,6 +520,7 @@ func (s *server) Shutdown() error {
 
 	// If shutdown times out, make sure the server is still shutdown.
 	_ = s.srv.Close()
+	s.grpcServer.Stop()
 	return err
 }
 
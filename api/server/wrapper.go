@@ -11,3 +11,10 @@ type Wrapper interface {
 	// WrapHandler wraps an http.Handler.
 	WrapHandler(h http.Handler) http.Handler
 }
+
+// AliasWrapper is implemented by wrappers that need to know which endpoints
+// are aliases of each other, such as "bc/X" and "bc/[chain ID]".
+type AliasWrapper interface {
+	// AliasEndpoint is called when [aliases] are registered for [endpoint].
+	AliasEndpoint(endpoint string, aliases ...string)
+}
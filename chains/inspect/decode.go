@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/codec"
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/linkeddb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/fxs"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/txs"
+	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/nftfx"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/status"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/validator"
+	"github.com/sankar-boro/axia-network-v2/vms/propertyfx"
+	"github.com/sankar-boro/axia-network-v2/vms/proposervm/block"
+	"github.com/sankar-boro/axia-network-v2/vms/proposervm/state"
+	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
+)
+
+// avmCodec is the codec of the fxs that the AVM is created with.
+var avmCodec codec.Manager
+
+func init() {
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		panic(err)
+	}
+	avmCodec = parser.Codec()
+}
+
+// platformStateTx is the format that the platformvm stores its transactions
+// in.
+type platformStateTx struct {
+	Tx     []byte        `serialize:"true"`
+	Status status.Status `serialize:"true"`
+}
+
+// platformUptime is the format that the platformvm stores the uptimes of its
+// current primary network validators in.
+type platformUptime struct {
+	UpDuration      time.Duration `serialize:"true"`
+	LastUpdated     uint64        `serialize:"true"` // Unix time in seconds
+	PotentialReward uint64        `serialize:"true"`
+}
+
+func getPlatformTx(db database.KeyValueReader, txID ids.ID) (*platformvm.Tx, status.Status, error) {
+	txBytes, err := db.Get(txID[:])
+	if err != nil {
+		return nil, status.Unknown, err
+	}
+
+	stx := platformStateTx{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(txBytes, &stx); err != nil {
+		return nil, status.Unknown, err
+	}
+
+	tx := platformvm.Tx{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(stx.Tx, &tx); err != nil {
+		return nil, status.Unknown, err
+	}
+	if err := tx.Sign(platformvm.GenesisCodec, nil); err != nil {
+		return nil, status.Unknown, err
+	}
+	return &tx, stx.Status, nil
+}
+
+// decodeProposerBlocks formats the blocks of a proposervm block state.
+func decodeProposerBlocks(db database.Database, limit int) ([]string, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	blockState := state.NewBlockState(db)
+	return decodeValues(it, limit, func(key []byte, _ []byte) (string, error) {
+		blkID, err := ids.ToID(key)
+		if err != nil {
+			return "", err
+		}
+		blk, blkStatus, err := blockState.GetBlock(blkID)
+		if err != nil {
+			return "", err
+		}
+
+		str := fmt.Sprintf("block %s: status=%s parent=%s", blkID, blkStatus, blk.ParentID())
+		signedBlk, ok := blk.(block.SignedBlock)
+		if !ok {
+			return str + " option", nil
+		}
+		return fmt.Sprintf(
+			"%s coreChainHeight=%d timestamp=%s proposer=%s",
+			str,
+			signedBlk.CoreChainHeight(),
+			signedBlk.Timestamp().UTC(),
+			signedBlk.Proposer(),
+		), nil
+	})
+}
+
+// decodePlatformStakers returns a decoder of the validator lists of the
+// platformvm. The transactions that added the stakers are read from [txDB].
+func decodePlatformStakers(txDB database.KeyValueReader) decoder {
+	return func(db database.Database, limit int) ([]string, error) {
+		it := linkeddb.NewDefault(db).NewIterator()
+		defer it.Release()
+
+		return decodeValues(it, limit, func(key []byte, value []byte) (string, error) {
+			return decodePlatformStaker(txDB, key, value)
+		})
+	}
+}
+
+func decodePlatformStaker(txDB database.KeyValueReader, key []byte, value []byte) (string, error) {
+	txID, err := ids.ToID(key)
+	if err != nil {
+		return "", err
+	}
+	tx, _, err := getPlatformTx(txDB, txID)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		vdr validator.Validator
+		str string
+	)
+	switch utx := tx.UnsignedTx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		vdr = utx.Validator
+		str = fmt.Sprintf("shares=%d", utx.Shares)
+		if len(value) > 0 {
+			uptime := platformUptime{}
+			if _, err := platformvm.GenesisCodec.Unmarshal(value, &uptime); err != nil {
+				return "", err
+			}
+			str = fmt.Sprintf(
+				"%s upDuration=%s lastUpdated=%s potentialReward=%d",
+				str,
+				uptime.UpDuration,
+				time.Unix(int64(uptime.LastUpdated), 0).UTC(),
+				uptime.PotentialReward,
+			)
+		}
+	case *platformvm.UnsignedAddNominatorTx:
+		vdr = utx.Validator
+		if len(value) > 0 {
+			potentialReward, err := database.ParseUInt64(value)
+			if err != nil {
+				return "", err
+			}
+			str = fmt.Sprintf("potentialReward=%d", potentialReward)
+		}
+	case *platformvm.UnsignedAddAllychainValidatorTx:
+		vdr = utx.Validator.Validator
+		str = fmt.Sprintf("allychain=%s", utx.Validator.Allychain)
+	default:
+		return "", fmt.Errorf("unexpected transaction type %T", utx)
+	}
+	return fmt.Sprintf(
+		"tx %s: nodeID=%s weight=%d start=%s end=%s %s",
+		txID,
+		vdr.ID(),
+		vdr.Weight(),
+		vdr.StartTime().UTC(),
+		vdr.EndTime().UTC(),
+		str,
+	), nil
+}
+
+// decodeIDs formats the keys of a linkeddb of IDs.
+func decodeIDs(db database.Database, limit int) ([]string, error) {
+	it := linkeddb.NewDefault(db).NewIterator()
+	defer it.Release()
+
+	return decodeValues(it, limit, func(key []byte, _ []byte) (string, error) {
+		id, err := ids.ToID(key)
+		if err != nil {
+			return "", err
+		}
+		return id.String(), nil
+	})
+}
+
+// decodeUTXOs returns a decoder of the UTXOs of an axc.UTXOState that was
+// created with [c].
+func decodeUTXOs(c codec.Manager) decoder {
+	return func(db database.Database, limit int) ([]string, error) {
+		it := db.NewIterator()
+		defer it.Release()
+
+		return decodeValues(it, limit, func(_ []byte, value []byte) (string, error) {
+			utxo := &axc.UTXO{}
+			if _, err := c.Unmarshal(value, utxo); err != nil {
+				return "", err
+			}
+
+			str := fmt.Sprintf("utxo %s: asset=%s output=%T", &utxo.UTXOID, utxo.AssetID(), utxo.Out)
+			if out, ok := utxo.Out.(axc.Amounter); ok {
+				str = fmt.Sprintf("%s amount=%d", str, out.Amount())
+			}
+			return str, nil
+		})
+	}
+}
+
+// decodeValues formats up to [limit] key/value pairs of [it] with [format].
+// Pairs that fail to be formatted are reported rather than returned as an
+// error, so that a single corrupt value doesn't hide the others.
+func decodeValues(it database.Iterator, limit int, format func(key []byte, value []byte) (string, error)) ([]string, error) {
+	var values []string
+	for len(values) < limit && it.Next() {
+		str, err := format(it.Key(), it.Value())
+		if err != nil {
+			str = fmt.Sprintf("%x: failed to decode: %s", it.Key(), err)
+		}
+		values = append(values, str)
+	}
+	return values, it.Error()
+}
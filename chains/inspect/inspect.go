@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package inspect reports on the prefixes that a node, its chains and their
+// VMs store in the node's database.
+package inspect
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+)
+
+// Config of an inspection.
+type Config struct {
+	// ChainIDs are the chains to inspect. If empty, every chain is inspected.
+	ChainIDs []ids.ID
+	// DecodeLimit is the maximum number of values decoded per prefix.
+	DecodeLimit int
+}
+
+// Inspect writes a report on the prefixes of [db] to [w]. [db] is only read.
+func Inspect(w io.Writer, db database.Database, config Config) error {
+	chainList, err := Chains(db)
+	if err != nil {
+		return fmt.Errorf("couldn't read the chains: %w", err)
+	}
+	if len(config.ChainIDs) > 0 {
+		chainIDs := ids.Set{}
+		chainIDs.Add(config.ChainIDs...)
+
+		filtered := chainList[:0]
+		for _, chain := range chainList {
+			if chainIDs.Contains(chain.ID) {
+				filtered = append(filtered, chain)
+			}
+		}
+		chainList = filtered
+	}
+	return Write(w, Layout(db, chainList), config.DecodeLimit)
+}
+
+// Write writes the stats, and up to [decodeLimit] decoded values, of every
+// prefix of the tree rooted at [root] to [w].
+func Write(w io.Writer, root *Prefix, decodeLimit int) error {
+	stats, err := Count(root)
+	if err != nil {
+		return err
+	}
+
+	total := Stats{}
+	for _, s := range stats {
+		total.add(s)
+	}
+	if _, err := fmt.Fprintf(w, "total: %d keys, %d bytes\n", total.Keys, total.Bytes); err != nil {
+		return err
+	}
+	return write(w, root, stats, decodeLimit, 0)
+}
+
+func write(w io.Writer, p *Prefix, stats map[*Prefix]Stats, decodeLimit int, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	s := stats[p]
+	if _, err := fmt.Fprintf(w, "%s%s: %d keys, %d bytes\n", indent, p.Name, s.Keys, s.Bytes); err != nil {
+		return err
+	}
+
+	values, err := p.Decode(decodeLimit)
+	if err != nil {
+		return fmt.Errorf("couldn't decode %s: %w", p.Name, err)
+	}
+	for _, value := range values {
+		if _, err := fmt.Fprintf(w, "%s  - %s\n", indent, value); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range p.Children {
+		if err := write(w, child, stats, decodeLimit, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
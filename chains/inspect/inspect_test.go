@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/chains"
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/linkeddb"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow/choices"
+	"github.com/sankar-boro/axia-network-v2/utils/constants"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/fxs"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/states"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/txs"
+	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/status"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/validator"
+	"github.com/sankar-boro/axia-network-v2/vms/proposervm/block"
+	"github.com/sankar-boro/axia-network-v2/vms/proposervm/state"
+	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
+)
+
+var (
+	testUTXOID   = axc.UTXOID{TxID: ids.ID{'u'}, OutputIndex: 1}
+	testNodeID   = ids.NodeID{'n'}
+	testAssetID  = ids.ID{'a'}
+	testOtherKey = []byte("genesisID")
+)
+
+func vmDB(db database.Database, chainID ids.ID) database.Database {
+	return prefixdb.New(chains.VMDBPrefix, prefixdb.New(chainID[:], db))
+}
+
+func putPlatformTx(t *testing.T, db database.KeyValueWriter, utx platformvm.UnsignedTx) ids.ID {
+	tx := &platformvm.Tx{UnsignedTx: utx}
+	if err := tx.Sign(platformvm.GenesisCodec, nil); err != nil {
+		t.Fatal(err)
+	}
+	stxBytes, err := platformvm.GenesisCodec.Marshal(platformvm.CodecVersion, &platformStateTx{
+		Tx:     tx.Bytes(),
+		Status: status.Committed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txID := tx.ID()
+	if err := db.Put(txID[:], stxBytes); err != nil {
+		t.Fatal(err)
+	}
+	return txID
+}
+
+// populate writes a chain created on the Platform Chain, a validator of the
+// primary network, a proposervm block and an AVM UTXO into [db].
+func populate(t *testing.T, db database.Database) block.Block {
+	assert := assert.New(t)
+
+	// Platform Chain
+	platformDB := versiondb.New(vmDB(db, constants.PlatformChainID))
+	txDB := prefixdb.New(platformTxPrefix, platformDB)
+
+	chainTxID := putPlatformTx(t, txDB, &platformvm.UnsignedCreateChainTx{
+		AllychainID:   constants.PrimaryNetworkID,
+		ChainName:     "X",
+		VMID:          constants.AVMID,
+		AllychainAuth: &secp256k1fx.Input{},
+	})
+	chainDB := prefixdb.New(platformChainPrefix, platformDB)
+	primaryChainDB := linkeddb.NewDefault(prefixdb.New(constants.PrimaryNetworkID[:], chainDB))
+	assert.NoError(primaryChainDB.Put(chainTxID[:], nil))
+
+	now := time.Unix(1000, 0)
+	vdrTxID := putPlatformTx(t, txDB, &platformvm.UnsignedAddValidatorTx{
+		Validator: validator.Validator{
+			NodeID: testNodeID,
+			Start:  uint64(now.Unix()),
+			End:    uint64(now.Add(time.Hour).Unix()),
+			Wght:   5,
+		},
+		RewardsOwner: &secp256k1fx.OutputOwners{},
+		Shares:       300,
+	})
+	uptimeBytes, err := platformvm.GenesisCodec.Marshal(platformvm.CodecVersion, &platformUptime{
+		UpDuration:      time.Minute,
+		LastUpdated:     uint64(now.Unix()),
+		PotentialReward: 7,
+	})
+	assert.NoError(err)
+	validatorsDB := prefixdb.New(platformValidatorsPrefix, platformDB)
+	currentValidatorDB := prefixdb.New(platformValidatorPrefix, prefixdb.New(platformCurrentPrefix, validatorsDB))
+	assert.NoError(linkeddb.NewDefault(currentValidatorDB).Put(vdrTxID[:], uptimeBytes))
+	assert.NoError(platformDB.Commit())
+
+	// ProposerVM of the Platform Chain
+	proposerDB := versiondb.New(prefixdb.New(proposerVMPrefix, vmDB(db, constants.PlatformChainID)))
+	blk, err := block.BuildUnsigned(ids.ID{'p'}, now, 3, []byte{1})
+	assert.NoError(err)
+	assert.NoError(state.New(proposerDB).PutBlock(blk, choices.Accepted))
+	assert.NoError(proposerDB.Commit())
+
+	// AVM
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}})
+	assert.NoError(err)
+	avmDB := versiondb.New(vmDB(db, chainTxID))
+	avmState, err := states.New(avmDB, parser, prometheus.NewRegistry())
+	assert.NoError(err)
+	utxo := &axc.UTXO{
+		UTXOID: testUTXOID,
+		Asset:  axc.Asset{ID: testAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 12345,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{{1}},
+			},
+		},
+	}
+	assert.NoError(avmState.PutUTXO(utxo.InputID(), utxo))
+	assert.NoError(avmDB.Commit())
+
+	// A key that isn't in any known prefix.
+	assert.NoError(db.Put(testOtherKey, []byte{0}))
+	return blk
+}
+
+func TestChains(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	populate(t, db)
+
+	chainList, err := Chains(db)
+	assert.NoError(err)
+	assert.Len(chainList, 2)
+	assert.Equal(constants.PlatformChainID, chainList[0].ID)
+	assert.Equal(constants.PlatformVMID, chainList[0].VMID)
+	assert.Equal("X", chainList[1].Name)
+	assert.Equal(constants.AVMID, chainList[1].VMID)
+	assert.Equal(constants.PrimaryNetworkID, chainList[1].AllychainID)
+}
+
+func TestCount(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	populate(t, db)
+
+	chainList, err := Chains(db)
+	assert.NoError(err)
+	root := Layout(db, chainList)
+
+	stats, err := Count(root)
+	assert.NoError(err)
+
+	// Every key is attributed to exactly one prefix.
+	total := Stats{}
+	for _, s := range stats {
+		total.add(s)
+	}
+	it := db.NewIterator()
+	numKeys := uint64(0)
+	for it.Next() {
+		numKeys++
+	}
+	it.Release()
+	assert.Equal(numKeys, total.Keys)
+
+	// The only key outside of the known prefixes is [testOtherKey].
+	assert.Equal(Stats{Keys: 1, Bytes: uint64(len(testOtherKey) + 1)}, stats[root])
+
+	find := func(p *Prefix, names ...string) *Prefix {
+		for _, name := range names {
+			var next *Prefix
+			for _, child := range p.Children {
+				if child.Name == name {
+					next = child
+				}
+			}
+			if next == nil {
+				t.Fatalf("missing prefix %q", name)
+			}
+			p = next
+		}
+		return p
+	}
+	xChain := chainList[1]
+	xVM := find(root, "chain "+xChain.String(), "vm")
+	assert.EqualValues(1, stats[find(xVM, "utxos", "utxos")].Keys)
+	// The linkeddb that indexes the UTXO by its address stores a head key and
+	// a node.
+	assert.EqualValues(2, stats[find(xVM, "utxos", "index")].Keys)
+	assert.Zero(stats[xVM].Keys)
+
+	pChain := chainList[0]
+	blocks := find(root, "chain "+pChain.String(), "vm", "proposervm", "blocks")
+	assert.EqualValues(1, stats[blocks].Keys)
+}
+
+func TestInspect(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	blk := populate(t, db)
+
+	w := &bytes.Buffer{}
+	assert.NoError(Inspect(w, db, Config{DecodeLimit: 10}))
+	out := w.String()
+
+	assert.Contains(out, "chain P (11111111111111111111111111111111LpoYY):")
+	assert.Contains(out, "- block "+blk.ID().String()+": status=Accepted parent="+blk.ParentID().String()+" coreChainHeight=3")
+	assert.Contains(out, "nodeID="+testNodeID.String()+" weight=5")
+	assert.Contains(out, "shares=300 upDuration=1m0s")
+	assert.Contains(out, "potentialReward=7")
+	assert.Contains(out, "- utxo "+testUTXOID.String()+": asset="+testAssetID.String()+" output=*secp256k1fx.TransferOutput amount=12345")
+
+	// Only the requested chains are reported.
+	w.Reset()
+	assert.NoError(Inspect(w, db, Config{ChainIDs: []ids.ID{constants.PlatformChainID}}))
+	out = w.String()
+	assert.Contains(out, "chain P")
+	assert.NotContains(out, "chain X")
+	assert.NotContains(out, "- block")
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"fmt"
+
+	"github.com/sankar-boro/axia-network-v2/chains"
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/linkeddb"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/constants"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm"
+)
+
+// The prefixes below aren't exported by the packages that use them. They must
+// be kept in sync with the packages named in their comments.
+var (
+	// node
+	sharedMemoryPrefix = []byte("shared memory")
+	keystorePrefix     = []byte("keystore")
+	indexerPrefix      = []byte{0x00}
+	authPrefix         = []byte("auth")
+
+	// api/keystore
+	keystoreUsersPrefix     = []byte("users")
+	keystoreBCsPrefix       = []byte("bcs")
+	keystoreKeyParamsPrefix = []byte("keyParams")
+
+	// api/auth
+	authSingletonPrefix = []byte("singleton")
+	authTokenPrefix     = []byte("token")
+
+	// indexer
+	indexTxPrefix            = byte(0x01)
+	indexVtxPrefix           = byte(0x02)
+	indexBlockPrefix         = byte(0x03)
+	indexToContainerPrefix   = []byte{0x01}
+	indexContainerToIDPrefix = []byte{0x02}
+
+	// vms/proposervm
+	proposerVMPrefix       = []byte("proposervm")
+	proposerChainPrefix    = []byte("chain")
+	proposerBlockPrefix    = []byte("block")
+	proposerHeightPrefix   = []byte("height")
+	proposerMetadataPrefix = []byte("metadata")
+
+	// vms/platformvm
+	platformValidatorsPrefix         = []byte("validators")
+	platformCurrentPrefix            = []byte("current")
+	platformPendingPrefix            = []byte("pending")
+	platformValidatorPrefix          = []byte("validator")
+	platformNominatorPrefix          = []byte("nominator")
+	platformAllychainValidatorPrefix = []byte("allychainValidator")
+	platformValidatorDiffsPrefix     = []byte("validatorDiffs")
+	platformBlockPrefix              = []byte("block")
+	platformBlockHeightPrefix        = []byte("blockHeight")
+	platformTxPrefix                 = []byte("tx")
+	platformRewardUTXOsPrefix        = []byte("rewardUTXOs")
+	platformUTXOPrefix               = []byte("utxo")
+	platformAllychainPrefix          = []byte("allychain")
+	platformChainPrefix              = []byte("chain")
+	platformSingletonPrefix          = []byte("singleton")
+
+	// vms/avm/states and vms/components/axc
+	avmUTXOPrefix      = []byte("utxo")
+	avmStatusPrefix    = []byte("status")
+	avmSingletonPrefix = []byte("singleton")
+	avmTxPrefix        = []byte("tx")
+	utxoPrefix         = []byte("utxo")
+	utxoIndexPrefix    = []byte("index")
+)
+
+// Chain whose database is inspected.
+type Chain struct {
+	ID          ids.ID
+	Name        string
+	VMID        ids.ID
+	AllychainID ids.ID
+}
+
+func (c *Chain) String() string {
+	if c.Name == "" {
+		return c.ID.String()
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, c.ID)
+}
+
+// Chains returns the Platform Chain followed by every chain that was created
+// on it, as recorded in the Platform Chain's state in [db].
+func Chains(db database.Database) ([]Chain, error) {
+	platformChain := Chain{
+		ID:          constants.PlatformChainID,
+		Name:        "P",
+		VMID:        constants.PlatformVMID,
+		AllychainID: constants.PrimaryNetworkID,
+	}
+	chainList := []Chain{platformChain}
+
+	vmDB := prefixdb.New(chains.VMDBPrefix, prefixdb.New(platformChain.ID[:], db))
+	baseDB := versiondb.New(vmDB)
+	txDB := prefixdb.New(platformTxPrefix, baseDB)
+	chainDB := prefixdb.New(platformChainPrefix, baseDB)
+
+	allychainIDs := []ids.ID{constants.PrimaryNetworkID}
+	allychainIDs, err := appendIDs(allychainIDs, linkeddb.NewDefault(prefixdb.New(platformAllychainPrefix, baseDB)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, allychainID := range allychainIDs {
+		chainIDs, err := appendIDs(nil, linkeddb.NewDefault(prefixdb.New(allychainID[:], chainDB)))
+		if err != nil {
+			return nil, err
+		}
+		for _, chainID := range chainIDs {
+			tx, _, err := getPlatformTx(txDB, chainID)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't get the transaction of chain %s: %w", chainID, err)
+			}
+			createChainTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+			if !ok {
+				return nil, fmt.Errorf("transaction of chain %s has unexpected type %T", chainID, tx.UnsignedTx)
+			}
+			chainList = append(chainList, Chain{
+				ID:          chainID,
+				Name:        createChainTx.ChainName,
+				VMID:        createChainTx.VMID,
+				AllychainID: createChainTx.AllychainID,
+			})
+		}
+	}
+	return chainList, nil
+}
+
+// appendIDs appends the keys of [db] to [idList].
+func appendIDs(idList []ids.ID, db linkeddb.LinkedDB) ([]ids.ID, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		id, err := ids.ToID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		idList = append(idList, id)
+	}
+	return idList, it.Error()
+}
+
+// Layout returns the prefixes that a node stores in [db], including the
+// prefixes of [chainList].
+func Layout(db database.Database, chainList []Chain) *Prefix {
+	root := NewRoot("database", db)
+	root.Child("shared memory", sharedMemoryPrefix)
+
+	keystore := root.Child("keystore", keystorePrefix)
+	keystore.Child("users", keystoreUsersPrefix)
+	keystore.Child("blockchains", keystoreBCsPrefix)
+	keystore.Child("key params", keystoreKeyParamsPrefix)
+
+	auth := root.Child("auth", authPrefix)
+	auth.Child("singletons", authSingletonPrefix)
+	auth.Child("tokens", authTokenPrefix)
+
+	allychainIDs := []ids.ID(nil)
+	allychainIDSet := ids.Set{}
+	for _, chain := range chainList {
+		if !allychainIDSet.Contains(chain.AllychainID) {
+			allychainIDSet.Add(chain.AllychainID)
+			allychainIDs = append(allychainIDs, chain.AllychainID)
+		}
+	}
+
+	indexer := root.Child("indexer", indexerPrefix)
+	for _, chain := range chainList {
+		addChain(root, indexer, chain, allychainIDs)
+	}
+	return root
+}
+
+// addChain adds the prefixes of [chain] to [root] and the prefixes of its
+// indices to [indexer].
+func addChain(root, indexer *Prefix, chain Chain, allychainIDs []ids.ID) {
+	chainPrefix := root.Child(fmt.Sprintf("chain %s", &chain), chain.ID[:])
+	vm := chainPrefix.Child("vm", chains.VMDBPrefix)
+
+	if chain.VMID == constants.AVMID {
+		chainPrefix.Child("vertices", chains.VertexDBPrefix)
+		chainPrefix.Child("vertex bootstrapping", chains.VertexBootstrappingDBPrefix)
+		chainPrefix.Child("tx bootstrapping", chains.TxBootstrappingDBPrefix)
+
+		addIndex(indexer, fmt.Sprintf("chain %s vertex index", &chain), chain.ID, indexVtxPrefix)
+		addIndex(indexer, fmt.Sprintf("chain %s tx index", &chain), chain.ID, indexTxPrefix)
+
+		addAVM(vm)
+		return
+	}
+
+	chainPrefix.Child("block bootstrapping", chains.BlockBootstrappingDBPrefix)
+	addIndex(indexer, fmt.Sprintf("chain %s block index", &chain), chain.ID, indexBlockPrefix)
+
+	// Every linear chain is wrapped in the proposervm, which shares the
+	// database of the VM it wraps.
+	addProposerVM(vm)
+	if chain.VMID == constants.PlatformVMID {
+		addPlatformVM(vm, allychainIDs)
+	}
+}
+
+func addIndex(indexer *Prefix, name string, chainID ids.ID, indexPrefix byte) {
+	prefix := make([]byte, len(chainID)+1)
+	copy(prefix, chainID[:])
+	prefix[len(chainID)] = indexPrefix
+
+	index := indexer.Child(name, prefix)
+	index.VersionedChild("index to container", indexToContainerPrefix)
+	index.VersionedChild("container to index", indexContainerToIDPrefix)
+}
+
+func addProposerVM(vm *Prefix) {
+	proposerVM := vm.Child("proposervm", proposerVMPrefix)
+	proposerVM.VersionedChild("chain", proposerChainPrefix)
+
+	blocks := proposerVM.VersionedChild("blocks", proposerBlockPrefix)
+	blocks.decode = decodeProposerBlocks
+
+	heightIndex := proposerVM.VersionedChild("height index", proposerHeightPrefix)
+	heightIndex.Child("heights", proposerHeightPrefix)
+	heightIndex.Child("metadata", proposerMetadataPrefix)
+}
+
+func addPlatformVM(vm *Prefix, allychainIDs []ids.ID) {
+	txs := vm.VersionedChild("txs", platformTxPrefix)
+
+	validators := vm.VersionedChild("validators", platformValidatorsPrefix)
+	for _, set := range []*Prefix{
+		validators.Child("current", platformCurrentPrefix),
+		validators.Child("pending", platformPendingPrefix),
+	} {
+		for _, list := range []*Prefix{
+			set.Child("validators", platformValidatorPrefix),
+			set.Child("nominators", platformNominatorPrefix),
+			set.Child("allychain validators", platformAllychainValidatorPrefix),
+		} {
+			list.decode = decodePlatformStakers(txs.db)
+		}
+	}
+	validators.Child("diffs", platformValidatorDiffsPrefix)
+
+	vm.VersionedChild("blocks", platformBlockPrefix)
+	vm.VersionedChild("block heights", platformBlockHeightPrefix)
+	vm.VersionedChild("reward utxos", platformRewardUTXOsPrefix)
+
+	utxos := vm.VersionedChild("utxos", platformUTXOPrefix)
+	utxos.Child("utxos", utxoPrefix).decode = decodeUTXOs(platformvm.GenesisCodec)
+	utxos.Child("index", utxoIndexPrefix)
+
+	vm.VersionedChild("allychains", platformAllychainPrefix).decode = decodeIDs
+
+	chainLists := vm.VersionedChild("chains", platformChainPrefix)
+	for _, allychainID := range allychainIDs {
+		chainLists.Child(fmt.Sprintf("allychain %s", allychainID), allychainID[:]).decode = decodeIDs
+	}
+	vm.VersionedChild("singletons", platformSingletonPrefix)
+}
+
+func addAVM(vm *Prefix) {
+	utxos := vm.VersionedChild("utxos", avmUTXOPrefix)
+	utxos.Child("utxos", utxoPrefix).decode = decodeUTXOs(avmCodec)
+	utxos.Child("index", utxoIndexPrefix)
+
+	vm.VersionedChild("statuses", avmStatusPrefix)
+	vm.VersionedChild("singletons", avmSingletonPrefix)
+	vm.VersionedChild("txs", avmTxPrefix)
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+)
+
+// decoder formats up to [limit] of the values stored in [db].
+type decoder func(db database.Database, limit int) ([]string, error)
+
+// Prefix is a named partition of the inspected database, along with the
+// partitions nested inside of it.
+type Prefix struct {
+	Name     string
+	Children []*Prefix
+
+	db database.Database
+	// within is the prefix whose key space contains the keys of this prefix.
+	// prefixdb flattens the prefixes of nested prefixdbs, so this isn't
+	// necessarily the parent of this prefix. It is nil for the root.
+	within *Prefix
+	// keyOverhead is the number of bytes that are prepended to the keys of
+	// this prefix before they are written to the root.
+	keyOverhead int
+	decode      decoder
+}
+
+// NewRoot returns the prefix that spans all of [db].
+func NewRoot(name string, db database.Database) *Prefix {
+	return &Prefix{
+		Name: name,
+		db:   db,
+	}
+}
+
+// Child adds the prefix that a prefixdb.New([prefix], db) call stores its
+// keys under, where db is the database of [p].
+func (p *Prefix) Child(name string, prefix []byte) *Prefix {
+	return p.add(name, p.db, prefix)
+}
+
+// VersionedChild adds the prefix that a prefixdb.New([prefix], db) call stores
+// its keys under, where db is a versiondb over the database of [p].
+func (p *Prefix) VersionedChild(name string, prefix []byte) *Prefix {
+	return p.add(name, versiondb.New(p.db), prefix)
+}
+
+func (p *Prefix) add(name string, db database.Database, prefix []byte) *Prefix {
+	within := p
+	if _, ok := db.(*prefixdb.Database); ok {
+		within = p.within
+	}
+	child := &Prefix{
+		Name:        name,
+		db:          prefixdb.New(prefix, db),
+		within:      within,
+		keyOverhead: within.keyOverhead + hashing.HashLen,
+	}
+	p.Children = append(p.Children, child)
+	return child
+}
+
+// Stats of a set of key/value pairs.
+type Stats struct {
+	Keys uint64
+	// Bytes is the sum of the lengths of the keys, as stored in the root, and
+	// the values.
+	Bytes uint64
+}
+
+func (s *Stats) add(o Stats) {
+	s.Keys += o.Keys
+	s.Bytes += o.Bytes
+}
+
+func (s *Stats) sub(o Stats) {
+	s.Keys -= o.Keys
+	s.Bytes -= o.Bytes
+}
+
+// Count returns, for every prefix of the tree rooted at [root], the stats of
+// the keys that belong to the prefix but to none of the other prefixes of the
+// tree. The keys of prefixes that aren't part of the tree, such as the
+// per-transaction prefixes of the platformvm's reward UTXOs, are attributed to
+// the prefix whose key space contains them.
+func Count(root *Prefix) (map[*Prefix]Stats, error) {
+	totals := make(map[*Prefix]Stats)
+	if err := count(root, totals); err != nil {
+		return nil, err
+	}
+
+	stats := make(map[*Prefix]Stats, len(totals))
+	for p, total := range totals {
+		stats[p] = total
+	}
+	for p, total := range totals {
+		if p.within == nil {
+			continue
+		}
+		withinStats := stats[p.within]
+		withinStats.sub(total)
+		stats[p.within] = withinStats
+	}
+	return stats, nil
+}
+
+// count populates [totals] with the stats of the key space of every prefix of
+// the tree rooted at [p].
+func count(p *Prefix, totals map[*Prefix]Stats) error {
+	it := p.db.NewIterator()
+	defer it.Release()
+
+	total := Stats{}
+	for it.Next() {
+		total.add(Stats{
+			Keys:  1,
+			Bytes: uint64(p.keyOverhead + len(it.Key()) + len(it.Value())),
+		})
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	totals[p] = total
+
+	for _, child := range p.Children {
+		if err := count(child, totals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decode returns up to [limit] formatted values of [p], or nil if the values
+// of [p] aren't known.
+func (p *Prefix) Decode(limit int) ([]string, error) {
+	if p.decode == nil || limit <= 0 {
+		return nil, nil
+	}
+	return p.decode(p.db, limit)
+}
@@ -60,6 +60,22 @@ import (
 const defaultChannelSize = 1
 
 var (
+	// VMDBPrefix is the prefix, within the database of a chain, of the
+	// database handed to the chain's VM.
+	VMDBPrefix = []byte("vm")
+	// VertexDBPrefix is the prefix, within the database of a DAG chain, of the
+	// vertex storage of the consensus engine.
+	VertexDBPrefix = []byte("vertex")
+	// VertexBootstrappingDBPrefix is the prefix, within the database of a DAG
+	// chain, of the vertex bootstrapping queue.
+	VertexBootstrappingDBPrefix = []byte("vertex_bs")
+	// TxBootstrappingDBPrefix is the prefix, within the database of a DAG
+	// chain, of the transaction bootstrapping queue.
+	TxBootstrappingDBPrefix = []byte("tx_bs")
+	// BlockBootstrappingDBPrefix is the prefix, within the database of a linear
+	// chain, of the block bootstrapping queue.
+	BlockBootstrappingDBPrefix = []byte("bs")
+
 	errUnknownChainID   = errors.New("unknown chain ID")
 	errUnknownVMType    = errors.New("the vm should have type axia.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM = errors.New("attempted to create a chain running the PlatformVM")
@@ -526,12 +542,12 @@ func (m *manager) createAxiaChain(
 		return nil, err
 	}
 	prefixDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
-	vmDBManager := prefixDBManager.NewPrefixDBManager([]byte("vm"))
+	vmDBManager := prefixDBManager.NewPrefixDBManager(VMDBPrefix)
 
 	db := prefixDBManager.Current()
-	vertexDB := prefixdb.New([]byte("vertex"), db.Database)
-	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db.Database)
-	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db.Database)
+	vertexDB := prefixdb.New(VertexDBPrefix, db.Database)
+	vertexBootstrappingDB := prefixdb.New(VertexBootstrappingDBPrefix, db.Database)
+	txBootstrappingDB := prefixdb.New(TxBootstrappingDBPrefix, db.Database)
 
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.Registerer)
 	if err != nil {
@@ -718,10 +734,10 @@ func (m *manager) createSnowmanChain(
 		return nil, err
 	}
 	prefixDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
-	vmDBManager := prefixDBManager.NewPrefixDBManager([]byte("vm"))
+	vmDBManager := prefixDBManager.NewPrefixDBManager(VMDBPrefix)
 
 	db := prefixDBManager.Current()
-	bootstrappingDB := prefixdb.New([]byte("bs"), db.Database)
+	bootstrappingDB := prefixdb.New(BlockBootstrappingDBPrefix, db.Database)
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
 	if err != nil {
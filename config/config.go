@@ -18,10 +18,12 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/sankar-boro/axia-network-v2/api/ratelimit"
 	"github.com/sankar-boro/axia-network-v2/app/runner"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/genesis"
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer"
 	"github.com/sankar-boro/axia-network-v2/ipcs"
 	"github.com/sankar-boro/axia-network-v2/nat"
 	"github.com/sankar-boro/axia-network-v2/network"
@@ -35,6 +37,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/snow/networking/sender"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/staking"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/dynamicip"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
@@ -43,6 +46,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/profiler"
 	"github.com/sankar-boro/axia-network-v2/utils/storage"
 	"github.com/sankar-boro/axia-network-v2/utils/timer"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
 	"github.com/sankar-boro/axia-network-v2/vms"
 )
 
@@ -189,6 +193,32 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	return config, nil
 }
 
+func getPasswordHashParams(v *viper.Viper) (password.Params, error) {
+	var (
+		time    = v.GetUint(PasswordHashTimeKey)
+		memory  = v.GetUint64(PasswordHashMemoryKey) / units.KiB
+		threads = v.GetUint(PasswordHashThreadsKey)
+	)
+	switch {
+	case time > math.MaxUint32:
+		return password.Params{}, fmt.Errorf("%q must be at most %d", PasswordHashTimeKey, uint32(math.MaxUint32))
+	case memory > math.MaxUint32:
+		return password.Params{}, fmt.Errorf("%q must be at most %d", PasswordHashMemoryKey, uint64(math.MaxUint32)*units.KiB)
+	case threads > math.MaxUint8:
+		return password.Params{}, fmt.Errorf("%q must be at most %d", PasswordHashThreadsKey, math.MaxUint8)
+	}
+
+	params := password.Params{
+		Time:    uint32(time),
+		Memory:  uint32(memory),
+		Threads: uint8(threads),
+	}
+	if err := params.Verify(); err != nil {
+		return password.Params{}, fmt.Errorf("invalid password hash parameters: %w", err)
+	}
+	return params, nil
+}
+
 func getIPCConfig(v *viper.Viper) node.IPCConfig {
 	config := node.IPCConfig{
 		IPCAPIEnabled: v.GetBool(IpcAPIEnabledKey),
@@ -242,12 +272,14 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexExportDir:       GetExpandedArg(v, IndexExportDirKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			SchemaAPIEnabled:   v.GetBool(SchemaAPIEnabledKey),
 		},
 		HTTPHost:          v.GetString(HTTPHostKey),
 		HTTPPort:          uint16(v.GetUint(HTTPPortKey)),
@@ -258,16 +290,108 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 
 		ShutdownTimeout: v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:    v.GetDuration(HTTPShutdownWaitKey),
+
+		GRPCEnabled: v.GetBool(GRPCEnabledKey),
+		GRPCPort:    uint16(v.GetUint(GRPCPortKey)),
+
+		MaxBatchSize: int(v.GetUint(APIMaxBatchSizeKey)),
+
+		APIAuditLogEnabled: v.GetBool(APIAuditLogEnabledKey),
+		APIAuditLogMethods: strings.Split(v.GetString(APIAuditLogMethodsKey), ","),
+		APIAuditLogMaxSize: int(v.GetUint(APIAuditLogMaxSizeKey)),
+	}
+
+	if config.GRPCPort != 0 && config.GRPCPort == config.HTTPPort {
+		return node.HTTPConfig{}, fmt.Errorf("%q can't be the same as %q. Set it to 0 to serve gRPC on the HTTP port", GRPCPortKey, HTTPPortKey)
+	}
+
+	config.APIRateLimits, err = getAPIRateLimits(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	if config.APIRateLimits != nil && !v.IsSet(APIRateLimitsContentKey) {
+		config.APIRateLimitsFile = filepath.Clean(GetExpandedArg(v, APIRateLimitsFileKey))
 	}
 
 	config.APIAuthConfig, err = getAPIAuthConfig(v)
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
+	config.PasswordHashParams, err = getPasswordHashParams(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.IndexWebhooks, err = getIndexWebhooks(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	if len(config.IndexWebhooks) != 0 && !config.IndexAPIEnabled {
+		return node.HTTPConfig{}, fmt.Errorf("index webhooks require %q to be set", IndexEnabledKey)
+	}
+	config.IndexRetentions, err = getIndexRetentions(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
 	config.IPCConfig = getIPCConfig(v)
 	return config, nil
 }
 
+// getFileOrContent returns the base64 decoded value of [contentKey] if it's
+// set, or else the contents of the file at [fileKey]. Returns nil if neither
+// is set.
+func getFileOrContent(v *viper.Viper, fileKey, contentKey string) ([]byte, error) {
+	switch {
+	case v.IsSet(contentKey):
+		contentBytes, err := base64.StdEncoding.DecodeString(v.GetString(contentKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content of %q: %w", contentKey, err)
+		}
+		return contentBytes, nil
+	case v.IsSet(fileKey):
+		return os.ReadFile(filepath.Clean(GetExpandedArg(v, fileKey)))
+	default:
+		return nil, nil
+	}
+}
+
+func getAPIRateLimits(v *viper.Viper) (*ratelimit.Config, error) {
+	rateLimitsBytes, err := getFileOrContent(v, APIRateLimitsFileKey, APIRateLimitsContentKey)
+	if err != nil || rateLimitsBytes == nil {
+		return nil, err
+	}
+	rateLimits, err := ratelimit.ParseConfig(rateLimitsBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API rate limits: %w", err)
+	}
+	return &rateLimits, nil
+}
+
+func getIndexWebhooks(v *viper.Viper) ([]indexer.WebhookConfig, error) {
+	webhooksBytes, err := getFileOrContent(v, IndexWebhooksFileKey, IndexWebhooksContentKey)
+	if err != nil || webhooksBytes == nil {
+		return nil, err
+	}
+
+	var webhooks []indexer.WebhookConfig
+	if err := json.Unmarshal(webhooksBytes, &webhooks); err != nil {
+		return nil, fmt.Errorf("problem unmarshaling index webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func getIndexRetentions(v *viper.Viper) ([]indexer.RetentionConfig, error) {
+	retentionsBytes, err := getFileOrContent(v, IndexRetentionFileKey, IndexRetentionContentKey)
+	if err != nil || retentionsBytes == nil {
+		return nil, err
+	}
+
+	var retentions []indexer.RetentionConfig
+	if err := json.Unmarshal(retentionsBytes, &retentions); err != nil {
+		return nil, fmt.Errorf("problem unmarshaling index retentions: %w", err)
+	}
+	return retentions, nil
+}
+
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
 	config := router.HealthConfig{
 		MaxDropRate:            v.GetFloat64(RouterHealthMaxDropRateKey),
@@ -399,8 +523,11 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 			InitialReconnectDelay: v.GetDuration(NetworkInitialReconnectDelayKey),
 		},
 
+		PeerDBMaxAge:                 v.GetDuration(NetworkPeerDBMaxAgeKey),
+		PeerTrafficMetricsNumPeers:   int(v.GetUint(NetworkPeerTrafficMetricsNumPeersKey)),
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionEnabled:           v.GetBool(NetworkCompressionEnabledKey),
+		CompressionType:              compression.TypeNone,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              v.GetBool(NetworkAllowPrivateIPsKey),
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -411,6 +538,22 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
 	}
 
+	if config.CompressionEnabled {
+		compressionType, err := compression.TypeFromString(v.GetString(NetworkCompressionTypeKey))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkCompressionTypeKey, err)
+		}
+		config.CompressionType = compressionType
+	}
+
+	if proxyURLStr := v.GetString(OutboundConnectionProxyURL); proxyURLStr != "" {
+		proxyURL, err := dialer.ParseProxyURL(proxyURLStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", OutboundConnectionProxyURL, err)
+		}
+		config.DialerConfig.ProxyURL = proxyURL
+	}
+
 	switch {
 	case config.HealthConfig.MaxTimeSinceMsgSent < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthMaxTimeSinceMsgSentKey)
@@ -428,6 +571,8 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxReconnectDelayKey)
 	case config.InitialReconnectDelay < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkInitialReconnectDelayKey)
+	case config.PeerDBMaxAge < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerDBMaxAgeKey)
 	case config.MaxReconnectDelay < config.InitialReconnectDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %s", NetworkMaxReconnectDelayKey, NetworkInitialReconnectDelayKey)
 	case config.PingPongTimeout < 0:
@@ -43,6 +43,7 @@ const (
 	InboundThrottlerMaxConnsPerSecKey                  = "inbound-connection-throttling-max-conns-per-sec"
 	OutboundConnectionThrottlingRps                    = "outbound-connection-throttling-rps"
 	OutboundConnectionTimeout                          = "outbound-connection-timeout"
+	OutboundConnectionProxyURL                         = "outbound-connection-proxy-url"
 	HTTPHostKey                                        = "http-host"
 	HTTPPortKey                                        = "http-port"
 	HTTPSEnabledKey                                    = "http-tls-enabled"
@@ -53,9 +54,20 @@ const (
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                                = "http-shutdown-wait"
+	GRPCEnabledKey                                     = "api-grpc-enabled"
+	GRPCPortKey                                        = "api-grpc-port"
+	APIMaxBatchSizeKey                                 = "api-max-batch-size"
+	APIRateLimitsFileKey                               = "api-rate-limits-file"
+	APIRateLimitsContentKey                            = "api-rate-limits-file-content"
+	APIAuditLogEnabledKey                              = "api-audit-log-enabled"
+	APIAuditLogMethodsKey                              = "api-audit-log-methods"
+	APIAuditLogMaxSizeKey                              = "api-audit-log-max-size"
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
+	PasswordHashTimeKey                                = "password-hash-time"
+	PasswordHashMemoryKey                              = "password-hash-memory"
+	PasswordHashThreadsKey                             = "password-hash-threads"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
 	BootstrapIPsKey                                    = "bootstrap-ips"
@@ -90,7 +102,10 @@ const (
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
+	NetworkPeerDBMaxAgeKey                             = "network-peer-db-max-age"
+	NetworkPeerTrafficMetricsNumPeersKey               = "network-peer-traffic-metrics-num-peers"
 	NetworkCompressionEnabledKey                       = "network-compression-enabled"
+	NetworkCompressionTypeKey                          = "network-compression-type"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
@@ -127,6 +142,7 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	SchemaAPIEnabledKey                                = "api-schema-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
@@ -145,6 +161,11 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexWebhooksFileKey                               = "index-webhooks-file"
+	IndexWebhooksContentKey                            = "index-webhooks-file-content"
+	IndexRetentionFileKey                              = "index-retention-file"
+	IndexRetentionContentKey                           = "index-retention-file-content"
+	IndexExportDirKey                                  = "index-export-dir"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -12,6 +12,15 @@ package database
 type Batch interface {
 	KeyValueWriterDeleter
 
+	// DeleteRange queues the removal of all the keys in the range
+	// [start, limit), including the keys that were put into this batch
+	// before.
+	//
+	// Batches of databases that don't support range deletions natively
+	// resolve the keys to remove when DeleteRange is called, so keys that
+	// are written to the database afterwards may not be removed.
+	DeleteRange(start []byte, limit []byte) error
+
 	// Size retrieves the amount of data queued up for writing, this includes
 	// the keys, values, and deleted keys.
 	Size() int
@@ -23,7 +32,7 @@ type Batch interface {
 	Reset()
 
 	// Replay replays the batch contents in the same order they were written
-	// to the batch.
+	// to the batch. Range deletions are replayed with [ReplayDeleteRange].
 	Replay(w KeyValueWriterDeleter) error
 
 	// Inner returns a Batch writing to the inner database, if one exists. If
@@ -11,8 +11,10 @@ import (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ database.Batch       = &batch{}
+	_ database.Snapshot    = &snapshot{}
 )
 
 // CorruptableDB is a wrapper around Database
@@ -21,8 +23,8 @@ type Database struct {
 	database.Database
 
 	// initialError stores the error other than "not found" or "closed" while
-	// performing a db operation. If not nil, Has, Get, Put, Delete and batch
-	// writes will fail with initialError.
+	// performing a db operation. If not nil, Has, Get, Put, Delete, DeleteRange
+	// and batch writes will fail with initialError.
 	errorLock    sync.RWMutex
 	initialError error
 }
@@ -66,6 +68,14 @@ func (db *Database) Delete(key []byte) error {
 	return db.handleError(db.Database.Delete(key))
 }
 
+// DeleteRange removes the keys in the range [start, limit) from the database
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(db.Database.DeleteRange(start, limit))
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
@@ -86,6 +96,27 @@ func (db *Database) NewBatch() database.Batch {
 	}
 }
 
+// NewSnapshot returns a snapshot of the underlying database. Returns
+// [database.ErrSnapshotNotSupported] if the underlying database doesn't support
+// snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	snapshotter, ok := db.Database.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrSnapshotNotSupported
+	}
+	s, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return nil, db.handleError(err)
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) corrupted() error {
 	db.errorLock.RLock()
 	defer db.errorLock.RUnlock()
@@ -95,7 +126,7 @@ func (db *Database) corrupted() error {
 
 func (db *Database) handleError(err error) error {
 	switch err {
-	case nil, database.ErrNotFound, database.ErrClosed:
+	case nil, database.ErrNotFound, database.ErrClosed, database.ErrSnapshotNotSupported:
 	// If we get an error other than "not found" or "closed", disallow future
 	// database operations to avoid possible corruption
 	default:
@@ -124,3 +155,27 @@ func (b *batch) Write() error {
 	}
 	return b.db.handleError(b.Batch.Write())
 }
+
+// snapshot is a wrapper around the snapshot to check for corruption.
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has returns if the key is set in the snapshot
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if err := s.db.corrupted(); err != nil {
+		return false, err
+	}
+	has, err := s.Snapshot.Has(key)
+	return has, s.db.handleError(err)
+}
+
+// Get returns the value the key maps to in the snapshot
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if err := s.db.corrupted(); err != nil {
+		return nil, err
+	}
+	value, err := s.Snapshot.Get(key)
+	return value, s.db.handleError(err)
+}
@@ -20,6 +20,14 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		db := New(baseDB)
+		test(t, db)
+	}
+}
+
 // TestCorruption tests to make sure corruptabledb wrapper works as expected.
 func TestCorruption(t *testing.T) {
 	key := []byte("hello")
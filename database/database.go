@@ -34,6 +34,17 @@ type KeyValueDeleter interface {
 	Delete(key []byte) error
 }
 
+// RangeDeleter wraps the DeleteRange method of a backing data store.
+type RangeDeleter interface {
+	// DeleteRange removes all the keys in the range [start, limit) from the
+	// key-value data store.
+	//
+	// A nil start is treated as a key before all keys in the data store.
+	// And a nil limit is treated as a key after all keys in the data store.
+	// Therefore if both are nil then every key will be removed.
+	DeleteRange(start []byte, limit []byte) error
+}
+
 // KeyValueReaderWriter allows read/write acccess to a backing data store.
 type KeyValueReaderWriter interface {
 	KeyValueReader
@@ -67,10 +78,32 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Snapshot is a read-only view of a backing data store that stays fixed at the
+// point in time it was created, regardless of the writes that happen after.
+//
+// Once the snapshot was released, or the data store it was taken from was
+// closed, reads return [ErrClosed].
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases the resources held by the snapshot. Release should
+	// always succeed and can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a snapshot of the current state of the data store.
+	// The snapshot must be released after use.
+	NewSnapshot() (Snapshot, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
 	KeyValueReaderWriterDeleter
+	RangeDeleter
 	Batcher
 	Iteratee
 	Compacter
@@ -15,7 +15,6 @@ import (
 	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/nodb"
 	"github.com/sankar-boro/axia-network-v2/utils"
-	"github.com/sankar-boro/axia-network-v2/utils/hashing"
 )
 
 const (
@@ -36,10 +35,20 @@ type Database struct {
 	db     database.Database
 }
 
-// New returns a new encrypted database
+// New returns a new encrypted database whose key is derived from [password]
+// with the zero value of KeyParams.
 func New(password []byte, db database.Database) (*Database, error) {
-	h := hashing.ComputeHash256(password)
-	aead, err := chacha20poly1305.NewX(h)
+	return NewWithParams(password, KeyParams{}, db)
+}
+
+// NewWithParams returns a new encrypted database whose key is derived from
+// [password] with [params].
+func NewWithParams(password []byte, params KeyParams, db database.Database) (*Database, error) {
+	key, err := params.Key(password)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +110,18 @@ func (db *Database) Delete(key []byte) error {
 	return db.db.Delete(key)
 }
 
+// DeleteRange removes the keys in the range [start, limit). Only values are
+// encrypted, so the range is passed to the underlying database as is.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.DeleteRange(start, limit)
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{
 		Batch: db.db.NewBatch(),
@@ -175,6 +196,10 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, the keys in the range [key, limit) are removed.
+	deleteRange bool
+	limit       []byte
 }
 
 type batch struct {
@@ -185,7 +210,7 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), value: utils.CopyBytes(value)})
 	encValue, err := b.db.encrypt(value)
 	if err != nil {
 		return err
@@ -194,10 +219,19 @@ func (b *batch) Put(key, value []byte) error {
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), delete: true})
 	return b.Batch.Delete(key)
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	return b.Batch.DeleteRange(start, limit)
+}
+
 func (b *batch) Write() error {
 	b.db.lock.Lock()
 	defer b.db.lock.Unlock()
@@ -222,12 +256,19 @@ func (b *batch) Reset() {
 // Replay replays the batch contents.
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, keyvalue := range b.writes {
-		if keyvalue.delete {
+		switch {
+		case keyvalue.deleteRange:
+			if err := database.ReplayDeleteRange(w, keyvalue.key, keyvalue.limit); err != nil {
+				return err
+			}
+		case keyvalue.delete:
 			if err := w.Delete(keyvalue.key); err != nil {
 				return err
 			}
-		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
-			return err
+		default:
+			if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -6,6 +6,8 @@ package encdb
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
 )
@@ -23,6 +25,52 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestInterfaceWithParams(t *testing.T) {
+	pw := "lol totally a secure password" // #nosec G101
+	for _, test := range database.Tests {
+		params, err := DefaultKeyParams()
+		if err != nil {
+			t.Fatal(err)
+		}
+		db, err := NewWithParams([]byte(pw), params, memdb.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestKeyParams(t *testing.T) {
+	assert := assert.New(t)
+
+	pw := []byte("lol totally a secure password") // #nosec G101
+	unencryptedDB := memdb.New()
+
+	// The zero value derives the same key as New.
+	legacyDB, err := New(pw, unencryptedDB)
+	assert.NoError(err)
+	assert.NoError(legacyDB.Put([]byte("key"), []byte("value")))
+
+	zeroDB, err := NewWithParams(pw, KeyParams{}, unencryptedDB)
+	assert.NoError(err)
+	value, err := zeroDB.Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value"), value)
+
+	// A salt changes the key.
+	params, err := DefaultKeyParams()
+	assert.NoError(err)
+	assert.Len(params.Salt, SaltLen)
+	saltedDB, err := NewWithParams(pw, params, unencryptedDB)
+	assert.NoError(err)
+	_, err = saltedDB.Get([]byte("key"))
+	assert.Error(err)
+
+	_, err = NewWithParams(pw, KeyParams{KDF: SHA256 + 1}, unencryptedDB)
+	assert.ErrorIs(err, errUnknownKDF)
+}
+
 func BenchmarkInterface(b *testing.B) {
 	pw := "lol totally a secure password" // #nosec G101
 	for _, size := range database.BenchmarkSizes {
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+)
+
+// SaltLen is the length of the salts generated by DefaultKeyParams.
+const SaltLen = 16
+
+var errUnknownKDF = errors.New("unknown key derivation function")
+
+// KDF identifies the function that derives the encryption key of a Database
+// from its password.
+type KDF uint32
+
+const (
+	// SHA256 derives the key as the SHA-256 hash of the salt followed by the
+	// password. Databases created before key parameters were recorded use it
+	// without a salt.
+	SHA256 KDF = iota
+)
+
+func (kdf KDF) String() string {
+	switch kdf {
+	case SHA256:
+		return "sha256"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint32(kdf))
+	}
+}
+
+// KeyParams are the parameters that the encryption key of a Database is
+// derived from its password with. The zero value describes the derivation of
+// the keys of databases that were created before the parameters were recorded.
+type KeyParams struct {
+	KDF  KDF    `serialize:"true"`
+	Salt []byte `serialize:"true"`
+}
+
+// DefaultKeyParams returns new parameters, with a random salt, that the keys of
+// new databases should be derived with.
+func DefaultKeyParams() (KeyParams, error) {
+	salt := make([]byte, SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return KeyParams{}, err
+	}
+	return KeyParams{
+		KDF:  SHA256,
+		Salt: salt,
+	}, nil
+}
+
+// Key derives the encryption key from [password].
+func (p *KeyParams) Key(password []byte) ([]byte, error) {
+	switch p.KDF {
+	case SHA256:
+		saltedPassword := make([]byte, len(p.Salt)+len(password))
+		copy(saltedPassword, p.Salt)
+		copy(saltedPassword[len(p.Salt):], password)
+		return hashing.ComputeHash256(saltedPassword), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownKDF, p.KDF)
+	}
+}
@@ -9,4 +9,7 @@ import "errors"
 var (
 	ErrClosed   = errors.New("closed")
 	ErrNotFound = errors.New("not found")
+
+	ErrSnapshotNotSupported    = errors.New("snapshots aren't supported")
+	ErrRangeDeleteNotSupported = errors.New("range deletions aren't supported")
 )
@@ -4,6 +4,7 @@
 package database
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -176,3 +177,37 @@ func ClearPrefix(readerDB Iteratee, deleterDB KeyValueDeleter, prefix []byte) er
 	}
 	return iterator.Error()
 }
+
+// ClearRange deletes the keys of [readerDB] in the range [start, limit) from
+// [deleterDB]. A nil limit is treated as a key after all keys.
+func ClearRange(readerDB Iteratee, deleterDB KeyValueDeleter, start, limit []byte) error {
+	iterator := readerDB.NewIteratorWithStart(start)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		key := iterator.Key()
+		if limit != nil && bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		if err := deleterDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return iterator.Error()
+}
+
+// InRange returns true if [key] is in the range [start, limit). A nil limit
+// is treated as a key after all keys.
+func InRange(key, start, limit []byte) bool {
+	return bytes.Compare(key, start) >= 0 && (limit == nil || bytes.Compare(key, limit) < 0)
+}
+
+// ReplayDeleteRange replays the removal of the range [start, limit) into [w].
+// Returns [ErrRangeDeleteNotSupported] if [w] isn't a [RangeDeleter].
+func ReplayDeleteRange(w KeyValueWriterDeleter, start, limit []byte) error {
+	rangeDeleter, ok := w.(RangeDeleter)
+	if !ok {
+		return ErrRangeDeleteNotSupported
+	}
+	return rangeDeleter.DeleteRange(start, limit)
+}
@@ -51,12 +51,19 @@ const (
 	// levelDBByteOverhead is the number of bytes of constant overhead that
 	// should be added to a batch size per operation.
 	levelDBByteOverhead = 8
+
+	// deleteRangeBatchSize is the number of bytes of deletions written at a
+	// time by DeleteRange, so that removing a large range doesn't require
+	// buffering every key in memory.
+	deleteRangeBatchSize = 4 * opt.MiB
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iter{}
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ database.Snapshot    = &snapshot{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iter{}
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -160,6 +167,12 @@ type config struct {
 	// MetricUpdateFrequency is the frequency to poll LevelDB metrics.
 	// If <= 0, LevelDB metrics aren't polled.
 	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+
+	// ReadOnly opens the database without modifying it. Writes fail and a
+	// corrupted database isn't recovered.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
 }
 
 // New returns a wrapped LevelDB object.
@@ -199,8 +212,9 @@ func New(file string, configBytes []byte, log logging.Logger, namespace string,
 		OpenFilesCacheCapacity:        parsedConfig.OpenFilesCacheCapacity,
 		WriteBuffer:                   parsedConfig.WriteBuffer,
 		Filter:                        filter.NewBloomFilter(parsedConfig.FilterBitsPerKey),
+		ReadOnly:                      parsedConfig.ReadOnly,
 	})
-	if _, corrupted := err.(*errors.ErrCorrupted); corrupted {
+	if _, corrupted := err.(*errors.ErrCorrupted); corrupted && !parsedConfig.ReadOnly {
 		db, err = leveldb.RecoverFile(file, nil)
 	}
 	if err != nil {
@@ -262,6 +276,33 @@ func (db *Database) Delete(key []byte) error {
 	return updateError(db.DB.Delete(key, nil))
 }
 
+// DeleteRange removes all the keys in the range [start, limit) from the
+// database. LevelDB doesn't support range deletions natively, so the keys are
+// deleted in batches of bounded size. The deletion isn't atomic: if it fails,
+// only a part of the range may have been removed.
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	it := db.DB.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+	defer it.Release()
+
+	b := &batch{db: db}
+	for it.Next() {
+		if err := b.Delete(it.Key()); err != nil {
+			return err
+		}
+		if b.Size() < deleteRangeBatchSize {
+			continue
+		}
+		if err := b.Write(); err != nil {
+			return err
+		}
+		b.Reset()
+	}
+	if err := updateError(it.Error()); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
 // NewBatch creates a write/delete-only buffer that is atomically committed to
 // the database when write is called
 func (db *Database) NewBatch() database.Batch { return &batch{db: db} }
@@ -296,14 +337,22 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
-	}
 	return &iter{
 		db:       db,
-		Iterator: db.DB.NewIterator(iterRange, nil),
+		Iterator: db.DB.NewIterator(startAndPrefixRange(start, prefix), nil),
+	}
+}
+
+// NewSnapshot returns a leveldb snapshot of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snap, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
 	}
+	return &snapshot{
+		db:       db,
+		Snapshot: snap,
+	}, nil
 }
 
 // This comment is basically copy pasted from the underlying levelDB library:
@@ -357,6 +406,24 @@ func (b *batch) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange queues the deletion of every key in the range [start, limit)
+// that is either in the database or was put into this batch.
+func (b *batch) DeleteRange(start []byte, limit []byte) error {
+	puts := &putsInRange{
+		start: start,
+		limit: limit,
+	}
+	if err := b.Replay(puts); err != nil {
+		return err
+	}
+	for _, key := range puts.keys {
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+	}
+	return database.ClearRange(b.db, b, start, limit)
+}
+
 // Size retrieves the amount of data queued up for writing.
 func (b *batch) Size() int { return b.size }
 
@@ -403,6 +470,78 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writerDeleter.Delete(key)
 }
 
+// putsInRange collects the keys in the range [start, limit) that are put into
+// it.
+type putsInRange struct {
+	start, limit []byte
+	keys         [][]byte
+}
+
+func (p *putsInRange) Put(key, _ []byte) error {
+	if database.InRange(key, p.start, p.limit) {
+		p.keys = append(p.keys, utils.CopyBytes(key))
+	}
+	return nil
+}
+
+func (*putsInRange) Delete([]byte) error { return nil }
+
+// snapshot is a wrapper around a levelDB snapshot to match the database
+// interfaces.
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(startAndPrefixRange(start, prefix), nil),
+	}
+}
+
+// startAndPrefixRange returns the range of the keys that have the provided
+// prefix and are not before start
+func startAndPrefixRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
+
 type iter struct {
 	db *Database
 	iterator.Iterator
@@ -448,6 +587,8 @@ func updateError(err error) error {
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
+	case leveldb.ErrSnapshotReleased:
+		return database.ErrClosed
 	default:
 		return err
 	}
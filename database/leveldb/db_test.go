@@ -4,6 +4,7 @@
 package leveldb
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -30,6 +31,64 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		folder := t.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		if err != nil {
+			t.Fatalf("leveldb.New(%q, logging.NoLog{}) errored with %s", folder, err)
+		}
+
+		test(t, db)
+
+		// The database may have been closed by the test, so we don't care if it
+		// errors here.
+		_ = db.Close()
+	}
+}
+
+// Ranges larger than a single batch are deleted in multiple batches
+func TestDeleteRangeInBatches(t *testing.T) {
+	db, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	const numKeys = 3 * deleteRangeBatchSize / 1024
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		key := make([]byte, 1024)
+		binary.BigEndian.PutUint64(key, uint64(i))
+		if err := batch.Put(key, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the keys before the limit are deleted
+	limit := make([]byte, 8)
+	binary.BigEndian.PutUint64(limit, numKeys-1)
+	if err := db.DeleteRange(nil, limit); err != nil {
+		t.Fatal(err)
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+	numLeft := 0
+	for it.Next() {
+		numLeft++
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if numLeft != 1 {
+		t.Fatalf("expected 1 key to be left but found %d", numLeft)
+	}
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
@@ -49,3 +108,41 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+// A read-only database can be read but not written, and can't be opened while
+// the database is open for writing.
+func TestReadOnly(t *testing.T) {
+	folder := t.TempDir()
+	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	readOnlyConfig := []byte(`{"readOnly":true}`)
+	if _, err := New(folder, readOnlyConfig, logging.NoLog{}, "", prometheus.NewRegistry()); err == nil {
+		t.Fatal("opened a read-only database while it was open for writing")
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err = New(folder, readOnlyConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	value, err := db.Get([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "value" {
+		t.Fatalf("expected %q but got %q", "value", value)
+	}
+	if err := db.Put([]byte("key"), []byte("other")); err == nil {
+		t.Fatal("wrote to a read-only database")
+	}
+}
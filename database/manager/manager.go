@@ -17,6 +17,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/database/leveldb"
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
 	"github.com/sankar-boro/axia-network-v2/database/meterdb"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
 	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
 	"github.com/sankar-boro/axia-network-v2/database/rocksdb"
 	"github.com/sankar-boro/axia-network-v2/utils"
@@ -120,6 +121,29 @@ func NewLevelDB(
 	)
 }
 
+// NewPebbleDB creates a database manager of pebble databases at [filePath] by
+// creating a database instance from each directory with a version <=
+// [currentVersion]. If [includePreviousVersions], opens previous database
+// versions and includes them in the returned Manager.
+func NewPebbleDB(
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	currentVersion version.Version,
+	namespace string,
+	reg prometheus.Registerer,
+) (Manager, error) {
+	return new(
+		pebble.New,
+		dbDirPath,
+		dbConfig,
+		log,
+		currentVersion,
+		namespace,
+		reg,
+	)
+}
+
 // new creates a database manager at [filePath] by creating a database instance
 // from each directory with a version <= [currentVersion]. If
 // [includePreviousVersions], opens previous database versions and includes them
@@ -15,7 +15,9 @@ import (
 	"github.com/sankar-boro/axia-network-v2/database/leveldb"
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
 	"github.com/sankar-boro/axia-network-v2/database/meterdb"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
 	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/version"
 )
@@ -55,6 +57,41 @@ func TestNewSingleLevelDB(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewSinglePebbleDB(t *testing.T) {
+	dir := t.TempDir()
+
+	v1 := version.DefaultVersion1_0_0
+
+	dbPath := filepath.Join(dir, v1.String())
+	db, err := pebble.New(dbPath, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := NewPebbleDB(dir, nil, logging.NoLog{}, v1, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	semDB := manager.Current()
+	cmp := semDB.Version.Compare(v1)
+	assert.Equal(t, 0, cmp, "incorrect version on current database")
+
+	_, exists := manager.Previous()
+	assert.False(t, exists, "there should be no previous database")
+
+	dbs := manager.GetDatabases()
+	assert.Len(t, dbs, 1)
+
+	err = manager.Close()
+	assert.NoError(t, err)
+}
+
 func TestNewCreatesSingleDB(t *testing.T) {
 	dir := t.TempDir()
 
@@ -331,6 +368,37 @@ func TestCompleteMeterDBManager(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestSnapshotLevelDB tests that the database of a manager, wrapped the same
+// way the node wraps the databases it passes to the chains, supports snapshots.
+func TestSnapshotLevelDB(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := NewLevelDB(t.TempDir(), nil, logging.NoLog{}, version.DefaultVersion1_0_0, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	defer m.Close()
+
+	m, err = m.NewMeterDBManager("", prometheus.NewRegistry())
+	assert.NoError(err)
+	m = m.NewPrefixDBManager([]byte("chain"))
+	db := versiondb.New(m.Current().Database)
+
+	key := []byte("hello")
+	value := []byte("world")
+	assert.NoError(db.Put(key, value))
+	assert.NoError(db.Commit())
+
+	snapshot, err := db.NewSnapshot()
+	assert.NoError(err)
+	defer snapshot.Release()
+
+	assert.NoError(db.Delete(key))
+	assert.NoError(db.Commit())
+
+	got, err := snapshot.Get(key)
+	assert.NoError(err)
+	assert.Equal(value, got)
+}
+
 func TestNewManagerFromDBs(t *testing.T) {
 	versions := []version.Version{
 		version.NewDefaultVersion(3, 2, 0),
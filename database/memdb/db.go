@@ -22,9 +22,10 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iterator{}
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -105,6 +106,26 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.deleteRange(start, limit)
+	return nil
+}
+
+// deleteRange assumes that the lock is held.
+func (db *Database) deleteRange(start, limit []byte) {
+	for key := range db.db {
+		if database.InRange([]byte(key), start, limit) {
+			delete(db.db, key)
+		}
+	}
+}
+
 func (db *Database) NewBatch() database.Batch { return &batch{db: db} }
 
 func (db *Database) NewIterator() database.Iterator {
@@ -126,25 +147,26 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.db == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(db.db, start, prefix, db.isClosed)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.db))
-	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
-	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([][]byte, 0, len(keys))
-	for _, key := range keys {
-		values = append(values, db.db[key])
+// NewSnapshot returns a copy of the current key/value pairs. Values are never
+// modified in place, so only the map needs to be copied.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
 	}
-	return &iterator{
-		db:     db,
-		keys:   keys,
-		values: values,
+	kvs := make(map[string][]byte, len(db.db))
+	for key, value := range db.db {
+		kvs[key] = value
 	}
+	return &snapshot{
+		db:  db,
+		kvs: kvs,
+	}, nil
 }
 
 func (db *Database) Compact(start []byte, limit []byte) error {
@@ -168,6 +190,10 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, the keys in the range [key, limit) are removed.
+	deleteRange bool
+	limit       []byte
 }
 
 type batch struct {
@@ -177,17 +203,27 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), value: utils.CopyBytes(value)})
 	b.size += len(key) + len(value)
 	return nil
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), delete: true})
 	b.size += len(key)
 	return nil
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *batch) Size() int { return b.size }
 
 func (b *batch) Write() error {
@@ -200,9 +236,12 @@ func (b *batch) Write() error {
 
 	for _, kv := range b.writes {
 		key := string(kv.key)
-		if kv.delete {
+		switch {
+		case kv.deleteRange:
+			b.db.deleteRange(kv.key, kv.limit)
+		case kv.delete:
 			delete(b.db.db, key)
-		} else {
+		default:
 			b.db.db[key] = kv.value
 		}
 	}
@@ -220,12 +259,19 @@ func (b *batch) Reset() {
 
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, keyvalue := range b.writes {
-		if keyvalue.delete {
+		switch {
+		case keyvalue.deleteRange:
+			if err := database.ReplayDeleteRange(w, keyvalue.key, keyvalue.limit); err != nil {
+				return err
+			}
+		case keyvalue.delete:
 			if err := w.Delete(keyvalue.key); err != nil {
 				return err
 			}
-		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
-			return err
+		default:
+			if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -234,8 +280,32 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// newIterator returns an iterator over the key/value pairs of [kvs] that start
+// at [start] and have the prefix [prefix]. The iterator fails once [isClosed]
+// returns true.
+func newIterator(kvs map[string][]byte, start, prefix []byte, isClosed func() bool) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(kvs))
+	for key := range kvs {
+		if strings.HasPrefix(key, prefixString) && key >= startString {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) // Keys need to be in sorted order
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, kvs[key])
+	}
+	return &iterator{
+		isClosed: isClosed,
+		keys:     keys,
+		values:   values,
+	}
+}
+
 type iterator struct {
-	db          *Database
+	isClosed    func() bool
 	initialized bool
 	keys        []string
 	values      [][]byte
@@ -244,7 +314,7 @@ type iterator struct {
 
 func (it *iterator) Next() bool {
 	// Short-circuit and set an error if the underlying database has been closed.
-	if it.db.isClosed() {
+	if it.isClosed() {
 		it.keys = nil
 		it.values = nil
 		it.err = database.ErrClosed
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package memdb
+
+import (
+	"sync"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/nodb"
+	"github.com/sankar-boro/axia-network-v2/utils"
+)
+
+var _ database.Snapshot = &snapshot{}
+
+// snapshot is a copy of the key/value pairs of a database at a point in time.
+type snapshot struct {
+	db *Database
+
+	lock sync.RWMutex
+	kvs  map[string][]byte
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed() {
+		return false, database.ErrClosed
+	}
+	_, ok := s.kvs[string(key)]
+	return ok, nil
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed() {
+		return nil, database.ErrClosed
+	}
+	if entry, ok := s.kvs[string(key)]; ok {
+		return utils.CopyBytes(entry), nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.kvs, start, prefix, s.isClosed)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.kvs = nil
+}
+
+func (s *snapshot) isClosed() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.closed()
+}
+
+// closed assumes that [s.lock] is held.
+func (s *snapshot) closed() bool {
+	return s.kvs == nil || s.db.isClosed()
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+)
+
+// prefixChecksum is the checksum of all the key/value pairs whose keys share
+// the same prefix.
+type prefixChecksum struct {
+	prefix   []byte
+	count    uint64
+	checksum ids.ID
+}
+
+// checksummer reads the checksums of the prefixes of a database in order.
+type checksummer struct {
+	it        database.Iterator
+	prefixLen int
+
+	hasher   hash.Hash
+	lenBytes []byte
+
+	// the first key/value pair of the next prefix, if it was already read
+	key, value []byte
+	done       bool
+}
+
+func newChecksummer(db database.Iteratee, prefixLen int) *checksummer {
+	return &checksummer{
+		it:        db.NewIterator(),
+		prefixLen: prefixLen,
+		hasher:    sha256.New(),
+		lenBytes:  make([]byte, binary.MaxVarintLen64),
+	}
+}
+
+// next returns the checksum of the next prefix, or false if all the prefixes
+// were read.
+func (c *checksummer) next() (prefixChecksum, bool, error) {
+	if c.key == nil && !c.done {
+		c.read()
+	}
+	if c.done {
+		return prefixChecksum{}, false, c.it.Error()
+	}
+
+	prefix := c.prefixOf(c.key)
+	c.hasher.Reset()
+	count := uint64(0)
+	for !c.done && bytes.HasPrefix(c.key, prefix) && len(c.prefixOf(c.key)) == len(prefix) {
+		c.write(c.key)
+		c.write(c.value)
+		count++
+		c.read()
+	}
+	if c.done {
+		if err := c.it.Error(); err != nil {
+			return prefixChecksum{}, false, err
+		}
+	}
+
+	checksum := prefixChecksum{
+		prefix: prefix,
+		count:  count,
+	}
+	copy(checksum.checksum[:], c.hasher.Sum(nil))
+	return checksum, true, nil
+}
+
+func (c *checksummer) read() {
+	if !c.it.Next() {
+		c.key = nil
+		c.value = nil
+		c.done = true
+		return
+	}
+	c.key = c.it.Key()
+	c.value = c.it.Value()
+}
+
+// write [b] to the hasher, prefixed by its length so that the boundaries
+// between keys and values are unambiguous.
+func (c *checksummer) write(b []byte) {
+	n := binary.PutUvarint(c.lenBytes, uint64(len(b)))
+	_, _ = c.hasher.Write(c.lenBytes[:n])
+	_, _ = c.hasher.Write(b)
+}
+
+func (c *checksummer) prefixOf(key []byte) []byte {
+	if len(key) > c.prefixLen {
+		key = key[:c.prefixLen]
+	}
+	return utils.CopyBytes(key)
+}
+
+func (c *checksummer) release() {
+	c.it.Release()
+}
+
+// verify that [source] and [destination] contain the same key/value pairs by
+// comparing the checksums of every prefix. Returns the number of verified
+// prefixes.
+func verify(log logging.Logger, source, destination database.Iteratee, prefixLen int) (int, error) {
+	sourceSums := newChecksummer(source, prefixLen)
+	defer sourceSums.release()
+	destinationSums := newChecksummer(destination, prefixLen)
+	defer destinationSums.release()
+
+	var (
+		numPrefixes   int
+		numMismatched int
+	)
+	sourceSum, sourceOk, err := sourceSums.next()
+	if err != nil {
+		return 0, err
+	}
+	destinationSum, destinationOk, err := destinationSums.next()
+	if err != nil {
+		return 0, err
+	}
+	for sourceOk || destinationOk {
+		numPrefixes++
+
+		var cmp int
+		switch {
+		case !destinationOk:
+			cmp = -1
+		case !sourceOk:
+			cmp = 1
+		default:
+			cmp = bytes.Compare(sourceSum.prefix, destinationSum.prefix)
+		}
+
+		switch {
+		case cmp < 0:
+			numMismatched++
+			log.Error("prefix 0x%x with %d entries is missing from the destination", sourceSum.prefix, sourceSum.count)
+		case cmp > 0:
+			numMismatched++
+			log.Error("prefix 0x%x with %d entries is missing from the source", destinationSum.prefix, destinationSum.count)
+		case sourceSum.count != destinationSum.count || sourceSum.checksum != destinationSum.checksum:
+			numMismatched++
+			log.Error(
+				"prefix 0x%x has %d entries with checksum %s in the source but %d entries with checksum %s in the destination",
+				sourceSum.prefix,
+				sourceSum.count,
+				sourceSum.checksum,
+				destinationSum.count,
+				destinationSum.checksum,
+			)
+		}
+
+		if cmp <= 0 {
+			sourceSum, sourceOk, err = sourceSums.next()
+			if err != nil {
+				return 0, err
+			}
+		}
+		if cmp >= 0 {
+			destinationSum, destinationOk, err = destinationSums.next()
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if numMismatched > 0 {
+		return 0, fmt.Errorf("%w: %d of %d prefixes differ", errChecksumMismatch, numMismatched, numPrefixes)
+	}
+	return numPrefixes, nil
+}
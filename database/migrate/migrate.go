@@ -0,0 +1,391 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/leveldb"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
+	"github.com/sankar-boro/axia-network-v2/database/rocksdb"
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/perms"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+	"github.com/sankar-boro/axia-network-v2/version"
+)
+
+const (
+	// DefaultBatchSize is the number of bytes written to the destination
+	// database before the progress is persisted.
+	DefaultBatchSize = 4 * units.MiB
+
+	// DefaultPrefixLen is the number of leading key bytes that checksums are
+	// grouped by. prefixdb hashes its prefixes, so the keys of every
+	// top-level prefixdb share their first [hashing.HashLen] bytes.
+	DefaultPrefixLen = hashing.HashLen
+
+	// progressFileName is the name of the file, in the destination directory,
+	// that the progress of a migration is persisted to.
+	progressFileName = "migration.json"
+)
+
+var (
+	errUnknownDatabaseType = errors.New("unknown database type")
+	errSameDirectory       = errors.New("source and destination directories must differ")
+	errNoSourceDatabases   = errors.New("no versioned databases found in the source directory")
+	errChecksumMismatch    = errors.New("checksum mismatch")
+)
+
+// Opener opens the database at [path].
+type Opener func(
+	path string,
+	configBytes []byte,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+) (database.Database, error)
+
+// GetOpener returns the opener of the [dbType] databases.
+func GetOpener(dbType string) (Opener, error) {
+	switch dbType {
+	case leveldb.Name:
+		return leveldb.New, nil
+	case rocksdb.Name:
+		return rocksdb.New, nil
+	case pebble.Name:
+		return pebble.New, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownDatabaseType, dbType)
+	}
+}
+
+// Dir returns the directory that a node with the database directory [dbDir]
+// stores its versioned [dbType] databases in.
+func Dir(dbDir string, dbType string) string {
+	switch dbType {
+	case rocksdb.Name, pebble.Name:
+		return filepath.Join(dbDir, dbType)
+	default:
+		return dbDir
+	}
+}
+
+type Config struct {
+	SourceType   string
+	SourceDir    string
+	SourceConfig []byte
+
+	DestinationType   string
+	DestinationDir    string
+	DestinationConfig []byte
+
+	// BatchSize is the number of bytes written to the destination before the
+	// progress is persisted.
+	BatchSize int
+	// PrefixLen is the number of leading key bytes that checksums are grouped
+	// by.
+	PrefixLen int
+	// SkipVerify skips comparing the checksums of the source and the
+	// destination after the copy.
+	SkipVerify bool
+}
+
+// progress of a migration. It is persisted after every batch so that an
+// interrupted migration can be resumed.
+type progress struct {
+	Versions map[string]*versionProgress `json:"versions"`
+}
+
+type versionProgress struct {
+	// Started is true once a batch was written to the destination.
+	Started bool `json:"started"`
+	// LastKey is the last key that was written to the destination.
+	LastKey []byte `json:"lastKey"`
+	// Copied is the number of key/value pairs written to the destination.
+	Copied uint64 `json:"copied"`
+	// Done is true once every key/value pair was written.
+	Done bool `json:"done"`
+	// Verified is true once the checksums of the source and the destination
+	// matched.
+	Verified bool `json:"verified"`
+}
+
+// Migrator copies the versioned databases of one directory into another,
+// possibly with a different database type.
+type Migrator struct {
+	config Config
+	log    logging.Logger
+
+	openSource      Opener
+	openDestination Opener
+
+	progressPath string
+	progress     progress
+}
+
+// New returns a migrator that resumes the migration persisted in the
+// destination directory, if any.
+func New(config Config, log logging.Logger) (*Migrator, error) {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.PrefixLen <= 0 {
+		config.PrefixLen = DefaultPrefixLen
+	}
+
+	sourceDir, err := filepath.Abs(config.SourceDir)
+	if err != nil {
+		return nil, err
+	}
+	destinationDir, err := filepath.Abs(config.DestinationDir)
+	if err != nil {
+		return nil, err
+	}
+	if sourceDir == destinationDir {
+		return nil, errSameDirectory
+	}
+
+	openSource, err := GetOpener(config.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	openDestination, err := GetOpener(config.DestinationType)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{
+		config:          config,
+		log:             log,
+		openSource:      openSource,
+		openDestination: openDestination,
+		progressPath:    filepath.Join(config.DestinationDir, progressFileName),
+		progress: progress{
+			Versions: make(map[string]*versionProgress),
+		},
+	}
+
+	progressBytes, err := os.ReadFile(m.progressPath)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(progressBytes, &m.progress); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", m.progressPath, err)
+		}
+		if m.progress.Versions == nil {
+			m.progress.Versions = make(map[string]*versionProgress)
+		}
+		log.Info("resuming migration from %s", m.progressPath)
+	case os.IsNotExist(err):
+	default:
+		return nil, err
+	}
+	return m, nil
+}
+
+// Run copies, and unless disabled verifies, every versioned database of the
+// source directory.
+func (m *Migrator) Run() error {
+	versions, err := m.sourceVersions()
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return errNoSourceDatabases
+	}
+	if err := os.MkdirAll(m.config.DestinationDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if err := m.migrateVersion(v); err != nil {
+			return fmt.Errorf("failed to migrate database %s: %w", v, err)
+		}
+	}
+	m.log.Info("migrated %d databases from %s to %s", len(versions), m.config.SourceDir, m.config.DestinationDir)
+	return nil
+}
+
+// sourceVersions returns the versions of the databases in the source
+// directory, in ascending order. As in [manager.New], directories whose names
+// aren't versions are ignored.
+func (m *Migrator) sourceVersions() ([]version.Version, error) {
+	entries, err := os.ReadDir(m.config.SourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]version.Version, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		v, err := version.DefaultParser.Parse(entry.Name())
+		if err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) < 0
+	})
+	return versions, nil
+}
+
+func (m *Migrator) migrateVersion(v version.Version) error {
+	name := v.String()
+	vp, ok := m.progress.Versions[name]
+	if !ok {
+		vp = &versionProgress{}
+		m.progress.Versions[name] = vp
+	}
+	if vp.Done && (vp.Verified || m.config.SkipVerify) {
+		m.log.Info("database %s was already migrated", name)
+		return nil
+	}
+
+	source, err := m.openSource(
+		filepath.Join(m.config.SourceDir, name),
+		m.config.SourceConfig,
+		m.log,
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := m.openDestination(
+		filepath.Join(m.config.DestinationDir, name),
+		m.config.DestinationConfig,
+		m.log,
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		return err
+	}
+	defer destination.Close()
+
+	if !vp.Done {
+		if err := m.copy(name, source, destination, vp); err != nil {
+			return err
+		}
+	}
+	if m.config.SkipVerify {
+		return nil
+	}
+
+	m.log.Info("verifying database %s", name)
+	numPrefixes, err := verify(m.log, source, destination, m.config.PrefixLen)
+	if err != nil {
+		return err
+	}
+	m.log.Info("verified %d prefixes of database %s", numPrefixes, name)
+	vp.Verified = true
+	return m.saveProgress()
+}
+
+// copy streams the key/value pairs of [source] into [destination] in order,
+// starting after the last key that was persisted in [vp].
+func (m *Migrator) copy(name string, source, destination database.Database, vp *versionProgress) error {
+	var start []byte
+	if vp.Started {
+		// Resume at the smallest key after the last written key.
+		start = make([]byte, len(vp.LastKey)+1)
+		copy(start, vp.LastKey)
+		m.log.Info("resuming database %s after %d copied entries", name, vp.Copied)
+	} else {
+		it := destination.NewIterator()
+		notEmpty := it.Next()
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+		if notEmpty {
+			// The migration was interrupted after a batch was written but
+			// before its progress was persisted. Copying is idempotent, so
+			// start over; keys that aren't in the source are reported by
+			// the verification.
+			m.log.Warn("database %s isn't empty but has no persisted progress, copying it again from the start", name)
+		} else {
+			m.log.Info("copying database %s", name)
+		}
+	}
+
+	it := source.NewIteratorWithStart(start)
+	defer it.Release()
+
+	batch := destination.NewBatch()
+	var (
+		lastKey   []byte
+		numCopied uint64
+	)
+	flush := func() error {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+
+		vp.Started = true
+		vp.LastKey = lastKey
+		vp.Copied += numCopied
+		numCopied = 0
+		if err := m.saveProgress(); err != nil {
+			return err
+		}
+		m.log.Debug("copied %d entries of database %s", vp.Copied, name)
+		return nil
+	}
+
+	for it.Next() {
+		key := it.Key()
+		if err := batch.Put(key, it.Value()); err != nil {
+			return err
+		}
+		lastKey = key
+		numCopied++
+
+		if batch.Size() >= m.config.BatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if numCopied > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	vp.Done = true
+	m.log.Info("copied %d entries of database %s", vp.Copied, name)
+	return m.saveProgress()
+}
+
+// saveProgress atomically replaces the persisted progress.
+func (m *Migrator) saveProgress() error {
+	progressBytes, err := json.Marshal(&m.progress)
+	if err != nil {
+		return err
+	}
+	tmpPath := m.progressPath + ".tmp"
+	if err := os.WriteFile(tmpPath, progressBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, m.progressPath)
+}
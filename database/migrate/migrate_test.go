@@ -0,0 +1,318 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/leveldb"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/perms"
+	"github.com/sankar-boro/axia-network-v2/version"
+)
+
+var testVersions = []version.Version{
+	version.DefaultVersion1_0_0,
+	version.NewDefaultVersion(1, 4, 5),
+}
+
+// populate writes [numKeys] keys into two prefixdbs of every test version in
+// [dir]. Returns the expected contents of every version.
+func populate(t *testing.T, dir string, numKeys int) map[string]map[string][]byte {
+	expected := make(map[string]map[string][]byte)
+	for i, v := range testVersions {
+		db, err := leveldb.New(filepath.Join(dir, v.String()), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, prefix := range []string{"a", "b"} {
+			prefixDB := prefixdb.New([]byte(prefix), db)
+			for j := 0; j < numKeys; j++ {
+				key := []byte(fmt.Sprintf("key-%05d", j))
+				value := []byte(fmt.Sprintf("value-%d-%d", i, j))
+				if err := prefixDB.Put(key, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		expected[v.String()] = contents(t, db)
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return expected
+}
+
+func contents(t *testing.T, db database.Iteratee) map[string][]byte {
+	it := db.NewIterator()
+	defer it.Release()
+
+	kvs := make(map[string][]byte)
+	for it.Next() {
+		kvs[string(it.Key())] = it.Value()
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return kvs
+}
+
+func openPebble(t *testing.T, dir string, v version.Version) database.Database {
+	db, err := pebble.New(filepath.Join(dir, v.String()), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newTestMigrator(t *testing.T, sourceDir, destinationDir string) *Migrator {
+	m, err := New(Config{
+		SourceType:      leveldb.Name,
+		SourceDir:       sourceDir,
+		DestinationType: pebble.Name,
+		DestinationDir:  destinationDir,
+		BatchSize:       256,
+	}, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMigrate(t *testing.T) {
+	assert := assert.New(t)
+
+	sourceDir := t.TempDir()
+	destinationDir := filepath.Join(t.TempDir(), pebble.Name)
+	expected := populate(t, sourceDir, 100)
+
+	// Directories that aren't versions are ignored.
+	assert.NoError(os.Mkdir(filepath.Join(sourceDir, "not-a-version"), perms.ReadWriteExecute))
+
+	m := newTestMigrator(t, sourceDir, destinationDir)
+	assert.NoError(m.Run())
+
+	for _, v := range testVersions {
+		db := openPebble(t, destinationDir, v)
+		assert.Equal(expected[v.String()], contents(t, db))
+		assert.NoError(db.Close())
+
+		vp := m.progress.Versions[v.String()]
+		assert.True(vp.Done)
+		assert.True(vp.Verified)
+		assert.EqualValues(200, vp.Copied)
+	}
+	_, err := os.Stat(filepath.Join(destinationDir, "not-a-version"))
+	assert.True(os.IsNotExist(err))
+
+	// Running a finished migration again is a no-op.
+	m = newTestMigrator(t, sourceDir, destinationDir)
+	assert.NoError(m.Run())
+}
+
+func TestMigrateResume(t *testing.T) {
+	assert := assert.New(t)
+
+	sourceDir := t.TempDir()
+	destinationDir := t.TempDir()
+	expected := populate(t, sourceDir, 50)
+
+	// Simulate a migration of the first version that was interrupted after
+	// the first [numCopied] keys were written.
+	v := testVersions[0]
+	source, err := leveldb.New(filepath.Join(sourceDir, v.String()), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	destination := openPebble(t, destinationDir, v)
+
+	const numCopied = 30
+	it := source.NewIterator()
+	var lastKey []byte
+	for i := 0; i < numCopied && it.Next(); i++ {
+		lastKey = it.Key()
+		assert.NoError(destination.Put(lastKey, it.Value()))
+	}
+	assert.NoError(it.Error())
+	it.Release()
+	assert.NoError(source.Close())
+	assert.NoError(destination.Close())
+
+	progressBytes, err := json.Marshal(&progress{
+		Versions: map[string]*versionProgress{
+			v.String(): {
+				Started: true,
+				LastKey: lastKey,
+				Copied:  numCopied,
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(filepath.Join(destinationDir, progressFileName), progressBytes, perms.ReadWrite))
+
+	m := newTestMigrator(t, sourceDir, destinationDir)
+	assert.NoError(m.Run())
+
+	for _, v := range testVersions {
+		db := openPebble(t, destinationDir, v)
+		assert.Equal(expected[v.String()], contents(t, db))
+		assert.NoError(db.Close())
+	}
+	assert.EqualValues(100, m.progress.Versions[v.String()].Copied)
+}
+
+func TestMigrateDestinationNotEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	sourceDir := t.TempDir()
+	destinationDir := t.TempDir()
+	expected := populate(t, sourceDir, 10)
+
+	// Simulate a migration that was interrupted after the first batch was
+	// written but before its progress was persisted.
+	v := testVersions[0]
+	source, err := leveldb.New(filepath.Join(sourceDir, v.String()), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	destination := openPebble(t, destinationDir, v)
+
+	it := source.NewIterator()
+	for i := 0; i < 5 && it.Next(); i++ {
+		assert.NoError(destination.Put(it.Key(), it.Value()))
+	}
+	assert.NoError(it.Error())
+	it.Release()
+	assert.NoError(source.Close())
+	assert.NoError(destination.Close())
+
+	m := newTestMigrator(t, sourceDir, destinationDir)
+	assert.NoError(m.Run())
+
+	for _, v := range testVersions {
+		db := openPebble(t, destinationDir, v)
+		assert.Equal(expected[v.String()], contents(t, db))
+		assert.NoError(db.Close())
+
+		vp := m.progress.Versions[v.String()]
+		assert.True(vp.Verified)
+		assert.EqualValues(20, vp.Copied)
+	}
+}
+
+func TestMigrateDestinationForeignKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	sourceDir := t.TempDir()
+	destinationDir := t.TempDir()
+	populate(t, sourceDir, 10)
+
+	db := openPebble(t, destinationDir, testVersions[0])
+	assert.NoError(db.Put([]byte("key"), []byte("value")))
+	assert.NoError(db.Close())
+
+	m := newTestMigrator(t, sourceDir, destinationDir)
+	assert.ErrorIs(m.Run(), errChecksumMismatch)
+}
+
+func TestMigrateSameDirectory(t *testing.T) {
+	dir := t.TempDir()
+	_, err := New(Config{
+		SourceType:      leveldb.Name,
+		SourceDir:       dir,
+		DestinationType: pebble.Name,
+		DestinationDir:  filepath.Join(dir, "."),
+	}, logging.NoLog{})
+	assert.ErrorIs(t, err, errSameDirectory)
+}
+
+func TestMigrateChecksumMismatch(t *testing.T) {
+	assert := assert.New(t)
+
+	sourceDir := t.TempDir()
+	destinationDir := t.TempDir()
+	populate(t, sourceDir, 10)
+
+	m := newTestMigrator(t, sourceDir, destinationDir)
+	assert.NoError(m.Run())
+
+	// Corrupt a value of the copied database and force it to be verified
+	// again.
+	v := testVersions[1]
+	db := openPebble(t, destinationDir, v)
+	assert.NoError(prefixdb.New([]byte("b"), db).Put([]byte("key-00003"), []byte("corrupted")))
+	assert.NoError(db.Close())
+
+	m.progress.Versions[v.String()].Verified = false
+	assert.NoError(m.saveProgress())
+
+	m = newTestMigrator(t, sourceDir, destinationDir)
+	assert.ErrorIs(m.Run(), errChecksumMismatch)
+	assert.False(m.progress.Versions[v.String()].Verified)
+}
+
+func TestVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	source := memdbWith(t, map[string]string{
+		"aa1": "1",
+		"aa2": "2",
+		"bb1": "3",
+		"c":   "4",
+	})
+
+	numPrefixes, err := verify(logging.NoLog{}, source, source, 2)
+	assert.NoError(err)
+	assert.Equal(3, numPrefixes)
+
+	// A missing prefix is detected.
+	destination := memdbWith(t, map[string]string{
+		"aa1": "1",
+		"aa2": "2",
+		"c":   "4",
+	})
+	_, err = verify(logging.NoLog{}, source, destination, 2)
+	assert.ErrorIs(err, errChecksumMismatch)
+
+	// An extra key is detected.
+	destination = memdbWith(t, map[string]string{
+		"aa1": "1",
+		"aa2": "2",
+		"aa3": "3",
+		"bb1": "3",
+		"c":   "4",
+	})
+	_, err = verify(logging.NoLog{}, source, destination, 2)
+	assert.ErrorIs(err, errChecksumMismatch)
+
+	// Moving bytes between a key and its value is detected.
+	destination = memdbWith(t, map[string]string{
+		"aa1": "1",
+		"aa":  "22",
+		"bb1": "3",
+		"c":   "4",
+	})
+	_, err = verify(logging.NoLog{}, source, destination, 2)
+	assert.ErrorIs(err, errChecksumMismatch)
+}
+
+func memdbWith(t *testing.T, kvs map[string]string) database.Database {
+	db := memdb.New()
+	for key, value := range kvs {
+		if err := db.Put([]byte(key), []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
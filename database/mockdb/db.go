@@ -28,6 +28,7 @@ type Database struct {
 	OnGet                           func([]byte) ([]byte, error)
 	OnPut                           func([]byte, []byte) error
 	OnDelete                        func([]byte) error
+	OnDeleteRange                   func([]byte, []byte) error
 	OnNewBatch                      func() database.Batch
 	OnNewIterator                   func() database.Iterator
 	OnNewIteratorWithStart          func([]byte) database.Iterator
@@ -69,6 +70,13 @@ func (db *Database) Delete(k []byte) error {
 	return db.OnDelete(k)
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	if db.OnDeleteRange == nil {
+		return errNoFunction
+	}
+	return db.OnDeleteRange(start, limit)
+}
+
 func (db *Database) NewBatch() database.Batch {
 	if db.OnNewBatch == nil {
 		return nil
@@ -28,6 +28,9 @@ func (*Database) Put(_, _ []byte) error { return database.ErrClosed }
 // Delete returns nil
 func (*Database) Delete([]byte) error { return database.ErrClosed }
 
+// DeleteRange returns nil
+func (*Database) DeleteRange(_, _ []byte) error { return database.ErrClosed }
+
 // NewBatch returns a new batch
 func (*Database) NewBatch() database.Batch { return &Batch{} }
 
@@ -63,6 +66,9 @@ func (*Batch) Put(_, _ []byte) error { return database.ErrClosed }
 // Delete returns nil
 func (*Batch) Delete([]byte) error { return database.ErrClosed }
 
+// DeleteRange returns nil
+func (*Batch) DeleteRange(_, _ []byte) error { return database.ErrClosed }
+
 // Size returns 0
 func (*Batch) Size() int { return 0 }
 
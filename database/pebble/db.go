@@ -0,0 +1,798 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/nodb"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+)
+
+const (
+	// Name is the name of this database for database switches
+	Name = "pebble"
+
+	// DefaultCacheSize is the number of bytes to use for block caching in
+	// pebble.
+	DefaultCacheSize = 512 * units.MiB
+
+	// DefaultMemTableSize is the number of bytes to use for each memtable in
+	// pebble.
+	DefaultMemTableSize = 64 * units.MiB
+
+	// DefaultMaxOpenFiles is the number of files descriptors to cap pebble to
+	// use.
+	DefaultMaxOpenFiles = 4096
+
+	// DefaultBitsPerKey is the number of bits to add to the bloom filter per
+	// key.
+	DefaultBitsPerKey = 10
+
+	// DefaultMetricUpdateFrequency is the frequency to poll the pebble
+	// metrics.
+	DefaultMetricUpdateFrequency = 10 * time.Second
+
+	// pebbleByteOverhead is the number of bytes of constant overhead that
+	// should be added to a batch size per operation.
+	pebbleByteOverhead = 8
+)
+
+var (
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ database.Snapshot    = &snapshot{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iter{}
+
+	errInvalidOperation = errors.New("invalid operation")
+)
+
+// Database is a persistent key-value store. Apart from basic data storage
+// functionality it also supports batch writes and iterating over the keyspace
+// in binary-alphabetical order.
+type Database struct {
+	lock   sync.RWMutex
+	db     *pebble.DB
+	closed bool
+	// pebble refuses to close while iterators are open, so the open iterators
+	// are tracked to be closed along with the database.
+	openIterators map[*iter]struct{}
+	// snapshots that haven't been released yet are also released when the
+	// database is closed.
+	openSnapshots map[*snapshot]struct{}
+	writeOptions  *pebble.WriteOptions
+
+	// metrics is only initialized and used when [MetricUpdateFrequency] is >= 0
+	// in the config
+	metrics   metrics
+	closeOnce sync.Once
+	// closeCh is closed when Close() is called.
+	closeCh chan struct{}
+}
+
+type config struct {
+	// CacheSize is the number of bytes used to cache uncompressed blocks.
+	//
+	// The default value is 512MiB.
+	CacheSize int64 `json:"cacheSize"`
+	// BytesPerSync syncs sstables periodically while they are being written,
+	// in order to smooth out writes to disk.
+	//
+	// The default value is 512KiB.
+	BytesPerSync int `json:"bytesPerSync"`
+	// WALBytesPerSync syncs the write ahead log periodically while it is
+	// being written. 0 disables the periodic syncs.
+	//
+	// The default value is 0.
+	WALBytesPerSync int `json:"walBytesPerSync"`
+	// MemTableSize is the size of a memtable before it is flushed to an
+	// sstable.
+	//
+	// The default value is 64MiB.
+	MemTableSize int `json:"memTableSize"`
+	// MemTableStopWritesThreshold is the number of queued memtables at which
+	// writes are stopped until the memtables are flushed.
+	//
+	// The default value is 2.
+	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"`
+	// L0CompactionThreshold is the number of level-0 sublevels that trigger a
+	// compaction.
+	//
+	// The default value is 4.
+	L0CompactionThreshold int `json:"l0CompactionThreshold"`
+	// L0StopWritesThreshold is the number of level-0 sublevels at which writes
+	// are stopped until compactions catch up.
+	//
+	// The default value is 12.
+	L0StopWritesThreshold int `json:"l0StopWritesThreshold"`
+	// LBaseMaxBytes is the maximum number of bytes in the base level.
+	//
+	// The default value is 64MiB.
+	LBaseMaxBytes int64 `json:"lBaseMaxBytes"`
+	// MaxConcurrentCompactions is the maximum number of compactions run
+	// concurrently.
+	//
+	// The default value is 1.
+	MaxConcurrentCompactions int `json:"maxConcurrentCompactions"`
+	// MaxOpenFiles is the number of file descriptors pebble can hold open.
+	//
+	// The default value is 4096.
+	MaxOpenFiles int `json:"maxOpenFiles"`
+	// FilterBitsPerKey is the number of bits per key of the bloom filters.
+	//
+	// The default value is 10.
+	FilterBitsPerKey int `json:"filterBitsPerKey"`
+	// Sync makes every write wait until it was synced to disk.
+	//
+	// The default is false.
+	Sync bool `json:"sync"`
+
+	// ReadOnly opens the database without modifying it. Writes fail.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
+
+	// MetricUpdateFrequency is the frequency to poll pebble metrics.
+	// If <= 0, pebble metrics aren't polled.
+	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+}
+
+// New returns a wrapped pebble object.
+func New(file string, configBytes []byte, log logging.Logger, namespace string, reg prometheus.Registerer) (database.Database, error) {
+	parsedConfig := config{
+		CacheSize:             DefaultCacheSize,
+		MemTableSize:          DefaultMemTableSize,
+		MaxOpenFiles:          DefaultMaxOpenFiles,
+		FilterBitsPerKey:      DefaultBitsPerKey,
+		MetricUpdateFrequency: DefaultMetricUpdateFrequency,
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+	configJSON, err := json.Marshal(&parsedConfig)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("pebble config: %s", string(configJSON))
+
+	cache := pebble.NewCache(parsedConfig.CacheSize)
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:                       cache,
+		BytesPerSync:                parsedConfig.BytesPerSync,
+		WALBytesPerSync:             parsedConfig.WALBytesPerSync,
+		MemTableSize:                parsedConfig.MemTableSize,
+		MemTableStopWritesThreshold: parsedConfig.MemTableStopWritesThreshold,
+		L0CompactionThreshold:       parsedConfig.L0CompactionThreshold,
+		L0StopWritesThreshold:       parsedConfig.L0StopWritesThreshold,
+		LBaseMaxBytes:               parsedConfig.LBaseMaxBytes,
+		MaxConcurrentCompactions:    parsedConfig.MaxConcurrentCompactions,
+		MaxOpenFiles:                parsedConfig.MaxOpenFiles,
+		ReadOnly:                    parsedConfig.ReadOnly,
+		Levels:                      make([]pebble.LevelOptions, 7),
+	}
+	for i := range opts.Levels {
+		opts.Levels[i].FilterPolicy = bloom.FilterPolicy(parsedConfig.FilterBitsPerKey)
+		opts.Levels[i].FilterType = pebble.TableFilter
+	}
+	opts.EnsureDefaults()
+
+	db, err := pebble.Open(file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	writeOptions := pebble.NoSync
+	if parsedConfig.Sync {
+		writeOptions = pebble.Sync
+	}
+	wrappedDB := &Database{
+		db:            db,
+		openIterators: make(map[*iter]struct{}),
+		openSnapshots: make(map[*snapshot]struct{}),
+		writeOptions:  writeOptions,
+		closeCh:       make(chan struct{}),
+	}
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		metrics, err := newMetrics(namespace, reg)
+		if err != nil {
+			// Drop any close error to report the original error
+			_ = db.Close()
+			return nil, err
+		}
+		wrappedDB.metrics = metrics
+		go func() {
+			t := time.NewTicker(parsedConfig.MetricUpdateFrequency)
+			defer t.Stop()
+
+			for {
+				err := wrappedDB.updateMetrics()
+				if err != nil && err != database.ErrClosed {
+					log.Warn("failed to update pebble metrics: %s", err)
+				}
+
+				select {
+				case <-t.C:
+				case <-wrappedDB.closeCh:
+					return
+				}
+			}
+		}()
+	}
+	return wrappedDB, nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	_, err := db.Get(key)
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	value, closer, err := db.db.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	// [value] is only valid until [closer] is closed.
+	value = utils.CopyBytes(value)
+	return value, closer.Close()
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.db.Set(key, value, db.writeOptions))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.db.Delete(key, db.writeOptions))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIter(db.db, start, prefix)
+}
+
+// newIter returns an iterator over the keys of [reader] starting at start
+// and ignoring keys that do not start with the provided prefix.
+//
+// Invariant: [db.lock] is held.
+func (db *Database) newIter(reader pebble.Reader, start, prefix []byte) *iter {
+	opts := &pebble.IterOptions{
+		LowerBound: utils.CopyBytes(prefix),
+		UpperBound: prefixToUpperBound(prefix),
+	}
+	if bytes.Compare(start, prefix) == 1 {
+		opts.LowerBound = utils.CopyBytes(start)
+	}
+	it := &iter{
+		db:   db,
+		iter: reader.NewIter(opts),
+	}
+	db.openIterators[it] = struct{}{}
+	return it
+}
+
+// NewSnapshot returns a pebble snapshot of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	s := &snapshot{
+		db:       db,
+		snapshot: db.db.NewSnapshot(),
+	}
+	db.openSnapshots[s] = struct{}{}
+	return s, nil
+}
+
+// Compact the underlying DB for the given key range.
+// Specifically, deleted and overwritten versions are discarded,
+// and the data is rearranged to reduce the cost of operations
+// needed to access the data. This operation should typically only
+// be invoked by users who understand the underlying implementation.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+// Therefore if both are nil then it will compact entire DB.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		var err error
+		limit, err = db.upperBound()
+		if err != nil {
+			return err
+		}
+		if limit == nil {
+			// The database is empty.
+			return nil
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	return updateError(db.db.Compact(start, limit, true /* parallelize */))
+}
+
+// DeleteRange removes all the keys in the range [start, limit) from the
+// database with a single range tombstone.
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		var err error
+		limit, err = db.upperBound()
+		if err != nil {
+			return err
+		}
+		if limit == nil {
+			// The database is empty.
+			return nil
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	return updateError(db.db.DeleteRange(start, limit, db.writeOptions))
+}
+
+// upperBound returns the successor of the last key in the database, or nil if
+// the database is empty. pebble requires exclusive upper bounds for ranges, so
+// this is used in place of a nil limit. Assumes the lock is held.
+func (db *Database) upperBound() ([]byte, error) {
+	it := db.db.NewIter(&pebble.IterOptions{})
+	var limit []byte
+	if it.Last() {
+		limit = append(utils.CopyBytes(it.Key()), 0)
+	}
+	return limit, updateError(it.Close())
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+	db.closeOnce.Do(func() {
+		close(db.closeCh)
+	})
+
+	for it := range db.openIterators {
+		it.close()
+	}
+	for s := range db.openSnapshots {
+		s.close()
+	}
+	return updateError(db.db.Close())
+}
+
+func (db *Database) isClosed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.closed
+}
+
+func (db *Database) HealthCheck() (interface{}, error) {
+	if db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+// batch is a wrapper around a pebble batch to contain sizes.
+type batch struct {
+	batch *pebble.Batch
+	db    *Database
+	size  int
+	// maxKey is the largest key put into the batch. It is used to bound range
+	// deletions with a nil limit.
+	maxKey []byte
+	// pebble batches can only be committed once, so a written batch is copied
+	// before it is written again.
+	written bool
+}
+
+// Put the value into the batch for later writing
+func (b *batch) Put(key, value []byte) error {
+	b.size += len(key) + len(value) + pebbleByteOverhead
+	if bytes.Compare(key, b.maxKey) > 0 {
+		b.maxKey = utils.CopyBytes(key)
+	}
+	return b.batch.Set(key, value, nil)
+}
+
+// Delete the key during writing
+func (b *batch) Delete(key []byte) error {
+	b.size += len(key) + pebbleByteOverhead
+	return b.batch.Delete(key, nil)
+}
+
+// DeleteRange queues a range tombstone for the keys in [start, limit). A nil
+// limit is resolved to the successor of the largest key that is currently
+// either in the database or in the batch.
+func (b *batch) DeleteRange(start []byte, limit []byte) error {
+	if limit == nil {
+		b.db.lock.RLock()
+		if b.db.closed {
+			b.db.lock.RUnlock()
+			return database.ErrClosed
+		}
+		dbLimit, err := b.db.upperBound()
+		b.db.lock.RUnlock()
+		if err != nil {
+			return err
+		}
+
+		if b.maxKey != nil {
+			limit = append(utils.CopyBytes(b.maxKey), 0)
+		}
+		if bytes.Compare(dbLimit, limit) > 0 {
+			limit = dbLimit
+		}
+		if limit == nil {
+			// Neither the database nor the batch contain any keys.
+			return nil
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	b.size += len(start) + len(limit) + pebbleByteOverhead
+	return b.batch.DeleteRange(start, limit, nil)
+}
+
+// Size retrieves the amount of data queued up for writing.
+func (b *batch) Size() int { return b.size }
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.closed {
+		return database.ErrClosed
+	}
+
+	if b.written {
+		newBatch := b.db.db.NewBatch()
+		if err := newBatch.Apply(b.batch, nil); err != nil {
+			return err
+		}
+		b.batch = newBatch
+	}
+	b.written = true
+	return updateError(b.batch.Commit(b.db.writeOptions))
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.batch.Reset()
+	b.size = 0
+	b.maxKey = nil
+	b.written = false
+}
+
+// Replay the batch contents.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	reader := b.batch.Reader()
+	for {
+		kind, key, value, ok := reader.Next()
+		if !ok {
+			return nil
+		}
+		switch kind {
+		case pebble.InternalKeyKindSet:
+			if err := w.Put(key, value); err != nil {
+				return err
+			}
+		case pebble.InternalKeyKindDelete:
+			if err := w.Delete(key); err != nil {
+				return err
+			}
+		case pebble.InternalKeyKindRangeDelete:
+			if err := database.ReplayDeleteRange(w, key, value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %s", errInvalidOperation, kind)
+		}
+	}
+}
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch { return b }
+
+// snapshot is a wrapper around a pebble snapshot. pebble panics when a closed
+// snapshot is used, so the snapshot is guarded by the database lock.
+type snapshot struct {
+	db       *Database
+	snapshot *pebble.Snapshot
+	released bool
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	_, err := s.Get(key)
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.released {
+		return nil, database.ErrClosed
+	}
+
+	value, closer, err := s.snapshot.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	// [value] is only valid until [closer] is closed.
+	value = utils.CopyBytes(value)
+	return value, closer.Close()
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	if s.db.closed || s.released {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newIter(s.snapshot, start, prefix)
+}
+
+// Release the snapshot
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.close()
+}
+
+// close the underlying snapshot.
+//
+// Invariant: [s.db.lock] is held.
+func (s *snapshot) close() {
+	if s.released {
+		return
+	}
+	s.released = true
+	delete(s.db.openSnapshots, s)
+	_ = s.snapshot.Close()
+}
+
+type iter struct {
+	db   *Database
+	iter *pebble.Iterator
+
+	started  bool
+	released bool
+	key, val []byte
+	err      error
+}
+
+func (it *iter) Next() bool {
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.closed {
+		it.key = nil
+		it.val = nil
+		it.err = database.ErrClosed
+		return false
+	}
+	if it.released {
+		it.key = nil
+		it.val = nil
+		return false
+	}
+
+	var hasNext bool
+	if it.started {
+		hasNext = it.iter.Next()
+	} else {
+		hasNext = it.iter.First()
+		it.started = true
+	}
+	if hasNext {
+		it.key = utils.CopyBytes(it.iter.Key())
+		it.val = utils.CopyBytes(it.iter.Value())
+	} else {
+		it.key = nil
+		it.val = nil
+	}
+	return hasNext
+}
+
+func (it *iter) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	if it.released {
+		return nil
+	}
+	return updateError(it.iter.Error())
+}
+
+func (it *iter) Key() []byte { return it.key }
+
+func (it *iter) Value() []byte { return it.val }
+
+func (it *iter) Release() {
+	it.db.lock.Lock()
+	defer it.db.lock.Unlock()
+
+	it.close()
+}
+
+// close the underlying iterator.
+//
+// Invariant: [it.db.lock] is held.
+func (it *iter) close() {
+	if it.released {
+		return
+	}
+	it.released = true
+	delete(it.db.openIterators, it)
+	if err := it.iter.Close(); err != nil && it.err == nil {
+		it.err = updateError(err)
+	}
+}
+
+// prefixToUpperBound returns the smallest key that is larger than all the keys
+// with [prefix], or nil if there is no such key.
+func prefixToUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := utils.CopyBytes(prefix[:i+1])
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
+
+func updateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pebble.ErrNotFound):
+		return database.ErrNotFound
+	case errors.Is(err, pebble.ErrClosed):
+		return database.ErrClosed
+	default:
+		return err
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+)
+
+var levelLabels = []string{"level"}
+
+type metrics struct {
+	// total number of compactions performed
+	compactions prometheus.Counter
+	// number of compactions currently in progress
+	compactionsInProgress prometheus.Gauge
+	// estimated number of bytes that need to be compacted for the LSM to
+	// reach a stable state
+	compactionDebt prometheus.Gauge
+	// total number of memtable flushes performed
+	flushes prometheus.Counter
+
+	// number of currently alive snapshots
+	aliveSnapshots prometheus.Gauge
+	// number of currently open sstable iterators
+	aliveIterators prometheus.Gauge
+
+	// total number of bytes of cached data blocks
+	blockCacheSize prometheus.Gauge
+	// total number of block cache hits
+	blockCacheHits prometheus.Counter
+	// total number of block cache misses
+	blockCacheMisses prometheus.Counter
+
+	// number of bytes allocated by memtables
+	memTableSize prometheus.Gauge
+	// number of memtables
+	memTableCount prometheus.Gauge
+
+	// size of the live data in the WAL
+	walSize prometheus.Gauge
+	// total amount of data written to the WAL
+	walWrites prometheus.Counter
+
+	// total disk space used by the database
+	diskUsage prometheus.Gauge
+
+	// number of tables per level
+	levelTableCount *prometheus.GaugeVec
+	// size of each level
+	levelSize *prometheus.GaugeVec
+	// amount of bytes read while compacting each level
+	levelReads *prometheus.CounterVec
+	// amount of bytes written while compacting or flushing into each level
+	levelWrites *prometheus.CounterVec
+
+	priorMetrics *pebble.Metrics
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
+	m := metrics{
+		compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comps",
+			Help:      "total number of compactions performed",
+		}),
+		compactionsInProgress: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comps_in_progress",
+			Help:      "number of compactions currently in progress",
+		}),
+		compactionDebt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comp_debt",
+			Help:      "estimated number of bytes that need to be compacted",
+		}),
+		flushes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "flushes",
+			Help:      "total number of memtable flushes performed",
+		}),
+
+		aliveSnapshots: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_snapshots",
+			Help:      "number of currently alive snapshots",
+		}),
+		aliveIterators: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_iterators",
+			Help:      "number of currently open sstable iterators",
+		}),
+
+		blockCacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "block_cache_size",
+			Help:      "total size of cached blocks",
+		}),
+		blockCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_hits",
+			Help:      "cumulative number of block cache hits",
+		}),
+		blockCacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_misses",
+			Help:      "cumulative number of block cache misses",
+		}),
+
+		memTableSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "memtable_size",
+			Help:      "number of bytes allocated by memtables",
+		}),
+		memTableCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "memtable_count",
+			Help:      "number of memtables",
+		}),
+
+		walSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "wal_size",
+			Help:      "size of the live data in the write ahead log",
+		}),
+		walWrites: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "wal_writes",
+			Help:      "cumulative amount of bytes written to the write ahead log",
+		}),
+
+		diskUsage: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "disk_usage",
+			Help:      "total amount of bytes used on disk",
+		}),
+
+		levelTableCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "table_count",
+				Help:      "number of tables allocated by level",
+			},
+			levelLabels,
+		),
+		levelSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "amount of bytes allocated by level",
+			},
+			levelLabels,
+		),
+		levelReads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reads",
+				Help:      "amount of bytes read during compaction by level",
+			},
+			levelLabels,
+		),
+		levelWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "amount of bytes written during compaction and flushes by level",
+			},
+			levelLabels,
+		),
+
+		priorMetrics: &pebble.Metrics{},
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.compactions),
+		reg.Register(m.compactionsInProgress),
+		reg.Register(m.compactionDebt),
+		reg.Register(m.flushes),
+
+		reg.Register(m.aliveSnapshots),
+		reg.Register(m.aliveIterators),
+
+		reg.Register(m.blockCacheSize),
+		reg.Register(m.blockCacheHits),
+		reg.Register(m.blockCacheMisses),
+
+		reg.Register(m.memTableSize),
+		reg.Register(m.memTableCount),
+
+		reg.Register(m.walSize),
+		reg.Register(m.walWrites),
+
+		reg.Register(m.diskUsage),
+
+		reg.Register(m.levelTableCount),
+		reg.Register(m.levelSize),
+		reg.Register(m.levelReads),
+		reg.Register(m.levelWrites),
+	)
+	return m, errs.Err
+}
+
+func (db *Database) updateMetrics() error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	metrics := &db.metrics
+	prior := metrics.priorMetrics
+	current := db.db.Metrics()
+
+	metrics.compactions.Add(float64(current.Compact.Count - prior.Compact.Count))
+	metrics.compactionsInProgress.Set(float64(current.Compact.NumInProgress))
+	metrics.compactionDebt.Set(float64(current.Compact.EstimatedDebt))
+	metrics.flushes.Add(float64(current.Flush.Count - prior.Flush.Count))
+
+	metrics.aliveSnapshots.Set(float64(current.Snapshots.Count))
+	metrics.aliveIterators.Set(float64(current.TableIters))
+
+	metrics.blockCacheSize.Set(float64(current.BlockCache.Size))
+	metrics.blockCacheHits.Add(float64(current.BlockCache.Hits - prior.BlockCache.Hits))
+	metrics.blockCacheMisses.Add(float64(current.BlockCache.Misses - prior.BlockCache.Misses))
+
+	metrics.memTableSize.Set(float64(current.MemTable.Size))
+	metrics.memTableCount.Set(float64(current.MemTable.Count))
+
+	metrics.walSize.Set(float64(current.WAL.Size))
+	metrics.walWrites.Add(float64(current.WAL.BytesWritten - prior.WAL.BytesWritten))
+
+	metrics.diskUsage.Set(float64(current.DiskSpaceUsage()))
+
+	for level, levelMetrics := range current.Levels {
+		levelStr := strconv.Itoa(level)
+		priorLevel := prior.Levels[level]
+		metrics.levelTableCount.WithLabelValues(levelStr).Set(float64(levelMetrics.NumFiles))
+		metrics.levelSize.WithLabelValues(levelStr).Set(float64(levelMetrics.Size))
+		metrics.levelReads.WithLabelValues(levelStr).Add(float64(levelMetrics.BytesRead - priorLevel.BytesRead))
+		metrics.levelWrites.WithLabelValues(levelStr).Add(float64(
+			levelMetrics.BytesCompacted + levelMetrics.BytesFlushed -
+				priorLevel.BytesCompacted - priorLevel.BytesFlushed,
+		))
+	}
+
+	// update the prior metrics to update the counters correctly next time this
+	// method is called
+	metrics.priorMetrics = current
+	return nil
+}
@@ -16,9 +16,11 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ database.Snapshot    = &snapshot{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iterator{}
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -26,6 +28,9 @@ var (
 type Database struct {
 	// All keys in this db begin with this byte slice
 	dbPrefix []byte
+	// The smallest key that is larger than every key that begins with
+	// [dbPrefix], or nil if there is no such key.
+	dbLimit []byte
 	// Holds unused []byte
 	bufferPool sync.Pool
 
@@ -50,8 +55,10 @@ func New(prefix []byte, db database.Database) *Database {
 // NewNested returns a new prefixed database without attempting to compress
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
+	dbPrefix := hashing.ComputeHash256(prefix)
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: dbPrefix,
+		dbLimit:  prefixLimit(dbPrefix),
 		db:       db,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
@@ -126,6 +133,29 @@ func (db *Database) Delete(key []byte) error {
 	return err
 }
 
+// Assumes that it is OK for the arguments to db.db.DeleteRange
+// to be modified after db.db.DeleteRange returns.
+// [start] and [limit] may be modified after this method returns.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	prefixedStart := db.prefix(start)
+	var err error
+	if limit == nil {
+		err = db.db.DeleteRange(prefixedStart, db.dbLimit)
+	} else {
+		prefixedLimit := db.prefix(limit)
+		err = db.db.DeleteRange(prefixedStart, prefixedLimit)
+		db.bufferPool.Put(prefixedLimit)
+	}
+	db.bufferPool.Put(prefixedStart)
+	return err
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{
 		Batch: db.db.NewBatch(),
@@ -165,6 +195,30 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// NewSnapshot returns a snapshot of the underlying database that only exposes
+// the keys of this db. Returns [database.ErrSnapshotNotSupported] if the
+// underlying database doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrSnapshotNotSupported
+	}
+	s, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db:       db,
+		snapshot: s,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -224,10 +278,28 @@ func (db *Database) prefix(key []byte) []byte {
 	return prefixedKey
 }
 
+// prefixLimit returns the smallest key that is larger than every key that
+// begins with [prefix], or nil if there is no such key.
+func prefixLimit(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i]++
+			return limit
+		}
+	}
+	return nil
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, the keys in the range [key, value) are removed.
+	// A nil value is treated as a key after all keys in the database.
+	deleteRange bool
 }
 
 // Batch of database operations
@@ -247,7 +319,7 @@ type batch struct {
 // [value] may not be modified after this method returns.
 func (b *batch) Put(key, value []byte) error {
 	prefixedKey := b.db.prefix(key)
-	b.writes = append(b.writes, keyValue{prefixedKey, value, false})
+	b.writes = append(b.writes, keyValue{key: prefixedKey, value: value})
 	return b.Batch.Put(prefixedKey, value)
 }
 
@@ -256,10 +328,24 @@ func (b *batch) Put(key, value []byte) error {
 // [key] may be modified after this method returns.
 func (b *batch) Delete(key []byte) error {
 	prefixedKey := b.db.prefix(key)
-	b.writes = append(b.writes, keyValue{prefixedKey, nil, true})
+	b.writes = append(b.writes, keyValue{key: prefixedKey, delete: true})
 	return b.Batch.Delete(prefixedKey)
 }
 
+// Assumes that it is OK for the arguments to b.Batch.DeleteRange
+// to be modified after b.Batch.DeleteRange returns
+// [start] and [limit] may be modified after this method returns.
+func (b *batch) DeleteRange(start, limit []byte) error {
+	prefixedStart := b.db.prefix(start)
+	if limit == nil {
+		b.writes = append(b.writes, keyValue{key: prefixedStart, deleteRange: true})
+		return b.Batch.DeleteRange(prefixedStart, b.db.dbLimit)
+	}
+	prefixedLimit := b.db.prefix(limit)
+	b.writes = append(b.writes, keyValue{key: prefixedStart, value: prefixedLimit, deleteRange: true})
+	return b.Batch.DeleteRange(prefixedStart, prefixedLimit)
+}
+
 // Write flushes any accumulated data to the memory database.
 func (b *batch) Write() error {
 	b.db.lock.RLock()
@@ -279,6 +365,9 @@ func (b *batch) Reset() {
 	// value argument to w.Put.
 	for _, kv := range b.writes {
 		b.db.bufferPool.Put(kv.key)
+		if kv.deleteRange && kv.value != nil {
+			b.db.bufferPool.Put(kv.value)
+		}
 	}
 
 	// Clear b.writes
@@ -298,11 +387,20 @@ func (b *batch) Reset() {
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, keyvalue := range b.writes {
 		keyWithoutPrefix := keyvalue.key[len(b.db.dbPrefix):]
-		if keyvalue.delete {
+		switch {
+		case keyvalue.deleteRange:
+			var limitWithoutPrefix []byte
+			if keyvalue.value != nil {
+				limitWithoutPrefix = keyvalue.value[len(b.db.dbPrefix):]
+			}
+			if err := database.ReplayDeleteRange(w, keyWithoutPrefix, limitWithoutPrefix); err != nil {
+				return err
+			}
+		case keyvalue.delete:
 			if err := w.Delete(keyWithoutPrefix); err != nil {
 				return err
 			}
-		} else {
+		default:
 			if err := w.Put(keyWithoutPrefix, keyvalue.value); err != nil {
 				return err
 			}
@@ -311,6 +409,63 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
+type snapshot struct {
+	db       *Database
+	snapshot database.Snapshot
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	has, err := s.snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	val, err := s.snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+func (s *snapshot) Release() { s.snapshot.Release() }
+
 type iterator struct {
 	database.Iterator
 	db *Database
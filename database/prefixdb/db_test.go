@@ -22,6 +22,14 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db := memdb.New()
+		test(t, New([]byte("hello"), db))
+		test(t, NewNested([]byte("wor"), New([]byte("ld"), db)))
+	}
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
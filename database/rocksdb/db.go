@@ -8,7 +8,9 @@ package rocksdb
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"runtime"
 	"sync"
@@ -39,9 +41,11 @@ const (
 var (
 	errFailedToCreateIterator = errors.New("failed to create iterator")
 
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ database.Snapshot    = &snapshot{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iterator{}
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -53,11 +57,28 @@ type Database struct {
 	readOptions     *grocksdb.ReadOptions
 	iteratorOptions *grocksdb.ReadOptions
 	writeOptions    *grocksdb.WriteOptions
+	// snapshots that haven't been released yet. They are released when the
+	// database is closed.
+	snapshots map[*snapshot]struct{}
+}
+
+type config struct {
+	// ReadOnly opens the database without modifying it. Writes fail.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
 }
 
 // New returns a wrapped RocksDB object.
-// TODO: use configBytes to config the database options
-func New(file string, _ []byte, _ logging.Logger, _ string, _ prometheus.Registerer) (database.Database, error) {
+// TODO: use configBytes to config the remaining database options
+func New(file string, configBytes []byte, _ logging.Logger, _ string, _ prometheus.Registerer) (database.Database, error) {
+	var parsedConfig config
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+
 	filter := grocksdb.NewBloomFilter(BitsPerKey)
 
 	blockOptions := grocksdb.NewDefaultBlockBasedTableOptions()
@@ -66,15 +87,22 @@ func New(file string, _ []byte, _ logging.Logger, _ string, _ prometheus.Registe
 	blockOptions.SetFilterPolicy(filter)
 
 	options := grocksdb.NewDefaultOptions()
-	options.SetCreateIfMissing(true)
 	options.OptimizeUniversalStyleCompaction(MemoryBudget)
 	options.SetBlockBasedTableFactory(blockOptions)
 
-	if err := os.MkdirAll(file, perms.ReadWriteExecute); err != nil {
-		return nil, err
+	var (
+		db  *grocksdb.DB
+		err error
+	)
+	if parsedConfig.ReadOnly {
+		db, err = grocksdb.OpenDbForReadOnly(options, file, false)
+	} else {
+		options.SetCreateIfMissing(true)
+		if err := os.MkdirAll(file, perms.ReadWriteExecute); err != nil {
+			return nil, err
+		}
+		db, err = grocksdb.OpenDb(options, file)
 	}
-
-	db, err := grocksdb.OpenDb(options, file)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +115,7 @@ func New(file string, _ []byte, _ logging.Logger, _ string, _ prometheus.Registe
 		readOptions:     grocksdb.NewDefaultReadOptions(),
 		iteratorOptions: iteratorOptions,
 		writeOptions:    grocksdb.NewDefaultWriteOptions(),
+		snapshots:       make(map[*snapshot]struct{}),
 	}, nil
 }
 
@@ -271,6 +300,52 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return nil
 }
 
+// DeleteRange removes all the keys in the range [start, limit) from the
+// database with a single range tombstone.
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		limit = db.upperBound()
+		if limit == nil {
+			// The database is empty.
+			return nil
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+
+	b := grocksdb.NewWriteBatch()
+	defer b.Destroy()
+
+	b.DeleteRange(start, limit)
+	return db.db.Write(db.writeOptions, b)
+}
+
+// upperBound returns the successor of the last key in the database, or nil if
+// the database is empty. rocksdb requires exclusive upper bounds for range
+// deletions, so this is used in place of a nil limit. Assumes the lock is
+// held.
+func (db *Database) upperBound() []byte {
+	it := db.db.NewIterator(db.iteratorOptions)
+	defer it.Close()
+
+	it.SeekToLast()
+	if !it.Valid() {
+		return nil
+	}
+	key := it.Key()
+	defer key.Free()
+
+	return append(utils.CopyBytes(key.Data()), 0)
+}
+
 func (db *Database) Close() error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -279,6 +354,9 @@ func (db *Database) Close() error {
 		return database.ErrClosed
 	}
 
+	for s := range db.snapshots {
+		s.release()
+	}
 	db.readOptions.Destroy()
 	db.iteratorOptions.Destroy()
 	db.writeOptions.Destroy()
@@ -288,6 +366,32 @@ func (db *Database) Close() error {
 	return nil
 }
 
+// NewSnapshot returns a rocksdb snapshot of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+
+	snap := db.db.NewSnapshot()
+	readOptions := grocksdb.NewDefaultReadOptions()
+	readOptions.SetSnapshot(snap)
+	iteratorOptions := grocksdb.NewDefaultReadOptions()
+	iteratorOptions.SetFillCache(false)
+	iteratorOptions.SetSnapshot(snap)
+
+	s := &snapshot{
+		db:              db,
+		snapshot:        snap,
+		readOptions:     readOptions,
+		iteratorOptions: iteratorOptions,
+	}
+	db.snapshots[s] = struct{}{}
+	return s, nil
+}
+
 func (db *Database) isClosed() bool {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -307,10 +411,16 @@ type batch struct {
 	batch *grocksdb.WriteBatch
 	db    *Database
 	size  int
+	// maxKey is the largest key put into the batch. It is used to bound range
+	// deletions with a nil limit.
+	maxKey []byte
 }
 
 // Put the value into the batch for later writing
 func (b *batch) Put(key, value []byte) error {
+	if bytes.Compare(key, b.maxKey) > 0 {
+		b.maxKey = utils.CopyBytes(key)
+	}
 	b.batch.Put(key, value)
 	b.size += len(key) + len(value) + rocksDBByteOverhead
 	return nil
@@ -323,6 +433,38 @@ func (b *batch) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange queues a range tombstone for the keys in [start, limit). A nil
+// limit is resolved to the successor of the largest key that is currently
+// either in the database or in the batch.
+func (b *batch) DeleteRange(start []byte, limit []byte) error {
+	if limit == nil {
+		b.db.lock.RLock()
+		if b.db.db == nil {
+			b.db.lock.RUnlock()
+			return database.ErrClosed
+		}
+		dbLimit := b.db.upperBound()
+		b.db.lock.RUnlock()
+
+		if b.maxKey != nil {
+			limit = append(utils.CopyBytes(b.maxKey), 0)
+		}
+		if bytes.Compare(dbLimit, limit) > 0 {
+			limit = dbLimit
+		}
+		if limit == nil {
+			// Neither the database nor the batch contain any keys.
+			return nil
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	b.batch.DeleteRange(start, limit)
+	b.size += len(start) + len(limit) + rocksDBByteOverhead
+	return nil
+}
+
 // Size retrieves the amount of data queued up for writing.
 func (b *batch) Size() int { return b.size }
 
@@ -342,6 +484,7 @@ func (b *batch) Write() error {
 func (b *batch) Reset() {
 	b.batch.Clear()
 	b.size = 0
+	b.maxKey = nil
 }
 
 // Replay the batch contents.
@@ -360,11 +503,120 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 			if err := w.Put(rec.Key, rec.Value); err != nil {
 				return err
 			}
+		case grocksdb.WriteBatchRangeDeletion:
+			if err := database.ReplayDeleteRange(w, rec.Key, rec.Value); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// snapshot is a wrapper around a rocksdb snapshot. The snapshot is held by the
+// read options that are used for reads.
+type snapshot struct {
+	db              *Database
+	snapshot        *grocksdb.Snapshot
+	readOptions     *grocksdb.ReadOptions
+	iteratorOptions *grocksdb.ReadOptions
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	_, err := s.Get(key)
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.db == nil || s.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+
+	value, err := s.db.db.GetBytes(s.readOptions, key)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		return value, nil
+	}
+	return nil, database.ErrNotFound
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.db == nil || s.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+
+	it := s.db.db.NewIterator(s.iteratorOptions)
+	if it == nil {
+		return &nodb.Iterator{Err: errFailedToCreateIterator}
+	}
+	if bytes.Compare(start, prefix) == 1 {
+		it.Seek(start)
+	} else {
+		it.Seek(prefix)
+	}
+	return &iterator{
+		it:     it,
+		db:     s.db,
+		prefix: prefix,
+	}
+}
+
+// Release the snapshot
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	if s.db.db != nil && s.snapshot != nil {
+		s.release()
+	}
+}
+
+// release assumes that [s.db.lock] is held and that neither the database nor
+// the snapshot were released.
+func (s *snapshot) release() {
+	s.readOptions.Destroy()
+	s.iteratorOptions.Destroy()
+	s.db.db.ReleaseSnapshot(s.snapshot)
+	s.snapshot = nil
+	delete(s.db.snapshots, s)
+}
+
 type iterator struct {
 	it      *grocksdb.Iterator
 	db      *Database
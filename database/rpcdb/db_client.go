@@ -28,9 +28,11 @@ const (
 )
 
 var (
-	_ database.Database = &DatabaseClient{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database    = &DatabaseClient{}
+	_ database.Snapshotter = &DatabaseClient{}
+	_ database.Snapshot    = &snapshot{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iterator{}
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -91,6 +93,23 @@ func (db *DatabaseClient) Delete(key []byte) error {
 	return errCodeToError[resp.Err]
 }
 
+// DeleteRange attempts to remove all the keys in the range [start, limit)
+func (db *DatabaseClient) DeleteRange(start, limit []byte) error {
+	resp, err := db.client.DeleteRange(context.Background(), newDeleteRangeRequest(start, limit))
+	if err != nil {
+		return err
+	}
+	return errCodeToError[resp.Err]
+}
+
+func newDeleteRangeRequest(start, limit []byte) *rpcdbpb.DeleteRangeRequest {
+	return &rpcdbpb.DeleteRangeRequest{
+		Start:   start,
+		Limit:   limit,
+		NoLimit: limit == nil,
+	}
+}
+
 // NewBatch returns a new batch
 func (db *DatabaseClient) NewBatch() database.Batch { return &batch{db: db} }
 
@@ -121,6 +140,21 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	}
 }
 
+// NewSnapshot returns a snapshot of the remote database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
@@ -158,8 +192,15 @@ type keyValue struct {
 	delete bool
 }
 
+type keyRange struct {
+	start, limit []byte
+}
+
 type batch struct {
-	db     *DatabaseClient
+	db *DatabaseClient
+	// ranges are written before [writes]. Writes that happened before a range
+	// deletion that covers them are removed from [writes].
+	ranges []keyRange
 	writes []keyValue
 	size   int
 }
@@ -176,6 +217,22 @@ func (b *batch) Delete(key []byte) error {
 	return nil
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	writes := b.writes[:0]
+	for _, kv := range b.writes {
+		if !database.InRange(kv.key, start, limit) {
+			writes = append(writes, kv)
+		}
+	}
+	b.writes = writes
+	b.ranges = append(b.ranges, keyRange{
+		start: utils.CopyBytes(start),
+		limit: utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *batch) Size() int { return b.size }
 
 func (b *batch) Write() error {
@@ -184,6 +241,10 @@ func (b *batch) Write() error {
 		Continues: true,
 	}
 	currentSize := 0
+	for _, r := range b.ranges {
+		request.DeleteRanges = append(request.DeleteRanges, newDeleteRangeRequest(r.start, r.limit))
+		currentSize += baseElementSize + len(r.start) + len(r.limit)
+	}
 	keySet := make(map[string]struct{}, len(b.writes))
 	for i := len(b.writes) - 1; i >= 0; i-- {
 		kv := b.writes[i]
@@ -203,6 +264,7 @@ func (b *batch) Write() error {
 				return err
 			}
 			currentSize = 0
+			request.DeleteRanges = nil
 			request.Deletes = request.Deletes[:0]
 			request.Puts = request.Puts[:0]
 		}
@@ -229,6 +291,7 @@ func (b *batch) Write() error {
 }
 
 func (b *batch) Reset() {
+	b.ranges = nil
 	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
 		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
 	} else {
@@ -238,6 +301,11 @@ func (b *batch) Reset() {
 }
 
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, r := range b.ranges {
+		if err := database.ReplayDeleteRange(w, r.start, r.limit); err != nil {
+			return err
+		}
+	}
 	for _, keyvalue := range b.writes {
 		if keyvalue.delete {
 			if err := w.Delete(keyvalue.key); err != nil {
@@ -252,6 +320,90 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 
 func (b *batch) Inner() database.Batch { return b }
 
+type snapshot struct {
+	db       *DatabaseClient
+	id       uint64
+	released utils.AtomicBool
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.released.GetValue() {
+		return false, database.ErrClosed
+	}
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbpb.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, errCodeToError[resp.Err]
+}
+
+// Get attempts to return the value that was mapped to the key when the
+// snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.released.GetValue() {
+		return nil, database.ErrClosed
+	}
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbpb.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, errCodeToError[resp.Err]
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns a new iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.released.GetValue() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	resp, err := s.db.client.SnapshotNewIterator(context.Background(), &rpcdbpb.SnapshotNewIteratorRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// Release frees the snapshot on the remote database
+func (s *snapshot) Release() {
+	if s.released.GetValue() {
+		return
+	}
+	s.released.SetValue(true)
+	// The snapshot can't be used anymore, so there is nothing to do with a
+	// failure to release it remotely.
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbpb.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
+
 type iterator struct {
 	db *DatabaseClient
 	id uint64
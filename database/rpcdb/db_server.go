@@ -39,6 +39,12 @@ type DatabaseServer struct {
 	iteratorLock   sync.RWMutex
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	// snapshotLock protects [nextSnapshotID] and [snapshots] from concurrent
+	// modifications. Snapshots are safe for concurrent use.
+	snapshotLock   sync.RWMutex
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -47,6 +53,7 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batches:   make(map[int64]database.Batch),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -81,6 +88,26 @@ func (db *DatabaseServer) Delete(_ context.Context, req *rpcdbpb.DeleteRequest)
 	return &rpcdbpb.DeleteResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
 }
 
+// DeleteRange delegates the DeleteRange call to the managed database and
+// returns the result
+func (db *DatabaseServer) DeleteRange(_ context.Context, req *rpcdbpb.DeleteRangeRequest) (*rpcdbpb.DeleteRangeResponse, error) {
+	err := db.db.DeleteRange(req.Start, rangeLimit(req))
+	return &rpcdbpb.DeleteRangeResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+}
+
+// rangeLimit returns the limit of the range deletion [req].
+func rangeLimit(req *rpcdbpb.DeleteRangeRequest) []byte {
+	if req.NoLimit {
+		return nil
+	}
+	if req.Limit == nil {
+		// An empty limit isn't distinguishable from a nil limit over the
+		// wire.
+		return []byte{}
+	}
+	return req.Limit
+}
+
 // Compact delegates the Compact call to the managed database and returns the
 // result
 func (db *DatabaseServer) Compact(_ context.Context, req *rpcdbpb.CompactRequest) (*rpcdbpb.CompactResponse, error) {
@@ -118,6 +145,15 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbpb.WriteBatchR
 	}
 	db.batchLock.Unlock()
 
+	for _, deleteRange := range req.DeleteRanges {
+		if err := batch.DeleteRange(deleteRange.Start, rangeLimit(deleteRange)); err != nil {
+			// Because we are reporting an error, we free the allocated batch.
+			delete(db.batches, req.Id)
+
+			return &rpcdbpb.WriteBatchResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+		}
+	}
+
 	for _, put := range req.Puts {
 		if err := batch.Put(put.Key, put.Value); err != nil {
 			// Because we are reporting an error, we free the allocated batch.
@@ -152,14 +188,18 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbpb.WriteBatchR
 // ID
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbpb.NewIteratorWithStartAndPrefixRequest) (*rpcdbpb.NewIteratorWithStartAndPrefixResponse, error) {
 	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: db.addIterator(it)}, nil
+}
 
+// addIterator allocates an ID for [it]
+func (db *DatabaseServer) addIterator(it database.Iterator) uint64 {
 	db.iteratorLock.Lock()
 	defer db.iteratorLock.Unlock()
 
 	id := db.nextIteratorID
 	db.iterators[id] = it
 	db.nextIteratorID++
-	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+	return id
 }
 
 // IteratorNext attempts to call next on the requested iterator
@@ -214,3 +254,88 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbpb.Iterat
 	it.Release()
 	return &rpcdbpb.IteratorReleaseResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
 }
+
+// NewSnapshot allocates a snapshot of the managed database and returns the
+// snapshot ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *emptypb.Empty) (*rpcdbpb.NewSnapshotResponse, error) {
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		err := database.ErrSnapshotNotSupported
+		return &rpcdbpb.NewSnapshotResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	snapshot, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return &rpcdbpb.NewSnapshotResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	db.snapshotLock.Lock()
+	defer db.snapshotLock.Unlock()
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+	db.nextSnapshotID++
+	return &rpcdbpb.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas delegates the Has call to the requested snapshot. A snapshot
+// that was already released reports [database.ErrClosed].
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbpb.SnapshotHasRequest) (*rpcdbpb.HasResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.HasResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	has, err := snapshot.Has(req.Key)
+	return &rpcdbpb.HasResponse{
+		Has: has,
+		Err: errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotGet delegates the Get call to the requested snapshot. A snapshot
+// that was already released reports [database.ErrClosed].
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbpb.SnapshotGetRequest) (*rpcdbpb.GetResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.GetResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	value, err := snapshot.Get(req.Key)
+	return &rpcdbpb.GetResponse{
+		Value: value,
+		Err:   errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotNewIterator allocates an iterator over the requested snapshot and
+// returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIterator(_ context.Context, req *rpcdbpb.SnapshotNewIteratorRequest) (*rpcdbpb.SnapshotNewIteratorResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.SnapshotNewIteratorResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	it := snapshot.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	return &rpcdbpb.SnapshotNewIteratorResponse{Id: db.addIterator(it)}, nil
+}
+
+// SnapshotRelease releases the requested snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbpb.SnapshotReleaseRequest) (*emptypb.Empty, error) {
+	db.snapshotLock.Lock()
+	snapshot, exists := db.snapshots[req.Id]
+	delete(db.snapshots, req.Id)
+	db.snapshotLock.Unlock()
+
+	if exists {
+		snapshot.Release()
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (db *DatabaseServer) getSnapshot(id uint64) (database.Snapshot, error) {
+	db.snapshotLock.RLock()
+	defer db.snapshotLock.RUnlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+	return snapshot, nil
+}
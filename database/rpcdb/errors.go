@@ -11,10 +11,12 @@ var (
 	errCodeToError = map[uint32]error{
 		1: database.ErrClosed,
 		2: database.ErrNotFound,
+		3: database.ErrSnapshotNotSupported,
 	}
 	errorToErrCode = map[error]uint32{
-		database.ErrClosed:   1,
-		database.ErrNotFound: 2,
+		database.ErrClosed:               1,
+		database.ErrNotFound:             2,
+		database.ErrSnapshotNotSupported: 3,
 	}
 )
 
@@ -40,6 +40,18 @@ var Tests = []func(t *testing.T, db Database){
 	TestMemorySafetyBatch,
 	TestClear,
 	TestClearPrefix,
+	TestDeleteRange,
+	TestBatchDeleteRange,
+	TestBatchReplayDeleteRange,
+}
+
+// SnapshotTests is a list of all the tests of databases that implement
+// [Snapshotter]
+var SnapshotTests = []func(t *testing.T, db Database){
+	TestSnapshot,
+	TestSnapshotIterator,
+	TestSnapshotRelease,
+	TestSnapshotClosed,
 }
 
 // TestSimpleKeyValue tests to make sure that simple Put + Get + Delete + Has
@@ -1169,3 +1181,321 @@ func TestClearPrefix(t *testing.T, db Database) {
 	err = db.Close()
 	assert.NoError(err)
 }
+
+// TestDeleteRange tests to make sure range deletion works as expected.
+func TestDeleteRange(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	keys := [][]byte{
+		[]byte("a"),
+		[]byte("b"),
+		[]byte("b1"),
+		[]byte("c"),
+		[]byte("d"),
+	}
+	for _, key := range keys {
+		assert.NoError(db.Put(key, key))
+	}
+
+	// An empty range doesn't delete anything.
+	assert.NoError(db.DeleteRange([]byte("c"), []byte("c")))
+	count, err := Count(db)
+	assert.NoError(err)
+	assert.Equal(5, count)
+
+	// The limit is exclusive.
+	assert.NoError(db.DeleteRange([]byte("b"), []byte("c")))
+	for i, key := range keys {
+		has, err := db.Has(key)
+		assert.NoError(err)
+		assert.Equal(i != 1 && i != 2, has, "unexpected result for key %s", key)
+	}
+
+	// A nil start is before all keys.
+	assert.NoError(db.DeleteRange(nil, []byte("c")))
+	has, err := db.Has(keys[0])
+	assert.NoError(err)
+	assert.False(has)
+
+	// A nil limit is after all keys.
+	assert.NoError(db.DeleteRange([]byte("c"), nil))
+	isEmpty, err := IsEmpty(db)
+	assert.NoError(err)
+	assert.True(isEmpty)
+
+	// Deleting from an empty database succeeds.
+	assert.NoError(db.DeleteRange(nil, nil))
+
+	assert.NoError(db.Close())
+	assert.Equal(ErrClosed, db.DeleteRange(nil, nil))
+}
+
+// TestBatchDeleteRange tests to make sure that range deletions in a batch
+// remove the keys of the database and the keys that were put into the batch
+// before them, but not the keys that were put into the batch after them.
+func TestBatchDeleteRange(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	key3 := []byte("hello3")
+	key4 := []byte("hello4")
+	key5 := []byte("z")
+
+	assert.NoError(db.Put(key1, key1))
+	assert.NoError(db.Put(key2, key2))
+
+	batch := db.NewBatch()
+	assert.NoError(batch.Put(key3, key3))
+	assert.NoError(batch.DeleteRange(key2, key5))
+	assert.NoError(batch.Put(key4, key4))
+	assert.Positive(batch.Size())
+
+	// The batch isn't applied before it is written.
+	has, err := db.Has(key2)
+	assert.NoError(err)
+	assert.True(has)
+
+	assert.NoError(batch.Write())
+
+	for key, expected := range map[string]bool{
+		string(key1): true,
+		string(key2): false,
+		string(key3): false,
+		string(key4): true,
+	} {
+		has, err := db.Has([]byte(key))
+		assert.NoError(err)
+		assert.Equal(expected, has, "unexpected result for key %s", key)
+	}
+
+	// A range without a limit removes the keys put into the batch that are
+	// larger than every key of the database.
+	batch.Reset()
+	assert.NoError(batch.Put(key5, key5))
+	assert.NoError(batch.DeleteRange(key2, nil))
+	assert.NoError(batch.Write())
+
+	count, err := Count(db)
+	assert.NoError(err)
+	assert.Equal(1, count)
+
+	assert.NoError(db.Close())
+}
+
+// TestBatchReplayDeleteRange tests to make sure that replaying a batch with
+// range deletions has the same effect as writing it.
+func TestBatchReplayDeleteRange(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	key3 := []byte("hello3")
+
+	assert.NoError(db.Put(key1, key1))
+
+	batch := db.NewBatch()
+	assert.NoError(batch.Put(key2, key2))
+	assert.NoError(batch.DeleteRange(nil, key3))
+	assert.NoError(batch.Put(key3, key3))
+
+	replayBatch := db.NewBatch()
+	assert.NoError(batch.Replay(replayBatch))
+	assert.NoError(replayBatch.Write())
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.Equal(key3, iterator.Value())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+}
+
+func newSnapshot(t *testing.T, db Database) Snapshot {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		t.Fatalf("%T doesn't implement Snapshotter", db)
+	}
+	snapshot, err := snapshotter.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	return snapshot
+}
+
+// TestSnapshot tests to make sure that a snapshot isn't affected by the writes
+// that happen after it was taken.
+func TestSnapshot(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	assert.NoError(db.Put(key1, value1))
+	assert.NoError(db.Put(key2, value2))
+
+	snapshot := newSnapshot(t, db)
+	defer snapshot.Release()
+
+	assert.NoError(db.Put(key1, value2))
+	assert.NoError(db.Delete(key2))
+	assert.NoError(db.Put(key3, value3))
+
+	value, err := snapshot.Get(key1)
+	assert.NoError(err)
+	assert.Equal(value1, value)
+
+	value, err = snapshot.Get(key2)
+	assert.NoError(err)
+	assert.Equal(value2, value)
+
+	_, err = snapshot.Get(key3)
+	assert.Equal(ErrNotFound, err)
+
+	has, err := snapshot.Has(key2)
+	assert.NoError(err)
+	assert.True(has)
+
+	has, err = snapshot.Has(key3)
+	assert.NoError(err)
+	assert.False(has)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.Equal(value1, iterator.Value())
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+
+	// The database itself reflects the later writes.
+	value, err = db.Get(key1)
+	assert.NoError(err)
+	assert.Equal(value2, value)
+}
+
+// TestSnapshotIterator tests to make sure that the iterators of a snapshot
+// respect their start and prefix.
+func TestSnapshotIterator(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("goodbye")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	assert.NoError(db.Put(key1, value1))
+	assert.NoError(db.Put(key2, value2))
+	assert.NoError(db.Put(key3, value3))
+
+	snapshot := newSnapshot(t, db)
+	defer snapshot.Release()
+
+	assert.NoError(db.Delete(key3))
+
+	iterator := snapshot.NewIteratorWithStart(key1)
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.Equal(value3, iterator.Value())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+	iterator.Release()
+
+	iterator = snapshot.NewIteratorWithPrefix([]byte("h"))
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+	iterator.Release()
+
+	iterator = snapshot.NewIteratorWithStartAndPrefix(key3, []byte("h"))
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+	iterator.Release()
+}
+
+// TestSnapshotRelease tests to make sure that a released snapshot can't be
+// read.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	assert.NoError(db.Put(key, value))
+
+	snapshot := newSnapshot(t, db)
+	snapshot.Release()
+	// Releasing a snapshot multiple times is allowed.
+	snapshot.Release()
+
+	_, err := snapshot.Has(key)
+	assert.Equal(ErrClosed, err)
+
+	_, err = snapshot.Get(key)
+	assert.Equal(ErrClosed, err)
+
+	iterator := snapshot.NewIterator()
+	assert.False(iterator.Next())
+	assert.Equal(ErrClosed, iterator.Error())
+	iterator.Release()
+
+	// The database is still usable.
+	has, err := db.Has(key)
+	assert.NoError(err)
+	assert.True(has)
+}
+
+// TestSnapshotClosed tests to make sure that a snapshot can't be read after
+// its database was closed.
+func TestSnapshotClosed(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	assert.NoError(db.Put(key, value))
+
+	snapshot := newSnapshot(t, db)
+	defer snapshot.Release()
+
+	assert.NoError(db.Close())
+
+	_, err := snapshot.Has(key)
+	assert.Equal(ErrClosed, err)
+
+	_, err = snapshot.Get(key)
+	assert.Equal(ErrClosed, err)
+
+	iterator := snapshot.NewIterator()
+	assert.False(iterator.Next())
+	assert.Equal(ErrClosed, iterator.Error())
+	iterator.Release()
+
+	if snapshotter, ok := db.(Snapshotter); ok {
+		_, err = snapshotter.NewSnapshot()
+		assert.Equal(ErrClosed, err)
+	}
+}
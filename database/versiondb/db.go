@@ -19,10 +19,13 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ Commitable        = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database    = &Database{}
+	_ database.Snapshotter = &Database{}
+	_ Commitable           = &Database{}
+	_ database.Snapshot    = &snapshot{}
+	_ database.Batch       = &batch{}
+	_ database.Iterator    = &iterator{}
+	_ database.Iterator    = &rangesIterator{}
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -36,10 +39,13 @@ type Commitable interface {
 // database, writing changes to the underlying database only when commit is
 // called.
 type Database struct {
-	lock  sync.RWMutex
-	mem   map[string]valueDelete
-	db    database.Database
-	batch database.Batch
+	lock sync.RWMutex
+	mem  map[string]valueDelete
+	// ranges are the uncommitted range deletions. Keys in [mem] take
+	// precedence over them.
+	ranges []keyRange
+	db     database.Database
+	batch  database.Batch
 }
 
 type valueDelete struct {
@@ -47,6 +53,22 @@ type valueDelete struct {
 	delete bool
 }
 
+// keyRange is the range of keys [start, limit). A nil limit is treated as a
+// key after all keys.
+type keyRange struct {
+	start, limit []byte
+}
+
+// deletedByRange returns true if [key] is in one of [ranges].
+func deletedByRange(ranges []keyRange, key []byte) bool {
+	for _, r := range ranges {
+		if database.InRange(key, r.start, r.limit) {
+			return true
+		}
+	}
+	return false
+}
+
 // New returns a new versioned database
 func New(db database.Database) *Database {
 	return &Database{
@@ -66,6 +88,9 @@ func (db *Database) Has(key []byte) (bool, error) {
 	if val, has := db.mem[string(key)]; has {
 		return !val.delete, nil
 	}
+	if deletedByRange(db.ranges, key) {
+		return false, nil
+	}
 	return db.db.Has(key)
 }
 
@@ -82,6 +107,9 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 		}
 		return utils.CopyBytes(val.value), nil
 	}
+	if deletedByRange(db.ranges, key) {
+		return nil, database.ErrNotFound
+	}
 	return db.db.Get(key)
 }
 
@@ -107,6 +135,32 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange removes the keys in the range [start, limit). The range is
+// written to the underlying database as a single range deletion on commit.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+	db.deleteRange(start, limit)
+	return nil
+}
+
+// deleteRange assumes that the lock is held.
+func (db *Database) deleteRange(start, limit []byte) {
+	for key := range db.mem {
+		if database.InRange([]byte(key), start, limit) {
+			delete(db.mem, key)
+		}
+	}
+	db.ranges = append(db.ranges, keyRange{
+		start: utils.CopyBytes(start),
+		limit: utils.CopyBytes(limit),
+	})
+}
+
 func (db *Database) NewBatch() database.Batch { return &batch{db: db} }
 
 func (db *Database) NewIterator() database.Iterator {
@@ -128,27 +182,42 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(db, db.mem, db.ranges, db.db, start, prefix)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
+// NewSnapshot returns a snapshot of the uncommitted operations of this
+// database on top of a snapshot of the underlying database. Returns
+// [database.ErrSnapshotNotSupported] if the underlying database doesn't support
+// snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrSnapshotNotSupported
 	}
-
-	return &iterator{
-		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+	// Holding [db.lock] prevents a commit from happening between taking the
+	// snapshot and copying the uncommitted operations.
+	s, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return nil, err
 	}
+	mem := make(map[string]valueDelete, len(db.mem))
+	for key, value := range db.mem {
+		mem[key] = value
+	}
+	ranges := make([]keyRange, len(db.ranges))
+	copy(ranges, db.ranges)
+	return &snapshot{
+		db:       db,
+		mem:      mem,
+		ranges:   ranges,
+		snapshot: s,
+	}, nil
 }
 
 func (db *Database) Compact(start, limit []byte) error {
@@ -209,6 +278,8 @@ func (db *Database) Abort() {
 }
 
 func (db *Database) abort() {
+	db.ranges = nil
+
 	// If there are a lot of keys, clear the map by just allocating a new one
 	if len(db.mem) > iterativeDeleteThreshold {
 		db.mem = make(map[string]valueDelete, memdb.DefaultSize)
@@ -220,7 +291,8 @@ func (db *Database) abort() {
 	}
 }
 
-// CommitBatch returns a batch that contains all uncommitted puts/deletes.
+// CommitBatch returns a batch that contains all uncommitted puts/deletes/range
+// deletions.
 // Calling Write() on the returned batch causes the puts/deletes to be
 // written to the underlying database. The returned batch should be written before
 // future calls to this DB unless the batch will never be written.
@@ -239,6 +311,13 @@ func (db *Database) commitBatch() (database.Batch, error) {
 	}
 
 	db.batch.Reset()
+	// Range deletions are written first, because keys in [db.mem] take
+	// precedence over them.
+	for _, r := range db.ranges {
+		if err := db.batch.DeleteRange(r.start, r.limit); err != nil {
+			return nil, err
+		}
+	}
 	for key, value := range db.mem {
 		if value.delete {
 			if err := db.batch.Delete([]byte(key)); err != nil {
@@ -261,6 +340,7 @@ func (db *Database) Close() error {
 	}
 	db.batch = nil
 	db.mem = nil
+	db.ranges = nil
 	db.db = nil
 	return nil
 }
@@ -286,6 +366,10 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, the keys in the range [key, limit) are removed.
+	deleteRange bool
+	limit       []byte
 }
 
 type batch struct {
@@ -295,17 +379,27 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), value: utils.CopyBytes(value)})
 	b.size += len(key) + len(value)
 	return nil
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), delete: true})
 	b.size += len(key)
 	return nil
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *batch) Size() int { return b.size }
 
 func (b *batch) Write() error {
@@ -317,6 +411,10 @@ func (b *batch) Write() error {
 	}
 
 	for _, kv := range b.writes {
+		if kv.deleteRange {
+			b.db.deleteRange(kv.key, kv.limit)
+			continue
+		}
 		b.db.mem[string(kv.key)] = valueDelete{
 			value:  kv.value,
 			delete: kv.delete,
@@ -336,12 +434,19 @@ func (b *batch) Reset() {
 
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, kv := range b.writes {
-		if kv.delete {
+		switch {
+		case kv.deleteRange:
+			if err := database.ReplayDeleteRange(w, kv.key, kv.limit); err != nil {
+				return err
+			}
+		case kv.delete:
 			if err := w.Delete(kv.key); err != nil {
 				return err
 			}
-		} else if err := w.Put(kv.key, kv.value); err != nil {
-			return err
+		default:
+			if err := w.Put(kv.key, kv.value); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -350,6 +455,138 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// snapshot is a copy of the uncommitted operations of a database and a
+// snapshot of its underlying database.
+type snapshot struct {
+	db *Database
+
+	lock     sync.RWMutex
+	mem      map[string]valueDelete
+	ranges   []keyRange
+	snapshot database.Snapshot
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	if deletedByRange(s.ranges, key) {
+		return false, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	if deletedByRange(s.ranges, key) {
+		return nil, database.ErrNotFound
+	}
+	return s.snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.db, s.mem, s.ranges, s.snapshot, start, prefix)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.mem = nil
+	s.ranges = nil
+	s.snapshot.Release()
+}
+
+// newIterator returns an iterator over the operations in [mem] on top of the
+// key/value pairs of [db] that start at [start], have the prefix [prefix] and
+// aren't in [ranges].
+func newIterator(
+	versionDB *Database,
+	mem map[string]valueDelete,
+	ranges []keyRange,
+	db database.Iteratee,
+	start, prefix []byte,
+) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) && key >= startString {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) // Keys need to be in sorted order
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+
+	var it database.Iterator = db.NewIteratorWithStartAndPrefix(start, prefix)
+	if len(ranges) > 0 {
+		it = &rangesIterator{
+			Iterator: it,
+			ranges:   append([]keyRange(nil), ranges...),
+		}
+	}
+	return &iterator{
+		db:       versionDB,
+		Iterator: it,
+		keys:     keys,
+		values:   values,
+	}
+}
+
+// rangesIterator skips the keys of the wrapped iterator that were removed by
+// uncommitted range deletions.
+type rangesIterator struct {
+	database.Iterator
+	ranges []keyRange
+}
+
+func (it *rangesIterator) Next() bool {
+	for it.Iterator.Next() {
+		if !deletedByRange(it.ranges, it.Iterator.Key()) {
+			return true
+		}
+	}
+	return false
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
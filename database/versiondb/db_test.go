@@ -7,6 +7,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
 )
@@ -18,6 +20,111 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		test(t, New(baseDB))
+	}
+}
+
+func TestSnapshotUncommitted(t *testing.T) {
+	assert := assert.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	assert.NoError(db.Put(key1, value1))
+	assert.NoError(db.Commit())
+	assert.NoError(db.Put(key2, value2))
+	assert.NoError(db.Delete(key1))
+
+	snapshot, err := db.NewSnapshot()
+	assert.NoError(err)
+	defer snapshot.Release()
+
+	// Committing and aborting after the snapshot was taken doesn't change it.
+	assert.NoError(db.Commit())
+	assert.NoError(db.Put(key1, value2))
+	db.Abort()
+	assert.NoError(baseDB.Put(key1, value2))
+
+	has, err := snapshot.Has(key1)
+	assert.NoError(err)
+	assert.False(has)
+
+	value, err := snapshot.Get(key2)
+	assert.NoError(err)
+	assert.Equal(value2, value)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+}
+
+func TestDeleteRangeUncommitted(t *testing.T) {
+	assert := assert.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	key3 := []byte("hello3")
+	value := []byte("world")
+
+	assert.NoError(baseDB.Put(key1, value))
+	assert.NoError(baseDB.Put(key2, value))
+	assert.NoError(db.Put(key3, value))
+
+	assert.NoError(db.DeleteRange(key2, nil))
+	assert.NoError(db.Put(key2, value))
+
+	// The range deletion isn't written to the underlying database before the
+	// commit.
+	has, err := baseDB.Has(key2)
+	assert.NoError(err)
+	assert.True(has)
+
+	has, err = db.Has(key3)
+	assert.NoError(err)
+	assert.False(has)
+
+	iterator := db.NewIterator()
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+	iterator.Release()
+
+	// Keys written after the range deletion take precedence over it.
+	assert.NoError(db.Commit())
+
+	count, err := database.Count(baseDB)
+	assert.NoError(err)
+	assert.Equal(2, count)
+
+	// Aborting drops the range deletions.
+	assert.NoError(db.DeleteRange(nil, nil))
+	db.Abort()
+
+	has, err = db.Has(key1)
+	assert.NoError(err)
+	assert.True(has)
+}
+
 func TestIterate(t *testing.T) {
 	baseDB := memdb.New()
 	db := New(baseDB)
@@ -0,0 +1,321 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer/export"
+	"github.com/sankar-boro/axia-network-v2/utils/constants"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/math"
+)
+
+const (
+	// DefaultExportPartitionSize is the number of heights covered by each
+	// export file, if not given
+	DefaultExportPartitionSize = 100000
+	// How often the progress of an export is logged
+	exportLogFrequency = 30 * time.Second
+)
+
+var (
+	errExportDisabled     = errors.New("exports are disabled as no export directory is configured")
+	errAlreadyExported    = errors.New("chain is already being exported")
+	errExportReindexing   = errors.New("chain can't be exported while it's reindexed")
+	errExportUnsupported  = errors.New("only the txs of the platform chain and AVM chains can be exported")
+	errExportStopped      = errors.New("export stopped before it finished")
+	errInvalidExportRange = errors.New("start index is greater than end index")
+)
+
+// Exporter writes the txs accepted by chains to files, so that the history of
+// a chain can be loaded into other tools without fetching it through the API.
+type Exporter interface {
+	// Export starts writing the txs accepted by a chain to files in the
+	// background.
+	Export(config ExportConfig) error
+	// ExportStatuses returns the progress of the exports started since the
+	// node started.
+	ExportStatuses() []ExportStatus
+}
+
+// ExportConfig describes the txs that are exported and the files they're
+// written to
+type ExportConfig struct {
+	// ID or alias of the chain whose txs are exported
+	Chain string
+	// Formats of the files the txs are written to. If empty, the txs are
+	// written to files of every format.
+	Formats []export.Format
+	// Number of heights covered by each file. The txs of AVM chains use the
+	// index they were accepted at as their height. If 0,
+	// DefaultExportPartitionSize is used.
+	PartitionSize uint64
+	// Encoding of the bytes of the txs
+	Encoding formatting.Encoding
+	// Index of the first container whose txs are exported. Containers that
+	// were pruned aren't exported.
+	StartIndex uint64
+	// Index of the last container whose txs are exported. If 0, the
+	// containers up to the last one accepted when the export starts are
+	// exported.
+	EndIndex uint64
+}
+
+// ExportStatus is the progress of writing the txs of a chain to files
+type ExportStatus struct {
+	Chain   string `json:"chain"`
+	ChainID ids.ID `json:"chainID"`
+	// True until every tx is written
+	Active bool `json:"active"`
+	// Directory the files are written to
+	Dir string `json:"dir"`
+	// Number of txs written
+	Exported json.Uint64 `json:"exported"`
+	// Index of the next container whose txs are written
+	NextIndex json.Uint64 `json:"nextIndex"`
+	EndIndex  json.Uint64 `json:"endIndex"`
+	// Paths of the files that were written
+	Files     []string  `json:"files"`
+	StartTime time.Time `json:"startTime"`
+	// Why the export failed, if it did
+	Error string `json:"error,omitempty"`
+}
+
+type indexExport struct {
+	log     logging.Logger
+	name    string
+	chainID ids.ID
+	dir     string
+	index   Index
+	decoder export.Decoder
+	writer  *export.Writer
+
+	// Closed to stop the export
+	stop chan struct{}
+	// Closed when the export stops
+	stopped chan struct{}
+
+	lock        sync.RWMutex
+	startTime   time.Time
+	active      bool
+	nextIndex   uint64
+	endIndex    uint64
+	numExported uint64
+	files       []string
+	err         error
+}
+
+func (e *indexExport) run() {
+	defer close(e.stopped)
+
+	err := e.export()
+	if closeErr := e.writer.Close(); err == nil {
+		err = closeErr
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.active = false
+	e.files = append([]string(nil), e.writer.Files()...)
+	if err != nil {
+		e.log.Error("couldn't export chain %s: %s", e.name, err)
+		e.err = err
+		return
+	}
+	e.log.Info("exported %d txs of chain %s to %s in %s", e.numExported, e.name, e.dir, time.Since(e.startTime))
+}
+
+func (e *indexExport) export() error {
+	lastLog := e.startTime
+	for e.nextIndex <= e.endIndex {
+		select {
+		case <-e.stop:
+			return errExportStopped
+		default:
+		}
+
+		numToFetch := math.Min64(MaxFetchedByRange, e.endIndex-e.nextIndex+1)
+		containers, err := e.index.GetContainerRange(e.nextIndex, numToFetch)
+		if err != nil {
+			return err
+		}
+		numExported := uint64(0)
+		for j, container := range containers {
+			txs, err := e.decoder.Decode(export.Container{
+				Index:     e.nextIndex + uint64(j),
+				ID:        container.ID,
+				Bytes:     container.Bytes,
+				Timestamp: container.Timestamp,
+			})
+			if err != nil {
+				return err
+			}
+			for _, tx := range txs {
+				if err := e.writer.Write(tx); err != nil {
+					return err
+				}
+			}
+			numExported += uint64(len(txs))
+		}
+
+		e.lock.Lock()
+		e.nextIndex += uint64(len(containers))
+		e.numExported += numExported
+		e.files = append([]string(nil), e.writer.Files()...)
+		e.lock.Unlock()
+
+		if time.Since(lastLog) >= exportLogFrequency {
+			lastLog = time.Now()
+			e.log.Info("exported %d txs of chain %s, up to index %d of %d", e.numExported, e.name, e.nextIndex, e.endIndex)
+		}
+	}
+	return nil
+}
+
+func (e *indexExport) status() ExportStatus {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	status := ExportStatus{
+		Chain:     e.name,
+		ChainID:   e.chainID,
+		Active:    e.active,
+		Dir:       e.dir,
+		Exported:  json.Uint64(e.numExported),
+		NextIndex: json.Uint64(e.nextIndex),
+		EndIndex:  json.Uint64(e.endIndex),
+		Files:     e.files,
+		StartTime: e.startTime,
+	}
+	if e.err != nil {
+		status.Error = e.err.Error()
+	}
+	return status
+}
+
+// Export starts writing the txs accepted by the chain of [config] to files in
+// a directory, named after the chain, of the export directory. The files of
+// previous exports of the chain are overwritten.
+// See Exporter
+func (i *indexer) Export(config ExportConfig) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.closed {
+		return errClosed
+	}
+	if i.exportDir == "" {
+		return errExportDisabled
+	}
+	chainID, c, err := i.getIndexedChain(config.Chain)
+	if err != nil {
+		return err
+	}
+	if e, ok := i.exports[chainID]; ok && e.status().Active {
+		return fmt.Errorf("%w: %s", errAlreadyExported, config.Chain)
+	}
+	if r, ok := i.reindexes[chainID]; ok && r.status().Active {
+		return fmt.Errorf("%w: %s", errExportReindexing, config.Chain)
+	}
+
+	var (
+		index   Index
+		decoder export.Decoder
+		ctx     = c.engine.Context()
+	)
+	switch {
+	case i.txIndices[chainID] != nil:
+		index = i.txIndices[chainID]
+		decoder, err = export.NewAVMDecoder(ctx.Context)
+		if err != nil {
+			return err
+		}
+	case chainID == constants.PlatformChainID:
+		index = i.blockIndices[chainID]
+		decoder = export.NewPlatformDecoder(ctx.Context)
+	default:
+		return fmt.Errorf("%w: %s", errExportUnsupported, config.Chain)
+	}
+
+	startIndex := math.Max64(config.StartIndex, index.GetFirstIndex())
+	endIndex := config.EndIndex
+	if endIndex == 0 {
+		lastAccepted, err := index.GetLastAccepted()
+		if err != nil {
+			return fmt.Errorf("couldn't get last accepted container of chain %s: %w", c.name, err)
+		}
+		endIndex, err = index.GetIndex(lastAccepted.ID)
+		if err != nil {
+			return fmt.Errorf("couldn't get index of last accepted container of chain %s: %w", c.name, err)
+		}
+	}
+	if startIndex > endIndex {
+		return fmt.Errorf("%w: %d > %d", errInvalidExportRange, startIndex, endIndex)
+	}
+
+	writerConfig := export.WriterConfig{
+		Dir:           filepath.Join(i.exportDir, c.name),
+		Name:          "txs",
+		Formats:       config.Formats,
+		PartitionSize: config.PartitionSize,
+		Encoding:      config.Encoding,
+	}
+	if len(writerConfig.Formats) == 0 {
+		writerConfig.Formats = []export.Format{export.CSV, export.NDJSON}
+	}
+	if writerConfig.PartitionSize == 0 {
+		writerConfig.PartitionSize = DefaultExportPartitionSize
+	}
+	writer, err := export.NewWriter(writerConfig)
+	if err != nil {
+		return err
+	}
+
+	e := &indexExport{
+		log:       i.log,
+		name:      c.name,
+		chainID:   chainID,
+		dir:       writerConfig.Dir,
+		index:     index,
+		decoder:   decoder,
+		writer:    writer,
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		startTime: time.Now(),
+		active:    true,
+		nextIndex: startIndex,
+		endIndex:  endIndex,
+	}
+	i.exports[chainID] = e
+	i.log.Info("exporting txs of chain %s from index %d to %d to %s", c.name, startIndex, endIndex, writerConfig.Dir)
+	go i.log.RecoverAndPanic(e.run)
+	return nil
+}
+
+// ExportStatuses returns the progress of the exports started since the node
+// started.
+// See Exporter
+func (i *indexer) ExportStatuses() []ExportStatus {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	statuses := make([]ExportStatus, 0, len(i.exports))
+	for _, e := range i.exports {
+		statuses = append(statuses, e.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Chain < statuses[j].Chain
+	})
+	return statuses
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/fxs"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/txs"
+	"github.com/sankar-boro/axia-network-v2/vms/nftfx"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm"
+	"github.com/sankar-boro/axia-network-v2/vms/propertyfx"
+	"github.com/sankar-boro/axia-network-v2/vms/proposervm/block"
+	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
+)
+
+var (
+	errUnexpectedBlockType = errors.New("unexpected block type")
+
+	_ Decoder = &avmDecoder{}
+	_ Decoder = &platformDecoder{}
+)
+
+// Container is an accepted container of an index
+type Container struct {
+	// Index of the container in its index
+	Index uint64
+	ID    ids.ID
+	Bytes []byte
+	// Unix time, in nanoseconds, at which the container was accepted
+	Timestamp int64
+}
+
+// Tx is an accepted transaction, decoded from the container it was accepted
+// in
+type Tx struct {
+	// Index of the container the tx was accepted in
+	Index uint64
+	// Height of the container the tx was accepted in. Containers without a
+	// height use their index.
+	Height      uint64
+	ContainerID ids.ID
+	ID          ids.ID
+	// Name of the type of the unsigned tx, e.g. BaseTx
+	Type string
+	// Time at which the container was accepted
+	Timestamp time.Time
+	// The unsigned tx, which is exported as JSON
+	UnsignedTx interface{}
+	Bytes      []byte
+}
+
+// Decoder decodes the txs of accepted containers
+type Decoder interface {
+	// Decode returns the txs accepted in [container], in the order they were
+	// accepted in. The containers of an index must be decoded in order, as
+	// whether the txs of a container were accepted may only be known once the
+	// following container is decoded.
+	Decode(container Container) ([]Tx, error)
+}
+
+type avmDecoder struct {
+	ctx    *snow.Context
+	parser txs.Parser
+}
+
+// NewAVMDecoder returns a Decoder of the containers of the tx index of an AVM
+// chain. [ctx] is the context of the chain, which is used to format addresses.
+func NewAVMDecoder(ctx *snow.Context) (Decoder, error) {
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &avmDecoder{
+		ctx:    ctx,
+		parser: parser,
+	}, nil
+}
+
+func (d *avmDecoder) Decode(container Container) ([]Tx, error) {
+	tx, err := d.parser.Parse(container.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse tx %s: %w", container.ID, err)
+	}
+	tx.UnsignedTx.InitCtx(d.ctx)
+	return []Tx{{
+		Index:       container.Index,
+		Height:      container.Index,
+		ContainerID: container.ID,
+		ID:          tx.ID(),
+		Type:        typeName(tx.UnsignedTx),
+		Timestamp:   time.Unix(0, container.Timestamp).UTC(),
+		UnsignedTx:  tx.UnsignedTx,
+		Bytes:       tx.Bytes(),
+	}}, nil
+}
+
+type platformDecoder struct {
+	ctx *snow.Context
+	// The tx of the last decoded block, if it was a proposal block. The tx is
+	// only accepted if the following block commits it.
+	proposal *proposalTx
+}
+
+type proposalTx struct {
+	blkID ids.ID
+	tx    Tx
+}
+
+// NewPlatformDecoder returns a Decoder of the containers of the block index of
+// the platform chain. [ctx] is the context of the chain, which is used to
+// format addresses.
+func NewPlatformDecoder(ctx *snow.Context) Decoder {
+	return &platformDecoder{ctx: ctx}
+}
+
+func (d *platformDecoder) Decode(container Container) ([]Tx, error) {
+	// Blocks accepted after the proposervm fork wrap the platform block
+	blkBytes := container.Bytes
+	if proposerBlk, err := block.Parse(container.Bytes); err == nil {
+		blkBytes = proposerBlk.Block()
+	}
+
+	var blk platformvm.Block
+	if _, err := platformvm.Codec.Unmarshal(blkBytes, &blk); err != nil {
+		return nil, fmt.Errorf("couldn't parse block %s: %w", container.ID, err)
+	}
+
+	proposal := d.proposal
+	d.proposal = nil
+
+	var blkTxs []*platformvm.Tx
+	switch blk := blk.(type) {
+	case *platformvm.ProposalBlock:
+		tx, err := d.decodeTx(container, blk, &blk.Tx)
+		if err != nil {
+			return nil, err
+		}
+		d.proposal = &proposalTx{
+			blkID: hashing.ComputeHash256Array(blkBytes),
+			tx:    tx,
+		}
+		return nil, nil
+	case *platformvm.CommitBlock:
+		if proposal != nil && proposal.blkID == blk.Parent() {
+			return []Tx{proposal.tx}, nil
+		}
+		return nil, nil
+	case *platformvm.AbortBlock:
+		// The tx of the aborted proposal wasn't accepted
+		return nil, nil
+	case *platformvm.AtomicBlock:
+		blkTxs = []*platformvm.Tx{&blk.Tx}
+	case *platformvm.StandardBlock:
+		blkTxs = blk.Txs
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnexpectedBlockType, blk)
+	}
+
+	decoded := make([]Tx, len(blkTxs))
+	for i, tx := range blkTxs {
+		var err error
+		decoded[i], err = d.decodeTx(container, blk, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+func (d *platformDecoder) decodeTx(container Container, blk platformvm.Block, tx *platformvm.Tx) (Tx, error) {
+	// Computes the ID of the tx
+	if err := tx.Sign(platformvm.Codec, nil); err != nil {
+		return Tx{}, fmt.Errorf("couldn't initialize tx of block %s: %w", container.ID, err)
+	}
+	tx.UnsignedTx.InitCtx(d.ctx)
+	return Tx{
+		Index:       container.Index,
+		Height:      blk.Height(),
+		ContainerID: container.ID,
+		ID:          tx.ID(),
+		Type:        typeName(tx.UnsignedTx),
+		Timestamp:   time.Unix(0, container.Timestamp).UTC(),
+		UnsignedTx:  tx.UnsignedTx,
+		Bytes:       tx.Bytes(),
+	}, nil
+}
+
+// typeName returns the name of the type of [utx], without the Unsigned prefix
+// of the platform txs
+func typeName(utx interface{}) string {
+	t := reflect.TypeOf(utx)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return strings.TrimPrefix(t.Name(), "Unsigned")
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+	"github.com/sankar-boro/axia-network-v2/vms/avm/txs"
+	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm"
+	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
+)
+
+func TestAVMDecoder(t *testing.T) {
+	assert := assert.New(t)
+	ctx := snow.DefaultContextTest()
+	decoder, err := NewAVMDecoder(ctx)
+	assert.NoError(err)
+
+	tx := &txs.Tx{UnsignedTx: &txs.BaseTx{BaseTx: axc.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}}
+	assert.NoError(decoder.(*avmDecoder).parser.InitializeTx(tx))
+
+	container := Container{
+		Index:     7,
+		ID:        tx.ID(),
+		Bytes:     tx.Bytes(),
+		Timestamp: time.Unix(10, 0).UnixNano(),
+	}
+	decoded, err := decoder.Decode(container)
+	assert.NoError(err)
+	assert.Len(decoded, 1)
+	assert.Equal(tx.ID(), decoded[0].ID)
+	assert.Equal(tx.ID(), decoded[0].ContainerID)
+	assert.Equal("BaseTx", decoded[0].Type)
+	assert.EqualValues(7, decoded[0].Index)
+	assert.EqualValues(7, decoded[0].Height)
+	assert.Equal(time.Unix(10, 0).UTC(), decoded[0].Timestamp)
+	assert.Equal(tx.Bytes(), decoded[0].Bytes)
+
+	container.Bytes = []byte{1, 2, 3}
+	_, err = decoder.Decode(container)
+	assert.Error(err)
+}
+
+func TestPlatformDecoder(t *testing.T) {
+	assert := assert.New(t)
+	ctx := snow.DefaultContextTest()
+	decoder := NewPlatformDecoder(ctx)
+
+	newTx := func() *platformvm.Tx {
+		tx := &platformvm.Tx{UnsignedTx: &platformvm.UnsignedCreateAllychainTx{
+			BaseTx: platformvm.BaseTx{BaseTx: axc.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+				Memo:         utils.RandomBytes(32),
+			}},
+			Owner: &secp256k1fx.OutputOwners{},
+		}}
+		assert.NoError(tx.Sign(platformvm.Codec, nil))
+		return tx
+	}
+	txs := []*platformvm.Tx{newTx(), newTx()}
+
+	var blk platformvm.Block = &platformvm.StandardBlock{
+		CommonDecisionBlock: platformvm.CommonDecisionBlock{
+			CommonBlock: platformvm.CommonBlock{
+				PrntID: ids.GenerateTestID(),
+				Hght:   12,
+			},
+		},
+		Txs: txs,
+	}
+	blkBytes, err := platformvm.Codec.Marshal(platformvm.CodecVersion, &blk)
+	assert.NoError(err)
+
+	decoded, err := decoder.Decode(Container{
+		Index: 11,
+		ID:    ids.GenerateTestID(),
+		Bytes: blkBytes,
+	})
+	assert.NoError(err)
+	assert.Len(decoded, len(txs))
+	for i, tx := range txs {
+		assert.Equal(tx.ID(), decoded[i].ID)
+		assert.Equal("CreateAllychainTx", decoded[i].Type)
+		assert.EqualValues(11, decoded[i].Index)
+		assert.EqualValues(12, decoded[i].Height)
+		assert.Equal(tx.Bytes(), decoded[i].Bytes)
+	}
+
+	// Blocks without txs are decoded to no txs
+	decoded, err = decoder.Decode(Container{Bytes: newOptionBlock(t, true, ids.GenerateTestID(), 13)})
+	assert.NoError(err)
+	assert.Empty(decoded)
+
+	// The tx of a proposal block is only decoded once it's committed
+	proposalTx := newTx()
+	blk = &platformvm.ProposalBlock{
+		CommonBlock: platformvm.CommonBlock{
+			PrntID: ids.GenerateTestID(),
+			Hght:   14,
+		},
+		Tx: *proposalTx,
+	}
+	proposalBytes, err := platformvm.Codec.Marshal(platformvm.CodecVersion, &blk)
+	assert.NoError(err)
+	proposalID := hashing.ComputeHash256Array(proposalBytes)
+
+	decoded, err = decoder.Decode(Container{Index: 14, Bytes: proposalBytes})
+	assert.NoError(err)
+	assert.Empty(decoded)
+	decoded, err = decoder.Decode(Container{Index: 15, Bytes: newOptionBlock(t, true, proposalID, 15)})
+	assert.NoError(err)
+	assert.Len(decoded, 1)
+	assert.Equal(proposalTx.ID(), decoded[0].ID)
+	assert.EqualValues(14, decoded[0].Index)
+	assert.EqualValues(14, decoded[0].Height)
+
+	// The tx of an aborted proposal block isn't decoded
+	decoded, err = decoder.Decode(Container{Index: 14, Bytes: proposalBytes})
+	assert.NoError(err)
+	assert.Empty(decoded)
+	decoded, err = decoder.Decode(Container{Index: 15, Bytes: newOptionBlock(t, false, proposalID, 15)})
+	assert.NoError(err)
+	assert.Empty(decoded)
+}
+
+// newOptionBlock returns the bytes of a commit block, or an abort block if
+// not [commit], whose parent is [parentID]
+func newOptionBlock(t *testing.T, commit bool, parentID ids.ID, height uint64) []byte {
+	decisionBlk := platformvm.DoubleDecisionBlock{
+		CommonDecisionBlock: platformvm.CommonDecisionBlock{
+			CommonBlock: platformvm.CommonBlock{
+				PrntID: parentID,
+				Hght:   height,
+			},
+		},
+	}
+	var blk platformvm.Block = &platformvm.AbortBlock{DoubleDecisionBlock: decisionBlk}
+	if commit {
+		blk = &platformvm.CommitBlock{DoubleDecisionBlock: decisionBlk}
+	}
+	blkBytes, err := platformvm.Codec.Marshal(platformvm.CodecVersion, &blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blkBytes
+}
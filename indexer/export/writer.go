@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/perms"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+)
+
+const (
+	// CSV files have a header row, followed by a row per tx. The unsigned tx
+	// is a JSON column.
+	CSV Format = "csv"
+	// NDJSON files have a JSON object per tx, each on its own line
+	NDJSON Format = "ndjson"
+)
+
+var (
+	errUnknownFormat     = errors.New("unknown export format")
+	errNoFormats         = errors.New("at least one export format must be given")
+	errZeroPartitionSize = errors.New("partition size must be positive")
+	errJSONBytesEncoding = errors.New("tx bytes can't be encoded as JSON")
+	errDecreasingHeight  = errors.New("tx height is lower than the height of a previous tx")
+	errWriterClosed      = errors.New("writer is closed")
+
+	csvHeader = []string{"index", "height", "containerID", "txID", "type", "timestamp", "unsignedTx", "bytes"}
+
+	_ txWriter = &csvWriter{}
+	_ txWriter = &ndjsonWriter{}
+)
+
+// Format of the files that txs are exported to
+type Format string
+
+// WriterConfig describes the files that a Writer writes txs to
+type WriterConfig struct {
+	// Directory the files are written to. Created if it doesn't exist.
+	Dir string
+	// Prefix of the names of the files
+	Name string
+	// Each tx is written to a file of each format
+	Formats []Format
+	// Number of heights covered by each file. A file of a partition is named
+	// [Name]-[first height]-[last height].[Format]
+	PartitionSize uint64
+	// Encoding of the bytes of the txs
+	Encoding formatting.Encoding
+}
+
+// Verify returns an error if [c] can't be used to create a Writer
+func (c *WriterConfig) Verify() error {
+	switch {
+	case len(c.Formats) == 0:
+		return errNoFormats
+	case c.PartitionSize == 0:
+		return errZeroPartitionSize
+	case c.Encoding == formatting.JSON:
+		return errJSONBytesEncoding
+	}
+	for _, format := range c.Formats {
+		if format != CSV && format != NDJSON {
+			return fmt.Errorf("%w: %q", errUnknownFormat, format)
+		}
+	}
+	return nil
+}
+
+// record is the form of a tx in the files it's written to
+type record struct {
+	Index       uint64      `json:"index"`
+	Height      uint64      `json:"height"`
+	ContainerID string      `json:"containerID"`
+	TxID        string      `json:"txID"`
+	Type        string      `json:"type"`
+	Timestamp   time.Time   `json:"timestamp"`
+	UnsignedTx  interface{} `json:"unsignedTx"`
+	Bytes       string      `json:"bytes"`
+}
+
+// txWriter writes records to a file of a single format
+type txWriter interface {
+	write(r *record) error
+	close() error
+}
+
+type csvWriter struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func newCSVWriter(file *os.File) (*csvWriter, error) {
+	w := &csvWriter{
+		file:   file,
+		writer: csv.NewWriter(file),
+	}
+	return w, w.writer.Write(csvHeader)
+}
+
+func (w *csvWriter) write(r *record) error {
+	unsignedTx, err := json.Marshal(r.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	return w.writer.Write([]string{
+		strconv.FormatUint(r.Index, 10),
+		strconv.FormatUint(r.Height, 10),
+		r.ContainerID,
+		r.TxID,
+		r.Type,
+		r.Timestamp.Format(time.RFC3339Nano),
+		string(unsignedTx),
+		r.Bytes,
+	})
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+	errs := wrappers.Errs{}
+	errs.Add(w.writer.Error(), w.file.Close())
+	return errs.Err
+}
+
+type ndjsonWriter struct {
+	file    *os.File
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(file *os.File) *ndjsonWriter {
+	buf := bufio.NewWriter(file)
+	return &ndjsonWriter{
+		file:    file,
+		buf:     buf,
+		encoder: json.NewEncoder(buf),
+	}
+}
+
+// Encode terminates each record with a newline
+func (w *ndjsonWriter) write(r *record) error { return w.encoder.Encode(r) }
+
+func (w *ndjsonWriter) close() error {
+	errs := wrappers.Errs{}
+	errs.Add(w.buf.Flush(), w.file.Close())
+	return errs.Err
+}
+
+// Writer writes txs to files that are partitioned by the height the txs were
+// accepted at. Txs must be written in order of height.
+type Writer struct {
+	config WriterConfig
+	// First height of the partition that files are open for
+	partitionStart uint64
+	// The open files of the partition, one per format
+	writers []txWriter
+	// Paths of the files that were created
+	files  []string
+	closed bool
+}
+
+// NewWriter returns a Writer that writes txs to files described by [config]
+func NewWriter(config WriterConfig) (*Writer, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(config.Dir, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create export directory: %w", err)
+	}
+	return &Writer{config: config}, nil
+}
+
+// Write [tx] to the files of its partition
+func (w *Writer) Write(tx Tx) error {
+	if w.closed {
+		return errWriterClosed
+	}
+	partitionStart := tx.Height - tx.Height%w.config.PartitionSize
+	switch {
+	case len(w.writers) != 0 && partitionStart < w.partitionStart:
+		return fmt.Errorf("%w: %d", errDecreasingHeight, tx.Height)
+	case len(w.writers) == 0 || partitionStart != w.partitionStart:
+		if err := w.openPartition(partitionStart); err != nil {
+			return err
+		}
+	}
+
+	txBytes, err := formatting.EncodeWithChecksum(w.config.Encoding, tx.Bytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx %s: %w", tx.ID, err)
+	}
+	r := &record{
+		Index:       tx.Index,
+		Height:      tx.Height,
+		ContainerID: tx.ContainerID.String(),
+		TxID:        tx.ID.String(),
+		Type:        tx.Type,
+		Timestamp:   tx.Timestamp,
+		UnsignedTx:  tx.UnsignedTx,
+		Bytes:       txBytes,
+	}
+	for _, writer := range w.writers {
+		if err := writer.write(r); err != nil {
+			return fmt.Errorf("couldn't write tx %s: %w", tx.ID, err)
+		}
+	}
+	return nil
+}
+
+// openPartition closes the files of the current partition and opens the files
+// of the partition starting at [partitionStart]
+func (w *Writer) openPartition(partitionStart uint64) error {
+	if err := w.closeWriters(); err != nil {
+		return err
+	}
+
+	w.partitionStart = partitionStart
+	partitionEnd := partitionStart + w.config.PartitionSize - 1
+	for _, format := range w.config.Formats {
+		path := filepath.Join(
+			w.config.Dir,
+			fmt.Sprintf("%s-%d-%d.%s", w.config.Name, partitionStart, partitionEnd, format),
+		)
+		file, err := perms.Create(filepath.Clean(path), perms.ReadWrite)
+		if err != nil {
+			return fmt.Errorf("couldn't create export file: %w", err)
+		}
+		w.files = append(w.files, path)
+
+		var writer txWriter
+		switch format {
+		case CSV:
+			writer, err = newCSVWriter(file)
+		case NDJSON:
+			writer = newNDJSONWriter(file)
+		}
+		w.writers = append(w.writers, writer)
+		if err != nil {
+			return fmt.Errorf("couldn't write to export file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *Writer) closeWriters() error {
+	errs := wrappers.Errs{}
+	for _, writer := range w.writers {
+		errs.Add(writer.close())
+	}
+	w.writers = nil
+	return errs.Err
+}
+
+// Files returns the paths of the files that were created, in the order they
+// were created in
+func (w *Writer) Files() []string { return w.files }
+
+// Close flushes and closes the open files
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.closeWriters()
+}
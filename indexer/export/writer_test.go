@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+)
+
+func newTestTx(height uint64) Tx {
+	return Tx{
+		Index:       height,
+		Height:      height,
+		ContainerID: ids.GenerateTestID(),
+		ID:          ids.GenerateTestID(),
+		Type:        "BaseTx",
+		Timestamp:   time.Unix(int64(height), 0).UTC(),
+		UnsignedTx:  map[string]uint64{"height": height},
+		Bytes:       []byte{byte(height)},
+	}
+}
+
+func TestWriterPartitions(t *testing.T) {
+	assert := assert.New(t)
+	dir := filepath.Join(t.TempDir(), "X")
+
+	w, err := NewWriter(WriterConfig{
+		Dir:           dir,
+		Name:          "txs",
+		Formats:       []Format{CSV, NDJSON},
+		PartitionSize: 5,
+		Encoding:      formatting.Hex,
+	})
+	assert.NoError(err)
+
+	txs := []Tx{newTestTx(0), newTestTx(4), newTestTx(4), newTestTx(5), newTestTx(12)}
+	for _, tx := range txs {
+		assert.NoError(w.Write(tx))
+	}
+	// Txs must be written in order of height
+	assert.ErrorIs(w.Write(newTestTx(9)), errDecreasingHeight)
+	assert.NoError(w.Close())
+	assert.ErrorIs(w.Write(newTestTx(13)), errWriterClosed)
+
+	assert.Equal([]string{
+		filepath.Join(dir, "txs-0-4.csv"),
+		filepath.Join(dir, "txs-0-4.ndjson"),
+		filepath.Join(dir, "txs-5-9.csv"),
+		filepath.Join(dir, "txs-5-9.ndjson"),
+		filepath.Join(dir, "txs-10-14.csv"),
+		filepath.Join(dir, "txs-10-14.ndjson"),
+	}, w.Files())
+
+	file, err := os.Open(filepath.Join(dir, "txs-0-4.csv"))
+	assert.NoError(err)
+	rows, err := csv.NewReader(file).ReadAll()
+	assert.NoError(err)
+	assert.NoError(file.Close())
+	assert.Len(rows, 4)
+	assert.Equal(csvHeader, rows[0])
+	txBytes, err := formatting.EncodeWithChecksum(formatting.Hex, txs[1].Bytes)
+	assert.NoError(err)
+	assert.Equal([]string{
+		"4",
+		"4",
+		txs[1].ContainerID.String(),
+		txs[1].ID.String(),
+		"BaseTx",
+		"1970-01-01T00:00:04Z",
+		`{"height":4}`,
+		txBytes,
+	}, rows[2])
+
+	file, err = os.Open(filepath.Join(dir, "txs-10-14.ndjson"))
+	assert.NoError(err)
+	scanner := bufio.NewScanner(file)
+	var records []map[string]interface{}
+	for scanner.Scan() {
+		record := map[string]interface{}{}
+		assert.NoError(json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.NoError(scanner.Err())
+	assert.NoError(file.Close())
+	assert.Len(records, 1)
+	assert.Equal(txs[4].ID.String(), records[0]["txID"])
+	assert.EqualValues(12, records[0]["height"])
+	assert.Equal(map[string]interface{}{"height": 12.0}, records[0]["unsignedTx"])
+}
+
+func TestWriterConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		config WriterConfig
+		err    error
+	}{
+		{
+			name:   "valid",
+			config: WriterConfig{Formats: []Format{NDJSON}, PartitionSize: 1},
+		},
+		{
+			name:   "no formats",
+			config: WriterConfig{PartitionSize: 1},
+			err:    errNoFormats,
+		},
+		{
+			name:   "unknown format",
+			config: WriterConfig{Formats: []Format{"parquet"}, PartitionSize: 1},
+			err:    errUnknownFormat,
+		},
+		{
+			name:   "zero partition size",
+			config: WriterConfig{Formats: []Format{CSV}},
+			err:    errZeroPartitionSize,
+		},
+		{
+			name:   "json encoding",
+			config: WriterConfig{Formats: []Format{CSV}, PartitionSize: 1, Encoding: formatting.JSON},
+			err:    errJSONBytesEncoding,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ErrorIs(t, test.config.Verify(), test.err)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer/export"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+)
+
+// testDecoder decodes each container to two txs
+type testDecoder struct{}
+
+func (testDecoder) Decode(container export.Container) ([]export.Tx, error) {
+	txs := make([]export.Tx, 2)
+	for i := range txs {
+		txs[i] = export.Tx{
+			Index:       container.Index,
+			Height:      container.Index,
+			ContainerID: container.ID,
+			ID:          ids.GenerateTestID(),
+			Bytes:       container.Bytes,
+		}
+	}
+	return txs, nil
+}
+
+func TestIndexExportRun(t *testing.T) {
+	assert := assert.New(t)
+	ctx := snow.DefaultConsensusContextTest()
+	index, _ := newTestStreamingIndex(t)
+	for i := 0; i < 5; i++ {
+		assert.NoError(index.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	}
+
+	dir := t.TempDir()
+	writer, err := export.NewWriter(export.WriterConfig{
+		Dir:           dir,
+		Name:          "txs",
+		Formats:       []export.Format{export.NDJSON},
+		PartitionSize: 2,
+	})
+	assert.NoError(err)
+	e := &indexExport{
+		log:       logging.NoLog{},
+		name:      "X",
+		dir:       dir,
+		index:     index,
+		decoder:   testDecoder{},
+		writer:    writer,
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		active:    true,
+		nextIndex: 1,
+		endIndex:  3,
+	}
+	e.run()
+
+	status := e.status()
+	assert.False(status.Active)
+	assert.Empty(status.Error)
+	assert.EqualValues(6, status.Exported)
+	assert.EqualValues(4, status.NextIndex)
+	assert.Equal([]string{
+		filepath.Join(dir, "txs-0-1.ndjson"),
+		filepath.Join(dir, "txs-2-3.ndjson"),
+	}, status.Files)
+}
+
+func TestIndexExportStop(t *testing.T) {
+	assert := assert.New(t)
+	index, _ := newTestStreamingIndex(t)
+	assert.NoError(index.Accept(snow.DefaultConsensusContextTest(), ids.GenerateTestID(), utils.RandomBytes(32)))
+
+	writer, err := export.NewWriter(export.WriterConfig{
+		Dir:           t.TempDir(),
+		Formats:       []export.Format{export.CSV},
+		PartitionSize: 1,
+	})
+	assert.NoError(err)
+	e := &indexExport{
+		log:     logging.NoLog{},
+		index:   index,
+		decoder: testDecoder{},
+		writer:  writer,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		active:  true,
+	}
+	close(e.stop)
+	e.run()
+
+	status := e.status()
+	assert.False(status.Active)
+	assert.Equal(errExportStopped.Error(), status.Error)
+	assert.Zero(status.Exported)
+}
+
+func TestExportErrors(t *testing.T) {
+	idxr := &indexer{
+		indexedChains: map[ids.ID]*indexedChain{},
+		exports:       map[ids.ID]*indexExport{},
+	}
+	assert.ErrorIs(t, idxr.Export(ExportConfig{Chain: "X"}), errExportDisabled)
+
+	idxr.exportDir = t.TempDir()
+	assert.ErrorIs(t, idxr.Export(ExportConfig{Chain: "X"}), errNotIndexed)
+	assert.Empty(t, idxr.ExportStatuses())
+}
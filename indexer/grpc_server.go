@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+
+	indexpb "github.com/sankar-boro/axia-network-v2/proto/pb/index"
+)
+
+// Containers are fetched from [service] hex encoded and are decoded before
+// being returned over gRPC.
+const grpcEncoding = formatting.Hex
+
+var _ indexpb.IndexServer = &grpcServer{}
+
+// grpcServer serves an index over gRPC by calling into [service]
+type grpcServer struct {
+	indexpb.UnsafeIndexServer
+	service *service
+}
+
+func (s *grpcServer) GetLastAccepted(context.Context, *emptypb.Empty) (*indexpb.Container, error) {
+	reply := FormattedContainer{}
+	if err := s.service.GetLastAccepted(nil, &GetLastAcceptedArgs{Encoding: grpcEncoding}, &reply); err != nil {
+		return nil, err
+	}
+	return newContainer(&reply)
+}
+
+func (s *grpcServer) GetContainerByIndex(_ context.Context, req *indexpb.GetContainerByIndexRequest) (*indexpb.Container, error) {
+	reply := FormattedContainer{}
+	args := &GetContainerByIndexArgs{
+		Index:    json.Uint64(req.Index),
+		Encoding: grpcEncoding,
+	}
+	if err := s.service.GetContainerByIndex(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	return newContainer(&reply)
+}
+
+func (s *grpcServer) GetContainerRange(_ context.Context, req *indexpb.GetContainerRangeRequest) (*indexpb.GetContainerRangeResponse, error) {
+	reply := GetContainerRangeResponse{}
+	args := &GetContainerRangeArgs{
+		StartIndex: json.Uint64(req.StartIndex),
+		NumToFetch: json.Uint64(req.NumToFetch),
+		Encoding:   grpcEncoding,
+	}
+	if err := s.service.GetContainerRange(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	containers := make([]*indexpb.Container, len(reply.Containers))
+	for i := range reply.Containers {
+		container, err := newContainer(&reply.Containers[i])
+		if err != nil {
+			return nil, err
+		}
+		containers[i] = container
+	}
+	return &indexpb.GetContainerRangeResponse{
+		Containers: containers,
+	}, nil
+}
+
+func (s *grpcServer) GetIndex(_ context.Context, req *indexpb.GetIndexRequest) (*indexpb.GetIndexResponse, error) {
+	containerID, err := ids.ToID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	reply := GetIndexResponse{}
+	if err := s.service.GetIndex(nil, &GetIndexArgs{ContainerID: containerID}, &reply); err != nil {
+		return nil, err
+	}
+	return &indexpb.GetIndexResponse{
+		Index: uint64(reply.Index),
+	}, nil
+}
+
+func (s *grpcServer) IsAccepted(_ context.Context, req *indexpb.IsAcceptedRequest) (*indexpb.IsAcceptedResponse, error) {
+	containerID, err := ids.ToID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	reply := IsAcceptedResponse{}
+	if err := s.service.IsAccepted(nil, &IsAcceptedArgs{ContainerID: containerID}, &reply); err != nil {
+		return nil, err
+	}
+	return &indexpb.IsAcceptedResponse{
+		IsAccepted: reply.IsAccepted,
+	}, nil
+}
+
+func (s *grpcServer) GetContainerByID(_ context.Context, req *indexpb.GetContainerByIDRequest) (*indexpb.Container, error) {
+	containerID, err := ids.ToID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	reply := FormattedContainer{}
+	args := &GetContainerByIDArgs{
+		ContainerID: containerID,
+		Encoding:    grpcEncoding,
+	}
+	if err := s.service.GetContainerByID(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	return newContainer(&reply)
+}
+
+func newContainer(fc *FormattedContainer) (*indexpb.Container, error) {
+	containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &indexpb.Container{
+		Id:        fc.ID[:],
+		Bytes:     containerBytes,
+		Timestamp: timestamppb.New(fc.Timestamp),
+		Index:     uint64(fc.Index),
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/sankar-boro/axia-network-v2/codec"
+	"github.com/sankar-boro/axia-network-v2/codec/linearcodec"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+
+	indexpb "github.com/sankar-boro/axia-network-v2/proto/pb/index"
+)
+
+func TestGRPCServer(t *testing.T) {
+	assert := assert.New(t)
+	codec := codec.NewDefaultManager()
+	assert.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	ctx := snow.DefaultConsensusContextTest()
+
+	idx, err := newIndex(versiondb.New(memdb.New()), logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
+	assert.NoError(err)
+	server := &grpcServer{service: &service{Index: idx}}
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	containers := [][]byte{utils.RandomBytes(32), utils.RandomBytes(32)}
+	for i, containerID := range containerIDs {
+		assert.NoError(idx.Accept(ctx, containerID, containers[i]))
+	}
+
+	lastAccepted, err := server.GetLastAccepted(context.Background(), &emptypb.Empty{})
+	assert.NoError(err)
+	assert.Equal(containerIDs[1][:], lastAccepted.Id)
+	assert.Equal(containers[1], lastAccepted.Bytes)
+	assert.EqualValues(1, lastAccepted.Index)
+
+	container, err := server.GetContainerByIndex(context.Background(), &indexpb.GetContainerByIndexRequest{Index: 0})
+	assert.NoError(err)
+	assert.Equal(containers[0], container.Bytes)
+
+	container, err = server.GetContainerByID(context.Background(), &indexpb.GetContainerByIDRequest{ContainerId: containerIDs[0][:]})
+	assert.NoError(err)
+	assert.Equal(containers[0], container.Bytes)
+	assert.EqualValues(0, container.Index)
+
+	containerRange, err := server.GetContainerRange(context.Background(), &indexpb.GetContainerRangeRequest{StartIndex: 0, NumToFetch: 2})
+	assert.NoError(err)
+	assert.Len(containerRange.Containers, 2)
+	assert.Equal(containers[1], containerRange.Containers[1].Bytes)
+
+	index, err := server.GetIndex(context.Background(), &indexpb.GetIndexRequest{ContainerId: containerIDs[1][:]})
+	assert.NoError(err)
+	assert.EqualValues(1, index.Index)
+
+	unknownID := ids.GenerateTestID()
+	accepted, err := server.IsAccepted(context.Background(), &indexpb.IsAcceptedRequest{ContainerId: unknownID[:]})
+	assert.NoError(err)
+	assert.False(accepted.IsAccepted)
+
+	_, err = server.IsAccepted(context.Background(), &indexpb.IsAcceptedRequest{ContainerId: []byte{1}})
+	assert.Error(err)
+}
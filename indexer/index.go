@@ -32,8 +32,12 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
-	errNoneAccepted        = errors.New("no containers have been accepted")
-	errNumToFetchZero      = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	// Maps to the byte representation of the index of the oldest container
+	// that hasn't been pruned
+	firstIndexKey     = []byte{0x03}
+	errNoneAccepted   = errors.New("no containers have been accepted")
+	errNumToFetchZero = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	errPruned         = errors.New("container has been pruned")
 
 	_ Index = &index{}
 )
@@ -49,6 +53,9 @@ type Index interface {
 	GetLastAccepted() (Container, error)
 	GetIndex(containerID ids.ID) (uint64, error)
 	GetContainerByID(containerID ids.ID) (Container, error)
+	// GetFirstIndex returns the index of the oldest container that hasn't been
+	// pruned
+	GetFirstIndex() uint64
 	io.Closer
 }
 
@@ -59,6 +66,18 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// The index of the oldest container that hasn't been pruned. Containers
+	// with lower indices have been removed from the index.
+	firstIndex uint64
+	// Determines which containers are pruned
+	retention RetentionConfig
+	// Closed to stop pruning
+	pruneDone chan struct{}
+	// Closed when pruning stops
+	pruneStopped chan struct{}
+	// True if the index is being rebuilt, in which case accepted containers
+	// are added by the rebuild rather than by Accept
+	rebuilding bool
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
@@ -72,11 +91,14 @@ type index struct {
 
 // Returns a new, thread-safe Index.
 // Closes [baseDB] on close.
+// If [retention] has a limit, containers outside of it are pruned in the
+// background.
 func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	codec codec.Manager,
 	clock mockable.Clock,
+	retention RetentionConfig,
 ) (Index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
@@ -90,25 +112,43 @@ func newIndex(
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
 		log:              log,
+		retention:        retention,
+	}
+
+	firstIndex, err := database.GetUInt64(i.vDB, firstIndexKey)
+	switch err {
+	case nil:
+		i.firstIndex = firstIndex
+	case database.ErrNotFound:
+	default:
+		return nil, fmt.Errorf("couldn't get first index from database: %w", err)
 	}
 
 	// Get next accepted index from db
 	nextAcceptedIndex, err := database.GetUInt64(i.vDB, nextAcceptedIndexKey)
-	if err == database.ErrNotFound {
+	switch err {
+	case nil:
+		i.nextAcceptedIndex = nextAcceptedIndex
+	case database.ErrNotFound:
 		// Couldn't find it in the database. Must not have accepted any containers in previous runs.
-		i.log.Info("next accepted index %d", i.nextAcceptedIndex)
-		return i, nil
-	}
-	if err != nil {
+	default:
 		return nil, fmt.Errorf("couldn't get next accepted index from database: %w", err)
 	}
-	i.nextAcceptedIndex = nextAcceptedIndex
 	i.log.Info("next accepted index %d", i.nextAcceptedIndex)
+
+	if retention.hasLimit() {
+		i.startPruning()
+	}
 	return i, nil
 }
 
 // Close this index
 func (i *index) Close() error {
+	if i.pruneDone != nil {
+		close(i.pruneDone)
+		<-i.pruneStopped
+	}
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		i.indexToContainer.Close(),
@@ -126,6 +166,15 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	if i.rebuilding {
+		ctx.Log.Debug("not indexing container %s because the index is being rebuilt", containerID)
+		return nil
+	}
+	return i.accept(ctx, containerID, containerBytes)
+}
+
+// Assumes [i.lock] is held
+func (i *index) accept(ctx *snow.ConsensusContext, containerID ids.ID, containerBytes []byte) error {
 	// It may be the case that in a previous run of this node, this index committed [containerID]
 	// as accepted and then the node shut down before the VM committed [containerID] as accepted.
 	// In that case, when the node restarts Accept will be called with the same container.
@@ -186,6 +235,9 @@ func (i *index) getContainerByIndex(index uint64) (Container, error) {
 	if !ok || index > lastAcceptedIndex {
 		return Container{}, fmt.Errorf("no container at index %d", index)
 	}
+	if index < i.firstIndex {
+		return Container{}, i.prunedErr(index)
+	}
 	indexBytes := database.PackUInt64(index)
 	return i.getContainerByIndexBytes(indexBytes)
 }
@@ -225,6 +277,8 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 		return nil, errNoneAccepted
 	} else if startIndex > lastAcceptedIndex {
 		return nil, fmt.Errorf("start index (%d) > last accepted index (%d)", startIndex, lastAcceptedIndex)
+	} else if startIndex < i.firstIndex {
+		return nil, i.prunedErr(startIndex)
 	}
 
 	// Calculate the last index we will fetch
@@ -265,6 +319,13 @@ func (i *index) GetContainerByID(containerID ids.ID) (Container, error) {
 	return i.getContainerByIndexBytes(indexBytes)
 }
 
+func (i *index) GetFirstIndex() uint64 {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.firstIndex
+}
+
 // GetLastAccepted returns the last accepted container.
 // Returns an error if no containers have been accepted.
 func (i *index) GetLastAccepted() (Container, error) {
@@ -286,3 +347,8 @@ func (i *index) GetLastAccepted() (Container, error) {
 func (i *index) lastAcceptedIndex() (uint64, bool) {
 	return i.nextAcceptedIndex - 1, i.nextAcceptedIndex != 0
 }
+
+// Assumes i.lock is held
+func (i *index) prunedErr(index uint64) error {
+	return fmt.Errorf("%w: index %d is below %d, the index of the oldest container kept", errPruned, index, i.firstIndex)
+}
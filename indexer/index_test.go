@@ -29,7 +29,7 @@ func TestIndex(t *testing.T) {
 	db := versiondb.New(baseDB)
 	ctx := snow.DefaultConsensusContextTest()
 
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
 	assert.NoError(err)
 	idx := indexIntf.(*index)
 
@@ -83,7 +83,7 @@ func TestIndex(t *testing.T) {
 	assert.NoError(db.Commit())
 	assert.NoError(idx.Close())
 	db = versiondb.New(baseDB)
-	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
 	assert.NoError(err)
 	idx = indexIntf.(*index)
 
@@ -118,7 +118,7 @@ func TestIndexGetContainerByRangeMaxPageSize(t *testing.T) {
 	assert.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
 	assert.NoError(err)
 	idx := indexIntf.(*index)
 
@@ -160,7 +160,7 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	assert.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
 	assert.NoError(err)
 
 	// Accept the same container twice
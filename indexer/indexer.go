@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/websocket"
 
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/api/server"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/codec"
@@ -28,6 +30,8 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
 	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+
+	indexpb "github.com/sankar-boro/axia-network-v2/proto/pb/index"
 )
 
 const (
@@ -48,7 +52,9 @@ var (
 	blockPrefix             = byte(0x03)
 	isIncompletePrefix      = byte(0x04)
 	previouslyIndexedPrefix = byte(0x05)
+	webhookPrefix           = byte(0x06)
 	hasRunKey               = []byte{0x07}
+	isReindexingPrefix      = byte(0x08)
 
 	_ Indexer = &indexer{}
 )
@@ -62,7 +68,13 @@ type Config struct {
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
 	APIServer              server.PathAdder
-	ShutdownF              func()
+	GRPCServer             server.GRPCAdder // nil if the index API isn't served over gRPC
+	Webhooks               []WebhookConfig
+	Retentions             []RetentionConfig
+	// Directory that the txs of chains are exported to. If empty, exports
+	// are disabled.
+	ExportDir string
+	ShutdownF func()
 }
 
 // Indexer causes accepted containers for a given chain
@@ -71,12 +83,22 @@ type Config struct {
 // Indexer is threadsafe.
 type Indexer interface {
 	chains.Registrant
+	WebhookManager
+	Reindexer
+	Exporter
 	// Close will do nothing and return nil after the first call
 	io.Closer
 }
 
 // NewIndexer returns a new Indexer and registers a new endpoint on the given API server.
 func NewIndexer(config Config) (Indexer, error) {
+	if err := verifyRetentions(config.Retentions); err != nil {
+		return nil, err
+	}
+	webhooks, err := newWebhooks(config.Webhooks, config.Log)
+	if err != nil {
+		return nil, err
+	}
 	indexer := &indexer{
 		codec:                  codec.NewManager(codecMaxSize),
 		log:                    config.Log,
@@ -88,7 +110,14 @@ func NewIndexer(config Config) (Indexer, error) {
 		txIndices:              map[ids.ID]Index{},
 		vtxIndices:             map[ids.ID]Index{},
 		blockIndices:           map[ids.ID]Index{},
+		indexedChains:          map[ids.ID]*indexedChain{},
+		reindexes:              map[ids.ID]*reindex{},
+		exports:                map[ids.ID]*indexExport{},
+		exportDir:              config.ExportDir,
 		pathAdder:              config.APIServer,
+		grpcAdder:              config.GRPCServer,
+		webhooks:               webhooks,
+		retentions:             config.Retentions,
 		shutdownF:              config.ShutdownF,
 	}
 
@@ -122,6 +151,15 @@ type indexer struct {
 
 	// Used to add API endpoint for new indices
 	pathAdder server.PathAdder
+	// Used to add gRPC services for new indices, if non-nil
+	grpcAdder server.GRPCAdder
+
+	// Webhook name --> webhook. Webhooks start delivering once their index
+	// is registered.
+	webhooks map[string]*webhook
+
+	// Limits which containers of indices are kept
+	retentions []RetentionConfig
 
 	// If true, allow running in such a way that could allow the creation
 	// of an index which could be missing accepted containers.
@@ -136,6 +174,14 @@ type indexer struct {
 	vtxIndices map[ids.ID]Index
 	// Chain ID --> index of txs of that chain (if applicable)
 	txIndices map[ids.ID]Index
+	// Chain ID --> chain whose indices were registered
+	indexedChains map[ids.ID]*indexedChain
+	// Chain ID --> the last reindex of that chain
+	reindexes map[ids.ID]*reindex
+	// Chain ID --> the last export of that chain
+	exports map[ids.ID]*indexExport
+	// Directory that exports are written to
+	exportDir string
 
 	// Notifies of newly accepted transactions
 	decisionAcceptorGroup snow.AcceptorGroup
@@ -173,6 +219,17 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		return
 	}
 
+	// A reindex that didn't finish in a previous run is resumed, which
+	// completes the index
+	reindexing, err := i.isReindexing(chainID)
+	if err != nil {
+		i.log.Error("couldn't get whether chain %s is being reindexed: %s", name, err)
+		if err := i.close(); err != nil {
+			i.log.Error("error while closing indexer: %s", err)
+		}
+		return
+	}
+
 	// See if this chain was indexed in a previous run
 	previouslyIndexed, err := i.previouslyIndexed(chainID)
 	if err != nil {
@@ -206,7 +263,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		return
 	}
 
-	if !i.allowIncompleteIndex && isIncomplete && (previouslyIndexed || i.hasRunBefore) {
+	if !i.allowIncompleteIndex && isIncomplete && !reindexing && (previouslyIndexed || i.hasRunBefore) {
 		i.log.Fatal("index %s is incomplete but incomplete indices are disabled. Shutting down", name)
 		if err := i.close(); err != nil {
 			i.log.Error("error while closing indexer: %s", err)
@@ -234,6 +291,11 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 			return
 		}
 		i.blockIndices[chainID] = index
+		i.indexedChains[chainID] = &indexedChain{
+			name:    name,
+			engine:  engine,
+			indices: []Index{index},
+		}
 	case axia.Engine:
 		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup)
 		if err != nil {
@@ -254,6 +316,11 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 			return
 		}
 		i.txIndices[chainID] = txIndex
+		i.indexedChains[chainID] = &indexedChain{
+			name:    name,
+			engine:  engine,
+			indices: []Index{vtxIndex, txIndex},
+		}
 	default:
 		i.log.Error("got unexpected engine type %T", engine)
 		if err := i.close(); err != nil {
@@ -261,6 +328,34 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		return
 	}
+
+	if reindexing {
+		if err := i.startReindex(chainID, i.indexedChains[chainID], true); err != nil {
+			i.log.Fatal("couldn't resume reindexing chain %s: %s", name, err)
+			if err := i.close(); err != nil {
+				i.log.Error("error while closing indexer: %s", err)
+			}
+		}
+	}
+}
+
+// indexedChain is a chain whose indices have been registered
+type indexedChain struct {
+	name   string
+	engine common.Engine
+	// The indices of the chain, in the order they're registered in
+	indices []Index
+}
+
+// getIndexedChain returns the indexed chain whose ID or alias is [chain].
+// Assumes [i.lock] is held.
+func (i *indexer) getIndexedChain(chain string) (ids.ID, *indexedChain, error) {
+	for chainID, c := range i.indexedChains {
+		if chain == c.name || chain == chainID.String() {
+			return chainID, c, nil
+		}
+	}
+	return ids.Empty, nil, fmt.Errorf("%w: %s", errNotIndexed, chain)
 }
 
 func (i *indexer) registerChainHelper(
@@ -273,11 +368,24 @@ func (i *indexer) registerChainHelper(
 	copy(prefix, chainID[:])
 	prefix[hashing.HashLen] = prefixEnd
 	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.codec, i.clock)
+	// The retentions are only checked for duplicates by chain ID and alias
+	// once the alias of the chain is known.
+	retention := RetentionConfig{}
+	for _, r := range i.retentions {
+		if !indexMatches(r.Chain, r.Index, chainID, name, endpoint) {
+			continue
+		}
+		if retention.hasLimit() {
+			return nil, fmt.Errorf("%w: %s/%s and %s/%s", errDuplicateRetention, retention.Chain, retention.Index, r.Chain, r.Index)
+		}
+		retention = r
+	}
+	baseIndex, err := newIndex(indexDB, i.log, i.codec, i.clock, retention)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
 	}
+	index := newStreamingIndex(baseIndex, i.log)
 
 	// Register index to learn about new accepted vertices
 	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
@@ -286,19 +394,47 @@ func (i *indexer) registerChainHelper(
 	}
 
 	// Create an API endpoint for this index
-	apiServer := rpc.NewServer()
+	apiServer := schema.NewServer()
 	codec := json.NewCodec()
 	apiServer.RegisterCodec(codec, "application/json")
 	apiServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	if err := apiServer.RegisterService(&service{Index: index}, "index"); err != nil {
+	service := &service{Index: index}
+	if err := apiServer.RegisterService(service, "index"); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
-	handler := &common.HTTPHandler{LockOptions: common.NoLock, Handler: apiServer}
+	// WebSocket upgrade requests to the endpoint subscribe to the containers
+	// accepted into the index
+	handler := &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler: schema.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				index.ServeSubscription(w, r)
+				return
+			}
+			apiServer.ServeHTTP(w, r)
+		}), apiServer),
+	}
 	if err := i.pathAdder.AddRoute(handler, &sync.RWMutex{}, "index/"+name, "/"+endpoint); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
+	if i.grpcAdder != nil {
+		grpcService := &common.GRPCService{
+			LockOptions: common.NoLock,
+			Name:        "index",
+			Desc:        &indexpb.Index_ServiceDesc,
+			Impl:        &grpcServer{service: service},
+		}
+		if err := i.grpcAdder.AddGRPCService(grpcService, &sync.RWMutex{}, fmt.Sprintf("index/%s/%s", name, endpoint)); err != nil {
+			_ = index.Close()
+			return nil, err
+		}
+	}
+	if err := i.startWebhooks(chainID, prefixEnd, name, endpoint, index); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
 	return index, nil
 }
 
@@ -319,6 +455,22 @@ func (i *indexer) close() error {
 	}
 	i.closed = true
 
+	// Stop the reindexes, exports and webhooks before their indices are
+	// closed
+	for _, r := range i.reindexes {
+		close(r.stop)
+		<-r.stopped
+	}
+	for _, e := range i.exports {
+		close(e.stop)
+		<-e.stopped
+	}
+	for _, w := range i.webhooks {
+		if w.index != nil {
+			w.stop()
+		}
+	}
+
 	errs := &wrappers.Errs{}
 	for chainID, txIndex := range i.txIndices {
 		errs.Add(
@@ -344,6 +496,36 @@ func (i *indexer) close() error {
 	return errs.Err
 }
 
+// startWebhooks starts the webhooks that deliver from [index], which is at
+// [endpoint] of chain [chainID].
+func (i *indexer) startWebhooks(
+	chainID ids.ID,
+	prefixEnd byte,
+	name, endpoint string,
+	index *streamingIndex,
+) error {
+	prefix := make([]byte, hashing.HashLen+2*wrappers.ByteLen)
+	copy(prefix, chainID[:])
+	prefix[hashing.HashLen] = webhookPrefix
+	prefix[hashing.HashLen+wrappers.ByteLen] = prefixEnd
+	started := []*webhook(nil)
+	for webhookName, w := range i.webhooks {
+		if !w.matches(chainID, name, endpoint) {
+			continue
+		}
+		webhookDB := prefixdb.New([]byte(webhookName), prefixdb.New(prefix, i.db))
+		if err := w.start(index, webhookDB); err != nil {
+			for _, startedWebhook := range started {
+				startedWebhook.stop()
+			}
+			return err
+		}
+		started = append(started, w)
+		i.log.Info("started webhook %s of index %s/%s", webhookName, name, endpoint)
+	}
+	return nil
+}
+
 func (i *indexer) markIncomplete(chainID ids.ID) error {
 	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
 	copy(key, chainID[:])
@@ -539,3 +539,42 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chainEngine)
 	assert.Len(idxr.blockIndices, 0)
 }
+
+// Retentions that refer to the same index by chain ID and by alias can't be
+// told apart until the chain is registered
+func TestDuplicateRetentionByAlias(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain1Ctx := snow.DefaultConsensusContextTest()
+	chain1Ctx.ChainID = ids.GenerateTestID()
+
+	config := Config{
+		IndexingEnabled:        true,
+		AllowIncompleteIndex:   false,
+		Log:                    logging.NoLog{},
+		DB:                     versiondb.New(memdb.New()),
+		DecisionAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:              &apiServerMock{},
+		Retentions: []RetentionConfig{
+			{Chain: "chain1", Index: "block", MaxContainers: 100},
+			{Chain: chain1Ctx.ChainID.String(), Index: "block", MaxAge: time.Hour},
+		},
+		ShutdownF: func() {},
+	}
+	idxrIntf, err := NewIndexer(config)
+	assert.NoError(err)
+	idxr, ok := idxrIntf.(*indexer)
+	assert.True(ok)
+
+	// Registering the chain fails rather than picking one of the retentions
+	chainVM := smblockmocks.NewMockChainVM(ctrl)
+	chainEngine := &smengmocks.Engine{}
+	chainEngine.On("Context").Return(chain1Ctx)
+	chainEngine.On("GetVM").Return(chainVM)
+	idxr.RegisterChain("chain1", chainEngine)
+	assert.Len(idxr.blockIndices, 0)
+	assert.True(idxr.closed)
+}
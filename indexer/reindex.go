@@ -0,0 +1,777 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/snow/choices"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/axia"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/snowman"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/snowman/block"
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/timer"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+	vmindex "github.com/sankar-boro/axia-network-v2/vms/components/index"
+)
+
+const (
+	// Maximum number of containers walked or reindexed while the chain's
+	// context lock is held
+	reindexBatchSize = MaxFetchedByRange
+	// How often the progress of a reindex is logged
+	reindexLogFrequency = 30 * time.Second
+)
+
+var (
+	errNotIndexed       = errors.New("chain isn't indexed")
+	errAlreadyReindexed = errors.New("chain is already being reindexed")
+	errReindexExporting = errors.New("chain can't be reindexed while it's exported")
+	errReindexStopped   = errors.New("reindex stopped before it finished")
+	errNotChainVM       = errors.New("vm isn't a block.ChainVM")
+	errClosed           = errors.New("indexer is closed")
+
+	_ acceptedHistory = &blockHistory{}
+	_ acceptedHistory = &vertexHistory{}
+)
+
+// Reindexer rebuilds the indices of chains from the containers the chains have
+// accepted, e.g. to index a chain that was accepted before indexing was
+// enabled.
+type Reindexer interface {
+	// Reindex starts rebuilding the indices of [chain], an ID or alias, in the
+	// background. The indices are incomplete until the reindex finishes.
+	Reindex(chain string) error
+	// ReindexStatuses returns the progress of the reindexes started since the
+	// node started.
+	ReindexStatuses() []ReindexStatus
+}
+
+// ReindexStatus is the progress of rebuilding the indices of a chain
+type ReindexStatus struct {
+	Chain   string `json:"chain"`
+	ChainID ids.ID `json:"chainID"`
+	// True until every container the chain has accepted is reindexed
+	Active bool `json:"active"`
+	// Number of containers added to the indices of the chain
+	Reindexed json.Uint64 `json:"reindexed"`
+	StartTime time.Time   `json:"startTime"`
+	// Estimated time until the reindex finishes, if known
+	ETA string `json:"eta,omitempty"`
+	// Why the reindex failed, if it did
+	Error string `json:"error,omitempty"`
+}
+
+// rebuildableIndex is an index that can be rebuilt from the containers
+// accepted by its chain
+type rebuildableIndex interface {
+	Index
+	// startRebuild removes every container from the index. Until
+	// finishRebuild is called, Accept doesn't index containers; they're added
+	// with acceptRebuilt instead.
+	startRebuild() error
+	// resumeRebuild continues the rebuild of the index that was in progress
+	// in a previous run. The containers already in the index are kept.
+	resumeRebuild()
+	acceptRebuilt(ctx *snow.ConsensusContext, containerID ids.ID, containerBytes []byte) error
+	finishRebuild()
+}
+
+func (i *index) startRebuild() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	// The containers are deleted from [i.baseDB] directly, rather than
+	// through [i.vDB], so that a database without range tombstones deletes
+	// them in bounded batches rather than in a single commit.
+	i.vDB.Abort()
+	if err := i.baseDB.DeleteRange(nil, nil); err != nil {
+		return fmt.Errorf("couldn't delete containers: %w", err)
+	}
+	i.nextAcceptedIndex = 0
+	i.firstIndex = 0
+	i.rebuilding = true
+	return nil
+}
+
+func (i *index) resumeRebuild() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.rebuilding = true
+}
+
+func (i *index) acceptRebuilt(ctx *snow.ConsensusContext, containerID ids.ID, containerBytes []byte) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.accept(ctx, containerID, containerBytes)
+}
+
+func (i *index) finishRebuild() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.rebuilding = false
+}
+
+func (i *streamingIndex) startRebuild() error {
+	return i.Index.(rebuildableIndex).startRebuild()
+}
+
+func (i *streamingIndex) resumeRebuild() {
+	i.Index.(rebuildableIndex).resumeRebuild()
+}
+
+func (i *streamingIndex) acceptRebuilt(ctx *snow.ConsensusContext, containerID ids.ID, containerBytes []byte) error {
+	if err := i.Index.(rebuildableIndex).acceptRebuilt(ctx, containerID, containerBytes); err != nil {
+		return err
+	}
+	i.notifyListeners()
+	return nil
+}
+
+func (i *streamingIndex) finishRebuild() {
+	i.Index.(rebuildableIndex).finishRebuild()
+}
+
+// acceptedHistory walks the containers accepted by a chain, in order of
+// acceptance, to add them to the chain's indices.
+// Assumes the chain's context lock is held when its methods are called.
+type acceptedHistory interface {
+	// start removes every container from the indices
+	start() error
+	// resume continues adding containers to the indices after the last
+	// container added in a previous run
+	resume() error
+	// next walks or adds to the indices up to [max] of the containers that
+	// have been accepted but aren't in the indices. Returns true if every
+	// accepted container is in the indices.
+	next(max int) (bool, error)
+	// finish resumes adding containers to the indices when they're accepted
+	finish() error
+	// reindexed returns the number of containers added to the indices
+	reindexed() uint64
+	// progress returns the amount of work done and the total amount of work,
+	// or zero if the total isn't known yet
+	progress() (uint64, uint64)
+}
+
+// newAcceptedHistory returns the history of the chain run by [engine], whose
+// indices are [indices].
+func newAcceptedHistory(ctx *snow.ConsensusContext, engine common.Engine, indices []Index) (acceptedHistory, error) {
+	switch engine := engine.(type) {
+	case snowman.Engine:
+		vm, ok := engine.GetVM().(block.ChainVM)
+		if !ok {
+			return nil, errNotChainVM
+		}
+		h := &blockHistory{
+			ctx:   ctx,
+			vm:    vm,
+			index: indices[0].(rebuildableIndex),
+		}
+		// Prefer the height index of the VM to walking back parent links
+		if heightIndexedVM, ok := vm.(block.HeightIndexedChainVM); ok && heightIndexedVM.VerifyHeightIndex() == nil {
+			h.heightIndexedVM = heightIndexedVM
+		}
+		return h, nil
+	case axia.Engine:
+		h := &vertexHistory{
+			ctx:      ctx,
+			engine:   engine,
+			vtxIndex: indices[0].(rebuildableIndex),
+			txIndex:  indices[1].(rebuildableIndex),
+		}
+		// The VM may maintain its own index of accepted txs
+		if reindexer, ok := engine.GetVM().(vmindex.Reindexer); ok {
+			h.vmReindexer = reindexer
+		}
+		return h, nil
+	default:
+		return nil, fmt.Errorf("got unexpected engine type %T", engine)
+	}
+}
+
+// blockHistory is the history of a chain of blocks
+type blockHistory struct {
+	ctx   *snow.ConsensusContext
+	vm    block.ChainVM
+	index rebuildableIndex
+	// Used to look up the blocks to add to [index], if non-nil. Otherwise,
+	// the blocks are found by walking back parent links from the last
+	// accepted block.
+	heightIndexedVM block.HeightIndexedChainVM
+
+	// Height of the last block added to [index]. The genesis block, at
+	// height 0, isn't indexed.
+	height uint64
+	// Height of the last accepted block
+	lastAcceptedHeight uint64
+	// True if parent links are being walked back from the last accepted block
+	walking bool
+	// Next block to walk back to
+	walkID ids.ID
+	// IDs of the blocks walked back to that haven't been added to [index],
+	// newest first
+	pending  []ids.ID
+	numAdded uint64
+}
+
+func (h *blockHistory) start() error {
+	return h.index.startRebuild()
+}
+
+func (h *blockHistory) resume() error {
+	h.index.resumeRebuild()
+	container, err := h.index.GetLastAccepted()
+	if err != nil {
+		// No block has been added to the index
+		return nil
+	}
+	blk, err := h.vm.GetBlock(container.ID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", container.ID, err)
+	}
+	h.height = blk.Height()
+	return nil
+}
+
+func (h *blockHistory) next(max int) (bool, error) {
+	lastAcceptedID, err := h.vm.LastAccepted()
+	if err != nil {
+		return false, fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	lastAccepted, err := h.vm.GetBlock(lastAcceptedID)
+	if err != nil {
+		return false, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	h.lastAcceptedHeight = lastAccepted.Height()
+
+	for n := 0; n < max; n++ {
+		switch {
+		case h.heightIndexedVM != nil:
+			if h.height >= h.lastAcceptedHeight {
+				return true, nil
+			}
+			blkID, err := h.heightIndexedVM.GetBlockIDAtHeight(h.height + 1)
+			if err != nil {
+				return false, fmt.Errorf("couldn't get block at height %d: %w", h.height+1, err)
+			}
+			if err := h.add(blkID); err != nil {
+				return false, err
+			}
+		case h.walking:
+			blk, err := h.vm.GetBlock(h.walkID)
+			if err != nil {
+				return false, fmt.Errorf("couldn't get block %s: %w", h.walkID, err)
+			}
+			if blk.Height() <= h.height {
+				h.walking = false
+				continue
+			}
+			h.pending = append(h.pending, blk.ID())
+			h.walkID = blk.Parent()
+		case len(h.pending) != 0:
+			blkID := h.pending[len(h.pending)-1]
+			h.pending = h.pending[:len(h.pending)-1]
+			if err := h.add(blkID); err != nil {
+				return false, err
+			}
+		case h.height >= h.lastAcceptedHeight:
+			return true, nil
+		default:
+			// Walk back from the last accepted block to the last block added
+			// to [index]
+			h.walking = true
+			h.walkID = lastAcceptedID
+		}
+	}
+	return false, nil
+}
+
+func (h *blockHistory) add(blkID ids.ID) error {
+	blk, err := h.vm.GetBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+	}
+	if err := h.index.acceptRebuilt(h.ctx, blkID, blk.Bytes()); err != nil {
+		return fmt.Errorf("couldn't index block %s: %w", blkID, err)
+	}
+	h.height = blk.Height()
+	h.numAdded++
+	return nil
+}
+
+func (h *blockHistory) finish() error {
+	h.index.finishRebuild()
+	return nil
+}
+
+func (h *blockHistory) reindexed() uint64 { return h.numAdded }
+
+func (h *blockHistory) progress() (uint64, uint64) {
+	if h.heightIndexedVM != nil {
+		return h.height, h.lastAcceptedHeight
+	}
+	// Each block is walked back to and then added to the index
+	return 2*h.height + uint64(len(h.pending)), 2 * h.lastAcceptedHeight
+}
+
+// vertexHistory is the history of a DAG. Vertices are reindexed in order of
+// height, which is consistent with, but may differ from, the order they were
+// accepted in.
+type vertexHistory struct {
+	ctx      *snow.ConsensusContext
+	engine   axia.Engine
+	vtxIndex rebuildableIndex
+	txIndex  rebuildableIndex
+	// Rebuilds the VM's own index of accepted txs, if non-nil
+	vmReindexer vmindex.Reindexer
+
+	// True if parents are being walked back from the accepted frontier
+	walking bool
+	// Vertices to walk back to
+	toWalk []ids.ID
+	// Vertices walked back to
+	walked ids.Set
+	// Vertices walked back to that haven't been added to [vtxIndex], ordered
+	// by height once the walk is done
+	pending     []pendingVertex
+	numAdded    uint64
+	numAddedTxs uint64
+}
+
+type pendingVertex struct {
+	vtxID  ids.ID
+	height uint64
+}
+
+func (h *vertexHistory) start() error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		h.vtxIndex.startRebuild(),
+		h.txIndex.startRebuild(),
+	)
+	if h.vmReindexer != nil {
+		errs.Add(h.vmReindexer.StartReindex())
+	}
+	return errs.Err
+}
+
+// resume walks back from the accepted frontier to the vertices that are in
+// [vtxIndex], so the vertices added in a previous run aren't added again.
+func (h *vertexHistory) resume() error {
+	h.vtxIndex.resumeRebuild()
+	h.txIndex.resumeRebuild()
+	if h.vmReindexer != nil {
+		return h.vmReindexer.ResumeReindex()
+	}
+	return nil
+}
+
+func (h *vertexHistory) next(max int) (bool, error) {
+	for n := 0; n < max; n++ {
+		switch {
+		case h.walking && len(h.toWalk) != 0:
+			vtxID := h.toWalk[len(h.toWalk)-1]
+			h.toWalk = h.toWalk[:len(h.toWalk)-1]
+			if err := h.walk(vtxID); err != nil {
+				return false, err
+			}
+		case h.walking:
+			h.walking = false
+			h.walked = nil
+			sort.SliceStable(h.pending, func(i, j int) bool {
+				return h.pending[i].height < h.pending[j].height
+			})
+		case len(h.pending) != 0:
+			vtxID := h.pending[0].vtxID
+			h.pending = h.pending[1:]
+			if err := h.add(vtxID); err != nil {
+				return false, err
+			}
+		default:
+			// Walk back from the accepted frontier to the vertices that are
+			// in [vtxIndex]
+			h.toWalk = nil
+			for _, vtxID := range h.engine.Edge() {
+				if _, err := h.vtxIndex.GetIndex(vtxID); err == database.ErrNotFound {
+					h.toWalk = append(h.toWalk, vtxID)
+				}
+			}
+			if len(h.toWalk) == 0 {
+				return true, nil
+			}
+			h.walking = true
+			h.walked = ids.Set{}
+		}
+	}
+	return false, nil
+}
+
+// walk adds [vtxID] to [h.pending] and its parents that aren't in [vtxIndex]
+// to [h.toWalk].
+func (h *vertexHistory) walk(vtxID ids.ID) error {
+	if h.walked.Contains(vtxID) {
+		return nil
+	}
+	h.walked.Add(vtxID)
+
+	vtx, err := h.engine.GetVtx(vtxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get vertex %s: %w", vtxID, err)
+	}
+	height, err := vtx.Height()
+	if err != nil {
+		return fmt.Errorf("couldn't get height of vertex %s: %w", vtxID, err)
+	}
+	h.pending = append(h.pending, pendingVertex{
+		vtxID:  vtxID,
+		height: height,
+	})
+
+	parents, err := vtx.Parents()
+	if err != nil {
+		return fmt.Errorf("couldn't get parents of vertex %s: %w", vtxID, err)
+	}
+	for _, parent := range parents {
+		parentID := parent.ID()
+		if _, err := h.vtxIndex.GetIndex(parentID); err == database.ErrNotFound {
+			h.toWalk = append(h.toWalk, parentID)
+		}
+	}
+	return nil
+}
+
+// add adds the txs of [vtxID] and then [vtxID] itself to the indices, in the
+// order they're accepted in.
+func (h *vertexHistory) add(vtxID ids.ID) error {
+	vtx, err := h.engine.GetVtx(vtxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get vertex %s: %w", vtxID, err)
+	}
+	txs, err := vtx.Txs()
+	if err != nil {
+		return fmt.Errorf("couldn't get txs of vertex %s: %w", vtxID, err)
+	}
+	for _, tx := range txs {
+		txID := tx.ID()
+		if tx.Status() != choices.Accepted {
+			continue
+		}
+		// A tx may be in multiple vertices
+		if _, err := h.txIndex.GetIndex(txID); err != database.ErrNotFound {
+			continue
+		}
+		// The tx is reindexed in the VM first, since if the reindex is
+		// interrupted in between, the VM ignores the tx being reindexed again
+		// once the reindex is resumed.
+		if h.vmReindexer != nil {
+			if err := h.vmReindexer.ReindexTx(txID); err != nil {
+				return fmt.Errorf("couldn't reindex tx %s in the VM: %w", txID, err)
+			}
+		}
+		if err := h.txIndex.acceptRebuilt(h.ctx, txID, tx.Bytes()); err != nil {
+			return fmt.Errorf("couldn't index tx %s: %w", txID, err)
+		}
+		h.numAddedTxs++
+	}
+	if err := h.vtxIndex.acceptRebuilt(h.ctx, vtxID, vtx.Bytes()); err != nil {
+		return fmt.Errorf("couldn't index vertex %s: %w", vtxID, err)
+	}
+	h.numAdded++
+	return nil
+}
+
+func (h *vertexHistory) finish() error {
+	h.vtxIndex.finishRebuild()
+	h.txIndex.finishRebuild()
+	if h.vmReindexer != nil {
+		return h.vmReindexer.FinishReindex()
+	}
+	return nil
+}
+
+func (h *vertexHistory) reindexed() uint64 { return h.numAdded + h.numAddedTxs }
+
+func (h *vertexHistory) progress() (uint64, uint64) {
+	if h.walking {
+		// The number of vertices isn't known until the walk is done
+		return 0, 0
+	}
+	return h.numAdded, h.numAdded + uint64(len(h.pending))
+}
+
+// reindex rebuilds the indices of a chain in the background
+type reindex struct {
+	log     logging.Logger
+	ctx     *snow.ConsensusContext
+	name    string
+	history acceptedHistory
+	// Called, with the chain's context lock held, once the indices are
+	// rebuilt
+	onFinish func() error
+
+	// Closed to stop the reindex
+	stop chan struct{}
+	// Closed when the reindex stops
+	stopped chan struct{}
+
+	// Progress of [history] when this reindex started. The ETA is estimated
+	// from the progress made since then, as a resumed reindex may have started
+	// with most of the history already reindexed.
+	startProgress uint64
+
+	lock      sync.RWMutex
+	startTime time.Time
+	active    bool
+	numAdded  uint64
+	eta       time.Duration
+	err       error
+}
+
+func (r *reindex) run() {
+	defer close(r.stopped)
+
+	lastLog := r.startTime
+	for {
+		select {
+		case <-r.stop:
+			r.setErr(errReindexStopped)
+			return
+		default:
+		}
+
+		r.ctx.Lock.Lock()
+		done, err := r.history.next(reindexBatchSize)
+		if err == nil && done {
+			err = r.history.finish()
+			if err == nil {
+				err = r.onFinish()
+			}
+		}
+		numAdded := r.history.reindexed()
+		progress, end := r.history.progress()
+		r.ctx.Lock.Unlock()
+
+		eta := time.Duration(0)
+		if progress > r.startProgress && end > r.startProgress {
+			eta = timer.EstimateETA(r.startTime, progress-r.startProgress, end-r.startProgress)
+		}
+		r.lock.Lock()
+		r.numAdded = numAdded
+		r.eta = eta
+		r.lock.Unlock()
+
+		if err != nil {
+			r.log.Error("couldn't reindex chain %s: %s", r.name, err)
+			r.setErr(err)
+			return
+		}
+		if done {
+			r.log.Info("reindexed %d containers of chain %s in %s", numAdded, r.name, time.Since(r.startTime))
+			r.lock.Lock()
+			r.active = false
+			r.lock.Unlock()
+			return
+		}
+		if time.Since(lastLog) >= reindexLogFrequency {
+			lastLog = time.Now()
+			r.log.Info("reindexed %d containers of chain %s. ETA = %s", numAdded, r.name, eta)
+		}
+	}
+}
+
+func (r *reindex) setErr(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.active = false
+	r.err = err
+}
+
+func (r *reindex) status() ReindexStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	status := ReindexStatus{
+		Chain:     r.name,
+		ChainID:   r.ctx.ChainID,
+		Active:    r.active,
+		Reindexed: json.Uint64(r.numAdded),
+		StartTime: r.startTime,
+	}
+	if r.active && r.eta != 0 {
+		status.ETA = r.eta.String()
+	}
+	if r.err != nil {
+		status.Error = r.err.Error()
+	}
+	return status
+}
+
+// Reindex starts rebuilding the indices of [chain] from the containers it has
+// accepted. Containers accepted while the chain is reindexed are added to the
+// indices by the reindex, so the indices are rebuilt in order of acceptance.
+// If the node stops before the reindex finishes, the reindex is resumed when
+// the chain is registered again.
+// See Reindexer
+func (i *indexer) Reindex(chain string) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.closed {
+		return errClosed
+	}
+
+	chainID, c, err := i.getIndexedChain(chain)
+	if err != nil {
+		return err
+	}
+	if r, ok := i.reindexes[chainID]; ok && r.status().Active {
+		return fmt.Errorf("%w: %s", errAlreadyReindexed, chain)
+	}
+	if e, ok := i.exports[chainID]; ok && e.status().Active {
+		return fmt.Errorf("%w: %s", errReindexExporting, chain)
+	}
+	return i.startReindex(chainID, c, false)
+}
+
+// startReindex rebuilds the indices of [c] in the background. If [resume],
+// the reindex continues where the reindex of a previous run stopped.
+// Assumes [i.lock] is held.
+func (i *indexer) startReindex(chainID ids.ID, c *indexedChain, resume bool) error {
+	history, err := newAcceptedHistory(c.engine.Context(), c.engine, c.indices)
+	if err != nil {
+		return err
+	}
+	r := &reindex{
+		log:     i.log,
+		ctx:     c.engine.Context(),
+		name:    c.name,
+		history: history,
+		onFinish: func() error {
+			return i.markComplete(chainID)
+		},
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		startTime: time.Now(),
+		active:    true,
+	}
+
+	// A reindex that was interrupted before the indices were cleared is
+	// started over
+	started := false
+	if resume {
+		started, err = i.reindexStarted(chainID)
+		if err != nil {
+			return fmt.Errorf("couldn't get reindex progress of chain %s: %w", c.name, err)
+		}
+	}
+
+	r.ctx.Lock.Lock()
+	if started {
+		err = history.resume()
+	} else {
+		// The indices are incomplete until the reindex finishes
+		err = i.markReindexing(chainID, false)
+		if err == nil {
+			err = history.start()
+		}
+		if err == nil {
+			err = i.markReindexing(chainID, true)
+		}
+	}
+	if err == nil {
+		r.startProgress, _ = history.progress()
+	}
+	r.ctx.Lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("couldn't start reindexing chain %s: %w", c.name, err)
+	}
+
+	i.reindexes[chainID] = r
+	if started {
+		i.log.Info("resuming reindex of chain %s", c.name)
+	} else {
+		i.log.Info("reindexing chain %s", c.name)
+	}
+	go i.log.RecoverAndPanic(r.run)
+	return nil
+}
+
+// ReindexStatuses returns the progress of the reindexes started since the node
+// started.
+// See Reindexer
+func (i *indexer) ReindexStatuses() []ReindexStatus {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	statuses := make([]ReindexStatus, 0, len(i.reindexes))
+	for _, r := range i.reindexes {
+		statuses = append(statuses, r.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Chain < statuses[j].Chain
+	})
+	return statuses
+}
+
+// markReindexing records that the indices of [chainID] are being rebuilt, and
+// whether the containers were removed from them, so that the reindex can be
+// resumed after a restart. The indices are incomplete until the reindex
+// finishes.
+func (i *indexer) markReindexing(chainID ids.ID, started bool) error {
+	if err := i.markIncomplete(chainID); err != nil {
+		return err
+	}
+	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[hashing.HashLen] = isReindexingPrefix
+	return database.PutBool(i.db, key, started)
+}
+
+// isReindexing returns true if the indices of [chainID] were being rebuilt
+// when the node stopped
+func (i *indexer) isReindexing(chainID ids.ID) (bool, error) {
+	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[hashing.HashLen] = isReindexingPrefix
+	return i.db.Has(key)
+}
+
+// reindexStarted returns true if the containers were removed from the indices
+// of [chainID] by the reindex in progress
+func (i *indexer) reindexStarted(chainID ids.ID) (bool, error) {
+	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[hashing.HashLen] = isReindexingPrefix
+	started, err := database.GetBool(i.db, key)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	return started, err
+}
+
+func (i *indexer) markComplete(chainID ids.ID) error {
+	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[hashing.HashLen] = isReindexingPrefix
+	if err := i.db.Delete(key); err != nil {
+		return err
+	}
+	key[hashing.HashLen] = isIncompletePrefix
+	return i.db.Delete(key)
+}
@@ -0,0 +1,443 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/snow/choices"
+	"github.com/sankar-boro/axia-network-v2/snow/consensus/axia"
+	"github.com/sankar-boro/axia-network-v2/snow/consensus/snowman"
+	"github.com/sankar-boro/axia-network-v2/snow/consensus/snowstorm"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/snowman/block"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+
+	axiaeng "github.com/sankar-boro/axia-network-v2/snow/engine/axia"
+)
+
+// testChain is a chain of accepted blocks served by a test VM
+type testChain struct {
+	blocks []*snowman.TestBlock
+	vm     *block.TestVM
+}
+
+func newTestChain(t *testing.T, numBlocks int) *testChain {
+	c := &testChain{
+		vm: &block.TestVM{},
+	}
+	c.vm.T = t
+	c.vm.LastAcceptedF = func() (ids.ID, error) {
+		return c.blocks[len(c.blocks)-1].ID(), nil
+	}
+	c.vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range c.blocks {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	for i := 0; i <= numBlocks; i++ {
+		c.add()
+	}
+	return c
+}
+
+// add accepts a new block, which is returned
+func (c *testChain) add() *snowman.TestBlock {
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: uint64(len(c.blocks)),
+		BytesV:  utils.RandomBytes(32),
+	}
+	if len(c.blocks) != 0 {
+		blk.ParentV = c.blocks[len(c.blocks)-1].ID()
+	}
+	c.blocks = append(c.blocks, blk)
+	return blk
+}
+
+func TestBlockHistoryReindex(t *testing.T) {
+	for _, heightIndexed := range []bool{false, true} {
+		heightIndexed := heightIndexed
+		t.Run(map[bool]string{false: "parent links", true: "height index"}[heightIndexed], func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := snow.DefaultConsensusContextTest()
+			index, _ := newTestStreamingIndex(t)
+			chain := newTestChain(t, 5)
+
+			h := &blockHistory{
+				ctx:   ctx,
+				vm:    chain.vm,
+				index: index,
+			}
+			if heightIndexed {
+				h.heightIndexedVM = &block.TestHeightIndexedVM{
+					T: t,
+					GetBlockIDAtHeightF: func(height uint64) (ids.ID, error) {
+						return chain.blocks[height].ID(), nil
+					},
+				}
+			}
+
+			// The containers in the index are removed
+			assert.NoError(index.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+			assert.NoError(h.start())
+			_, err := index.GetLastAccepted()
+			assert.Error(err)
+
+			done, err := h.next(2)
+			assert.NoError(err)
+			assert.False(done)
+
+			// Blocks accepted while reindexing are added by the reindex
+			blk := chain.add()
+			assert.NoError(index.Accept(ctx, blk.ID(), blk.Bytes()))
+			_, err = index.GetIndex(blk.ID())
+			assert.Equal(database.ErrNotFound, err)
+
+			for !done {
+				done, err = h.next(2)
+				assert.NoError(err)
+				progress, end := h.progress()
+				assert.LessOrEqual(progress, end)
+			}
+			assert.NoError(h.finish())
+			assert.EqualValues(6, h.reindexed())
+
+			// The genesis block isn't indexed
+			containers, err := index.GetContainerRange(0, 10)
+			assert.NoError(err)
+			assert.Len(containers, 6)
+			for i, container := range containers {
+				assert.Equal(chain.blocks[i+1].ID(), container.ID)
+				assert.Equal(chain.blocks[i+1].Bytes(), container.Bytes)
+			}
+
+			// Accepted blocks are indexed once the reindex finishes
+			blk = chain.add()
+			assert.NoError(index.Accept(ctx, blk.ID(), blk.Bytes()))
+			i, err := index.GetIndex(blk.ID())
+			assert.NoError(err)
+			assert.EqualValues(6, i)
+		})
+	}
+}
+
+// A reindex interrupted by a restart continues after the last indexed block
+func TestBlockHistoryResume(t *testing.T) {
+	assert := assert.New(t)
+	ctx := snow.DefaultConsensusContextTest()
+	index, _ := newTestStreamingIndex(t)
+	chain := newTestChain(t, 5)
+
+	h := &blockHistory{
+		ctx:   ctx,
+		vm:    chain.vm,
+		index: index,
+	}
+	assert.NoError(h.start())
+	// Starting the walk, walking back to each block and ending the walk take
+	// a step each, then 2 blocks are added
+	done, err := h.next(9)
+	assert.NoError(err)
+	assert.False(done)
+	assert.EqualValues(2, h.reindexed())
+
+	// Restart
+	index.finishRebuild()
+	h = &blockHistory{
+		ctx:   ctx,
+		vm:    chain.vm,
+		index: index,
+	}
+	assert.NoError(h.resume())
+	assert.EqualValues(2, h.height)
+
+	// Blocks accepted while reindexing are added by the reindex
+	blk := chain.add()
+	assert.NoError(index.Accept(ctx, blk.ID(), blk.Bytes()))
+	for !done {
+		done, err = h.next(reindexBatchSize)
+		assert.NoError(err)
+	}
+	assert.NoError(h.finish())
+	assert.EqualValues(4, h.reindexed())
+
+	containers, err := index.GetContainerRange(0, 10)
+	assert.NoError(err)
+	assert.Len(containers, 6)
+	for i, container := range containers {
+		assert.Equal(chain.blocks[i+1].ID(), container.ID)
+	}
+}
+
+// The progress of a reindex is persisted until it finishes
+func TestReindexMarkers(t *testing.T) {
+	assert := assert.New(t)
+	idxr := &indexer{db: memdb.New()}
+	chainID := ids.GenerateTestID()
+
+	reindexing, err := idxr.isReindexing(chainID)
+	assert.NoError(err)
+	assert.False(reindexing)
+
+	for _, started := range []bool{false, true} {
+		assert.NoError(idxr.markReindexing(chainID, started))
+		reindexing, err = idxr.isReindexing(chainID)
+		assert.NoError(err)
+		assert.True(reindexing)
+		reindexStarted, err := idxr.reindexStarted(chainID)
+		assert.NoError(err)
+		assert.Equal(started, reindexStarted)
+		incomplete, err := idxr.isIncomplete(chainID)
+		assert.NoError(err)
+		assert.True(incomplete)
+	}
+
+	assert.NoError(idxr.markComplete(chainID))
+	reindexing, err = idxr.isReindexing(chainID)
+	assert.NoError(err)
+	assert.False(reindexing)
+	incomplete, err := idxr.isIncomplete(chainID)
+	assert.NoError(err)
+	assert.False(incomplete)
+}
+
+type testVMReindexer struct {
+	started, resumed, finished bool
+	txIDs                      []ids.ID
+}
+
+func (r *testVMReindexer) StartReindex() error { r.started = true; return nil }
+
+func (r *testVMReindexer) ResumeReindex() error { r.resumed = true; return nil }
+
+func (r *testVMReindexer) ReindexTx(txID ids.ID) error {
+	r.txIDs = append(r.txIDs, txID)
+	return nil
+}
+
+func (r *testVMReindexer) FinishReindex() error { r.finished = true; return nil }
+
+func newTestTx() *snowstorm.TestTx {
+	return &snowstorm.TestTx{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: utils.RandomBytes(32),
+	}
+}
+
+func newTestVtx(height uint64, parents []axia.Vertex, txs []snowstorm.Tx) *axia.TestVertex {
+	return &axia.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV:  height,
+		ParentsV: parents,
+		TxsV:     txs,
+		BytesV:   utils.RandomBytes(32),
+	}
+}
+
+func TestVertexHistoryReindex(t *testing.T) {
+	assert := assert.New(t)
+	ctx := snow.DefaultConsensusContextTest()
+	vtxIndex, _ := newTestStreamingIndex(t)
+	txIndex, _ := newTestStreamingIndex(t)
+
+	txs := []*snowstorm.TestTx{newTestTx(), newTestTx(), newTestTx(), newTestTx()}
+	vtx0 := newTestVtx(0, nil, []snowstorm.Tx{txs[0]})
+	vtx1 := newTestVtx(1, []axia.Vertex{vtx0}, []snowstorm.Tx{txs[1]})
+	vtx2 := newTestVtx(1, []axia.Vertex{vtx0}, []snowstorm.Tx{txs[2]})
+	// A tx may be in multiple vertices
+	vtx3 := newTestVtx(2, []axia.Vertex{vtx1, vtx2}, []snowstorm.Tx{txs[1], txs[3]})
+	vtxs := []*axia.TestVertex{vtx0, vtx1, vtx2, vtx3}
+	edge := []ids.ID{vtx1.ID(), vtx2.ID()}
+
+	engine := &axiaeng.EngineTest{}
+	engine.T = t
+	engine.EdgeF = func() []ids.ID { return edge }
+	engine.GetVtxF = func(vtxID ids.ID) (axia.Vertex, error) {
+		for _, vtx := range vtxs {
+			if vtx.ID() == vtxID {
+				return vtx, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vmReindexer := &testVMReindexer{}
+	h := &vertexHistory{
+		ctx:         ctx,
+		engine:      engine,
+		vtxIndex:    vtxIndex,
+		txIndex:     txIndex,
+		vmReindexer: vmReindexer,
+	}
+
+	assert.NoError(h.start())
+	assert.True(vmReindexer.started)
+	// Starting and ending the walk back from the accepted frontier take a
+	// step each, and vtx0 is walked to twice
+	done, err := h.next(6)
+	assert.NoError(err)
+	assert.False(done)
+	progress, end := h.progress()
+	assert.Zero(progress)
+	assert.EqualValues(3, end)
+
+	// Vertices accepted while reindexing are added by the reindex
+	edge = []ids.ID{vtx3.ID()}
+	assert.NoError(vtxIndex.Accept(ctx, vtx3.ID(), vtx3.Bytes()))
+	for !done {
+		done, err = h.next(reindexBatchSize)
+		assert.NoError(err)
+	}
+	assert.NoError(h.finish())
+	assert.True(vmReindexer.finished)
+	assert.EqualValues(8, h.reindexed())
+
+	// Vertices are indexed in order of height
+	vtxIndices := make([]uint64, len(vtxs))
+	for i, vtx := range vtxs {
+		vtxIndices[i], err = vtxIndex.GetIndex(vtx.ID())
+		assert.NoError(err)
+	}
+	assert.EqualValues(0, vtxIndices[0])
+	assert.ElementsMatch([]uint64{1, 2}, vtxIndices[1:3])
+	assert.EqualValues(3, vtxIndices[3])
+
+	// Txs are indexed once, before their vertex
+	containers, err := txIndex.GetContainerRange(0, 10)
+	assert.NoError(err)
+	assert.Len(containers, len(txs))
+	assert.Equal(txs[0].ID(), containers[0].ID)
+	assert.Equal(txs[3].ID(), containers[3].ID)
+	txIDs := make([]ids.ID, len(containers))
+	for i, container := range containers {
+		txIDs[i] = container.ID
+	}
+	assert.Equal(txIDs, vmReindexer.txIDs)
+
+	// Resuming doesn't add the vertices and txs in the indices again
+	h = &vertexHistory{
+		ctx:         ctx,
+		engine:      engine,
+		vtxIndex:    vtxIndex,
+		txIndex:     txIndex,
+		vmReindexer: vmReindexer,
+	}
+	assert.NoError(h.resume())
+	assert.True(vmReindexer.resumed)
+	done, err = h.next(reindexBatchSize)
+	assert.NoError(err)
+	assert.True(done)
+	assert.NoError(h.finish())
+	assert.Zero(h.reindexed())
+}
+
+func TestReindexRun(t *testing.T) {
+	assert := assert.New(t)
+	ctx := snow.DefaultConsensusContextTest()
+	index, _ := newTestStreamingIndex(t)
+	chain := newTestChain(t, reindexBatchSize+10)
+
+	history := &blockHistory{
+		ctx:   ctx,
+		vm:    chain.vm,
+		index: index,
+	}
+	assert.NoError(history.start())
+	finished := false
+	r := &reindex{
+		log:     logging.NoLog{},
+		ctx:     ctx,
+		name:    "C",
+		history: history,
+		onFinish: func() error {
+			finished = true
+			return nil
+		},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		active:  true,
+	}
+	r.run()
+
+	assert.True(finished)
+	status := r.status()
+	assert.False(status.Active)
+	assert.Empty(status.Error)
+	assert.EqualValues(reindexBatchSize+10, status.Reindexed)
+	container, err := index.GetLastAccepted()
+	assert.NoError(err)
+	assert.Equal(chain.blocks[len(chain.blocks)-1].ID(), container.ID)
+}
+
+// testHistory is an acceptedHistory that reports fixed progress
+type testHistory struct {
+	acceptedHistory
+	progressed, end uint64
+	onNext          func()
+}
+
+func (h *testHistory) next(int) (bool, error) {
+	h.onNext()
+	return false, nil
+}
+
+func (h *testHistory) reindexed() uint64 { return h.progressed }
+
+func (h *testHistory) progress() (uint64, uint64) { return h.progressed, h.end }
+
+// The ETA of a resumed reindex only counts the progress made since it resumed
+func TestReindexResumedETA(t *testing.T) {
+	assert := assert.New(t)
+	history := &testHistory{
+		progressed: 90,
+		end:        100,
+	}
+	r := &reindex{
+		log:           logging.NoLog{},
+		ctx:           snow.DefaultConsensusContextTest(),
+		name:          "C",
+		history:       history,
+		startProgress: 90,
+		stop:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		startTime:     time.Now().Add(-time.Minute),
+		active:        true,
+	}
+	history.onNext = func() {
+		history.progressed = 95
+		close(r.stop)
+	}
+	r.run()
+
+	// Half of the remaining work took a minute
+	assert.InDelta(float64(time.Minute), float64(r.eta), float64(time.Second))
+}
+
+func TestReindexUnknownChain(t *testing.T) {
+	idxr := &indexer{
+		indexedChains: map[ids.ID]*indexedChain{},
+		reindexes:     map[ids.ID]*reindex{},
+	}
+	assert.ErrorIs(t, idxr.Reindex("X"), errNotIndexed)
+	assert.Empty(t, idxr.ReindexStatuses())
+}
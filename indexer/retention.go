@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/math"
+)
+
+const (
+	// How often an index with a retention limit is pruned
+	pruneFrequency = time.Minute
+	// Maximum number of containers pruned at a time, so that pruning doesn't
+	// block the index for long
+	pruneBatchSize = MaxFetchedByRange
+)
+
+var (
+	errMissingRetentionChain = errors.New("retention chain must be specified")
+	errInvalidRetentionIndex = errors.New("retention index must be one of \"block\", \"vtx\" or \"tx\"")
+	errNoRetentionLimit      = errors.New("retention must limit the number or the age of containers")
+	errNegativeMaxAge        = errors.New("retention max age can't be negative")
+	errDuplicateRetention    = errors.New("duplicate retention")
+)
+
+// RetentionConfig limits which containers of an index are kept. Containers
+// outside of the limits are pruned in the background. The last accepted
+// container is never pruned.
+type RetentionConfig struct {
+	// Chain is the ID or alias, e.g. "X", of the chain the index belongs to
+	Chain string `json:"chain"`
+	// Index of the chain the retention applies to: "block", "vtx" or "tx"
+	Index string `json:"index"`
+	// If non-zero, only the last [MaxContainers] accepted containers are kept
+	MaxContainers uint64 `json:"maxContainers"`
+	// If non-zero, only the containers accepted within the last [MaxAge] are
+	// kept. Represented in JSON as a duration string, e.g. "720h".
+	MaxAge time.Duration `json:"maxAge"`
+}
+
+// retentionConfigJSON is the JSON representation of a RetentionConfig
+type retentionConfigJSON struct {
+	Chain         string `json:"chain"`
+	Index         string `json:"index"`
+	MaxContainers uint64 `json:"maxContainers"`
+	MaxAge        string `json:"maxAge,omitempty"`
+}
+
+func (c RetentionConfig) MarshalJSON() ([]byte, error) {
+	config := retentionConfigJSON{
+		Chain:         c.Chain,
+		Index:         c.Index,
+		MaxContainers: c.MaxContainers,
+	}
+	if c.MaxAge != 0 {
+		config.MaxAge = c.MaxAge.String()
+	}
+	return stdjson.Marshal(config)
+}
+
+func (c *RetentionConfig) UnmarshalJSON(b []byte) error {
+	config := retentionConfigJSON{}
+	if err := stdjson.Unmarshal(b, &config); err != nil {
+		return err
+	}
+	c.Chain = config.Chain
+	c.Index = config.Index
+	c.MaxContainers = config.MaxContainers
+	c.MaxAge = 0
+	if config.MaxAge != "" {
+		maxAge, err := time.ParseDuration(config.MaxAge)
+		if err != nil {
+			return fmt.Errorf("couldn't parse max age: %w", err)
+		}
+		c.MaxAge = maxAge
+	}
+	return nil
+}
+
+// Verify returns an error if the config is invalid
+func (c *RetentionConfig) Verify() error {
+	switch {
+	case c.Chain == "":
+		return errMissingRetentionChain
+	case !isIndexEndpoint(c.Index):
+		return errInvalidRetentionIndex
+	case c.MaxAge < 0:
+		return errNegativeMaxAge
+	case !c.hasLimit():
+		return errNoRetentionLimit
+	default:
+		return nil
+	}
+}
+
+func (c *RetentionConfig) hasLimit() bool {
+	return c.MaxContainers != 0 || c.MaxAge != 0
+}
+
+// verifyRetentions returns an error if any of [retentions] is invalid or if
+// multiple refer to the same index in the same way. Retentions that refer to
+// the same chain by its ID and by its alias are rejected once the chain is
+// registered.
+func verifyRetentions(retentions []RetentionConfig) error {
+	indices := make(map[string]struct{}, len(retentions))
+	for _, retention := range retentions {
+		if err := retention.Verify(); err != nil {
+			return fmt.Errorf("invalid retention of %s/%s: %w", retention.Chain, retention.Index, err)
+		}
+		key := retention.Chain + "/" + retention.Index
+		if _, exists := indices[key]; exists {
+			return fmt.Errorf("%w: %s", errDuplicateRetention, key)
+		}
+		indices[key] = struct{}{}
+	}
+	return nil
+}
+
+// isIndexEndpoint returns true if [endpoint] is the name of a kind of index
+func isIndexEndpoint(endpoint string) bool {
+	return endpoint == "block" || endpoint == "vtx" || endpoint == "tx"
+}
+
+// indexMatches returns true if [chain], which is a chain ID or alias, and
+// [endpoint] refer to the index at [indexEndpoint] of the chain with ID
+// [chainID] and name [chainName].
+func indexMatches(chain, endpoint string, chainID ids.ID, chainName, indexEndpoint string) bool {
+	return (chain == chainName || chain == chainID.String()) && endpoint == indexEndpoint
+}
+
+func (i *index) startPruning() {
+	i.pruneDone = make(chan struct{})
+	i.pruneStopped = make(chan struct{})
+	go i.pruneLoop()
+}
+
+// pruneLoop periodically prunes the containers outside of [i.retention] until
+// [i.pruneDone] is closed.
+func (i *index) pruneLoop() {
+	defer close(i.pruneStopped)
+
+	ticker := time.NewTicker(pruneFrequency)
+	defer ticker.Stop()
+
+	for {
+		for {
+			pruned, err := i.prune()
+			if err != nil {
+				i.log.Error("couldn't prune index: %s", err)
+				break
+			}
+			if pruned < pruneBatchSize {
+				break
+			}
+
+			select {
+			case <-i.pruneDone:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-i.pruneDone:
+			return
+		}
+	}
+}
+
+// prune removes up to [pruneBatchSize] of the oldest containers that are
+// outside of [i.retention] and returns the number removed.
+func (i *index) prune() (uint64, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	lastAcceptedIndex, ok := i.lastAcceptedIndex()
+	if !ok {
+		return 0, nil
+	}
+
+	// Containers below [countLimit] are outside of [i.retention.MaxContainers]
+	countLimit := uint64(0)
+	if i.retention.MaxContainers != 0 && i.nextAcceptedIndex > i.retention.MaxContainers {
+		countLimit = i.nextAcceptedIndex - i.retention.MaxContainers
+	}
+	// Containers accepted before [cutoff] are outside of [i.retention.MaxAge]
+	cutoff := i.clock.Time().Add(-i.retention.MaxAge).UnixNano()
+
+	// The last accepted container is never pruned
+	batchEnd := math.Min64(i.firstIndex+pruneBatchSize, lastAcceptedIndex)
+	pruneEnd := i.firstIndex
+	prunedIDs := make([]ids.ID, 0, batchEnd-pruneEnd)
+	for ; pruneEnd < batchEnd; pruneEnd++ {
+		container, err := i.getContainerByIndex(pruneEnd)
+		if err != nil {
+			return 0, err
+		}
+		if pruneEnd >= countLimit && (i.retention.MaxAge == 0 || container.Timestamp >= cutoff) {
+			break
+		}
+		prunedIDs = append(prunedIDs, container.ID)
+	}
+	if pruneEnd == i.firstIndex {
+		return 0, nil
+	}
+
+	for _, containerID := range prunedIDs {
+		if err := i.containerToIndex.Delete(containerID[:]); err != nil {
+			i.vDB.Abort()
+			return 0, fmt.Errorf("couldn't delete index of container %s: %w", containerID, err)
+		}
+	}
+	if err := i.indexToContainer.DeleteRange(database.PackUInt64(i.firstIndex), database.PackUInt64(pruneEnd)); err != nil {
+		i.vDB.Abort()
+		return 0, fmt.Errorf("couldn't delete containers: %w", err)
+	}
+	if err := database.PutUInt64(i.vDB, firstIndexKey, pruneEnd); err != nil {
+		i.vDB.Abort()
+		return 0, fmt.Errorf("couldn't put first index: %w", err)
+	}
+	// Atomically commit the removal of the containers to [i.baseDB]
+	if err := i.vDB.Commit(); err != nil {
+		i.vDB.Abort()
+		return 0, fmt.Errorf("couldn't commit pruning: %w", err)
+	}
+
+	pruned := pruneEnd - i.firstIndex
+	i.log.Debug("pruned containers with indices [%d, %d)", i.firstIndex, pruneEnd)
+	i.firstIndex = pruneEnd
+	return pruned, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	stdjson "encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/codec"
+	"github.com/sankar-boro/axia-network-v2/codec/linearcodec"
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+)
+
+func TestRetentionConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	retentions := []RetentionConfig(nil)
+	assert.NoError(stdjson.Unmarshal([]byte(`[
+		{"chain": "X", "index": "tx", "maxContainers": 100},
+		{"chain": "P", "index": "block", "maxAge": "720h"}
+	]`), &retentions))
+	assert.Equal([]RetentionConfig{
+		{Chain: "X", Index: "tx", MaxContainers: 100},
+		{Chain: "P", Index: "block", MaxAge: 720 * time.Hour},
+	}, retentions)
+	assert.NoError(verifyRetentions(retentions))
+
+	retentionsJSON, err := stdjson.Marshal(retentions)
+	assert.NoError(err)
+	unmarshalled := []RetentionConfig(nil)
+	assert.NoError(stdjson.Unmarshal(retentionsJSON, &unmarshalled))
+	assert.Equal(retentions, unmarshalled)
+
+	assert.Error(stdjson.Unmarshal([]byte(`{"chain": "X", "index": "tx", "maxAge": "1 day"}`), &RetentionConfig{}))
+	assert.ErrorIs(verifyRetentions(append(retentions, retentions[0])), errDuplicateRetention)
+	assert.ErrorIs(verifyRetentions([]RetentionConfig{{Chain: "X", Index: "tx"}}), errNoRetentionLimit)
+	assert.ErrorIs(verifyRetentions([]RetentionConfig{{Chain: "X", Index: "txs", MaxContainers: 1}}), errInvalidRetentionIndex)
+	assert.ErrorIs(verifyRetentions([]RetentionConfig{{Index: "tx", MaxContainers: 1}}), errMissingRetentionChain)
+}
+
+// newTestPrunedIndex returns an index with [numContainers] containers accepted
+// a second apart, whose pruning is driven by the test.
+func newTestPrunedIndex(t *testing.T, db database.Database, retention RetentionConfig, numContainers int) (*index, []ids.ID) {
+	codec := codec.NewDefaultManager()
+	if err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault()); err != nil {
+		t.Fatal(err)
+	}
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := indexIntf.(*index)
+	idx.retention = retention
+
+	ctx := snow.DefaultConsensusContextTest()
+	start := time.Unix(1000, 0)
+	containerIDs := make([]ids.ID, numContainers)
+	for i := range containerIDs {
+		idx.clock.Set(start.Add(time.Duration(i) * time.Second))
+		containerIDs[i] = ids.GenerateTestID()
+		if err := idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return idx, containerIDs
+}
+
+func TestIndexPruneMaxContainers(t *testing.T) {
+	assert := assert.New(t)
+	db := memdb.New()
+	idx, containerIDs := newTestPrunedIndex(t, db, RetentionConfig{MaxContainers: 3}, pruneBatchSize+10)
+
+	// Pruning happens in batches
+	pruned, err := idx.prune()
+	assert.NoError(err)
+	assert.EqualValues(pruneBatchSize, pruned)
+	pruned, err = idx.prune()
+	assert.NoError(err)
+	assert.EqualValues(7, pruned)
+	pruned, err = idx.prune()
+	assert.NoError(err)
+	assert.Zero(pruned)
+
+	firstIndex := uint64(len(containerIDs) - 3)
+	assert.Equal(firstIndex, idx.GetFirstIndex())
+
+	_, err = idx.GetContainerByIndex(firstIndex - 1)
+	assert.ErrorIs(err, errPruned)
+	_, err = idx.GetContainerRange(firstIndex-1, 2)
+	assert.ErrorIs(err, errPruned)
+	_, err = idx.GetIndex(containerIDs[firstIndex-1])
+	assert.Equal(database.ErrNotFound, err)
+
+	containers, err := idx.GetContainerRange(firstIndex, 10)
+	assert.NoError(err)
+	assert.Len(containers, 3)
+	assert.Equal(containerIDs[firstIndex], containers[0].ID)
+	index, err := idx.GetIndex(containerIDs[firstIndex])
+	assert.NoError(err)
+	assert.Equal(firstIndex, index)
+
+	// The service reports that the container may have been pruned
+	s := &service{Index: idx}
+	err = s.GetIndex(nil, &GetIndexArgs{ContainerID: containerIDs[0]}, &GetIndexResponse{})
+	assert.ErrorIs(err, database.ErrNotFound)
+	assert.Contains(err.Error(), "pruned")
+
+	// The first index is persisted
+	codec := codec.NewDefaultManager()
+	assert.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	reopened, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
+	assert.NoError(err)
+	assert.Equal(firstIndex, reopened.GetFirstIndex())
+}
+
+func TestIndexPruneMaxAge(t *testing.T) {
+	assert := assert.New(t)
+	idx, containerIDs := newTestPrunedIndex(t, memdb.New(), RetentionConfig{MaxAge: 5 * time.Second}, 10)
+
+	// The last container was accepted at 1009s, so containers accepted before
+	// 1005s are pruned
+	idx.clock.Set(time.Unix(1010, 0))
+	pruned, err := idx.prune()
+	assert.NoError(err)
+	assert.EqualValues(5, pruned)
+	assert.EqualValues(5, idx.GetFirstIndex())
+
+	// The last accepted container is never pruned
+	idx.clock.Set(time.Unix(2000, 0))
+	pruned, err = idx.prune()
+	assert.NoError(err)
+	assert.EqualValues(4, pruned)
+	container, err := idx.GetLastAccepted()
+	assert.NoError(err)
+	assert.Equal(containerIDs[9], container.ID)
+}
+
+func TestIndexPruneInBackground(t *testing.T) {
+	assert := assert.New(t)
+
+	codec := codec.NewDefaultManager()
+	assert.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	db := memdb.New()
+	_, _ = newTestPrunedIndex(t, db, RetentionConfig{}, 5)
+
+	// Containers outside of the retention are pruned on startup
+	reopened, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{MaxContainers: 2})
+	assert.NoError(err)
+	assert.Eventually(func() bool {
+		return reopened.GetFirstIndex() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(reopened.Close())
+}
@@ -121,6 +121,11 @@ type GetIndexResponse struct {
 
 func (s *service) GetIndex(r *http.Request, args *GetIndexArgs, reply *GetIndexResponse) error {
 	index, err := s.Index.GetIndex(args.ContainerID)
+	if err == database.ErrNotFound {
+		if firstIndex := s.Index.GetFirstIndex(); firstIndex != 0 {
+			return fmt.Errorf("%w: container %s may have been pruned, as containers with indices below %d have been", err, args.ContainerID, firstIndex)
+		}
+	}
 	reply.Index = json.Uint64(index)
 	return err
 }
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+)
+
+const (
+	// Query parameter of the index to start streaming containers from
+	startIndexParam = "startIndex"
+	// Query parameter of the encoding to stream containers in
+	encodingParam = "encoding"
+
+	// Time allowed to write a message to a subscriber
+	subscriptionWriteWait = 10 * time.Second
+	// Time allowed to read the next pong message from a subscriber
+	subscriptionPongWait = 60 * time.Second
+	// Send pings to subscribers with this period. Must be less than
+	// [subscriptionPongWait].
+	subscriptionPingPeriod = (subscriptionPongWait * 9) / 10
+	// Maximum message size allowed from a subscriber. Subscribers aren't
+	// expected to send anything but control messages.
+	subscriptionMaxMessageSize = units.KiB
+)
+
+var (
+	subscriptionUpgrader = websocket.Upgrader{
+		ReadBufferSize:  units.KiB,
+		WriteBufferSize: units.KiB,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
+
+	_ Index = &streamingIndex{}
+)
+
+// streamingIndex is an Index that streams the containers accepted into it to
+// WebSocket subscribers, formatted as they are returned by the index API.
+//
+// A subscriber may set the [startIndexParam] query parameter to first be sent
+// the containers accepted from that index onward, e.g. to resume a stream after
+// reconnecting. Otherwise, only containers accepted after it subscribes are
+// sent.
+type streamingIndex struct {
+	Index
+	log logging.Logger
+
+	lock        sync.Mutex
+	closed      bool
+	subscribers map[*subscriber]struct{}
+	// Signalled when containers are accepted
+	listeners map[chan struct{}]struct{}
+}
+
+type subscriber struct {
+	conn     *websocket.Conn
+	encoding formatting.Encoding
+	// Index of the next container to send
+	nextIndex uint64
+	// Signalled when containers are accepted
+	notify chan struct{}
+	// Closed when the subscription should end
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *subscriber) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+func newStreamingIndex(index Index, log logging.Logger) *streamingIndex {
+	return &streamingIndex{
+		Index:       index,
+		log:         log,
+		subscribers: make(map[*subscriber]struct{}),
+		listeners:   make(map[chan struct{}]struct{}),
+	}
+}
+
+// Accept indexes the container and then notifies the listeners of it.
+func (i *streamingIndex) Accept(ctx *snow.ConsensusContext, containerID ids.ID, containerBytes []byte) error {
+	if err := i.Index.Accept(ctx, containerID, containerBytes); err != nil {
+		return err
+	}
+	i.notifyListeners()
+	return nil
+}
+
+func (i *streamingIndex) notifyListeners() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for listener := range i.listeners {
+		select {
+		case listener <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// addListener causes [listener] to be signalled, without blocking, whenever a
+// container is accepted.
+func (i *streamingIndex) addListener(listener chan struct{}) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.listeners[listener] = struct{}{}
+}
+
+func (i *streamingIndex) removeListener(listener chan struct{}) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	delete(i.listeners, listener)
+}
+
+// Close ends all subscriptions and closes the underlying index.
+func (i *streamingIndex) Close() error {
+	i.lock.Lock()
+	i.closed = true
+	for sub := range i.subscribers {
+		sub.close()
+	}
+	i.lock.Unlock()
+
+	return i.Index.Close()
+}
+
+// ServeSubscription upgrades [r] to a WebSocket connection over which accepted
+// containers are streamed.
+func (i *streamingIndex) ServeSubscription(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	encoding := formatting.CB58
+	if encodingStr := query.Get(encodingParam); encodingStr != "" {
+		if err := encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", encodingParam, encodingStr, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	sub := &subscriber{
+		encoding: encoding,
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	if startIndexStr := query.Get(startIndexParam); startIndexStr != "" {
+		startIndex, err := strconv.ParseUint(startIndexStr, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", startIndexParam, startIndexStr, err), http.StatusBadRequest)
+			return
+		}
+		sub.nextIndex = startIndex
+	} else {
+		nextIndex, err := i.nextAcceptedIndex()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sub.nextIndex = nextIndex
+	}
+
+	conn, err := subscriptionUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		i.log.Debug("failed to upgrade subscription: %s", err)
+		return
+	}
+	sub.conn = conn
+
+	i.lock.Lock()
+	if i.closed {
+		i.lock.Unlock()
+		_ = conn.Close()
+		return
+	}
+	i.subscribers[sub] = struct{}{}
+	i.listeners[sub.notify] = struct{}{}
+	i.lock.Unlock()
+
+	// Send the containers that were accepted before the subscription was
+	// registered.
+	sub.notify <- struct{}{}
+
+	go i.writePump(sub)
+	go i.readPump(sub)
+}
+
+// readPump reads from the connection of [sub] so that control messages are
+// handled, and ends the subscription when the connection closes.
+func (i *streamingIndex) readPump(sub *subscriber) {
+	defer sub.close()
+
+	sub.conn.SetReadLimit(subscriptionMaxMessageSize)
+	if err := sub.conn.SetReadDeadline(time.Now().Add(subscriptionPongWait)); err != nil {
+		return
+	}
+	sub.conn.SetPongHandler(func(string) error {
+		return sub.conn.SetReadDeadline(time.Now().Add(subscriptionPongWait))
+	})
+	for {
+		if _, _, err := sub.conn.NextReader(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				i.log.Debug("unexpected close of subscription: %s", err)
+			}
+			return
+		}
+	}
+}
+
+// writePump is the only writer to the connection of [sub].
+func (i *streamingIndex) writePump(sub *subscriber) {
+	ticker := time.NewTicker(subscriptionPingPeriod)
+	defer func() {
+		ticker.Stop()
+		sub.close()
+
+		i.lock.Lock()
+		delete(i.subscribers, sub)
+		delete(i.listeners, sub.notify)
+		i.lock.Unlock()
+
+		// close is called after the readPump has exited or will cause it to
+		// exit, so the error can be ignored
+		_ = sub.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-sub.notify:
+			if err := i.sendAccepted(sub); err != nil {
+				i.log.Debug("ending subscription due to: %s", err)
+				return
+			}
+		case <-ticker.C:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(subscriptionWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-sub.done:
+			_ = sub.conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(subscriptionWriteWait),
+			)
+			return
+		}
+	}
+}
+
+// sendAccepted sends [sub] every container accepted from [sub.nextIndex]
+// onward.
+func (i *streamingIndex) sendAccepted(sub *subscriber) error {
+	for {
+		nextAcceptedIndex, err := i.nextAcceptedIndex()
+		if err != nil {
+			return err
+		}
+		if sub.nextIndex >= nextAcceptedIndex {
+			return nil
+		}
+
+		containers, err := i.Index.GetContainerRange(sub.nextIndex, MaxFetchedByRange)
+		if err != nil {
+			return err
+		}
+		for _, container := range containers {
+			formatted, err := newFormattedContainer(container, sub.nextIndex, sub.encoding)
+			if err != nil {
+				return err
+			}
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(subscriptionWriteWait)); err != nil {
+				return err
+			}
+			if err := sub.conn.WriteJSON(formatted); err != nil {
+				return err
+			}
+			sub.nextIndex++
+		}
+	}
+}
+
+// nextAcceptedIndex returns the index the next accepted container will have.
+func (i *streamingIndex) nextAcceptedIndex() (uint64, error) {
+	container, err := i.Index.GetLastAccepted()
+	if err == errNoneAccepted {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	index, err := i.Index.GetIndex(container.ID)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get index: %w", err)
+	}
+	return index + 1, nil
+}
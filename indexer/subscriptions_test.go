@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/codec"
+	"github.com/sankar-boro/axia-network-v2/codec/linearcodec"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+)
+
+func newTestStreamingIndex(t *testing.T) (*streamingIndex, string) {
+	codec := codec.NewDefaultManager()
+	if err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault()); err != nil {
+		t.Fatal(err)
+	}
+	baseIndex, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{}, RetentionConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := newStreamingIndex(baseIndex, logging.NoLog{})
+	server := httptest.NewServer(http.HandlerFunc(index.ServeSubscription))
+	t.Cleanup(server.Close)
+	return index, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func readContainer(t *testing.T, conn *websocket.Conn) FormattedContainer {
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	container := FormattedContainer{}
+	if err := conn.ReadJSON(&container); err != nil {
+		t.Fatal(err)
+	}
+	return container
+}
+
+func TestStreamingIndex(t *testing.T) {
+	assert := assert.New(t)
+	index, url := newTestStreamingIndex(t)
+	ctx := snow.DefaultConsensusContextTest()
+
+	containerIDs := make([]ids.ID, 4)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	assert.NoError(index.Accept(ctx, containerIDs[0], utils.RandomBytes(32)))
+	assert.NoError(index.Accept(ctx, containerIDs[1], utils.RandomBytes(32)))
+
+	// Only containers accepted after subscribing are sent by default
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?encoding=hex", nil)
+	assert.NoError(err)
+	defer conn.Close()
+
+	// Resume from index 1
+	resumedConn, _, err := websocket.DefaultDialer.Dial(url+"?startIndex=1", nil)
+	assert.NoError(err)
+	defer resumedConn.Close()
+
+	container := readContainer(t, resumedConn)
+	assert.Equal(containerIDs[1], container.ID)
+	assert.EqualValues(1, container.Index)
+	assert.Equal(formatting.CB58, container.Encoding)
+
+	assert.NoError(index.Accept(ctx, containerIDs[2], utils.RandomBytes(32)))
+	assert.NoError(index.Accept(ctx, containerIDs[3], utils.RandomBytes(32)))
+
+	for i := 2; i < len(containerIDs); i++ {
+		container := readContainer(t, conn)
+		assert.Equal(containerIDs[i], container.ID)
+		assert.EqualValues(i, container.Index)
+		assert.Equal(formatting.Hex, container.Encoding)
+
+		container = readContainer(t, resumedConn)
+		assert.Equal(containerIDs[i], container.ID)
+		assert.EqualValues(i, container.Index)
+	}
+
+	// Closing the index ends the subscriptions
+	assert.NoError(index.Close())
+	_, _, err = conn.ReadMessage()
+	assert.True(websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
+
+func TestStreamingIndexInvalidParams(t *testing.T) {
+	assert := assert.New(t)
+	_, url := newTestStreamingIndex(t)
+
+	_, resp, err := websocket.DefaultDialer.Dial(url+"?startIndex=-1", nil)
+	assert.Error(err)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	_, resp, err = websocket.DefaultDialer.Dial(url+"?encoding=base64", nil)
+	assert.Error(err)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
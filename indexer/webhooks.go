@@ -0,0 +1,476 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+	"github.com/sankar-boro/axia-network-v2/utils/json"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+)
+
+const (
+	// WebhookSignatureHeader is the header of a webhook delivery that holds
+	// "sha256=" followed by the hex encoded HMAC-SHA256, keyed by the secret of
+	// the webhook, of the body of the delivery.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	defaultWebhookBatchSize   = 1
+	webhookTimeout            = 30 * time.Second
+	webhookInitialRetryDelay  = time.Second
+	webhookMaxRetryDelay      = 5 * time.Minute
+	maxWebhookResponseDrained = 4096
+)
+
+var (
+	webhookCursorKey = []byte{0x00}
+	webhookPausedKey = []byte{0x01}
+
+	errUnknownWebhook       = errors.New("unknown webhook")
+	errWebhookInactive      = errors.New("webhook's index isn't running")
+	errDuplicateWebhookName = errors.New("duplicate webhook name")
+	errMissingWebhookName   = errors.New("webhook name must be specified")
+	errMissingWebhookChain  = errors.New("webhook chain must be specified")
+	errInvalidWebhookIndex  = errors.New("webhook index must be one of \"block\", \"vtx\" or \"tx\"")
+	errInvalidWebhookURL    = errors.New("webhook URL must be an absolute http or https URL")
+	errInvalidBatchSize     = fmt.Errorf("webhook batch size must be in [0,%d]", MaxFetchedByRange)
+)
+
+// WebhookConfig configures the delivery of the containers accepted into an
+// index to an HTTP endpoint.
+type WebhookConfig struct {
+	// Name uniquely identifies the webhook
+	Name string `json:"name"`
+	// Chain is the ID or alias, e.g. "X", of the chain the index belongs to
+	Chain string `json:"chain"`
+	// Index of the chain to deliver: "block", "vtx" or "tx"
+	Index string `json:"index"`
+	// URL the accepted containers are POSTed to
+	URL string `json:"url"`
+	// Secret, if non-empty, that deliveries are signed with
+	Secret string `json:"secret"`
+	// Maximum number of containers per delivery. Defaults to 1.
+	BatchSize int `json:"batchSize"`
+	// Encoding of the delivered containers
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// Verify returns an error if the config is invalid
+func (c *WebhookConfig) Verify() error {
+	switch {
+	case c.Name == "":
+		return errMissingWebhookName
+	case c.Chain == "":
+		return errMissingWebhookChain
+	case !isIndexEndpoint(c.Index):
+		return errInvalidWebhookIndex
+	case c.BatchSize < 0 || c.BatchSize > MaxFetchedByRange:
+		return errInvalidBatchSize
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidWebhookURL
+	}
+	return nil
+}
+
+// WebhookDelivery is the body of a webhook delivery
+type WebhookDelivery struct {
+	Webhook    string               `json:"webhook"`
+	Chain      string               `json:"chain"`
+	Index      string               `json:"index"`
+	Containers []FormattedContainer `json:"containers"`
+}
+
+// WebhookStatus describes the state of a webhook
+type WebhookStatus struct {
+	Name  string `json:"name"`
+	Chain string `json:"chain"`
+	Index string `json:"index"`
+	URL   string `json:"url"`
+	// True if the index of the webhook is running
+	Active bool `json:"active"`
+	Paused bool `json:"paused"`
+	// Index of the next container to deliver
+	NextIndex           json.Uint64 `json:"nextIndex"`
+	ConsecutiveFailures int         `json:"consecutiveFailures"`
+	LastError           string      `json:"lastError,omitempty"`
+}
+
+// WebhookManager allows the webhooks of an indexer to be inspected and
+// controlled.
+type WebhookManager interface {
+	// Webhooks returns the status of every webhook, ordered by name
+	Webhooks() []WebhookStatus
+	// PauseWebhook stops deliveries by the webhook until it is resumed
+	PauseWebhook(name string) error
+	// ResumeWebhook resumes deliveries by a paused webhook
+	ResumeWebhook(name string) error
+	// ReplayWebhook causes the webhook to deliver again every container
+	// accepted from [startIndex] onward. Returns an error if the container at
+	// [startIndex] was pruned.
+	ReplayWebhook(name string, startIndex uint64) error
+}
+
+// webhook POSTs the containers accepted into an index to a URL, in order.
+// Containers are delivered at least once: the index of the next container to
+// deliver is persisted only after a delivery succeeds. Failed deliveries are
+// retried with exponential backoff.
+type webhook struct {
+	config WebhookConfig
+	log    logging.Logger
+	client *http.Client
+
+	initialRetryDelay, maxRetryDelay time.Duration
+
+	lock sync.Mutex
+	// The index delivered from. nil until the index starts running.
+	index *streamingIndex
+	// Persists the state of the webhook
+	db        database.Database
+	nextIndex uint64
+	paused    bool
+	// Incremented on each replay, so that a delivery that was in flight during
+	// a replay doesn't advance the cursor
+	replays   uint64
+	failures  int
+	lastError error
+
+	// Signalled when containers are accepted or the state of the webhook
+	// changes
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	// Closed when the delivery goroutine exits
+	done chan struct{}
+}
+
+func newWebhook(config WebhookConfig, log logging.Logger) *webhook {
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultWebhookBatchSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &webhook{
+		config:            config,
+		log:               log,
+		client:            &http.Client{Timeout: webhookTimeout},
+		initialRetryDelay: webhookInitialRetryDelay,
+		maxRetryDelay:     webhookMaxRetryDelay,
+		wake:              make(chan struct{}, 1),
+		ctx:               ctx,
+		cancel:            cancel,
+		done:              make(chan struct{}),
+	}
+}
+
+// matches returns true if the webhook delivers from the index at [endpoint] of
+// the chain with ID [chainID] and name [name].
+func (w *webhook) matches(chainID ids.ID, name, endpoint string) bool {
+	return indexMatches(w.config.Chain, w.config.Index, chainID, name, endpoint)
+}
+
+// start delivering the containers accepted into [index]. The state of the
+// webhook is persisted in [db]. If the webhook hasn't run before, delivery
+// starts from the next accepted container.
+func (w *webhook) start(index *streamingIndex, db database.Database) error {
+	nextIndex, err := database.GetUInt64(db, webhookCursorKey)
+	if err == database.ErrNotFound {
+		nextIndex, err = index.nextAcceptedIndex()
+		if err == nil {
+			err = database.PutUInt64(db, webhookCursorKey, nextIndex)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get the cursor of webhook %s: %w", w.config.Name, err)
+	}
+	paused, err := database.GetBool(db, webhookPausedKey)
+	if err != nil && err != database.ErrNotFound {
+		return fmt.Errorf("couldn't get whether webhook %s is paused: %w", w.config.Name, err)
+	}
+
+	w.lock.Lock()
+	w.index = index
+	w.db = db
+	w.nextIndex = nextIndex
+	w.paused = paused
+	w.lock.Unlock()
+
+	index.addListener(w.wake)
+	w.signal()
+	go w.run()
+	return nil
+}
+
+// stop delivering and wait for any in flight delivery to finish. Must only be
+// called after start.
+func (w *webhook) stop() {
+	w.index.removeListener(w.wake)
+	w.cancel()
+	<-w.done
+}
+
+func (w *webhook) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *webhook) run() {
+	defer close(w.done)
+
+	retryDelay := w.initialRetryDelay
+	for {
+		delivered, err := w.deliverNext()
+		switch {
+		case err != nil:
+			w.log.Warn("webhook %s failed to deliver, retrying in %s: %s", w.config.Name, retryDelay, err)
+			timer := time.NewTimer(retryDelay)
+			select {
+			case <-timer.C:
+			case <-w.ctx.Done():
+				timer.Stop()
+				return
+			}
+			retryDelay *= 2
+			if retryDelay > w.maxRetryDelay {
+				retryDelay = w.maxRetryDelay
+			}
+		case delivered:
+			retryDelay = w.initialRetryDelay
+		default:
+			select {
+			case <-w.wake:
+			case <-w.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// deliverNext delivers the next batch of accepted containers. Returns false if
+// there was nothing to deliver.
+func (w *webhook) deliverNext() (bool, error) {
+	w.lock.Lock()
+	paused, startIndex, replays := w.paused, w.nextIndex, w.replays
+	w.lock.Unlock()
+	if paused {
+		return false, nil
+	}
+
+	nextAcceptedIndex, err := w.index.nextAcceptedIndex()
+	if err != nil {
+		return false, err
+	}
+	if startIndex >= nextAcceptedIndex {
+		return false, nil
+	}
+	containers, err := w.index.GetContainerRange(startIndex, uint64(w.config.BatchSize))
+	if errors.Is(err, errPruned) {
+		// The containers were pruned before they were delivered. They can
+		// never be delivered, so delivery continues from the oldest container
+		// kept.
+		return true, w.skipPruned(startIndex, replays)
+	}
+	if err != nil {
+		return false, err
+	}
+	delivery := WebhookDelivery{
+		Webhook:    w.config.Name,
+		Chain:      w.config.Chain,
+		Index:      w.config.Index,
+		Containers: make([]FormattedContainer, len(containers)),
+	}
+	for i, container := range containers {
+		delivery.Containers[i], err = newFormattedContainer(container, startIndex+uint64(i), w.config.Encoding)
+		if err != nil {
+			return false, err
+		}
+	}
+	body, err := stdjson.Marshal(delivery)
+	if err != nil {
+		return false, err
+	}
+
+	err = w.post(body)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err != nil {
+		w.failures++
+		w.lastError = err
+		return false, err
+	}
+	w.failures = 0
+	w.lastError = nil
+	if w.replays != replays {
+		// The webhook was replayed while delivering, so the cursor was reset
+		return true, nil
+	}
+	w.nextIndex = startIndex + uint64(len(containers))
+	return true, database.PutUInt64(w.db, webhookCursorKey, w.nextIndex)
+}
+
+// skipPruned advances the cursor from [startIndex] to the index of the oldest
+// container that wasn't pruned.
+func (w *webhook) skipPruned(startIndex, replays uint64) error {
+	firstIndex := w.index.GetFirstIndex()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.replays != replays || firstIndex <= startIndex {
+		return nil
+	}
+	w.log.Warn("webhook %s skipped the pruned containers with indices [%d, %d)", w.config.Name, startIndex, firstIndex)
+	w.nextIndex = firstIndex
+	return database.PutUInt64(w.db, webhookCursorKey, w.nextIndex)
+}
+
+func (w *webhook) post(body []byte) error {
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.config.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.config.Secret))
+		_, _ = mac.Write(body)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseDrained))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *webhook) status() WebhookStatus {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	status := WebhookStatus{
+		Name:                w.config.Name,
+		Chain:               w.config.Chain,
+		Index:               w.config.Index,
+		URL:                 w.config.URL,
+		Active:              w.index != nil,
+		Paused:              w.paused,
+		NextIndex:           json.Uint64(w.nextIndex),
+		ConsecutiveFailures: w.failures,
+	}
+	if w.lastError != nil {
+		status.LastError = w.lastError.Error()
+	}
+	return status
+}
+
+func (w *webhook) setPaused(paused bool) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.index == nil {
+		return errWebhookInactive
+	}
+	if err := database.PutBool(w.db, webhookPausedKey, paused); err != nil {
+		return err
+	}
+	w.paused = paused
+	w.signal()
+	return nil
+}
+
+func (w *webhook) replay(startIndex uint64) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.index == nil {
+		return errWebhookInactive
+	}
+	if firstIndex := w.index.GetFirstIndex(); startIndex < firstIndex {
+		return fmt.Errorf("%w: index %d is below %d, the index of the oldest container kept", errPruned, startIndex, firstIndex)
+	}
+	if err := database.PutUInt64(w.db, webhookCursorKey, startIndex); err != nil {
+		return err
+	}
+	w.nextIndex = startIndex
+	w.replays++
+	w.signal()
+	return nil
+}
+
+// newWebhooks returns the webhooks described by [configs], mapped by name
+func newWebhooks(configs []WebhookConfig, log logging.Logger) (map[string]*webhook, error) {
+	webhooks := make(map[string]*webhook, len(configs))
+	for _, config := range configs {
+		if err := config.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid webhook %q: %w", config.Name, err)
+		}
+		if _, exists := webhooks[config.Name]; exists {
+			return nil, fmt.Errorf("%w: %s", errDuplicateWebhookName, config.Name)
+		}
+		webhooks[config.Name] = newWebhook(config, log)
+	}
+	return webhooks, nil
+}
+
+func (i *indexer) Webhooks() []WebhookStatus {
+	statuses := make([]WebhookStatus, 0, len(i.webhooks))
+	for _, w := range i.webhooks {
+		statuses = append(statuses, w.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func (i *indexer) PauseWebhook(name string) error {
+	w, exists := i.webhooks[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownWebhook, name)
+	}
+	return w.setPaused(true)
+}
+
+func (i *indexer) ResumeWebhook(name string) error {
+	w, exists := i.webhooks[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownWebhook, name)
+	}
+	return w.setPaused(false)
+}
+
+func (i *indexer) ReplayWebhook(name string, startIndex uint64) error {
+	w, exists := i.webhooks[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownWebhook, name)
+	}
+	return w.replay(startIndex)
+}
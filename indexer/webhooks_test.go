@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stdjson "encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+)
+
+func TestWebhookConfigVerify(t *testing.T) {
+	valid := WebhookConfig{
+		Name:  "explorer",
+		Chain: "X",
+		Index: "tx",
+		URL:   "https://example.com/hook",
+	}
+	tests := []struct {
+		name        string
+		modify      func(*WebhookConfig)
+		expectedErr error
+	}{
+		{"valid", func(*WebhookConfig) {}, nil},
+		{"no name", func(c *WebhookConfig) { c.Name = "" }, errMissingWebhookName},
+		{"no chain", func(c *WebhookConfig) { c.Chain = "" }, errMissingWebhookChain},
+		{"bad index", func(c *WebhookConfig) { c.Index = "blocks" }, errInvalidWebhookIndex},
+		{"bad batch size", func(c *WebhookConfig) { c.BatchSize = MaxFetchedByRange + 1 }, errInvalidBatchSize},
+		{"relative URL", func(c *WebhookConfig) { c.URL = "/hook" }, errInvalidWebhookURL},
+		{"bad scheme", func(c *WebhookConfig) { c.URL = "ftp://example.com" }, errInvalidWebhookURL},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := valid
+			test.modify(&config)
+			assert.Equal(t, test.expectedErr, config.Verify())
+		})
+	}
+
+	_, err := newWebhooks([]WebhookConfig{valid, valid}, logging.NoLog{})
+	assert.ErrorIs(t, err, errDuplicateWebhookName)
+}
+
+type webhookRequest struct {
+	delivery  WebhookDelivery
+	signature string
+	body      []byte
+}
+
+func readDelivery(t *testing.T, requests <-chan webhookRequest) webhookRequest {
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a delivery")
+		return webhookRequest{}
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	assert := assert.New(t)
+	index, _ := newTestStreamingIndex(t)
+	ctx := snow.DefaultConsensusContextTest()
+
+	requests := make(chan webhookRequest, 16)
+	// The first delivery fails
+	failNext := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+		if failNext {
+			failNext = false
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		req := webhookRequest{
+			signature: r.Header.Get(WebhookSignatureHeader),
+			body:      body,
+		}
+		assert.NoError(stdjson.Unmarshal(body, &req.delivery))
+		requests <- req
+	}))
+	defer server.Close()
+
+	containerIDs := make([]ids.ID, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	// Accepted before the webhook starts, so isn't delivered
+	assert.NoError(index.Accept(ctx, containerIDs[0], utils.RandomBytes(32)))
+
+	w := newWebhook(WebhookConfig{
+		Name:      "test",
+		Chain:     "X",
+		Index:     "tx",
+		URL:       server.URL,
+		Secret:    "secret",
+		BatchSize: 2,
+	}, logging.NoLog{})
+	w.initialRetryDelay = time.Millisecond
+	db := memdb.New()
+	assert.NoError(w.start(index, db))
+	defer w.stop()
+
+	assert.NoError(index.Accept(ctx, containerIDs[1], utils.RandomBytes(32)))
+	assert.NoError(index.Accept(ctx, containerIDs[2], utils.RandomBytes(32)))
+
+	// Containers are delivered in order, after the failed delivery is retried
+	delivered := []ids.ID(nil)
+	for len(delivered) < 2 {
+		req := readDelivery(t, requests)
+		assert.Equal("test", req.delivery.Webhook)
+		for _, container := range req.delivery.Containers {
+			assert.EqualValues(len(delivered)+1, container.Index)
+			delivered = append(delivered, container.ID)
+		}
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write(req.body)
+		assert.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), req.signature)
+	}
+	assert.Equal(containerIDs[1:], delivered)
+
+	// The cursor is persisted once the delivery succeeds
+	assert.Eventually(func() bool {
+		cursor, err := database.GetUInt64(db, webhookCursorKey)
+		return err == nil && cursor == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	status := w.status()
+	assert.True(status.Active)
+	assert.EqualValues(3, status.NextIndex)
+	assert.Zero(status.ConsecutiveFailures)
+
+	// Replaying delivers the containers again
+	assert.NoError(w.replay(0))
+	req := readDelivery(t, requests)
+	assert.Len(req.delivery.Containers, 2)
+	assert.Equal(containerIDs[0], req.delivery.Containers[0].ID)
+	req = readDelivery(t, requests)
+	assert.Len(req.delivery.Containers, 1)
+	assert.Equal(containerIDs[2], req.delivery.Containers[0].ID)
+
+	// Nothing is delivered while paused
+	assert.NoError(w.setPaused(true))
+	paused, err := database.GetBool(db, webhookPausedKey)
+	assert.NoError(err)
+	assert.True(paused)
+	newContainerID := ids.GenerateTestID()
+	assert.NoError(index.Accept(ctx, newContainerID, utils.RandomBytes(32)))
+	select {
+	case <-requests:
+		t.Fatal("delivered while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(w.setPaused(false))
+	req = readDelivery(t, requests)
+	assert.Equal(newContainerID, req.delivery.Containers[0].ID)
+}
+
+func TestWebhookSkipsPruned(t *testing.T) {
+	assert := assert.New(t)
+	idx, containerIDs := newTestPrunedIndex(t, memdb.New(), RetentionConfig{MaxContainers: 2}, 5)
+	index := newStreamingIndex(idx, logging.NoLog{})
+
+	requests := make(chan webhookRequest, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := webhookRequest{}
+		assert.NoError(stdjson.NewDecoder(r.Body).Decode(&req.delivery))
+		requests <- req
+	}))
+	defer server.Close()
+
+	w := newWebhook(WebhookConfig{
+		Name:      "test",
+		Chain:     "X",
+		Index:     "tx",
+		URL:       server.URL,
+		BatchSize: 2,
+	}, logging.NoLog{})
+	w.initialRetryDelay = time.Millisecond
+
+	// The containers the webhook is about to deliver are pruned
+	db := memdb.New()
+	assert.NoError(database.PutUInt64(db, webhookCursorKey, 0))
+	pruned, err := idx.prune()
+	assert.NoError(err)
+	assert.EqualValues(3, pruned)
+
+	assert.NoError(w.start(index, db))
+	defer w.stop()
+
+	// Delivery continues from the oldest container kept
+	req := readDelivery(t, requests)
+	assert.Len(req.delivery.Containers, 2)
+	assert.EqualValues(3, req.delivery.Containers[0].Index)
+	assert.Equal(containerIDs[3], req.delivery.Containers[0].ID)
+
+	// Pruned containers can't be replayed
+	assert.ErrorIs(w.replay(2), errPruned)
+	assert.NoError(w.replay(3))
+	req = readDelivery(t, requests)
+	assert.Equal(containerIDs[3], req.delivery.Containers[0].ID)
+}
+
+func TestWebhookInactive(t *testing.T) {
+	assert := assert.New(t)
+
+	webhooks, err := newWebhooks([]WebhookConfig{{
+		Name:  "test",
+		Chain: "X",
+		Index: "tx",
+		URL:   "http://127.0.0.1:1",
+	}}, logging.NoLog{})
+	assert.NoError(err)
+	idxr := &indexer{webhooks: webhooks}
+
+	statuses := idxr.Webhooks()
+	assert.Len(statuses, 1)
+	assert.False(statuses[0].Active)
+	assert.ErrorIs(idxr.PauseWebhook("test"), errWebhookInactive)
+	assert.ErrorIs(idxr.ReplayWebhook("test", 0), errWebhookInactive)
+	assert.ErrorIs(idxr.ResumeWebhook("unknown"), errUnknownWebhook)
+}
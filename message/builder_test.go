@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/units"
 	"github.com/sankar-boro/axia-network-v2/version"
@@ -32,7 +33,7 @@ func init() {
 		panic(err)
 	}
 	TestCodec = codec
-	UncompressingBuilder = NewOutboundBuilder(codec, false /*compress*/)
+	UncompressingBuilder = NewOutboundBuilder(codec, compression.TypeNone)
 }
 
 func TestBuildVersion(t *testing.T) {
@@ -72,6 +73,7 @@ func TestBuildVersion(t *testing.T) {
 	assert.EqualValues(t, myVersionTime, parsedMsg.Get(VersionTime))
 	assert.EqualValues(t, sig, parsedMsg.Get(SigBytes))
 	assert.EqualValues(t, allychainIDs, parsedMsg.Get(TrackedAllychains))
+	assert.EqualValues(t, supportedCompressionTypes, parsedMsg.Get(CompressionTypes))
 }
 
 func TestBuildGetAcceptedFrontier(t *testing.T) {
@@ -182,8 +184,8 @@ func TestBuildPut(t *testing.T) {
 	containerID := ids.Empty.Prefix(1)
 	container := []byte{2}
 
-	for _, compress := range []bool{false, true} {
-		builder := NewOutboundBuilder(TestCodec, compress)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		builder := NewOutboundBuilder(TestCodec, compressionType)
 		msg, err := builder.Put(chainID, requestID, containerID, container)
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
@@ -207,8 +209,8 @@ func TestBuildPushQuery(t *testing.T) {
 	containerID := ids.Empty.Prefix(1)
 	container := []byte{2}
 
-	for _, compress := range []bool{false, true} {
-		builder := NewOutboundBuilder(TestCodec, compress)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		builder := NewOutboundBuilder(TestCodec, compressionType)
 		msg, err := builder.PushQuery(chainID, requestID, time.Duration(deadline), containerID, container)
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
@@ -274,8 +276,8 @@ func TestBuildAncestors(t *testing.T) {
 	container2 := ids.Empty.Prefix(2)
 	containers := [][]byte{container[:], container2[:]}
 
-	for _, compress := range []bool{false, true} {
-		builder := NewOutboundBuilder(TestCodec, compress)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		builder := NewOutboundBuilder(TestCodec, compressionType)
 		msg, err := builder.Ancestors(chainID, requestID, containers)
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
@@ -298,8 +300,8 @@ func TestBuildAppRequestMsg(t *testing.T) {
 	appRequestBytes[len(appRequestBytes)-1] = 1
 	deadline := uint64(time.Now().Unix())
 
-	for _, compress := range []bool{false, true} {
-		builder := NewOutboundBuilder(TestCodec, compress)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		builder := NewOutboundBuilder(TestCodec, compressionType)
 		msg, err := builder.AppRequest(chainID, 1, time.Duration(deadline), appRequestBytes)
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
@@ -318,8 +320,8 @@ func TestBuildAppResponseMsg(t *testing.T) {
 	appResponseBytes[0] = 1
 	appResponseBytes[len(appResponseBytes)-1] = 1
 
-	for _, compress := range []bool{false, true} {
-		builder := NewOutboundBuilder(TestCodec, compress)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		builder := NewOutboundBuilder(TestCodec, compressionType)
 		msg, err := builder.AppResponse(chainID, 1, appResponseBytes)
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
@@ -341,8 +343,8 @@ func TestBuildAppGossipMsg(t *testing.T) {
 	appGossipBytes[0] = 1
 	appGossipBytes[len(appGossipBytes)-1] = 1
 
-	for _, compress := range []bool{false, true} {
-		testBuilder := NewOutboundBuilder(TestCodec, compress)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		testBuilder := NewOutboundBuilder(TestCodec, compressionType)
 		msg, err := testBuilder.AppGossip(chainID, appGossipBytes)
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
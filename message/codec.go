@@ -21,8 +21,10 @@ import (
 )
 
 var (
-	errMissingField = errors.New("message missing field")
-	errBadOp        = errors.New("input field has invalid operation")
+	errMissingField       = errors.New("message missing field")
+	errBadOp              = errors.New("input field has invalid operation")
+	errUnknownCompression = errors.New("unknown compression type")
+	errNotCompressible    = errors.New("message can't be compressed")
 
 	_ Codec = &codec{}
 )
@@ -31,9 +33,16 @@ type Packer interface {
 	Pack(
 		op Op,
 		fieldValues map[Field]interface{},
-		compress bool,
+		compressionType compression.Type,
 		bypassThrottling bool,
 	) (OutboundMessage, error)
+
+	// Recompress returns a message with the contents of [msg] whose payload is
+	// compressed with [compressionType]. [msg] isn't modified. The copy is
+	// cached on [msg], so the payload is only recompressed once per message
+	// regardless of how many peers it is sent to. The caller must DecRef the
+	// returned message.
+	Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error)
 }
 
 type Parser interface {
@@ -58,23 +67,34 @@ type codec struct {
 
 	clock mockable.Clock
 
-	compressTimeMetrics   map[Op]metric.Averager
-	decompressTimeMetrics map[Op]metric.Averager
-	compressor            compression.Compressor
-	maxMessageTimeout     time.Duration
+	compressTimeMetrics    map[Op]metric.Averager
+	decompressTimeMetrics  map[Op]metric.Averager
+	compressRatioMetrics   map[Op]metric.Averager
+	decompressRatioMetrics map[Op]metric.Averager
+	compressors            map[compression.Type]compression.Compressor
+	maxMessageTimeout      time.Duration
 }
 
 func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration) (Codec, error) {
+	zstdCompressor, err := compression.NewZstdCompressor(maxMessageSize, zstdDictionary)
+	if err != nil {
+		return nil, err
+	}
 	c := &codec{
 		byteSlicePool: sync.Pool{
 			New: func() interface{} {
 				return make([]byte, 0, constants.DefaultByteSliceCap)
 			},
 		},
-		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
-		compressor:            compression.NewGzipCompressor(maxMessageSize),
-		maxMessageTimeout:     maxMessageTimeout,
+		compressTimeMetrics:    make(map[Op]metric.Averager, len(ExternalOps)),
+		decompressTimeMetrics:  make(map[Op]metric.Averager, len(ExternalOps)),
+		compressRatioMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
+		decompressRatioMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		compressors: map[compression.Type]compression.Compressor{
+			compression.TypeGzip: compression.NewGzipCompressor(maxMessageSize),
+			compression.TypeZstd: zstdCompressor,
+		},
+		maxMessageTimeout: maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
@@ -97,6 +117,20 @@ func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, max
 			metrics,
 			&errs,
 		)
+		c.compressRatioMetrics[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_compress_ratio", op),
+			fmt.Sprintf("size of compressed %s messages' payloads divided by their uncompressed size", op),
+			metrics,
+			&errs,
+		)
+		c.decompressRatioMetrics[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_decompress_ratio", op),
+			fmt.Sprintf("size of received compressed %s messages' payloads divided by their uncompressed size", op),
+			metrics,
+			&errs,
+		)
 	}
 	return c, errs.Err
 }
@@ -110,18 +144,25 @@ func (c *codec) SetTime(t time.Time) {
 // Uses [buffer] to hold the message's byte repr.
 // [buffer]'s contents may be overwritten by this method.
 // [buffer] may be nil.
-// If [compress], compress the payload.
+// The payload is compressed with [compressionType].
 // If [bypassThrottling], mark the message to avoid outbound throttling checks.
 func (c *codec) Pack(
 	op Op,
 	fieldValues map[Field]interface{},
-	compress bool,
+	compressionType compression.Type,
 	bypassThrottling bool,
 ) (OutboundMessage, error) {
 	msgFields, ok := messages[op]
 	if !ok {
 		return nil, errBadOp
 	}
+	compressor, compress := c.compressors[compressionType]
+	if !compress && compressionType != compression.TypeNone {
+		return nil, fmt.Errorf("%w: %d", errUnknownCompression, compressionType)
+	}
+	if compress && !op.Compressible() {
+		return nil, fmt.Errorf("%w: %s", errNotCompressible, op)
+	}
 
 	buffer := c.byteSlicePool.Get().([]byte)
 	p := wrappers.Packer{
@@ -131,14 +172,19 @@ func (c *codec) Pack(
 	// Pack the op code (message type)
 	p.PackByte(byte(op))
 
-	// Optionally, pack whether the payload is compressed
+	// Optionally, pack how the payload is compressed. Peers that only support
+	// gzip parse this as a bool, so that gzip is true.
 	if op.Compressible() {
-		p.PackBool(compress)
+		p.PackByte(byte(compressionType))
 	}
 
 	// Pack the uncompressed payload
 	for _, field := range msgFields {
 		data, ok := fieldValues[field]
+		if _, optional := optionalFields[field]; !ok && optional {
+			// Optional fields are trailing, so the message ends here.
+			break
+		}
 		if !ok {
 			return nil, errMissingField
 		}
@@ -158,24 +204,135 @@ func (c *codec) Pack(
 		return msg, nil
 	}
 
-	// If [compress], compress the payload (not the op code, not isCompressed).
+	// If [compress], compress the payload (not the op code, not the
+	// compression type).
 	// The slice below is guaranteed to be in-bounds because [p.Err] == nil
 	// implies that len(msg.bytes) >= 2
-	payloadBytes := msg.bytes[wrappers.BoolLen+wrappers.ByteLen:]
-	startTime := time.Now()
-	compressedPayloadBytes, err := c.compressor.Compress(payloadBytes)
+	payloadBytes := msg.bytes[wrappers.ByteLen+wrappers.ByteLen:]
+	compressedPayloadBytes, err := c.compress(op, compressor, payloadBytes)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't compress payload of %s message: %w", op, err)
+		return nil, err
 	}
-	c.compressTimeMetrics[op].Observe(float64(time.Since(startTime)))
+	msg.compressionType = compressionType
 	msg.bytesSavedCompression = len(payloadBytes) - len(compressedPayloadBytes) // may be negative
-	// Remove the uncompressed payload (keep just the message type and isCompressed)
-	msg.bytes = msg.bytes[:wrappers.BoolLen+wrappers.ByteLen]
+	// Remove the uncompressed payload (keep just the message type and the
+	// compression type)
+	msg.bytes = msg.bytes[:wrappers.ByteLen+wrappers.ByteLen]
 	// Attach the compressed payload
 	msg.bytes = append(msg.bytes, compressedPayloadBytes...)
 	return msg, nil
 }
 
+func (c *codec) Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	outMsg, ok := msg.(*outboundMessage)
+	if !ok {
+		return c.recompress(msg, compressionType)
+	}
+
+	outMsg.recompressedLock.Lock()
+	defer outMsg.recompressedLock.Unlock()
+
+	newMsg, ok := outMsg.recompressed[compressionType]
+	if !ok {
+		var err error
+		newMsg, err = c.recompress(msg, compressionType)
+		if err != nil {
+			return nil, err
+		}
+		if outMsg.recompressed == nil {
+			outMsg.recompressed = make(map[compression.Type]*outboundMessage)
+		}
+		// The reference of the cached copy is held by [outMsg] and released
+		// once [outMsg] is no longer referenced.
+		outMsg.recompressed[compressionType] = newMsg
+	}
+	newMsg.AddRef()
+	return newMsg, nil
+}
+
+// recompress returns a new message with the contents of [msg] whose payload is
+// compressed with [compressionType].
+func (c *codec) recompress(msg OutboundMessage, compressionType compression.Type) (*outboundMessage, error) {
+	op := msg.Op()
+	if !op.Compressible() {
+		return nil, fmt.Errorf("%w: %s", errNotCompressible, op)
+	}
+	compressor, compress := c.compressors[compressionType]
+	if !compress && compressionType != compression.TypeNone {
+		return nil, fmt.Errorf("%w: %d", errUnknownCompression, compressionType)
+	}
+
+	msgBytes := msg.Bytes()
+	if len(msgBytes) < wrappers.ByteLen+wrappers.ByteLen {
+		return nil, fmt.Errorf("%s message is too short", op)
+	}
+	payloadBytes := msgBytes[wrappers.ByteLen+wrappers.ByteLen:]
+	if msgCompressionType := msg.CompressionType(); msgCompressionType != compression.TypeNone {
+		var err error
+		payloadBytes, err = c.decompress(op, msgCompressionType, payloadBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newMsg := &outboundMessage{
+		op:               op,
+		bytes:            make([]byte, wrappers.ByteLen+wrappers.ByteLen, wrappers.ByteLen+wrappers.ByteLen+len(payloadBytes)),
+		refs:             1,
+		c:                c,
+		bypassThrottling: msg.BypassThrottling(),
+		compressionType:  compressionType,
+	}
+	newMsg.bytes[0] = byte(op)
+	newMsg.bytes[1] = byte(compressionType)
+	if !compress {
+		newMsg.bytes = append(newMsg.bytes, payloadBytes...)
+		return newMsg, nil
+	}
+
+	compressedPayloadBytes, err := c.compress(op, compressor, payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	newMsg.bytesSavedCompression = len(payloadBytes) - len(compressedPayloadBytes) // may be negative
+	newMsg.bytes = append(newMsg.bytes, compressedPayloadBytes...)
+	return newMsg, nil
+}
+
+// compress returns [payloadBytes] of an [op] message compressed with
+// [compressor]
+func (c *codec) compress(op Op, compressor compression.Compressor, payloadBytes []byte) ([]byte, error) {
+	startTime := time.Now()
+	compressedPayloadBytes, err := compressor.Compress(payloadBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't compress payload of %s message: %w", op, err)
+	}
+	c.compressTimeMetrics[op].Observe(float64(time.Since(startTime)))
+	if len(payloadBytes) > 0 {
+		c.compressRatioMetrics[op].Observe(float64(len(compressedPayloadBytes)) / float64(len(payloadBytes)))
+	}
+	return compressedPayloadBytes, nil
+}
+
+// decompress returns [compressedPayloadBytes] of an [op] message decompressed
+// as [compressionType]
+func (c *codec) decompress(op Op, compressionType compression.Type, compressedPayloadBytes []byte) ([]byte, error) {
+	compressor, ok := c.compressors[compressionType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownCompression, compressionType)
+	}
+	startTime := time.Now()
+	payloadBytes, err := compressor.Decompress(compressedPayloadBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decompress payload of %s message: %w", op, err)
+	}
+	c.decompressTimeMetrics[op].Observe(float64(time.Since(startTime)))
+	if len(payloadBytes) > 0 {
+		c.decompressRatioMetrics[op].Observe(float64(len(compressedPayloadBytes)) / float64(len(payloadBytes)))
+	}
+	return payloadBytes, nil
+}
+
 // Parse attempts to convert bytes into a message.
 // The first byte of the message is the opcode of the message.
 // Overrides client specified deadline in a message to maxDeadlineDuration
@@ -191,9 +348,9 @@ func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()
 	}
 
 	// See if messages of this type may be compressed
-	compressed := false
+	compressionType := compression.TypeNone
 	if op.Compressible() {
-		compressed = p.UnpackBool()
+		compressionType = compression.Type(p.UnpackByte())
 	}
 	if p.Err != nil {
 		return nil, p.Err
@@ -202,21 +359,20 @@ func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()
 	bytesSaved := 0
 
 	// If the payload is compressed, decompress it
-	if compressed {
+	if compressionType != compression.TypeNone {
 		// The slice below is guaranteed to be in-bounds because [p.Err] == nil
-		compressedPayloadBytes := p.Bytes[wrappers.ByteLen+wrappers.BoolLen:]
-		startTime := time.Now()
-		payloadBytes, err := c.compressor.Decompress(compressedPayloadBytes)
+		compressedPayloadBytes := p.Bytes[wrappers.ByteLen+wrappers.ByteLen:]
+		payloadBytes, err := c.decompress(op, compressionType, compressedPayloadBytes)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't decompress payload of %s message: %w", op, err)
+			return nil, err
 		}
-		c.decompressTimeMetrics[op].Observe(float64(time.Since(startTime)))
 		// Replace the compressed payload with the decompressed payload.
-		// Remove the compressed payload and isCompressed; keep just the message type
+		// Remove the compressed payload and the compression type; keep just
+		// the message type
 		p.Bytes = p.Bytes[:wrappers.ByteLen]
-		// Rewind offset by 1 because we removed the bool flag
+		// Rewind offset by 1 because we removed the compression type
 		// since the data now is uncompressed
-		p.Offset -= wrappers.BoolLen
+		p.Offset -= wrappers.ByteLen
 		// Attach the decompressed payload.
 		p.Bytes = append(p.Bytes, payloadBytes...)
 		bytesSaved = len(payloadBytes) - len(compressedPayloadBytes)
@@ -225,6 +381,9 @@ func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()
 	// Parse each field of the payload
 	fieldValues := make(map[Field]interface{}, len(msgFields))
 	for _, field := range msgFields {
+		if _, optional := optionalFields[field]; optional && p.Offset == len(p.Bytes) {
+			continue
+		}
 		fieldValues[field] = field.Unpacker()(&p)
 	}
 	if p.Err != nil {
@@ -248,6 +407,7 @@ func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()
 		op:                    op,
 		fields:                fieldValues,
 		bytesSavedCompression: bytesSaved,
+		numBytes:              len(bytes),
 		nodeID:                nodeID,
 		expirationTime:        expirationTime,
 		onFinishedHandling:    onFinishedHandling,
@@ -15,6 +15,7 @@ import (
 
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/staking"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/units"
 )
@@ -23,10 +24,10 @@ func TestCodecPackInvalidOp(t *testing.T) {
 	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
 	assert.NoError(t, err)
 
-	_, err = codec.Pack(math.MaxUint8, make(map[Field]interface{}), false, false)
+	_, err = codec.Pack(math.MaxUint8, make(map[Field]interface{}), compression.TypeNone, false)
 	assert.Error(t, err)
 
-	_, err = codec.Pack(math.MaxUint8, make(map[Field]interface{}), true, false)
+	_, err = codec.Pack(math.MaxUint8, make(map[Field]interface{}), compression.TypeGzip, false)
 	assert.Error(t, err)
 }
 
@@ -34,10 +35,10 @@ func TestCodecPackMissingField(t *testing.T) {
 	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
 	assert.NoError(t, err)
 
-	_, err = codec.Pack(Get, make(map[Field]interface{}), false, false)
+	_, err = codec.Pack(Get, make(map[Field]interface{}), compression.TypeNone, false)
 	assert.Error(t, err)
 
-	_, err = codec.Pack(Get, make(map[Field]interface{}), true, false)
+	_, err = codec.Pack(Get, make(map[Field]interface{}), compression.TypeGzip, false)
 	assert.Error(t, err)
 }
 
@@ -76,7 +77,7 @@ func TestDeadlineOverride(t *testing.T) {
 		},
 	}
 
-	packedIntf, err := c.Pack(m.op, m.fields, m.op.Compressible(), false)
+	packedIntf, err := c.Pack(m.op, m.fields, compression.TypeGzip, false)
 	assert.NoError(t, err, "failed to pack on operation %s", m.op)
 
 	unpackedIntf, err := c.Parse(packedIntf.Bytes(), dummyNodeID, dummyOnFinishedHandling)
@@ -88,8 +89,8 @@ func TestDeadlineOverride(t *testing.T) {
 }
 
 // Test packing and then parsing messages
-// when using a gzip compressor
-func TestCodecPackParseGzip(t *testing.T) {
+// when using each compressor
+func TestCodecPackParse(t *testing.T) {
 	c, err := NewCodecWithMemoryPool("", prometheus.DefaultRegisterer, 2*units.MiB, 10*time.Second)
 	assert.NoError(t, err)
 	id := ids.GenerateTestID()
@@ -112,6 +113,20 @@ func TestCodecPackParseGzip(t *testing.T) {
 				TrackedAllychains: [][]byte{id[:]},
 			},
 		},
+		{
+			op: Version,
+			fields: map[Field]interface{}{
+				NetworkID:         uint32(0),
+				NodeID:            uint32(1337),
+				MyTime:            uint64(time.Now().Unix()),
+				IP:                ips.IPPort{IP: net.IPv4(1, 2, 3, 4)},
+				VersionStr:        "v1.2.3",
+				VersionTime:       uint64(time.Now().Unix()),
+				SigBytes:          []byte{'y', 'e', 'e', 't'},
+				TrackedAllychains: [][]byte{id[:]},
+				CompressionTypes:  []byte{byte(compression.TypeGzip), byte(compression.TypeZstd)},
+			},
+		},
 		{
 			op: PeerList,
 			fields: map[Field]interface{}{
@@ -222,15 +237,94 @@ func TestCodecPackParseGzip(t *testing.T) {
 			},
 		},
 	}
-	for _, m := range msgs {
-		packedIntf, err := c.Pack(m.op, m.fields, m.op.Compressible(), false)
-		assert.NoError(t, err, "failed to pack on operation %s", m.op)
+	for _, compressionType := range []compression.Type{compression.TypeGzip, compression.TypeZstd} {
+		for _, m := range msgs {
+			msgCompressionType := compression.TypeNone
+			if m.op.Compressible() {
+				msgCompressionType = compressionType
+			}
+			packedIntf, err := c.Pack(m.op, m.fields, msgCompressionType, false)
+			assert.NoError(t, err, "failed to pack on operation %s", m.op)
+			assert.Equal(t, msgCompressionType, packedIntf.CompressionType())
+
+			unpackedIntf, err := c.Parse(packedIntf.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+			assert.NoError(t, err, "failed to parse w/ %s compression on operation %s", compressionType, m.op)
+
+			unpacked := unpackedIntf.(*inboundMessage)
+
+			assert.EqualValues(t, len(m.fields), len(unpacked.fields))
+			assert.Equal(t, len(packedIntf.Bytes()), unpacked.NumBytes())
+		}
+	}
+}
+
+func TestCodecParseUnknownCompression(t *testing.T) {
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
+	assert.NoError(t, err)
+
+	_, err = codec.Parse([]byte{byte(AppGossip), math.MaxUint8, 0x00}, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(t, err, errUnknownCompression)
+}
+
+func TestCodecRecompress(t *testing.T) {
+	assert := assert.New(t)
+
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
+	assert.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	appBytes := make([]byte, 1024)
+	fields := map[Field]interface{}{
+		ChainID:  chainID[:],
+		AppBytes: appBytes,
+	}
+	msg, err := codec.Pack(AppGossip, fields, compression.TypeZstd, true)
+	assert.NoError(err)
 
-		unpackedIntf, err := c.Parse(packedIntf.Bytes(), dummyNodeID, dummyOnFinishedHandling)
-		assert.NoError(t, err, "failed to parse w/ compression on operation %s", m.op)
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		recompressed, err := codec.Recompress(msg, compressionType)
+		assert.NoError(err)
+		assert.Equal(AppGossip, recompressed.Op())
+		assert.Equal(compressionType, recompressed.CompressionType())
+		assert.True(recompressed.BypassThrottling())
 
-		unpacked := unpackedIntf.(*inboundMessage)
+		parsed, err := codec.Parse(recompressed.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		assert.NoError(err)
+		assert.Equal(chainID[:], parsed.Get(ChainID))
+		assert.Equal(appBytes, parsed.Get(AppBytes))
+	}
 
-		assert.EqualValues(t, len(m.fields), len(unpacked.fields))
+	_, err = codec.Recompress(NewTestMsg(Ping, []byte{byte(Ping)}, false), compression.TypeGzip)
+	assert.ErrorIs(err, errNotCompressible)
+}
+
+func TestCodecRecompressCached(t *testing.T) {
+	assert := assert.New(t)
+
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
+	assert.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	fields := map[Field]interface{}{
+		ChainID:  chainID[:],
+		AppBytes: make([]byte, 1024),
 	}
+	msg, err := codec.Pack(AppGossip, fields, compression.TypeZstd, false)
+	assert.NoError(err)
+
+	first, err := codec.Recompress(msg, compression.TypeGzip)
+	assert.NoError(err)
+	second, err := codec.Recompress(msg, compression.TypeGzip)
+	assert.NoError(err)
+	assert.Same(first, second)
+
+	cached := first.(*outboundMessage)
+	assert.Equal(3, cached.refs)
+
+	// Releasing the original message releases its reference to the copy.
+	msg.DecRef()
+	assert.Equal(2, cached.refs)
+	first.DecRef()
+	second.DecRef()
+	assert.Equal(0, cached.refs)
 }
@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	_ "embed"
+
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
+)
+
+// supportedCompressionTypes are the types, other than compression.TypeNone,
+// that the payloads of parsed messages may be compressed with. They are listed
+// in the Version message so that peers only send messages compressed with
+// types that both sides support.
+var supportedCompressionTypes = []byte{
+	byte(compression.TypeGzip),
+	byte(compression.TypeZstd),
+}
+
+// zstdDictionary was trained on the payloads of the compressible messages. A
+// message compressed with it can only be decompressed with the same
+// dictionary, so it must not be changed without also changing the compression
+// type that peers negotiate.
+//
+//go:embed zstd_dictionary.bin
+var zstdDictionary []byte
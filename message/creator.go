@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -25,14 +26,14 @@ type creator struct {
 	InternalMsgBuilder
 }
 
-func NewCreator(metrics prometheus.Registerer, compressionEnabled bool, parentNamespace string, maxInboundMessageTimeout time.Duration) (Creator, error) {
+func NewCreator(metrics prometheus.Registerer, compressionType compression.Type, parentNamespace string, maxInboundMessageTimeout time.Duration) (Creator, error) {
 	namespace := fmt.Sprintf("%s_codec", parentNamespace)
 	codec, err := NewCodecWithMemoryPool(namespace, metrics, int64(constants.DefaultMaxMessageSize), maxInboundMessageTimeout)
 	if err != nil {
 		return nil, err
 	}
 	return &creator{
-		OutboundMsgBuilder: NewOutboundBuilder(codec, compressionEnabled),
+		OutboundMsgBuilder: NewOutboundBuilder(codec, compressionType),
 		InboundMsgBuilder:  NewInboundBuilder(codec),
 		InternalMsgBuilder: NewInternalBuilder(),
 	}, nil
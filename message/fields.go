@@ -35,6 +35,7 @@ const (
 	SummaryHeights                   // Used for state sync
 	SummaryIDs                       // Used for state sync
 	VersionStruct                    // Used internally
+	CompressionTypes                 // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -82,6 +83,8 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackUint64Slice
 	case SummaryIDs:
 		return wrappers.TryPackHashes
+	case CompressionTypes:
+		return wrappers.TryPackBytes
 	default:
 		return nil
 	}
@@ -132,6 +135,8 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackUint64Slice
 	case SummaryIDs:
 		return wrappers.TryUnpackHashes
+	case CompressionTypes:
+		return wrappers.TryUnpackBytes
 	default:
 		return nil
 	}
@@ -185,6 +190,8 @@ func (f Field) String() string {
 		return "SummaryIDs"
 	case VersionStruct:
 		return "VersionStruct"
+	case CompressionTypes:
+		return "CompressionTypes"
 	default:
 		return "Unknown Field"
 	}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 )
 
 var (
@@ -22,6 +23,7 @@ type InboundMessage interface {
 	fmt.Stringer
 
 	BytesSavedCompression() int
+	NumBytes() int
 	Op() Op
 	Get(Field) interface{}
 	NodeID() ids.NodeID
@@ -32,6 +34,7 @@ type InboundMessage interface {
 type inboundMessage struct {
 	op                    Op
 	bytesSavedCompression int
+	numBytes              int
 	fields                map[Field]interface{}
 	nodeID                ids.NodeID
 	expirationTime        time.Time
@@ -47,6 +50,10 @@ func (inMsg *inboundMessage) Op() Op { return inMsg.op }
 // compressed.
 func (inMsg *inboundMessage) BytesSavedCompression() int { return inMsg.bytesSavedCompression }
 
+// NumBytes returns the number of bytes this message was received as over the
+// network. 0 for messages that weren't received over the network.
+func (inMsg *inboundMessage) NumBytes() int { return inMsg.numBytes }
+
 // Field returns the value of the specified field in this message
 func (inMsg *inboundMessage) Get(field Field) interface{} { return inMsg.fields[field] }
 
@@ -96,6 +103,7 @@ func (inMsg *inboundMessage) String() string {
 // be serialized into a byte stream
 type OutboundMessage interface {
 	BytesSavedCompression() int
+	CompressionType() compression.Type
 	Bytes() []byte
 	Op() Op
 	BypassThrottling() bool
@@ -107,12 +115,18 @@ type OutboundMessage interface {
 type outboundMessage struct {
 	bytes                 []byte
 	bytesSavedCompression int
+	compressionType       compression.Type
 	op                    Op
 	bypassThrottling      bool
 
 	refLock sync.Mutex
 	refs    int
 	c       *codec
+
+	// recompressed caches the copies of this message returned by
+	// [codec.Recompress], keyed by their compression type.
+	recompressedLock sync.Mutex
+	recompressed     map[compression.Type]*outboundMessage
 }
 
 // Op returns the value of the specified operation in this message
@@ -127,6 +141,9 @@ func (outMsg *outboundMessage) Bytes() []byte { return outMsg.bytes }
 // compressed.
 func (outMsg *outboundMessage) BytesSavedCompression() int { return outMsg.bytesSavedCompression }
 
+// CompressionType returns how the payload of this message is compressed
+func (outMsg *outboundMessage) CompressionType() compression.Type { return outMsg.compressionType }
+
 func (outMsg *outboundMessage) AddRef() {
 	outMsg.refLock.Lock()
 	defer outMsg.refLock.Unlock()
@@ -141,9 +158,18 @@ func (outMsg *outboundMessage) DecRef() {
 	defer outMsg.refLock.Unlock()
 
 	outMsg.refs--
-	if outMsg.refs == 0 {
-		outMsg.c.byteSlicePool.Put(outMsg.bytes)
+	if outMsg.refs != 0 {
+		return
+	}
+	outMsg.c.byteSlicePool.Put(outMsg.bytes)
+
+	outMsg.recompressedLock.Lock()
+	defer outMsg.recompressedLock.Unlock()
+
+	for _, recompressed := range outMsg.recompressed {
+		recompressed.DecRef()
 	}
+	outMsg.recompressed = nil
 }
 
 // BypassThrottling when attempting to send this message
@@ -163,10 +189,11 @@ func NewTestMsg(op Op, bytes []byte, bypassThrottling bool) *TestMsg {
 	}
 }
 
-func (m *TestMsg) Op() Op                   { return m.op }
-func (*TestMsg) Get(Field) interface{}      { return nil }
-func (m *TestMsg) Bytes() []byte            { return m.bytes }
-func (*TestMsg) BytesSavedCompression() int { return 0 }
-func (*TestMsg) AddRef()                    {}
-func (*TestMsg) DecRef()                    {}
-func (m *TestMsg) BypassThrottling() bool   { return m.bypassThrottling }
+func (m *TestMsg) Op() Op                          { return m.op }
+func (*TestMsg) Get(Field) interface{}             { return nil }
+func (m *TestMsg) Bytes() []byte                   { return m.bytes }
+func (*TestMsg) BytesSavedCompression() int        { return 0 }
+func (*TestMsg) CompressionType() compression.Type { return compression.TypeNone }
+func (*TestMsg) AddRef()                           {}
+func (*TestMsg) DecRef()                           {}
+func (m *TestMsg) BypassThrottling() bool          { return m.bypassThrottling }
@@ -206,7 +206,7 @@ var (
 	messages = map[Op][]Field{
 		// Handshake:
 		// TODO: remove NodeID from the Version message
-		Version:  {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedAllychains},
+		Version:  {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedAllychains, CompressionTypes},
 		PeerList: {Peers},
 		Ping:     {},
 		Pong:     {Uptime},
@@ -233,6 +233,12 @@ var (
 		GetAcceptedStateSummary: {ChainID, RequestID, Deadline, SummaryHeights},
 		AcceptedStateSummary:    {ChainID, RequestID, SummaryIDs},
 	}
+
+	// optionalFields are trailing fields that peers running older versions
+	// don't send. They are only parsed if the message has bytes left.
+	optionalFields = map[Field]struct{}{
+		CompressionTypes: {},
+	}
 )
 
 func (op Op) Compressible() bool {
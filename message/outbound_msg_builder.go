@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 )
 
@@ -150,18 +151,37 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	// Recompress returns a copy of [msg] whose payload is compressed with
+	// [compressionType]. Used to send [msg] to peers that don't support the
+	// compression type it was built with. The copy is shared by all callers
+	// recompressing [msg] to the same type.
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilder struct {
-	c        Codec
-	compress bool
+	c               Codec
+	compressionType compression.Type
 }
 
-func NewOutboundBuilder(c Codec, enableCompression bool) OutboundMsgBuilder {
+// NewOutboundBuilder returns a builder that compresses the payloads of
+// compressible messages with [compressionType]
+func NewOutboundBuilder(c Codec, compressionType compression.Type) OutboundMsgBuilder {
 	return &outMsgBuilder{
-		c:        c,
-		compress: enableCompression,
+		c:               c,
+		compressionType: compressionType,
+	}
+}
+
+// compression returns how the payload of an [op] message is compressed
+func (b *outMsgBuilder) compression(op Op) compression.Type {
+	if !op.Compressible() {
+		return compression.TypeNone
 	}
+	return b.compressionType
 }
 
 func (b *outMsgBuilder) Version(
@@ -181,16 +201,17 @@ func (b *outMsgBuilder) Version(
 	return b.c.Pack(
 		Version,
 		map[Field]interface{}{
-			NetworkID:      networkID,
-			NodeID:         uint32(0),
-			MyTime:         myTime,
-			IP:             ip,
-			VersionStr:     myVersion,
-			VersionTime:    myVersionTime,
-			SigBytes:       sig,
+			NetworkID:         networkID,
+			NodeID:            uint32(0),
+			MyTime:            myTime,
+			IP:                ip,
+			VersionStr:        myVersion,
+			VersionTime:       myVersionTime,
+			SigBytes:          sig,
 			TrackedAllychains: allychainIDBytes,
+			CompressionTypes:  supportedCompressionTypes,
 		},
-		Version.Compressible(), // Version Messages can't be compressed
+		compression.TypeNone, // Version Messages can't be compressed
 		true,
 	)
 }
@@ -201,7 +222,7 @@ func (b *outMsgBuilder) PeerList(peers []ips.ClaimedIPPort, bypassThrottling boo
 		map[Field]interface{}{
 			Peers: peers,
 		},
-		b.compress && PeerList.Compressible(), // PeerList messages may be compressed
+		b.compression(PeerList), // PeerList messages may be compressed
 		bypassThrottling,
 	)
 }
@@ -210,7 +231,7 @@ func (b *outMsgBuilder) Ping() (OutboundMessage, error) {
 	return b.c.Pack(
 		Ping,
 		nil,
-		Ping.Compressible(), // Ping messages can't be compressed
+		compression.TypeNone, // Ping messages can't be compressed
 		false,
 	)
 }
@@ -221,7 +242,7 @@ func (b *outMsgBuilder) Pong(uptimePercentage uint8) (OutboundMessage, error) {
 		map[Field]interface{}{
 			Uptime: uptimePercentage,
 		},
-		Pong.Compressible(), // Pong messages can't be compressed
+		compression.TypeNone, // Pong messages can't be compressed
 		false,
 	)
 }
@@ -238,7 +259,7 @@ func (b *outMsgBuilder) GetStateSummaryFrontier(
 			RequestID: requestID,
 			Deadline:  uint64(deadline),
 		},
-		GetStateSummaryFrontier.Compressible(), // GetStateSummaryFrontier messages can't be compressed
+		compression.TypeNone, // GetStateSummaryFrontier messages can't be compressed
 		false,
 	)
 }
@@ -255,7 +276,7 @@ func (b *outMsgBuilder) StateSummaryFrontier(
 			RequestID:    requestID,
 			SummaryBytes: summary,
 		},
-		b.compress && StateSummaryFrontier.Compressible(), // StateSummaryFrontier messages may be compressed
+		b.compression(StateSummaryFrontier), // StateSummaryFrontier messages may be compressed
 		false,
 	)
 }
@@ -274,7 +295,7 @@ func (b *outMsgBuilder) GetAcceptedStateSummary(
 			Deadline:       uint64(deadline),
 			SummaryHeights: heights,
 		},
-		b.compress && GetAcceptedStateSummary.Compressible(), // GetAcceptedStateSummary messages may be compressed
+		b.compression(GetAcceptedStateSummary), // GetAcceptedStateSummary messages may be compressed
 		false,
 	)
 }
@@ -293,7 +314,7 @@ func (b *outMsgBuilder) AcceptedStateSummary(
 			RequestID:  requestID,
 			SummaryIDs: summaryIDBytes,
 		},
-		b.compress && AcceptedStateSummary.Compressible(), // AcceptedStateSummary messages may be compressed
+		b.compression(AcceptedStateSummary), // AcceptedStateSummary messages may be compressed
 		false,
 	)
 }
@@ -310,7 +331,7 @@ func (b *outMsgBuilder) GetAcceptedFrontier(
 			RequestID: requestID,
 			Deadline:  uint64(deadline),
 		},
-		GetAcceptedFrontier.Compressible(), // GetAcceptedFrontier messages can't be compressed
+		compression.TypeNone, // GetAcceptedFrontier messages can't be compressed
 		false,
 	)
 }
@@ -329,7 +350,7 @@ func (b *outMsgBuilder) AcceptedFrontier(
 			RequestID:    requestID,
 			ContainerIDs: containerIDBytes,
 		},
-		AcceptedFrontier.Compressible(), // AcceptedFrontier messages can't be compressed
+		compression.TypeNone, // AcceptedFrontier messages can't be compressed
 		false,
 	)
 }
@@ -350,7 +371,7 @@ func (b *outMsgBuilder) GetAccepted(
 			Deadline:     uint64(deadline),
 			ContainerIDs: containerIDBytes,
 		},
-		GetAccepted.Compressible(), // GetAccepted messages can't be compressed
+		compression.TypeNone, // GetAccepted messages can't be compressed
 		false,
 	)
 }
@@ -369,7 +390,7 @@ func (b *outMsgBuilder) Accepted(
 			RequestID:    requestID,
 			ContainerIDs: containerIDBytes,
 		},
-		Accepted.Compressible(), // Accepted messages can't be compressed
+		compression.TypeNone, // Accepted messages can't be compressed
 		false,
 	)
 }
@@ -388,7 +409,7 @@ func (b *outMsgBuilder) GetAncestors(
 			Deadline:    uint64(deadline),
 			ContainerID: containerID[:],
 		},
-		GetAncestors.Compressible(), // GetAncestors messages can't be compressed
+		compression.TypeNone, // GetAncestors messages can't be compressed
 		false,
 	)
 }
@@ -405,7 +426,7 @@ func (b *outMsgBuilder) Ancestors(
 			RequestID:           requestID,
 			MultiContainerBytes: containers,
 		},
-		b.compress && Ancestors.Compressible(), // Ancestors messages may be compressed
+		b.compression(Ancestors), // Ancestors messages may be compressed
 		false,
 	)
 }
@@ -424,7 +445,7 @@ func (b *outMsgBuilder) Get(
 			Deadline:    uint64(deadline),
 			ContainerID: containerID[:],
 		},
-		Get.Compressible(), // Get messages can't be compressed
+		compression.TypeNone, // Get messages can't be compressed
 		false,
 	)
 }
@@ -443,7 +464,7 @@ func (b *outMsgBuilder) Put(
 			ContainerID:    containerID[:],
 			ContainerBytes: container,
 		},
-		b.compress && Put.Compressible(), // Put messages may be compressed
+		b.compression(Put), // Put messages may be compressed
 		false,
 	)
 }
@@ -464,7 +485,7 @@ func (b *outMsgBuilder) PushQuery(
 			ContainerID:    containerID[:],
 			ContainerBytes: container,
 		},
-		b.compress && PushQuery.Compressible(), // PushQuery messages may be compressed
+		b.compression(PushQuery), // PushQuery messages may be compressed
 		false,
 	)
 }
@@ -483,7 +504,7 @@ func (b *outMsgBuilder) PullQuery(
 			Deadline:    uint64(deadline),
 			ContainerID: containerID[:],
 		},
-		PullQuery.Compressible(), // PullQuery messages can't be compressed
+		compression.TypeNone, // PullQuery messages can't be compressed
 		false,
 	)
 }
@@ -502,7 +523,7 @@ func (b *outMsgBuilder) Chits(
 			RequestID:    requestID,
 			ContainerIDs: containerIDBytes,
 		},
-		Chits.Compressible(), // Chits messages can't be compressed
+		compression.TypeNone, // Chits messages can't be compressed
 		false,
 	)
 }
@@ -522,7 +543,7 @@ func (b *outMsgBuilder) AppRequest(
 			Deadline:  uint64(deadline),
 			AppBytes:  msg,
 		},
-		b.compress && AppRequest.Compressible(), // App messages may be compressed
+		b.compression(AppRequest), // App messages may be compressed
 		false,
 	)
 }
@@ -536,7 +557,7 @@ func (b *outMsgBuilder) AppResponse(chainID ids.ID, requestID uint32, msg []byte
 			RequestID: requestID,
 			AppBytes:  msg,
 		},
-		b.compress && AppResponse.Compressible(), // App messages may be compressed
+		b.compression(AppResponse), // App messages may be compressed
 		false,
 	)
 }
@@ -549,7 +570,14 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 			ChainID:  chainID[:],
 			AppBytes: msg,
 		},
-		b.compress && AppGossip.Compressible(), // App messages may be compressed
+		b.compression(AppGossip), // App messages may be compressed
 		false,
 	)
 }
+
+func (b *outMsgBuilder) Recompress(
+	msg OutboundMessage,
+	compressionType compression.Type,
+) (OutboundMessage, error) {
+	return b.c.Recompress(msg, compressionType)
+}
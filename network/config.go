@@ -8,12 +8,16 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/network/dialer"
+	"github.com/sankar-boro/axia-network-v2/network/peerfilter"
 	"github.com/sankar-boro/axia-network-v2/network/throttling"
+	"github.com/sankar-boro/axia-network-v2/snow/networking/router"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/snow/uptime"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 )
 
@@ -119,6 +123,10 @@ type Config struct {
 	// true.
 	CompressionEnabled bool `json:"compressionEnabled"`
 
+	// CompressionType is the compression used for available outbound messages.
+	// It's [compression.TypeNone] if [CompressionEnabled] is false.
+	CompressionType compression.Type `json:"compressionType"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 
@@ -145,6 +153,27 @@ type Config struct {
 	// the network negatively.
 	RequireValidatorToConnect bool `json:"requireValidatorToConnect"`
 
+	// PeerFilter decides which peers this node may be, or should always be,
+	// connected to.
+	PeerFilter peerfilter.Filter `json:"-"`
+
+	// PeerDB persists the signed IPs of validators so that the node can
+	// reconnect to them after a restart. If nil, IPs aren't persisted.
+	PeerDB database.Database `json:"-"`
+
+	// PeerDBMaxAge is how long a validator's IP is kept in [PeerDB] after it
+	// was last seen.
+	PeerDBMaxAge time.Duration `json:"peerDBMaxAge"`
+
+	// PeerTrafficMetricsNumPeers is the number of peers, the ones that
+	// exchanged the most bytes with this node, whose traffic is reported in
+	// metrics labeled by node ID. If 0, these metrics aren't reported.
+	PeerTrafficMetricsNumPeers int `json:"peerTrafficMetricsNumPeers"`
+
+	// ChainTraffic reports the traffic that peers sent to each chain, or nil
+	// if it isn't reported in the peer traffic metrics.
+	ChainTraffic router.TrafficReporter `json:"-"`
+
 	// MaximumInboundMessageTimeout is the maximum deadline duration in a
 	// message. Messages sent by clients setting values higher than this value
 	// will be reset to this value.
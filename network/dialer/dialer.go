@@ -5,8 +5,10 @@ package dialer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"time"
 
 	"github.com/sankar-boro/axia-network-v2/network/throttling"
@@ -14,7 +16,11 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 )
 
-var _ Dialer = &dialer{}
+var (
+	_ Dialer = &dialer{}
+
+	errIPBanned = errors.New("IP is banned")
+)
 
 // Dialer attempts to create a connection with the provided IP/port pair
 type Dialer interface {
@@ -23,16 +29,28 @@ type Dialer interface {
 	Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error)
 }
 
+// IPFilter reports whether connections to an IP are forbidden
+type IPFilter interface {
+	IPBanned(ip net.IP) bool
+}
+
 type dialer struct {
-	dialer    net.Dialer
-	log       logging.Logger
-	network   string
-	throttler throttling.DialThrottler
+	dialer            contextDialer
+	proxied           bool
+	connectionTimeout time.Duration
+	log               logging.Logger
+	network           string
+	throttler         throttling.DialThrottler
+	ipFilter          IPFilter
 }
 
 type Config struct {
 	ThrottleRps       uint32        `json:"throttleRps"`
 	ConnectionTimeout time.Duration `json:"connectionTimeout"`
+	// ProxyURL is the proxy that peers are dialed through, or nil if peers
+	// are dialed directly. It isn't serialized because it may contain
+	// credentials.
+	ProxyURL *url.URL `json:"-"`
 }
 
 // NewDialer returns a new Dialer that calls net.Dial with the provided network.
@@ -40,7 +58,10 @@ type Config struct {
 // [dialerConfig.connectionTimeout] gives the timeout when dialing an IP.
 // [dialerConfig.throttleRps] gives the max number of outgoing connection attempts/second.
 // If [dialerConfig.throttleRps] == 0, outgoing connections aren't rate-limited.
-func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
+// If [dialerConfig.ProxyURL] != nil, connections are made through that proxy
+// and [dialerConfig.connectionTimeout] includes the handshake with the proxy.
+// IPs that [ipFilter] reports as banned are never dialed.
+func NewDialer(network string, dialerConfig Config, ipFilter IPFilter, log logging.Logger) (Dialer, error) {
 	var throttler throttling.DialThrottler
 	if dialerConfig.ThrottleRps <= 0 {
 		throttler = throttling.NewNoDialThrottler()
@@ -52,22 +73,58 @@ func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
 		dialerConfig.ThrottleRps,
 		dialerConfig.ConnectionTimeout,
 	)
-	return &dialer{
-		dialer:    net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
-		log:       log,
-		network:   network,
-		throttler: throttler,
+	d := &dialer{
+		dialer:            &net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
+		connectionTimeout: dialerConfig.ConnectionTimeout,
+		log:               log,
+		network:           network,
+		throttler:         throttler,
+		ipFilter:          ipFilter,
+	}
+	if dialerConfig.ProxyURL != nil {
+		proxyDialer, err := newProxyDialer(dialerConfig.ProxyURL, &net.Dialer{Timeout: dialerConfig.ConnectionTimeout})
+		if err != nil {
+			return nil, err
+		}
+		log.Info("dialing peers through the proxy at %s", dialerConfig.ProxyURL.Host)
+		d.dialer = proxyDialer
+		d.proxied = true
 	}
+	return d, nil
 }
 
 func (d *dialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	if d.ipFilter.IPBanned(ip.IP) {
+		return nil, fmt.Errorf("not dialing %s: %w", ip, errIPBanned)
+	}
 	if err := d.throttler.Acquire(ctx); err != nil {
 		return nil, err
 	}
 	d.log.Verbo("dialing %s", ip)
+	if !d.proxied {
+		conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
+		if err != nil {
+			return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+		}
+		return conn, nil
+	}
+
+	// The connection timeout bounds both connecting to the proxy and the
+	// proxy connecting to [ip].
+	if d.connectionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.connectionTimeout)
+		defer cancel()
+	}
 	conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
 	if err != nil {
-		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+		return nil, fmt.Errorf("error while dialing %s through the proxy: %w", ip, err)
 	}
-	return conn, nil
+	return &proxiedConn{
+		Conn: conn,
+		remoteAddr: &net.TCPAddr{
+			IP:   ip.IP,
+			Port: int(ip.Port),
+		},
+	}, nil
 }
@@ -18,6 +18,14 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 )
 
+type testIPFilter struct {
+	banned net.IP
+}
+
+func (f testIPFilter) IPBanned(ip net.IP) bool {
+	return f.banned.Equal(ip)
+}
+
 // Test that canceling a context passed into Dial results
 // in giving up trying to connect
 func TestDialerCancelDial(t *testing.T) {
@@ -65,7 +73,8 @@ func TestDialerCancelDial(t *testing.T) {
 	}
 
 	// Create a dialer that should allow 10 outgoing connections per second
-	dialer := NewDialer("tcp", Config{ThrottleRps: 10, ConnectionTimeout: 30 * time.Second}, logging.NoLog{})
+	dialer, err := NewDialer("tcp", Config{ThrottleRps: 10, ConnectionTimeout: 30 * time.Second}, testIPFilter{}, logging.NoLog{})
+	assert.NoError(t, err)
 	// Make 5 outgoing connections. Should not be throttled.
 	for i := 0; i < 5; i++ {
 		startTime := time.Now()
@@ -94,3 +103,15 @@ func TestDialerCancelDial(t *testing.T) {
 	done <- struct{}{} // mark that test is done
 	_ = l.Close()
 }
+
+// Test that banned IPs aren't dialed
+func TestDialerBannedIP(t *testing.T) {
+	bannedIP := ips.IPPort{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 9651,
+	}
+	dialer, err := NewDialer("tcp", Config{ConnectionTimeout: 30 * time.Second}, testIPFilter{banned: bannedIP.IP}, logging.NoLog{})
+	assert.NoError(t, err)
+	_, err = dialer.Dial(context.Background(), bannedIP)
+	assert.ErrorIs(t, err, errIPBanned)
+}
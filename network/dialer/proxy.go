@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+const defaultHTTPPort = "80"
+
+var (
+	errInvalidProxyURL        = errors.New("invalid proxy URL")
+	errUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+	errMissingProxyHost       = errors.New("missing proxy host")
+	errProxyRefused           = errors.New("proxy refused the connection")
+
+	_ contextDialer = &net.Dialer{}
+	_ contextDialer = &httpProxyDialer{}
+	_ net.Conn      = &proxiedConn{}
+)
+
+type contextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// ParseProxyURL parses [s] as the URL of a proxy that peers can be dialed
+// through. The scheme must be socks5, socks5h or http. Credentials may be
+// given in the user info of the URL, so they aren't included in the returned
+// errors.
+func ParseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errInvalidProxyURL
+	}
+	switch u.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedProxyScheme, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errMissingProxyHost
+	}
+	return u, nil
+}
+
+// newProxyDialer returns a dialer that connects to addresses through the proxy
+// at [proxyURL]. The proxy itself is connected to with [forward].
+func newProxyDialer(proxyURL *url.URL, forward *net.Dialer) (contextDialer, error) {
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		d, err := proxy.FromURL(proxyURL, forward)
+		if err != nil {
+			return nil, err
+		}
+		// The SOCKS5 dialer of [proxy] always supports contexts.
+		return d.(proxy.ContextDialer), nil
+	case "http":
+		port := proxyURL.Port()
+		if port == "" {
+			port = defaultHTTPPort
+		}
+		d := &httpProxyDialer{
+			address: net.JoinHostPort(proxyURL.Hostname(), port),
+			forward: forward,
+		}
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			credentials := proxyURL.User.Username() + ":" + password
+			d.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+		}
+		return d, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedProxyScheme, proxyURL.Scheme)
+	}
+}
+
+// httpProxyDialer tunnels connections through an HTTP proxy with the CONNECT
+// method
+type httpProxyDialer struct {
+	// address of the proxy
+	address string
+	// authorization is the value of the Proxy-Authorization header, or empty
+	// if the proxy doesn't require credentials
+	authorization string
+	forward       *net.Dialer
+}
+
+func (d *httpProxyDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.forward.DialContext(ctx, network, d.address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The deadline of [ctx] also bounds the CONNECT handshake.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if d.authorization != "" {
+		req.Header.Set("Proxy-Authorization", d.authorization)
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%w: %s", errProxyRefused, resp.Status)
+	}
+	// The peer doesn't send anything before this node starts the TLS
+	// handshake, so the proxy's response must be the only data read.
+	if reader.Buffered() != 0 {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%w: unexpected data after the response", errProxyRefused)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// proxiedConn is a connection made through a proxy. RemoteAddr returns the
+// address that was dialed through the proxy, rather than the address of the
+// proxy, so that the peer is identified, and its IP filtered, like a peer that
+// was dialed directly.
+type proxiedConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c *proxiedConn) RemoteAddr() net.Addr { return c.remoteAddr }
@@ -4,10 +4,13 @@
 package network
 
 import (
+	"sort"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/network/peer"
+	"github.com/sankar-boro/axia-network-v2/snow/networking/router"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
 )
@@ -26,6 +29,10 @@ type metrics struct {
 	inboundConnAllowed        prometheus.Counter
 	nodeUptimeWeightedAverage prometheus.Gauge
 	nodeUptimeRewardingStake  prometheus.Gauge
+	peerBytes                 *prometheus.GaugeVec
+	peerMessages              *prometheus.GaugeVec
+	peerChainBytes            *prometheus.GaugeVec
+	peerChainMessages         *prometheus.GaugeVec
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer, initialAllychainIDs ids.Set) (*metrics, error) {
@@ -98,6 +105,38 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialAllyc
 			Name:      "node_uptime_rewarding_stake",
 			Help:      "The percentage of total stake which thinks this node is eligible for rewards",
 		}),
+		peerBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "peer_bytes",
+				Help:      "Bytes of messages of an op sent to, or received from, one of the peers that exchanged the most bytes with this node",
+			},
+			[]string{"nodeID", "op", "direction"},
+		),
+		peerMessages: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "peer_messages",
+				Help:      "Number of messages of an op sent to, or received from, one of the peers that exchanged the most bytes with this node",
+			},
+			[]string{"nodeID", "op", "direction"},
+		),
+		peerChainBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "peer_chain_received_bytes",
+				Help:      "Bytes of messages received for a chain from one of the peers that exchanged the most bytes with this node. Chains this node doesn't run are counted as \"unknown\"",
+			},
+			[]string{"nodeID", "chainID"},
+		),
+		peerChainMessages: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "peer_chain_received_messages",
+				Help:      "Number of messages received for a chain from one of the peers that exchanged the most bytes with this node. Chains this node doesn't run are counted as \"unknown\"",
+			},
+			[]string{"nodeID", "chainID"},
+		),
 	}
 
 	errs := wrappers.Errs{}
@@ -115,6 +154,10 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialAllyc
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
+		registerer.Register(m.peerBytes),
+		registerer.Register(m.peerMessages),
+		registerer.Register(m.peerChainBytes),
+		registerer.Register(m.peerChainMessages),
 	)
 
 	// init allychain tracker metrics with whitelisted allychains
@@ -156,3 +199,45 @@ func (m *metrics) markDisconnected(peer peer.Peer) {
 		m.numAllychainPeers.WithLabelValues(allychainID.String()).Dec()
 	}
 }
+
+// updatePeerTraffic reports the traffic of the [numPeers] peers in [peers] that
+// exchanged the most bytes with this node. The metrics of the other peers are
+// removed so that the number of label values stays bounded. If [chainTraffic]
+// isn't nil, the traffic the peers sent to each chain is reported as well.
+// [peers] is sorted in place.
+func (m *metrics) updatePeerTraffic(peers []peer.Details, chainTraffic router.TrafficReporter, numPeers int) {
+	totalBytes := make(map[ids.NodeID]uint64, len(peers))
+	for _, p := range peers {
+		total := peer.TotalTraffic(p.Traffic)
+		totalBytes[p.ID] = total.BytesSent + total.BytesReceived
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return totalBytes[peers[i].ID] > totalBytes[peers[j].ID]
+	})
+	if len(peers) > numPeers {
+		peers = peers[:numPeers]
+	}
+
+	m.peerBytes.Reset()
+	m.peerMessages.Reset()
+	m.peerChainBytes.Reset()
+	m.peerChainMessages.Reset()
+	for _, p := range peers {
+		nodeIDStr := p.ID.String()
+		for op, t := range p.Traffic {
+			opStr := op.String()
+			m.peerBytes.WithLabelValues(nodeIDStr, opStr, "sent").Set(float64(t.BytesSent))
+			m.peerBytes.WithLabelValues(nodeIDStr, opStr, "received").Set(float64(t.BytesReceived))
+			m.peerMessages.WithLabelValues(nodeIDStr, opStr, "sent").Set(float64(t.MessagesSent))
+			m.peerMessages.WithLabelValues(nodeIDStr, opStr, "received").Set(float64(t.MessagesReceived))
+		}
+
+		if chainTraffic == nil {
+			continue
+		}
+		for chain, t := range chainTraffic.ChainTraffic(p.ID) {
+			m.peerChainBytes.WithLabelValues(nodeIDStr, chain).Set(float64(t.BytesReceived))
+			m.peerChainMessages.WithLabelValues(nodeIDStr, chain).Set(float64(t.MessagesReceived))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/message"
+	"github.com/sankar-boro/axia-network-v2/network/peer"
+	"github.com/sankar-boro/axia-network-v2/snow/networking/router"
+)
+
+type testTrafficReporter map[ids.NodeID]map[string]router.ChainTraffic
+
+func (r testTrafficReporter) ChainTraffic(nodeID ids.NodeID) map[string]router.ChainTraffic {
+	return r[nodeID]
+}
+
+// peerTrafficNodeIDs returns the node IDs that the metric [name] in
+// [registry] is labeled with
+func peerTrafficNodeIDs(t *testing.T, registry *prometheus.Registry, name string) ids.NodeIDSet {
+	t.Helper()
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+
+	nodeIDs := ids.NodeIDSet{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "nodeID" {
+					continue
+				}
+				nodeID, err := ids.NodeIDFromString(label.GetValue())
+				assert.NoError(t, err)
+				nodeIDs.Add(nodeID)
+			}
+		}
+	}
+	return nodeIDs
+}
+
+func TestUpdatePeerTrafficMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	m, err := newMetrics("", registry, ids.Set{})
+	assert.NoError(err)
+
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	chainID := ids.GenerateTestID()
+	chainTraffic := testTrafficReporter{}
+	peers := make([]peer.Details, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		peers[i] = peer.Details{
+			Info: peer.Info{ID: nodeID},
+			Traffic: map[message.Op]peer.Traffic{
+				message.Get: {
+					MessagesReceived: 1,
+					BytesReceived:    uint64(100 * (i + 1)),
+				},
+			},
+		}
+		chainTraffic[nodeID] = map[string]router.ChainTraffic{
+			chainID.String(): {
+				MessagesReceived: 1,
+				BytesReceived:    uint64(100 * (i + 1)),
+			},
+		}
+	}
+
+	// Only the 2 peers that exchanged the most bytes are reported.
+	m.updatePeerTraffic(peers, chainTraffic, 2)
+	expected := ids.NodeIDSet{}
+	expected.Add(nodeIDs[1], nodeIDs[2])
+	assert.Equal(expected, peerTrafficNodeIDs(t, registry, "peer_bytes"))
+	assert.Equal(expected, peerTrafficNodeIDs(t, registry, "peer_chain_received_bytes"))
+
+	// Peers that drop out of the top are removed.
+	for _, p := range peers {
+		if p.ID == nodeIDs[0] {
+			p.Traffic[message.Get] = peer.Traffic{
+				MessagesReceived: 1,
+				BytesReceived:    1000,
+			}
+		}
+	}
+	m.updatePeerTraffic(peers, chainTraffic, 2)
+	expected = ids.NodeIDSet{}
+	expected.Add(nodeIDs[0], nodeIDs[2])
+	assert.Equal(expected, peerTrafficNodeIDs(t, registry, "peer_bytes"))
+}
@@ -78,6 +78,38 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
+	// Disconnect closes the connection to [nodeID]. Returns false if this node
+	// isn't connected, or connecting, to [nodeID]. The node may reconnect to
+	// [nodeID] later if it wants a connection to it.
+	Disconnect(nodeID ids.NodeID) bool
+
+	// BanNodeID forbids connections to [nodeID] for [duration], or until it's
+	// unbanned if [duration] is 0, and closes the connection to it.
+	BanNodeID(nodeID ids.NodeID, duration time.Duration) error
+
+	// UnbanNodeID allows connections to [nodeID] again.
+	UnbanNodeID(nodeID ids.NodeID) error
+
+	// BanIPs forbids connections to the IPs in [ipNet] for [duration], or
+	// until they're unbanned if [duration] is 0, and closes the connections to
+	// them.
+	BanIPs(ipNet *net.IPNet, duration time.Duration) error
+
+	// UnbanIPs allows connections to the IPs in [ipNet] again.
+	UnbanIPs(ipNet *net.IPNet) error
+
+	// Pin makes this node always attempt to be connected to [nodeID] at [ip],
+	// including after restarts.
+	Pin(nodeID ids.NodeID, ip ips.IPPort) error
+
+	// Unpin stops this node from always attempting to be connected to
+	// [nodeID].
+	Unpin(nodeID ids.NodeID) error
+
+	// PeerDetails returns information about peers and the traffic exchanged
+	// with them. If [nodeIDs] is empty, all connected peers are returned.
+	PeerDetails(nodeIDs []ids.NodeID) []peer.Details
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
@@ -121,6 +153,9 @@ type network struct {
 
 	sendFailRateCalculator math.Averager
 
+	// Persists the signed IPs of validators. Nil if IPs aren't persisted.
+	peerDB *peerDB
+
 	peersLock sync.RWMutex
 	// trackedIPs contains the set of IPs that we are currently attempting to
 	// connect to. An entry is added to this set when we first start attempting
@@ -252,6 +287,9 @@ func NewNetwork(
 		connectedPeers:  peer.NewSet(),
 		router:          router,
 	}
+	if config.PeerDB != nil {
+		n.peerDB = newPeerDB(config.PeerDB, &peerConfig.Clock, config.PeerDBMaxAge)
+	}
 	n.peerConfig.Network = n
 	return n, nil
 }
@@ -364,6 +402,8 @@ func (n *network) Connected(nodeID ids.NodeID) {
 
 	n.metrics.markConnected(peer)
 
+	n.storeConnectedIP(peer)
+
 	peerVersion := peer.Version()
 	n.router.Connected(nodeID, peerVersion)
 }
@@ -379,6 +419,12 @@ func (n *network) AllowConnection(nodeID ids.NodeID) bool {
 }
 
 func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
+	return n.track(claimedIPPort, true)
+}
+
+// track attempts to connect to the peer at [claimedIPPort]. If [store] and the
+// peer is a validator, its signed IP is persisted.
+func (n *network) track(claimedIPPort ips.ClaimedIPPort, store bool) bool {
 	nodeID := ids.NodeIDFromCert(claimedIPPort.Cert)
 
 	// Verify that we do want to attempt to make a connection to this peer
@@ -403,6 +449,10 @@ func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
 		return false
 	}
 
+	if store && n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+		n.storeIP(nodeID, claimedIPPort, false)
+	}
+
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
 
@@ -494,6 +544,9 @@ func (n *network) Pong(nodeID ids.NodeID) (message.OutboundMessage, error) {
 // Dispatch starts accepting connections from other nodes attempting to connect
 // to this node.
 func (n *network) Dispatch() error {
+	n.trackStoredIPs()
+	n.trackPins()
+
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
 	errs := wrappers.Errs{}
@@ -556,6 +609,74 @@ func (n *network) Dispatch() error {
 	return errs.Err
 }
 
+// storeIP persists the signed IP of the validator [nodeID]. If [connected],
+// this node finished a handshake with the validator at [ip].
+func (n *network) storeIP(nodeID ids.NodeID, ip ips.ClaimedIPPort, connected bool) {
+	if n.peerDB == nil {
+		return
+	}
+	if err := n.peerDB.put(nodeID, ip, connected); err != nil {
+		n.peerConfig.Log.Warn("failed to persist the IP of %s: %s", nodeID, err)
+	}
+}
+
+// storeConnectedIP persists the signed IP of [peer], which this node finished a
+// handshake with, if it's a validator.
+func (n *network) storeConnectedIP(peer peer.Peer) {
+	nodeID := peer.ID()
+	if !n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+		return
+	}
+	peerIP := peer.IP()
+	n.storeIP(nodeID, ips.ClaimedIPPort{
+		Cert:      peer.Cert(),
+		IPPort:    peerIP.IP.IP,
+		Timestamp: peerIP.IP.Timestamp,
+		Signature: peerIP.Signature,
+	}, true)
+}
+
+// storeConnectedIPs refreshes the persisted IPs of the connected validators,
+// so that they aren't expired while the validators stay connected.
+func (n *network) storeConnectedIPs() {
+	if n.peerDB == nil {
+		return
+	}
+
+	n.peersLock.RLock()
+	connected := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	for _, peer := range connected {
+		n.storeConnectedIP(peer)
+	}
+}
+
+// trackStoredIPs attempts to connect to the validators whose IPs were
+// persisted, starting with the most reliable ones.
+func (n *network) trackStoredIPs() {
+	if n.peerDB == nil {
+		return
+	}
+
+	storedIPs, err := n.peerDB.load()
+	if err != nil {
+		n.peerConfig.Log.Warn("failed to load persisted peer IPs: %s", err)
+		return
+	}
+
+	numTracked := 0
+	for _, storedIP := range storedIPs {
+		if n.track(storedIP.ClaimedIPPort, false) {
+			numTracked++
+		}
+	}
+	n.peerConfig.Log.Info(
+		"attempting to connect to %d of %d persisted peer IPs",
+		numTracked, len(storedIPs),
+	)
+}
+
 func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
@@ -564,6 +685,12 @@ func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 }
 
 func (n *network) wantsConnection(nodeID ids.NodeID) bool {
+	if n.config.PeerFilter.NodeIDBanned(nodeID) {
+		return false
+	}
+	if _, pinned := n.config.PeerFilter.Pinned(nodeID); pinned {
+		return true
+	}
 	return n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) ||
 		n.manuallyTrackedIDs.Contains(nodeID)
 }
@@ -573,7 +700,14 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	defer n.peersLock.Unlock()
 
 	n.manuallyTrackedIDs.Add(nodeID)
+	n.trackIP(nodeID, ip)
+}
 
+// trackIP starts attempting to connect to [nodeID] at [ip], unless this node
+// is already connected to, or attempting to connect to, [nodeID].
+//
+// Assumes [n.peersLock] is held.
+func (n *network) trackIP(nodeID ids.NodeID, ip ips.IPPort) {
 	_, connected := n.connectedPeers.GetByID(nodeID)
 	if connected {
 		// If I'm currently connected to [nodeID] then they will have told me
@@ -593,6 +727,105 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	}
 }
 
+func (n *network) Disconnect(nodeID ids.NodeID) bool {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	peer, ok := n.connectedPeers.GetByID(nodeID)
+	if !ok {
+		peer, ok = n.connectingPeers.GetByID(nodeID)
+	}
+	if ok {
+		peer.StartClose()
+	}
+	return ok
+}
+
+func (n *network) BanNodeID(nodeID ids.NodeID, duration time.Duration) error {
+	if err := n.config.PeerFilter.BanNodeID(nodeID, duration); err != nil {
+		return err
+	}
+	n.Disconnect(nodeID)
+	return nil
+}
+
+func (n *network) UnbanNodeID(nodeID ids.NodeID) error {
+	if err := n.config.PeerFilter.UnbanNodeID(nodeID); err != nil {
+		return err
+	}
+	n.trackPins()
+	return nil
+}
+
+func (n *network) BanIPs(ipNet *net.IPNet, duration time.Duration) error {
+	if err := n.config.PeerFilter.BanIPs(ipNet, duration); err != nil {
+		return err
+	}
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	for _, peers := range []peer.Set{n.connectingPeers, n.connectedPeers} {
+		for i := 0; i < peers.Len(); i++ {
+			peer, _ := peers.GetByIndex(i)
+			if n.peerIPBanned(peer) {
+				peer.StartClose()
+			}
+		}
+	}
+	return nil
+}
+
+func (n *network) UnbanIPs(ipNet *net.IPNet) error {
+	if err := n.config.PeerFilter.UnbanIPs(ipNet); err != nil {
+		return err
+	}
+	n.trackPins()
+	return nil
+}
+
+func (n *network) Pin(nodeID ids.NodeID, ip ips.IPPort) error {
+	if err := n.config.PeerFilter.Pin(nodeID, ip); err != nil {
+		return err
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	if n.wantsConnection(nodeID) && !n.config.PeerFilter.IPBanned(ip.IP) {
+		n.trackIP(nodeID, ip)
+	}
+	return nil
+}
+
+func (n *network) Unpin(nodeID ids.NodeID) error {
+	return n.config.PeerFilter.Unpin(nodeID)
+}
+
+// trackPins attempts to connect to the pinned peers that aren't banned
+func (n *network) trackPins() {
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	for _, pin := range n.config.PeerFilter.Pins() {
+		if n.wantsConnection(pin.NodeID) && !n.config.PeerFilter.IPBanned(pin.IP.IP) {
+			n.trackIP(pin.NodeID, pin.IP)
+		}
+	}
+}
+
+// peerIPBanned returns true if the address that [p] is connected from, or the
+// IP that [p] claims, is banned
+func (n *network) peerIPBanned(p peer.Peer) bool {
+	if ip, err := ips.ToIPPort(p.Info().IP); err == nil && n.config.PeerFilter.IPBanned(ip.IP) {
+		return true
+	}
+	if signedIP := p.IP(); signedIP != nil && n.config.PeerFilter.IPBanned(signedIP.IP.IP.IP) {
+		return true
+	}
+	return false
+}
+
 func (n *network) TracksAllychain(nodeID ids.NodeID, allychainID ids.ID) bool {
 	if n.config.MyNodeID == nodeID {
 		return allychainID == constants.PrimaryNetworkID || n.config.WhitelistedAllychains.Contains(allychainID)
@@ -769,6 +1002,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 
 func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.router.Disconnected(nodeID)
+	n.storeConnectedIP(peer)
 
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
@@ -796,6 +1030,14 @@ func (n *network) shouldTrack(nodeID ids.NodeID, ip ips.ClaimedIPPort) bool {
 		return false
 	}
 
+	if n.config.PeerFilter.IPBanned(ip.IPPort.IP) {
+		n.peerConfig.Log.Verbo(
+			"dropping suggested connected to %s because the ip (%s) is banned",
+			nodeID, ip.IPPort,
+		)
+		return false
+	}
+
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
@@ -849,7 +1091,7 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 			}
 
 			n.peersLock.Lock()
-			if !n.wantsConnection(nodeID) {
+			if !n.wantsConnection(nodeID) || n.config.PeerFilter.IPBanned(ip.ip.IP.IP) {
 				// Typically [n.trackedIPs[nodeID]] will already equal [ip], but
 				// the reference to [ip] is refreshed to avoid any potential
 				// race conditions before removing the entry.
@@ -917,6 +1159,12 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
 func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+	if ip, err := ips.ToIPPort(conn.RemoteAddr().String()); err == nil && n.config.PeerFilter.IPBanned(ip.IP) {
+		_ = conn.Close()
+		n.peerConfig.Log.Verbo("dropping connection to %s because the IP is banned", ip)
+		return nil
+	}
+
 	if conn, ok := conn.(*net.TCPConn); ok {
 		// If a connection is closed, we shouldn't bother keeping any messages
 		// in memory.
@@ -954,6 +1202,12 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if n.config.PeerFilter.NodeIDBanned(nodeID) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo("dropping connection to %s because it is banned", nodeID)
+		return nil
+	}
+
 	if !n.AllowConnection(nodeID) {
 		_ = tlsConn.Close()
 		n.peerConfig.Log.Verbo(
@@ -1020,10 +1274,43 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerDetails(nodeIDs []ids.NodeID) []peer.Details {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	var peers []peer.Peer
+	if len(nodeIDs) == 0 {
+		peers = make([]peer.Peer, n.connectedPeers.Len())
+		for i := range peers {
+			peers[i], _ = n.connectedPeers.GetByIndex(i)
+		}
+	} else {
+		peers = make([]peer.Peer, 0, len(nodeIDs))
+		for _, nodeID := range nodeIDs {
+			if peer, ok := n.connectedPeers.GetByID(nodeID); ok {
+				peers = append(peers, peer)
+			}
+		}
+	}
+
+	details := make([]peer.Details, len(peers))
+	for i, p := range peers {
+		details[i] = peer.Details{
+			Info:    p.Info(),
+			Traffic: p.Traffic(),
+		}
+	}
+	return details
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
 
+		// The IPs are refreshed before the peers disconnect, so that the
+		// validators are reconnected to after a restart.
+		n.storeConnectedIPs()
+
 		if err := n.listener.Close(); err != nil {
 			n.peerConfig.Log.Debug("closing the network listener failed with: %s", err)
 		}
@@ -1106,6 +1393,14 @@ func (n *network) runTimers() {
 		updateUptimes.Stop()
 	}()
 
+	// nil, so never ready, if IPs aren't persisted
+	var refreshPeerDB <-chan time.Time
+	if n.peerDB != nil {
+		refreshIPs := time.NewTicker(n.peerDB.refreshFrequency())
+		defer refreshIPs.Stop()
+		refreshPeerDB = refreshIPs.C
+	}
+
 	for {
 		select {
 		case <-n.onCloseCtx.Done():
@@ -1141,6 +1436,17 @@ func (n *network) runTimers() {
 			result, _ := n.NodeUptime()
 			n.metrics.nodeUptimeWeightedAverage.Set(result.WeightedAveragePercentage)
 			n.metrics.nodeUptimeRewardingStake.Set(result.RewardingStakePercentage)
+
+			if n.config.PeerTrafficMetricsNumPeers > 0 {
+				n.metrics.updatePeerTraffic(
+					n.PeerDetails(nil),
+					n.config.ChainTraffic,
+					n.config.PeerTrafficMetricsNumPeers,
+				)
+			}
+
+		case <-refreshPeerDB:
+			n.storeConnectedIPs()
 		}
 	}
 }
@@ -14,15 +14,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/message"
 	"github.com/sankar-boro/axia-network-v2/network/dialer"
+	"github.com/sankar-boro/axia-network-v2/network/peerfilter"
 	"github.com/sankar-boro/axia-network-v2/network/throttling"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/benchlist"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/router"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/snow/uptime"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
@@ -163,6 +166,10 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 
+		peerFilter, err := peerfilter.New(memdb.New())
+		assert.NoError(t, err)
+		config.PeerFilter = peerFilter
+
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
 		configs[i] = &config
@@ -174,7 +181,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	t.Helper()
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
-		true,
+		compression.TypeGzip,
 		"",
 		10*time.Second,
 	)
@@ -183,6 +190,12 @@ func newMessageCreator(t *testing.T) message.Creator {
 }
 
 func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler) ([]ids.NodeID, []Network, *sync.WaitGroup) {
+	return newConfiguredTestNetwork(t, handlers, func(*Config) {})
+}
+
+// newConfiguredTestNetwork is newFullyConnectedTestNetwork with the config of
+// every network modified by [configure].
+func newConfiguredTestNetwork(t *testing.T, handlers []router.InboundHandler, configure func(*Config)) ([]ids.NodeID, []Network, *sync.WaitGroup) {
 	assert := assert.New(t)
 
 	dialer, listeners, nodeIDs, configs := newTestNetwork(t, len(handlers))
@@ -212,6 +225,7 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 
 		config.Beacons = beacons
 		config.Validators = vdrs
+		configure(config)
 
 		var connected ids.NodeIDSet
 		net, err := NewNetwork(
@@ -357,3 +371,71 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBanNodeIDDisconnects(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	net0 := networks[0]
+	assert.NoError(net0.BanNodeID(nodeIDs[1], 0))
+
+	assert.Eventually(func() bool {
+		return len(net0.PeerInfo(nil)) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The banned node isn't reconnected to, even though it's a validator.
+	assert.False(net0.(*network).wantsConnection(nodeIDs[1]))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestStoreConnectedIPs(t *testing.T) {
+	assert := assert.New(t)
+
+	peerDBs := []*memdb.Database(nil)
+	nodeIDs, networks, wg := newConfiguredTestNetwork(t, []router.InboundHandler{nil, nil}, func(config *Config) {
+		db := memdb.New()
+		peerDBs = append(peerDBs, db)
+		config.PeerDB = db
+		config.PeerDBMaxAge = time.Hour
+	})
+
+	// expire marks the stored IP of nodeIDs[1] as last seen long ago
+	expire := func() {
+		b, err := peerDBs[0].Get(nodeIDs[1][:])
+		assert.NoError(err)
+		stored, err := parseStoredIP(b)
+		assert.NoError(err)
+		stored.LastSeen = 1
+		b, err = stored.bytes()
+		assert.NoError(err)
+		assert.NoError(peerDBs[0].Put(nodeIDs[1][:], b))
+	}
+	lastSeen := func() uint64 {
+		b, err := peerDBs[0].Get(nodeIDs[1][:])
+		assert.NoError(err)
+		stored, err := parseStoredIP(b)
+		assert.NoError(err)
+		return stored.LastSeen
+	}
+
+	// The IPs of connected validators are refreshed, so they don't expire
+	// while the validators stay connected.
+	net, ok := networks[0].(*network)
+	assert.True(ok)
+	expire()
+	net.storeConnectedIPs()
+	assert.Greater(lastSeen(), uint64(1))
+
+	// The IPs are refreshed on shutdown
+	expire()
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+	assert.Greater(lastSeen(), uint64(1))
+}
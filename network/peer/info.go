@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/message"
 	"github.com/sankar-boro/axia-network-v2/utils/json"
 )
 
@@ -20,3 +21,9 @@ type Info struct {
 	ObservedUptime json.Uint8 `json:"observedUptime"`
 	TrackedAllychains []ids.ID   `json:"trackedAllychains"`
 }
+
+// Details is the information about a peer and the traffic exchanged with it
+type Details struct {
+	Info
+	Traffic map[message.Op]Traffic
+}
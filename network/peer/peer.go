@@ -19,6 +19,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/message"
 	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/formatting"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
@@ -79,6 +80,10 @@ type Peer interface {
 	// returns true.
 	ObservedUptime() uint8
 
+	// Traffic returns the messages and bytes sent to, and received from, the
+	// peer since the connection was established, by op.
+	Traffic() map[message.Op]Traffic
+
 	// Send attempts to send [msg] to the peer. The peer takes ownership of
 	// [msg] for reference counting. This returns false if the message is
 	// guaranteed not to be delivered to the peer.
@@ -138,6 +143,13 @@ type peer struct {
 	// Only modified on the connection's reader routine.
 	finishedHandshake utils.AtomicBool
 
+	// The compression types, other than compression.TypeNone, that the peer
+	// listed in its Version message as a map[compression.Type]struct{}. Until
+	// the Version message is received, or if it doesn't list any types, only
+	// gzip is assumed to be supported.
+	// Only modified on the connection's reader routine.
+	compressionTypes utils.AtomicInterface
+
 	// onFinishHandshake is closed when the peer finishes the p2p handshake.
 	onFinishHandshake chan struct{}
 
@@ -155,6 +167,8 @@ type peer struct {
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
+
+	trafficCounter trafficCounter
 }
 
 func Start(
@@ -248,6 +262,8 @@ func (p *peer) ObservedUptime() uint8 {
 	return uptime
 }
 
+func (p *peer) Traffic() map[message.Op]Traffic { return p.trafficCounter.traffic() }
+
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
 	return p.messageQueue.Push(ctx, msg)
 }
@@ -403,6 +419,7 @@ func (p *peer) readMessages() {
 		atomic.StoreInt64(&p.Config.LastReceived, now)
 		atomic.StoreInt64(&p.lastReceived, now)
 		p.Metrics.Received(msg, msgLen)
+		p.trafficCounter.received(msg.Op(), int(msgLen))
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -452,7 +469,45 @@ func (p *peer) writeMessages() {
 	}
 }
 
+// sendCompressionType returns the type that messages compressed with
+// [compressionType] are sent to the peer with. If the peer didn't list
+// [compressionType], gzip is used if the peer listed it and otherwise the
+// messages are sent uncompressed.
+func (p *peer) sendCompressionType(compressionType compression.Type) compression.Type {
+	if compressionType == compression.TypeNone {
+		return compressionType
+	}
+	compressionTypes, _ := p.compressionTypes.GetValue().(map[compression.Type]struct{})
+	if compressionTypes == nil {
+		compressionTypes = map[compression.Type]struct{}{compression.TypeGzip: {}}
+	}
+	if _, ok := compressionTypes[compressionType]; ok {
+		return compressionType
+	}
+	if _, ok := compressionTypes[compression.TypeGzip]; ok {
+		return compression.TypeGzip
+	}
+	return compression.TypeNone
+}
+
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
+	if compressionType := p.sendCompressionType(msg.CompressionType()); compressionType != msg.CompressionType() {
+		// The peer can't parse messages compressed like [msg], so it is sent
+		// a copy compressed with a type that it listed instead. The copy is
+		// cached on [msg], so it is only compressed once for all the peers it
+		// is sent to.
+		recompressedMsg, err := p.MessageCreator.Recompress(msg, compressionType)
+		msg.DecRef()
+		if err != nil {
+			p.Log.Verbo(
+				"error recompressing %s message to %s: %s",
+				msg.Op(), p.id, err,
+			)
+			return
+		}
+		msg = recompressedMsg
+	}
+
 	msgBytes := msg.Bytes()
 	p.Log.Verbo(
 		"sending message to %s:\n%s",
@@ -483,6 +538,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time().Unix()
 	atomic.StoreInt64(&p.Config.LastSent, now)
 	atomic.StoreInt64(&p.lastSent, now)
+	p.trafficCounter.sent(msg.Op(), int(msgLen))
 	p.Metrics.Sent(msg)
 }
 
@@ -619,6 +675,20 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 	}
 	p.version = peerVersion
 
+	// Peers running older versions don't list the compression types they
+	// support. Every version supports gzip.
+	compressionTypes := map[compression.Type]struct{}{}
+	compressionTypeBytes, _ := msg.Get(message.CompressionTypes).([]byte)
+	for _, typeByte := range compressionTypeBytes {
+		if compressionType := compression.Type(typeByte); compressionType.Valid() && compressionType != compression.TypeNone {
+			compressionTypes[compressionType] = struct{}{}
+		}
+	}
+	if len(compressionTypes) == 0 {
+		compressionTypes[compression.TypeGzip] = struct{}{}
+	}
+	p.compressionTypes.SetValue(compressionTypes)
+
 	if p.VersionCompatibility.Version().Before(peerVersion) {
 		if p.Beacons.Contains(p.id) {
 			p.Log.Info(
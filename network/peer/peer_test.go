@@ -22,6 +22,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
 	"github.com/sankar-boro/axia-network-v2/staking"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
@@ -47,7 +48,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	t.Helper()
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
-		true,
+		compression.TypeGzip,
 		"",
 		10*time.Second,
 	)
@@ -285,3 +286,98 @@ func TestSend(t *testing.T) {
 	err = peer1.AwaitClosed(context.Background())
 	assert.NoError(err)
 }
+
+func TestSendZstdToPeerWithoutZstd(t *testing.T) {
+	assert := assert.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t)
+	assert.Equal(compression.TypeZstd, peer0.Peer.(*peer).sendCompressionType(compression.TypeZstd))
+
+	// Act as if peer1 only listed gzip in its Version message.
+	peer0.Peer.(*peer).compressionTypes.SetValue(map[compression.Type]struct{}{
+		compression.TypeGzip: {},
+	})
+
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		compression.TypeZstd,
+		"",
+		10*time.Second,
+	)
+	assert.NoError(err)
+
+	container := make([]byte, 1024)
+	outboundPutMsg, err := mc.Put(ids.Empty, 1, ids.Empty, container)
+	assert.NoError(err)
+	assert.Equal(compression.TypeZstd, outboundPutMsg.CompressionType())
+
+	sent := peer0.Send(context.Background(), outboundPutMsg)
+	assert.True(sent)
+
+	inboundPutMsg := <-peer1.inboundMsgChan
+	assert.Equal(message.Put, inboundPutMsg.Op())
+	assert.Equal(container, inboundPutMsg.Get(message.ContainerBytes))
+
+	peer1.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	assert.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	assert.NoError(err)
+}
+
+func TestTraffic(t *testing.T) {
+	assert := assert.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t)
+	mc := newMessageCreator(t)
+
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	assert.NoError(err)
+	numBytes := uint64(len(outboundGetMsg.Bytes()))
+
+	sent := peer0.Send(context.Background(), outboundGetMsg)
+	assert.True(sent)
+
+	inboundGetMsg := <-peer1.inboundMsgChan
+	assert.Equal(message.Get, inboundGetMsg.Op())
+
+	// The sender may count the message after the receiver handled it.
+	assert.Eventually(func() bool {
+		return peer0.Traffic()[message.Get].MessagesSent == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(Traffic{
+		MessagesSent: 1,
+		BytesSent:    numBytes,
+	}, peer0.Traffic()[message.Get])
+	assert.Equal(Traffic{
+		MessagesReceived: 1,
+		BytesReceived:    numBytes,
+	}, peer1.Traffic()[message.Get])
+
+	// The handshake is counted as well.
+	total := TotalTraffic(peer1.Traffic())
+	assert.Greater(total.MessagesReceived, uint64(1))
+
+	peer1.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	assert.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	assert.NoError(err)
+}
+
+func TestSendCompressionType(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &peer{}
+
+	// Until the peer's Version message is received, only gzip is used.
+	assert.Equal(compression.TypeNone, p.sendCompressionType(compression.TypeNone))
+	assert.Equal(compression.TypeGzip, p.sendCompressionType(compression.TypeGzip))
+	assert.Equal(compression.TypeGzip, p.sendCompressionType(compression.TypeZstd))
+
+	p.compressionTypes.SetValue(map[compression.Type]struct{}{
+		compression.TypeZstd: {},
+	})
+	assert.Equal(compression.TypeZstd, p.sendCompressionType(compression.TypeZstd))
+	assert.Equal(compression.TypeNone, p.sendCompressionType(compression.TypeGzip))
+}
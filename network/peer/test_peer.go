@@ -18,6 +18,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
 	"github.com/sankar-boro/axia-network-v2/staking"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
@@ -69,7 +70,7 @@ func StartTestPeer(
 
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
-		true,
+		compression.TypeGzip,
 		"",
 		10*time.Second,
 	)
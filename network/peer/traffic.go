@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/sankar-boro/axia-network-v2/message"
+)
+
+// Traffic counts the messages and bytes sent to, and received from, a peer
+type Traffic struct {
+	MessagesSent     uint64 `json:"messagesSent"`
+	BytesSent        uint64 `json:"bytesSent"`
+	MessagesReceived uint64 `json:"messagesReceived"`
+	BytesReceived    uint64 `json:"bytesReceived"`
+}
+
+// Add adds the counts of [other] to [t]
+func (t *Traffic) Add(other Traffic) {
+	t.MessagesSent += other.MessagesSent
+	t.BytesSent += other.BytesSent
+	t.MessagesReceived += other.MessagesReceived
+	t.BytesReceived += other.BytesReceived
+}
+
+// TotalTraffic returns the sum of the traffic of each op in [traffic]
+func TotalTraffic(traffic map[message.Op]Traffic) Traffic {
+	total := Traffic{}
+	for _, opTraffic := range traffic {
+		total.Add(opTraffic)
+	}
+	return total
+}
+
+// trafficCounter counts the traffic of a peer by op
+type trafficCounter struct {
+	lock sync.Mutex
+	ops  map[message.Op]*Traffic
+}
+
+func (c *trafficCounter) sent(op message.Op, numBytes int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	t := c.get(op)
+	t.MessagesSent++
+	t.BytesSent += uint64(numBytes)
+}
+
+func (c *trafficCounter) received(op message.Op, numBytes int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	t := c.get(op)
+	t.MessagesReceived++
+	t.BytesReceived += uint64(numBytes)
+}
+
+// get returns the traffic of [op], creating it if needed.
+//
+// Assumes [c.lock] is held.
+func (c *trafficCounter) get(op message.Op) *Traffic {
+	if c.ops == nil {
+		c.ops = make(map[message.Op]*Traffic)
+	}
+	t, ok := c.ops[op]
+	if !ok {
+		t = &Traffic{}
+		c.ops[op] = t
+	}
+	return t
+}
+
+// traffic returns a copy of the counts
+func (c *trafficCounter) traffic() map[message.Op]Traffic {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	traffic := make(map[message.Op]Traffic, len(c.ops))
+	for op, t := range c.ops {
+		traffic[op] = *t
+	}
+	return traffic
+}
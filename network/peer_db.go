@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/ips"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+)
+
+const (
+	storedIPVersion uint16 = 0
+
+	// maxStoredIPSize bounds the size of a serialized [storedIP]. Certificates
+	// are well below this size.
+	maxStoredIPSize = 64 * 1024
+
+	// maxPeerDBRefreshFrequency is the longest time between refreshes of the
+	// stored IPs of the connected validators.
+	maxPeerDBRefreshFrequency = time.Hour
+)
+
+var (
+	errUnknownStoredIPVersion = errors.New("unknown stored IP version")
+	errInvalidStoredIPLen     = errors.New("stored IP has trailing bytes")
+)
+
+// storedIP is a signed IP of a validator that was persisted so that the node
+// can reconnect to it after a restart.
+type storedIP struct {
+	ips.ClaimedIPPort
+
+	// LastSuccess is the unix time that this node last finished a handshake
+	// with the validator, or 0 if it never has.
+	LastSuccess uint64
+
+	// LastSeen is the unix time that the IP was last stored.
+	LastSeen uint64
+}
+
+func (s *storedIP) bytes() ([]byte, error) {
+	p := wrappers.Packer{MaxSize: maxStoredIPSize}
+	p.PackShort(storedIPVersion)
+	p.PackClaimedIPPort(s.ClaimedIPPort)
+	p.PackLong(s.LastSuccess)
+	p.PackLong(s.LastSeen)
+	return p.Bytes, p.Err
+}
+
+func parseStoredIP(b []byte) (*storedIP, error) {
+	p := wrappers.Packer{Bytes: b}
+	if version := p.UnpackShort(); p.Err == nil && version != storedIPVersion {
+		return nil, fmt.Errorf("%w: %d", errUnknownStoredIPVersion, version)
+	}
+	s := &storedIP{
+		ClaimedIPPort: p.UnpackClaimedIPPort(),
+		LastSuccess:   p.UnpackLong(),
+		LastSeen:      p.UnpackLong(),
+	}
+	if p.Offset != len(b) {
+		p.Add(errInvalidStoredIPLen)
+	}
+	return s, p.Err
+}
+
+// peerDB persists the signed IPs of validators, keyed by their node IDs.
+type peerDB struct {
+	// lock is held during read-modify-write cycles of entries
+	lock   sync.Mutex
+	db     database.Database
+	clock  *mockable.Clock
+	maxAge time.Duration
+}
+
+func newPeerDB(db database.Database, clock *mockable.Clock, maxAge time.Duration) *peerDB {
+	return &peerDB{
+		db:     db,
+		clock:  clock,
+		maxAge: maxAge,
+	}
+}
+
+// refreshFrequency returns how often the stored IPs of the connected
+// validators must be refreshed so that they don't expire while connected.
+func (p *peerDB) refreshFrequency() time.Duration {
+	if frequency := p.maxAge / 2; frequency < maxPeerDBRefreshFrequency {
+		return frequency
+	}
+	return maxPeerDBRefreshFrequency
+}
+
+// put stores [ip] as the IP of the validator [nodeID]. If a newer IP is
+// already stored, only the timestamps of the stored entry are updated. If
+// [connected], the node finished a handshake with the validator at this IP.
+func (p *peerDB) put(nodeID ids.NodeID, ip ips.ClaimedIPPort, connected bool) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	s := &storedIP{ClaimedIPPort: ip}
+	b, err := p.db.Get(nodeID[:])
+	switch err {
+	case nil:
+		stored, err := parseStoredIP(b)
+		if err == nil {
+			if stored.Timestamp > ip.Timestamp {
+				s.ClaimedIPPort = stored.ClaimedIPPort
+			}
+			s.LastSuccess = stored.LastSuccess
+		}
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	now := p.clock.Unix()
+	s.LastSeen = now
+	if connected {
+		s.LastSuccess = now
+	}
+
+	b, err = s.bytes()
+	if err != nil {
+		return err
+	}
+	return p.db.Put(nodeID[:], b)
+}
+
+// load returns the stored IPs, most reliable first. Entries that haven't been
+// seen within the max age, or that can't be parsed, are removed.
+func (p *peerDB) load() ([]*storedIP, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		now       = p.clock.Time()
+		storedIPs []*storedIP
+		expired   [][]byte
+	)
+
+	it := p.db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		s, err := parseStoredIP(it.Value())
+		if err != nil || now.Sub(time.Unix(int64(s.LastSeen), 0)) > p.maxAge {
+			// The iterator may reuse the key's memory.
+			expired = append(expired, append([]byte(nil), it.Key()...))
+			continue
+		}
+		storedIPs = append(storedIPs, s)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range expired {
+		if err := p.db.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validators that this node recently connected to are the most likely to
+	// still be reachable.
+	sort.SliceStable(storedIPs, func(i, j int) bool {
+		if storedIPs[i].LastSuccess != storedIPs[j].LastSuccess {
+			return storedIPs[i].LastSuccess > storedIPs[j].LastSuccess
+		}
+		return storedIPs[i].LastSeen > storedIPs[j].LastSeen
+	})
+	return storedIPs, nil
+}
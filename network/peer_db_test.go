@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/staking"
+	"github.com/sankar-boro/axia-network-v2/utils/ips"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+)
+
+func newTestClaimedIPPort(t *testing.T, port uint16, timestamp uint64) (ids.NodeID, ips.ClaimedIPPort) {
+	t.Helper()
+
+	tlsCert, err := staking.NewTLSCert()
+	assert.NoError(t, err)
+
+	return ids.NodeIDFromCert(tlsCert.Leaf), ips.ClaimedIPPort{
+		Cert: tlsCert.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(1, 2, 3, 4),
+			Port: port,
+		},
+		Timestamp: timestamp,
+		Signature: []byte{byte(port)},
+	}
+}
+
+func TestPeerDBPutLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := mockable.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	db := newPeerDB(memdb.New(), &clock, time.Hour)
+
+	nodeID0, ip0 := newTestClaimedIPPort(t, 0, 10)
+	nodeID1, ip1 := newTestClaimedIPPort(t, 1, 10)
+	nodeID2, ip2 := newTestClaimedIPPort(t, 2, 10)
+
+	assert.NoError(db.put(nodeID0, ip0, false))
+	assert.NoError(db.put(nodeID1, ip1, true))
+
+	clock.Set(time.Unix(2000, 0))
+	assert.NoError(db.put(nodeID2, ip2, false))
+
+	storedIPs, err := db.load()
+	assert.NoError(err)
+	assert.Len(storedIPs, 3)
+
+	// Validators that were connected to are first, followed by the most
+	// recently seen ones.
+	assert.Equal(ip1.IPPort, storedIPs[0].IPPort)
+	assert.EqualValues(1000, storedIPs[0].LastSuccess)
+	assert.Equal(ip2.IPPort, storedIPs[1].IPPort)
+	assert.EqualValues(0, storedIPs[1].LastSuccess)
+	assert.EqualValues(2000, storedIPs[1].LastSeen)
+	assert.Equal(ip0.IPPort, storedIPs[2].IPPort)
+
+	assert.Equal(ip1.Cert.Raw, storedIPs[0].Cert.Raw)
+	assert.Equal(ip1.Signature, storedIPs[0].Signature)
+	assert.Equal(ip1.Timestamp, storedIPs[0].Timestamp)
+}
+
+func TestPeerDBKeepsNewestIP(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := mockable.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	db := newPeerDB(memdb.New(), &clock, time.Hour)
+
+	nodeID, newIP := newTestClaimedIPPort(t, 1, 20)
+	oldIP := newIP
+	oldIP.IPPort.Port = 0
+	oldIP.Timestamp = 10
+
+	assert.NoError(db.put(nodeID, newIP, true))
+
+	clock.Set(time.Unix(2000, 0))
+	assert.NoError(db.put(nodeID, oldIP, false))
+
+	storedIPs, err := db.load()
+	assert.NoError(err)
+	assert.Len(storedIPs, 1)
+	assert.Equal(newIP.IPPort, storedIPs[0].IPPort)
+	assert.Equal(newIP.Timestamp, storedIPs[0].Timestamp)
+	assert.EqualValues(1000, storedIPs[0].LastSuccess)
+	assert.EqualValues(2000, storedIPs[0].LastSeen)
+}
+
+func TestPeerDBExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := mockable.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	baseDB := memdb.New()
+	db := newPeerDB(baseDB, &clock, time.Hour)
+
+	nodeID0, ip0 := newTestClaimedIPPort(t, 0, 10)
+	nodeID1, ip1 := newTestClaimedIPPort(t, 1, 10)
+
+	assert.NoError(db.put(nodeID0, ip0, true))
+
+	clock.Set(time.Unix(1000, 0).Add(30 * time.Minute))
+	assert.NoError(db.put(nodeID1, ip1, false))
+
+	// Entries that can't be parsed are removed as well.
+	assert.NoError(baseDB.Put([]byte{1, 2, 3}, []byte{4, 5, 6}))
+
+	clock.Set(time.Unix(1000, 0).Add(time.Hour + time.Minute))
+	storedIPs, err := db.load()
+	assert.NoError(err)
+	assert.Len(storedIPs, 1)
+	assert.Equal(ip1.IPPort, storedIPs[0].IPPort)
+
+	has, err := baseDB.Has(nodeID0[:])
+	assert.NoError(err)
+	assert.False(has)
+
+	has, err = baseDB.Has([]byte{1, 2, 3})
+	assert.NoError(err)
+	assert.False(has)
+}
+
+func TestPeerDBRefreshFrequency(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := mockable.Clock{}
+	assert.Equal(maxPeerDBRefreshFrequency, newPeerDB(memdb.New(), &clock, 7*24*time.Hour).refreshFrequency())
+	assert.Equal(15*time.Minute, newPeerDB(memdb.New(), &clock, 30*time.Minute).refreshFrequency())
+}
@@ -0,0 +1,449 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peerfilter
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/ips"
+	"github.com/sankar-boro/axia-network-v2/utils/timer/mockable"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+)
+
+var (
+	nodeIDBanPrefix = []byte("nodeIDBans")
+	ipBanPrefix     = []byte("ipBans")
+	pinPrefix       = []byte("pins")
+
+	errNegativeDuration = errors.New("ban duration can't be negative")
+	errNotBanned        = errors.New("not banned")
+	errNotPinned        = errors.New("not pinned")
+	errInvalidIPs       = errors.New("invalid IP or CIDR")
+
+	_ Filter = &filter{}
+)
+
+// NodeIDBan forbids connections to a node
+type NodeIDBan struct {
+	NodeID ids.NodeID
+	// Expiry is when the ban ends. The zero value means that the ban doesn't
+	// end until it's removed.
+	Expiry time.Time
+}
+
+// IPBan forbids connections to a range of IPs
+type IPBan struct {
+	IPs *net.IPNet
+	// Expiry is when the ban ends. The zero value means that the ban doesn't
+	// end until it's removed.
+	Expiry time.Time
+}
+
+// Pin is a peer that this node should always attempt to be connected to
+type Pin struct {
+	NodeID ids.NodeID
+	IP     ips.IPPort
+}
+
+// Filter decides which peers this node may be, or should always be, connected
+// to. Changes are persisted.
+type Filter interface {
+	// NodeIDBanned returns true if connections to [nodeID] are forbidden
+	NodeIDBanned(nodeID ids.NodeID) bool
+
+	// IPBanned returns true if connections to [ip] are forbidden
+	IPBanned(ip net.IP) bool
+
+	// Pinned returns the IP that [nodeID] was pinned at and true if this node
+	// should always attempt to be connected to [nodeID]
+	Pinned(nodeID ids.NodeID) (ips.IPPort, bool)
+
+	// BanNodeID forbids connections to [nodeID] for [duration]. If [duration]
+	// is 0, the ban doesn't end until it's removed.
+	BanNodeID(nodeID ids.NodeID, duration time.Duration) error
+
+	// UnbanNodeID removes the ban of [nodeID]
+	UnbanNodeID(nodeID ids.NodeID) error
+
+	// BanIPs forbids connections to the IPs in [ipNet] for [duration]. If
+	// [duration] is 0, the ban doesn't end until it's removed.
+	BanIPs(ipNet *net.IPNet, duration time.Duration) error
+
+	// UnbanIPs removes the ban of [ipNet]
+	UnbanIPs(ipNet *net.IPNet) error
+
+	// Pin makes this node always attempt to be connected to [nodeID] at [ip]
+	Pin(nodeID ids.NodeID, ip ips.IPPort) error
+
+	// Unpin removes the pin of [nodeID]
+	Unpin(nodeID ids.NodeID) error
+
+	// NodeIDBans returns the bans of node IDs that haven't expired
+	NodeIDBans() []NodeIDBan
+
+	// IPBans returns the bans of IPs that haven't expired
+	IPBans() []IPBan
+
+	// Pins returns the pinned peers
+	Pins() []Pin
+}
+
+type filter struct {
+	clock mockable.Clock
+
+	// lock protects the maps below and the writes to the databases
+	lock sync.RWMutex
+
+	nodeIDBanDB database.Database
+	ipBanDB     database.Database
+	pinDB       database.Database
+
+	// Maps banned node IDs to the unix time their bans expire, or 0
+	nodeIDBans map[ids.NodeID]uint64
+	// Maps the strings of banned IP ranges to their bans
+	ipBans map[string]*ipBan
+	pins   map[ids.NodeID]ips.IPPort
+}
+
+type ipBan struct {
+	ipNet  *net.IPNet
+	expiry uint64
+}
+
+// New returns a Filter whose bans and pins are persisted in [db]. Bans that
+// expired while the node was offline are removed.
+func New(db database.Database) (Filter, error) {
+	f := &filter{
+		nodeIDBanDB: prefixdb.New(nodeIDBanPrefix, db),
+		ipBanDB:     prefixdb.New(ipBanPrefix, db),
+		pinDB:       prefixdb.New(pinPrefix, db),
+		nodeIDBans:  make(map[ids.NodeID]uint64),
+		ipBans:      make(map[string]*ipBan),
+		pins:        make(map[ids.NodeID]ips.IPPort),
+	}
+	return f, f.load()
+}
+
+func (f *filter) load() error {
+	now := f.clock.Unix()
+
+	var expiredKeys [][]byte
+	err := forEach(f.nodeIDBanDB, func(key, value []byte) error {
+		nodeID, err := ids.ToNodeID(key)
+		if err != nil {
+			return err
+		}
+		expiry, err := database.ParseUInt64(value)
+		if err != nil {
+			return err
+		}
+		if expired(expiry, now) {
+			expiredKeys = append(expiredKeys, key)
+		} else {
+			f.nodeIDBans[nodeID] = expiry
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := deleteAll(f.nodeIDBanDB, expiredKeys); err != nil {
+		return err
+	}
+
+	expiredKeys = nil
+	err = forEach(f.ipBanDB, func(key, value []byte) error {
+		_, ipNet, err := net.ParseCIDR(string(key))
+		if err != nil {
+			return err
+		}
+		expiry, err := database.ParseUInt64(value)
+		if err != nil {
+			return err
+		}
+		if expired(expiry, now) {
+			expiredKeys = append(expiredKeys, key)
+		} else {
+			f.ipBans[string(key)] = &ipBan{
+				ipNet:  ipNet,
+				expiry: expiry,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := deleteAll(f.ipBanDB, expiredKeys); err != nil {
+		return err
+	}
+
+	return forEach(f.pinDB, func(key, value []byte) error {
+		nodeID, err := ids.ToNodeID(key)
+		if err != nil {
+			return err
+		}
+		p := wrappers.Packer{Bytes: value}
+		ip := p.UnpackIP()
+		if p.Err != nil {
+			return p.Err
+		}
+		f.pins[nodeID] = ip
+		return nil
+	})
+}
+
+func (f *filter) NodeIDBanned(nodeID ids.NodeID) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	expiry, ok := f.nodeIDBans[nodeID]
+	return ok && !expired(expiry, f.clock.Unix())
+}
+
+func (f *filter) IPBanned(ip net.IP) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	now := f.clock.Unix()
+	for _, ban := range f.ipBans {
+		if ban.ipNet.Contains(ip) && !expired(ban.expiry, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filter) Pinned(nodeID ids.NodeID) (ips.IPPort, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	ip, ok := f.pins[nodeID]
+	return ip, ok
+}
+
+func (f *filter) BanNodeID(nodeID ids.NodeID, duration time.Duration) error {
+	expiry, err := f.expiry(duration)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.nodeIDBanDB.Put(nodeID[:], database.PackUInt64(expiry)); err != nil {
+		return err
+	}
+	f.nodeIDBans[nodeID] = expiry
+	return nil
+}
+
+func (f *filter) UnbanNodeID(nodeID ids.NodeID) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.nodeIDBans[nodeID]; !ok {
+		return fmt.Errorf("%s is %w", nodeID, errNotBanned)
+	}
+	if err := f.nodeIDBanDB.Delete(nodeID[:]); err != nil {
+		return err
+	}
+	delete(f.nodeIDBans, nodeID)
+	return nil
+}
+
+func (f *filter) BanIPs(ipNet *net.IPNet, duration time.Duration) error {
+	expiry, err := f.expiry(duration)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ipNetStr := ipNet.String()
+	if err := f.ipBanDB.Put([]byte(ipNetStr), database.PackUInt64(expiry)); err != nil {
+		return err
+	}
+	f.ipBans[ipNetStr] = &ipBan{
+		ipNet:  ipNet,
+		expiry: expiry,
+	}
+	return nil
+}
+
+func (f *filter) UnbanIPs(ipNet *net.IPNet) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ipNetStr := ipNet.String()
+	if _, ok := f.ipBans[ipNetStr]; !ok {
+		return fmt.Errorf("%s is %w", ipNetStr, errNotBanned)
+	}
+	if err := f.ipBanDB.Delete([]byte(ipNetStr)); err != nil {
+		return err
+	}
+	delete(f.ipBans, ipNetStr)
+	return nil
+}
+
+func (f *filter) Pin(nodeID ids.NodeID, ip ips.IPPort) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.IPLen)}
+	p.PackIP(ip)
+	if err := f.pinDB.Put(nodeID[:], p.Bytes); err != nil {
+		return err
+	}
+	f.pins[nodeID] = ip
+	return nil
+}
+
+func (f *filter) Unpin(nodeID ids.NodeID) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.pins[nodeID]; !ok {
+		return fmt.Errorf("%s is %w", nodeID, errNotPinned)
+	}
+	if err := f.pinDB.Delete(nodeID[:]); err != nil {
+		return err
+	}
+	delete(f.pins, nodeID)
+	return nil
+}
+
+func (f *filter) NodeIDBans() []NodeIDBan {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	now := f.clock.Unix()
+	bans := make([]NodeIDBan, 0, len(f.nodeIDBans))
+	for nodeID, expiry := range f.nodeIDBans {
+		if expired(expiry, now) {
+			continue
+		}
+		bans = append(bans, NodeIDBan{
+			NodeID: nodeID,
+			Expiry: toTime(expiry),
+		})
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].NodeID.String() < bans[j].NodeID.String()
+	})
+	return bans
+}
+
+func (f *filter) IPBans() []IPBan {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	now := f.clock.Unix()
+	bans := make([]IPBan, 0, len(f.ipBans))
+	for _, ban := range f.ipBans {
+		if expired(ban.expiry, now) {
+			continue
+		}
+		bans = append(bans, IPBan{
+			IPs:    ban.ipNet,
+			Expiry: toTime(ban.expiry),
+		})
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].IPs.String() < bans[j].IPs.String()
+	})
+	return bans
+}
+
+func (f *filter) Pins() []Pin {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	pins := make([]Pin, 0, len(f.pins))
+	for nodeID, ip := range f.pins {
+		pins = append(pins, Pin{
+			NodeID: nodeID,
+			IP:     ip,
+		})
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		return pins[i].NodeID.String() < pins[j].NodeID.String()
+	})
+	return pins
+}
+
+// expiry returns the unix time that a ban for [duration] starting now expires
+func (f *filter) expiry(duration time.Duration) (uint64, error) {
+	switch {
+	case duration < 0:
+		return 0, errNegativeDuration
+	case duration == 0:
+		return 0, nil
+	default:
+		return uint64(f.clock.Time().Add(duration).Unix()), nil
+	}
+}
+
+// ParseIPs parses [s], which is either an IP or a CIDR, as the range of IPs it
+// covers
+func ParseIPs(s string) (*net.IPNet, error) {
+	if _, ipNet, err := net.ParseCIDR(s); err == nil {
+		return ipNet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("%w: %q", errInvalidIPs, s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(len(ip)*8, len(ip)*8),
+	}, nil
+}
+
+func expired(expiry, now uint64) bool {
+	return expiry != 0 && expiry <= now
+}
+
+func toTime(expiry uint64) time.Time {
+	if expiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(expiry), 0)
+}
+
+// forEach calls [f] with copies of every key and value in [db]
+func forEach(db database.Iteratee, f func(key, value []byte) error) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := append([]byte(nil), it.Key()...)
+		value := append([]byte(nil), it.Value()...)
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func deleteAll(db database.KeyValueDeleter, keys [][]byte) error {
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peerfilter
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/ips"
+)
+
+func TestFilterNodeIDBan(t *testing.T) {
+	assert := assert.New(t)
+
+	fIntf, err := New(memdb.New())
+	assert.NoError(err)
+	f := fIntf.(*filter)
+	f.clock.Set(time.Unix(1000, 0))
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	assert.ErrorIs(f.BanNodeID(nodeID0, -time.Second), errNegativeDuration)
+	assert.NoError(f.BanNodeID(nodeID0, time.Hour))
+	assert.NoError(f.BanNodeID(nodeID1, 0))
+	assert.True(f.NodeIDBanned(nodeID0))
+	assert.True(f.NodeIDBanned(nodeID1))
+	assert.Len(f.NodeIDBans(), 2)
+
+	f.clock.Set(time.Unix(1000, 0).Add(time.Hour))
+	assert.False(f.NodeIDBanned(nodeID0))
+	assert.True(f.NodeIDBanned(nodeID1))
+
+	assert.NoError(f.UnbanNodeID(nodeID1))
+	assert.False(f.NodeIDBanned(nodeID1))
+	assert.ErrorIs(f.UnbanNodeID(nodeID1), errNotBanned)
+}
+
+func TestFilterIPBan(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := New(memdb.New())
+	assert.NoError(err)
+
+	subnet, err := ParseIPs("10.0.0.0/8")
+	assert.NoError(err)
+	single, err := ParseIPs("1.2.3.4")
+	assert.NoError(err)
+
+	assert.NoError(f.BanIPs(subnet, 0))
+	assert.NoError(f.BanIPs(single, 0))
+
+	assert.True(f.IPBanned(net.IPv4(10, 1, 2, 3)))
+	assert.True(f.IPBanned(net.IPv4(1, 2, 3, 4)))
+	assert.False(f.IPBanned(net.IPv4(1, 2, 3, 5)))
+	assert.False(f.IPBanned(net.IPv4(11, 0, 0, 1)))
+
+	assert.NoError(f.UnbanIPs(subnet))
+	assert.False(f.IPBanned(net.IPv4(10, 1, 2, 3)))
+	assert.ErrorIs(f.UnbanIPs(subnet), errNotBanned)
+}
+
+func TestFilterPersistence(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	fIntf, err := New(db)
+	assert.NoError(err)
+	f := fIntf.(*filter)
+
+	bannedNodeID := ids.GenerateTestNodeID()
+	expiredNodeID := ids.GenerateTestNodeID()
+	pinnedNodeID := ids.GenerateTestNodeID()
+	pinnedIP := ips.IPPort{
+		IP:   net.IPv4(1, 2, 3, 4),
+		Port: 9651,
+	}
+	subnet, err := ParseIPs("10.0.0.0/8")
+	assert.NoError(err)
+
+	assert.NoError(f.BanNodeID(bannedNodeID, 0))
+	assert.NoError(f.BanIPs(subnet, 0))
+	assert.NoError(f.Pin(pinnedNodeID, pinnedIP))
+
+	// A ban that ended long ago is removed when the filter is loaded again.
+	f.clock.Set(time.Unix(1000, 0))
+	assert.NoError(f.BanNodeID(expiredNodeID, time.Hour))
+
+	f2, err := New(db)
+	assert.NoError(err)
+
+	assert.Equal([]NodeIDBan{{NodeID: bannedNodeID}}, f2.NodeIDBans())
+	assert.True(f2.IPBanned(net.IPv4(10, 1, 2, 3)))
+	assert.Equal([]Pin{{NodeID: pinnedNodeID, IP: pinnedIP}}, f2.Pins())
+
+	ip, pinned := f2.Pinned(pinnedNodeID)
+	assert.True(pinned)
+	assert.Equal(pinnedIP, ip)
+
+	has, err := f.nodeIDBanDB.Has(expiredNodeID[:])
+	assert.NoError(err)
+	assert.False(has)
+
+	assert.NoError(f2.Unpin(pinnedNodeID))
+	_, pinned = f2.Pinned(pinnedNodeID)
+	assert.False(pinned)
+	assert.ErrorIs(f2.Unpin(pinnedNodeID), errNotPinned)
+}
+
+func TestParseIPs(t *testing.T) {
+	assert := assert.New(t)
+
+	ipNet, err := ParseIPs("1.2.3.4")
+	assert.NoError(err)
+	assert.Equal("1.2.3.4/32", ipNet.String())
+
+	ipNet, err = ParseIPs("1.2.3.4/24")
+	assert.NoError(err)
+	assert.Equal("1.2.3.0/24", ipNet.String())
+
+	ipNet, err = ParseIPs("::1")
+	assert.NoError(err)
+	assert.Equal("::1/128", ipNet.String())
+
+	_, err = ParseIPs("not an ip")
+	assert.ErrorIs(err, errInvalidIPs)
+}
@@ -7,9 +7,11 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/sankar-boro/axia-network-v2/api/ratelimit"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/genesis"
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/indexer"
 	"github.com/sankar-boro/axia-network-v2/nat"
 	"github.com/sankar-boro/axia-network-v2/network"
 	"github.com/sankar-boro/axia-network-v2/snow/consensus/axia"
@@ -20,6 +22,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/dynamicip"
 	"github.com/sankar-boro/axia-network-v2/utils/ips"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/password"
 	"github.com/sankar-boro/axia-network-v2/utils/profiler"
 	"github.com/sankar-boro/axia-network-v2/utils/timer"
 	"github.com/sankar-boro/axia-network-v2/vms"
@@ -39,6 +42,10 @@ type APIAuthConfig struct {
 type APIIndexerConfig struct {
 	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
 	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	// Not serialized as webhooks hold secrets
+	IndexWebhooks   []indexer.WebhookConfig   `json:"-"`
+	IndexRetentions []indexer.RetentionConfig `json:"indexRetentions"`
+	IndexExportDir  string                    `json:"indexExportDir"`
 }
 
 type HTTPConfig struct {
@@ -54,6 +61,28 @@ type HTTPConfig struct {
 
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
+
+	// GRPCEnabled is true if APIs are also served over gRPC. If GRPCPort is 0,
+	// gRPC is served on HTTPPort.
+	GRPCEnabled bool   `json:"grpcEnabled"`
+	GRPCPort    uint16 `json:"grpcPort"`
+
+	// MaxBatchSize is the maximum number of calls in a JSON-RPC batch
+	MaxBatchSize int `json:"maxBatchSize"`
+
+	// APIRateLimits limits the rate of API calls of each client, if not nil
+	APIRateLimits *ratelimit.Config `json:"apiRateLimits"`
+	// APIRateLimitsFile is the file that APIRateLimits are reloaded from, or
+	// empty if they weren't read from a file
+	APIRateLimitsFile string `json:"apiRateLimitsFile"`
+
+	// APIAuditLogEnabled is true if calls to APIAuditLogMethods are recorded
+	// in the audit log
+	APIAuditLogEnabled bool     `json:"apiAuditLogEnabled"`
+	APIAuditLogMethods []string `json:"apiAuditLogMethods"`
+	// APIAuditLogMaxSize is the size in megabytes at which the audit log is
+	// rotated. Rotated files are never deleted.
+	APIAuditLogMaxSize int `json:"apiAuditLogMaxSize"`
 }
 
 type APIConfig struct {
@@ -67,6 +96,11 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+	SchemaAPIEnabled   bool `json:"schemaAPIEnabled"`
+
+	// Parameters that the passwords of the keystore and of API authorization
+	// are hashed with
+	PasswordHashParams password.Params `json:"passwordHashParams"`
 }
 
 type IPConfig struct {
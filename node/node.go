@@ -23,11 +23,14 @@ import (
 	coreth "github.com/sankar-boro/axia-network-v2-coreth/plugin/evm"
 
 	"github.com/sankar-boro/axia-network-v2/api/admin"
+	"github.com/sankar-boro/axia-network-v2/api/audit"
 	"github.com/sankar-boro/axia-network-v2/api/auth"
 	"github.com/sankar-boro/axia-network-v2/api/health"
 	"github.com/sankar-boro/axia-network-v2/api/info"
 	"github.com/sankar-boro/axia-network-v2/api/keystore"
 	"github.com/sankar-boro/axia-network-v2/api/metrics"
+	"github.com/sankar-boro/axia-network-v2/api/ratelimit"
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/api/server"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/chains/atomic"
@@ -35,6 +38,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/database/leveldb"
 	"github.com/sankar-boro/axia-network-v2/database/manager"
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
 	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
 	"github.com/sankar-boro/axia-network-v2/database/rocksdb"
 	"github.com/sankar-boro/axia-network-v2/genesis"
@@ -45,6 +49,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/network"
 	"github.com/sankar-boro/axia-network-v2/network/dialer"
 	"github.com/sankar-boro/axia-network-v2/network/peer"
+	"github.com/sankar-boro/axia-network-v2/network/peerfilter"
 	"github.com/sankar-boro/axia-network-v2/network/throttling"
 	"github.com/sankar-boro/axia-network-v2/snow"
 	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
@@ -76,11 +81,17 @@ import (
 	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
 
 	ipcsapi "github.com/sankar-boro/axia-network-v2/api/ipcs"
+	healthpb "github.com/sankar-boro/axia-network-v2/proto/pb/health"
 )
 
 var (
-	genesisHashKey  = []byte("genesisID")
-	indexerDBPrefix = []byte{0x00}
+	genesisHashKey     = []byte("genesisID")
+	indexerDBPrefix    = []byte{0x00}
+	authDBPrefix       = []byte("auth")
+	peerDBPrefix       = []byte("peers")
+	peerFilterDBPrefix = []byte("peer filter")
+
+	apiRateLimiterNamespace = fmt.Sprintf("%s_api_rate_limiter", constants.PlatformName)
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -143,6 +154,10 @@ type Node struct {
 
 	// Handles HTTP API calls
 	APIServer server.Server
+	// Limits the rate of API calls, if rate limits are configured
+	apiRateLimiter ratelimit.Limiter
+	// Records calls to privileged API methods, if enabled
+	apiAuditLog audit.Log
 
 	// This node's configuration
 	Config *Config
@@ -279,6 +294,19 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	if n.Config.NetworkConfig.PeerDBMaxAge > 0 {
+		n.Config.NetworkConfig.PeerDB = prefixdb.New(peerDBPrefix, n.DB)
+	}
+	n.Config.NetworkConfig.ChainTraffic = n.Config.ConsensusRouter
+	n.Config.NetworkConfig.PeerFilter, err = peerfilter.New(prefixdb.New(peerFilterDBPrefix, n.DB))
+	if err != nil {
+		return fmt.Errorf("couldn't load the peer filter: %w", err)
+	}
+
+	peerDialer, err := dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Config.NetworkConfig.PeerFilter, n.Log)
+	if err != nil {
+		return fmt.Errorf("couldn't create the dialer: %w", err)
+	}
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -286,7 +314,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		n.MetricsRegisterer,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		peerDialer,
 		consensusRouter,
 		n.benchlistManager,
 	)
@@ -415,16 +443,20 @@ func (n *Node) initDatabase() error {
 	case rocksdb.Name:
 		path := filepath.Join(n.Config.DatabaseConfig.Path, rocksdb.Name)
 		dbManager, err = manager.NewRocksDB(path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+	case pebble.Name:
+		path := filepath.Join(n.Config.DatabaseConfig.Path, pebble.Name)
+		dbManager, err = manager.NewPebbleDB(path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case leveldb.Name:
 		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
 	default:
 		err = fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s, %s}",
+			"db-type was %q but should have been one of {%s, %s, %s, %s}",
 			n.Config.DatabaseConfig.Name,
 			leveldb.Name,
 			rocksdb.Name,
+			pebble.Name,
 			memdb.Name,
 		)
 	}
@@ -508,6 +540,10 @@ func (n *Node) initIPCs() error {
 // initialized
 func (n *Node) initIndexer() error {
 	txIndexerDB := prefixdb.New(indexerDBPrefix, n.DB)
+	var grpcAdder server.GRPCAdder
+	if n.Config.GRPCEnabled {
+		grpcAdder = n.APIServer
+	}
 	var err error
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:        n.Config.IndexAPIEnabled,
@@ -517,6 +553,10 @@ func (n *Node) initIndexer() error {
 		DecisionAcceptorGroup:  n.DecisionAcceptorGroup,
 		ConsensusAcceptorGroup: n.ConsensusAcceptorGroup,
 		APIServer:              n.APIServer,
+		GRPCServer:             grpcAdder,
+		Webhooks:               n.Config.IndexWebhooks,
+		Retentions:             n.Config.IndexRetentions,
+		ExportDir:              n.Config.IndexExportDir,
 		ShutdownF:              func() { n.Shutdown(0) }, // TODO put exit code here
 	})
 	if err != nil {
@@ -549,22 +589,41 @@ func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 	n.APIServer = server.New()
 
-	if !n.Config.APIRequireAuthToken {
-		n.APIServer.Initialize(
-			n.Log,
-			n.LogFactory,
-			n.Config.HTTPHost,
-			n.Config.HTTPPort,
-			n.Config.APIAllowedOrigins,
-			n.Config.ShutdownTimeout,
-			n.ID,
-		)
-		return nil
+	var (
+		a        auth.Auth
+		wrappers []server.Wrapper
+		err      error
+	)
+	// The audit log is the innermost wrapper so that only authorized calls
+	// are recorded, along with the auth token that authorized them.
+	if n.Config.APIAuditLogEnabled {
+		n.apiAuditLog, err = audit.New(n.Log, n.Config.LoggingConfig.Directory, n.Config.APIAuditLogMaxSize, n.Config.APIAuditLogMethods)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize API audit log: %w", err)
+		}
+		wrappers = append(wrappers, n.apiAuditLog)
+	}
+	// The rate limiter runs inside of auth so that calls can be limited by the
+	// auth token that authorized them. Calls that don't need a token, such as
+	// calls to the auth service, are limited by client IP.
+	if n.Config.APIRateLimits != nil {
+		registry := prometheus.NewRegistry()
+		n.apiRateLimiter, err = ratelimit.New(n.Log, registry, *n.Config.APIRateLimits)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize API rate limiter: %w", err)
+		}
+		if err := n.MetricsGatherer.Register(apiRateLimiterNamespace, registry); err != nil {
+			return err
+		}
+		wrappers = append(wrappers, n.apiRateLimiter)
 	}
-
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
-	if err != nil {
-		return err
+	if n.Config.APIRequireAuthToken {
+		authDB := prefixdb.New(authDBPrefix, n.DB)
+		a, err = auth.New(n.Log, "auth", n.Config.APIAuthPassword, n.Config.PasswordHashParams, authDB)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, a)
 	}
 
 	n.APIServer.Initialize(
@@ -575,8 +634,15 @@ func (n *Node) initAPIServer() error {
 		n.Config.APIAllowedOrigins,
 		n.Config.ShutdownTimeout,
 		n.ID,
-		a,
+		wrappers...,
 	)
+	n.APIServer.SetMaxBatchSize(n.Config.MaxBatchSize)
+	if n.Config.GRPCEnabled {
+		n.APIServer.EnableGRPC(n.Config.GRPCPort)
+	}
+	if !n.Config.APIRequireAuthToken {
+		return nil
+	}
 
 	// only create auth service if token authorization is required
 	n.Log.Info("API authorization is enabled. Auth tokens must be passed in the header of API requests, except requests to the auth service.")
@@ -798,7 +864,7 @@ func (n *Node) initSharedMemory() error {
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
 	keystoreDB := n.DBManager.NewPrefixDBManager([]byte("keystore"))
-	n.keystore = keystore.New(n.Log, keystoreDB)
+	n.keystore = keystore.New(n.Log, keystoreDB, n.Config.PasswordHashParams)
 	keystoreHandler, err := n.keystore.CreateHandler()
 	if err != nil {
 		return err
@@ -815,12 +881,16 @@ func (n *Node) initKeystoreAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "keystore", "")
 }
 
-// initMetricsAPI initializes the Metrics API
-// Assumes n.APIServer is already set
-func (n *Node) initMetricsAPI() error {
+// initMetrics initializes the registry that the node's metrics are
+// registered with
+func (n *Node) initMetrics() {
 	n.MetricsRegisterer = prometheus.NewRegistry()
 	n.MetricsGatherer = metrics.NewMultiGatherer()
+}
 
+// initMetricsAPI initializes the Metrics API
+// Assumes n.APIServer is already set
+func (n *Node) initMetricsAPI() error {
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -859,7 +929,8 @@ func (n *Node) initMetricsAPI() error {
 }
 
 // initAdminAPI initializes the Admin API service
-// Assumes n.log, n.chainManager, and n.ValidatorAPI already initialized
+// Assumes n.log, n.chainManager, n.indexer and n.ValidatorAPI already
+// initialized
 func (n *Node) initAdminAPI() error {
 	// Convert node config to map
 	configJSON, err := json.Marshal(n.Config)
@@ -882,6 +953,14 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Webhooks:     n.indexer,
+			Reindexer:    n.indexer,
+			Exporter:     n.indexer,
+
+			APIRateLimiter:    n.apiRateLimiter,
+			APIRateLimitsFile: n.Config.APIRateLimitsFile,
+			Network:           n.Net,
+			PeerFilter:        n.Config.NetworkConfig.PeerFilter,
 		},
 	)
 	if err != nil {
@@ -890,6 +969,31 @@ func (n *Node) initAdminAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "admin", "")
 }
 
+// initSchemaAPI initializes the API that serves the OpenRPC document of the
+// JSON-RPC APIs of the node
+// Assumes n.APIServer is already set
+func (n *Node) initSchemaAPI() error {
+	if !n.Config.SchemaAPIEnabled {
+		n.Log.Info("skipping schema API initialization because it has been disabled")
+		return nil
+	}
+
+	n.Log.Info("initializing schema API")
+	handler, err := schema.CreateHandler(n.APIServer.Schema)
+	if err != nil {
+		return err
+	}
+	return n.APIServer.AddRoute(
+		&common.HTTPHandler{
+			LockOptions: common.NoLock,
+			Handler:     handler,
+		},
+		&sync.RWMutex{},
+		"schema",
+		"",
+	)
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -921,7 +1025,7 @@ func (n *Node) initInfoAPI() error {
 	n.Log.Info("initializing info API")
 
 	primaryValidators, _ := n.vdrs.GetValidators(constants.PrimaryNetworkID)
-	service, err := info.NewService(
+	service, grpcService, err := info.NewService(
 		info.Parameters{
 			Version:               version.CurrentApp,
 			NodeID:                n.ID,
@@ -944,7 +1048,14 @@ func (n *Node) initInfoAPI() error {
 	if err != nil {
 		return err
 	}
-	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "info", "")
+	lock := &sync.RWMutex{}
+	if err := n.APIServer.AddRoute(service, lock, "info", ""); err != nil {
+		return err
+	}
+	if !n.Config.GRPCEnabled {
+		return nil
+	}
+	return n.APIServer.AddGRPCService(grpcService, lock, "info")
 }
 
 // initHealthAPI initializes the Health API service
@@ -1047,7 +1158,7 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
-	return n.APIServer.AddRoute(
+	err = n.APIServer.AddRoute(
 		&common.HTTPHandler{
 			LockOptions: common.NoLock,
 			Handler:     health.NewGetHandler(healthChecker.Liveness),
@@ -1056,6 +1167,20 @@ func (n *Node) initHealthAPI() error {
 		"health",
 		"/liveness",
 	)
+	if err != nil || !n.Config.GRPCEnabled {
+		return err
+	}
+
+	return n.APIServer.AddGRPCService(
+		&common.GRPCService{
+			LockOptions: common.NoLock,
+			Name:        "health",
+			Desc:        &healthpb.Health_ServiceDesc,
+			Impl:        health.NewGRPCServer(n.Log, healthChecker),
+		},
+		&sync.RWMutex{},
+		"health",
+	)
 }
 
 // initIPCAPI initializes the IPC API service
@@ -1178,6 +1303,14 @@ func (n *Node) Initialize(
 		return fmt.Errorf("problem initializing node beacons: %w", err)
 	}
 
+	n.initMetrics()
+
+	// The database must be initialized before the API server, which persists
+	// API auth tokens in it
+	if err := n.initDatabase(); err != nil { // Set up the node's database
+		return fmt.Errorf("problem initializing database: %w", err)
+	}
+
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
 	}
@@ -1186,10 +1319,6 @@ func (n *Node) Initialize(
 		return fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
-	if err := n.initDatabase(); err != nil { // Set up the node's database
-		return fmt.Errorf("problem initializing database: %w", err)
-	}
-
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
@@ -1204,7 +1333,7 @@ func (n *Node) Initialize(
 	// message.Creator currently record metrics under network namespace
 	n.networkNamespace = "network"
 	n.msgCreator, err = message.NewCreator(n.MetricsRegisterer,
-		n.Config.NetworkConfig.CompressionEnabled,
+		n.Config.NetworkConfig.CompressionType,
 		n.networkNamespace,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
@@ -1242,6 +1371,9 @@ func (n *Node) Initialize(
 	if err := n.initVMs(); err != nil { // Initialize the VM registry.
 		return fmt.Errorf("couldn't initialize VM registry: %w", err)
 	}
+	if err := n.initIndexer(); err != nil {
+		return fmt.Errorf("couldn't initialize indexer: %w", err)
+	}
 	if err := n.initAdminAPI(); err != nil { // Start the Admin API
 		return fmt.Errorf("couldn't initialize admin API: %w", err)
 	}
@@ -1254,16 +1386,15 @@ func (n *Node) Initialize(
 	if err := n.initIPCAPI(); err != nil { // Start the IPC API
 		return fmt.Errorf("couldn't initialize the IPC API: %w", err)
 	}
+	if err := n.initSchemaAPI(); err != nil { // Start the Schema API
+		return fmt.Errorf("couldn't initialize schema API: %w", err)
+	}
 	if err := n.initChainAliases(n.Config.GenesisBytes); err != nil {
 		return fmt.Errorf("couldn't initialize chain aliases: %w", err)
 	}
 	if err := n.initAPIAliases(n.Config.GenesisBytes); err != nil {
 		return fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
-	if err := n.initIndexer(); err != nil {
-		return fmt.Errorf("couldn't initialize indexer: %w", err)
-	}
-
 	n.health.Start(n.Config.HealthCheckFreq)
 	n.initProfiler()
 
@@ -1321,6 +1452,11 @@ func (n *Node) shutdown() {
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown: %s", err)
 	}
+	if n.apiAuditLog != nil {
+		if err := n.apiAuditLog.Close(); err != nil {
+			n.Log.Debug("error closing API audit log: %s", err)
+		}
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer: %s", err)
 	}
@@ -0,0 +1,394 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: info/info.proto
+
+package info
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// InfoClient is the client API for Info service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InfoClient interface {
+	GetNodeVersion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNodeVersionResponse, error)
+	GetNodeID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNodeIDResponse, error)
+	GetNodeIP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNodeIPResponse, error)
+	GetNetworkID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkIDResponse, error)
+	GetNetworkName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkNameResponse, error)
+	GetBlockchainID(ctx context.Context, in *GetBlockchainIDRequest, opts ...grpc.CallOption) (*GetBlockchainIDResponse, error)
+	IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error)
+	GetTxFee(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTxFeeResponse, error)
+	Uptime(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UptimeResponse, error)
+}
+
+type infoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInfoClient(cc grpc.ClientConnInterface) InfoClient {
+	return &infoClient{cc}
+}
+
+func (c *infoClient) GetNodeVersion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNodeVersionResponse, error) {
+	out := new(GetNodeVersionResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetNodeVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNodeID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNodeIDResponse, error) {
+	out := new(GetNodeIDResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetNodeID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNodeIP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNodeIPResponse, error) {
+	out := new(GetNodeIPResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetNodeIP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNetworkID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkIDResponse, error) {
+	out := new(GetNetworkIDResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetNetworkID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNetworkName(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkNameResponse, error) {
+	out := new(GetNetworkNameResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetNetworkName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetBlockchainID(ctx context.Context, in *GetBlockchainIDRequest, opts ...grpc.CallOption) (*GetBlockchainIDResponse, error) {
+	out := new(GetBlockchainIDResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetBlockchainID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error) {
+	out := new(IsBootstrappedResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/IsBootstrapped", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetTxFee(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTxFeeResponse, error) {
+	out := new(GetTxFeeResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/GetTxFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) Uptime(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UptimeResponse, error) {
+	out := new(UptimeResponse)
+	err := c.cc.Invoke(ctx, "/info.Info/Uptime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InfoServer is the server API for Info service.
+// All implementations must embed UnimplementedInfoServer
+// for forward compatibility
+type InfoServer interface {
+	GetNodeVersion(context.Context, *emptypb.Empty) (*GetNodeVersionResponse, error)
+	GetNodeID(context.Context, *emptypb.Empty) (*GetNodeIDResponse, error)
+	GetNodeIP(context.Context, *emptypb.Empty) (*GetNodeIPResponse, error)
+	GetNetworkID(context.Context, *emptypb.Empty) (*GetNetworkIDResponse, error)
+	GetNetworkName(context.Context, *emptypb.Empty) (*GetNetworkNameResponse, error)
+	GetBlockchainID(context.Context, *GetBlockchainIDRequest) (*GetBlockchainIDResponse, error)
+	IsBootstrapped(context.Context, *IsBootstrappedRequest) (*IsBootstrappedResponse, error)
+	GetTxFee(context.Context, *emptypb.Empty) (*GetTxFeeResponse, error)
+	Uptime(context.Context, *emptypb.Empty) (*UptimeResponse, error)
+	mustEmbedUnimplementedInfoServer()
+}
+
+// UnimplementedInfoServer must be embedded to have forward compatible implementations.
+type UnimplementedInfoServer struct {
+}
+
+func (UnimplementedInfoServer) GetNodeVersion(context.Context, *emptypb.Empty) (*GetNodeVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeVersion not implemented")
+}
+func (UnimplementedInfoServer) GetNodeID(context.Context, *emptypb.Empty) (*GetNodeIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeID not implemented")
+}
+func (UnimplementedInfoServer) GetNodeIP(context.Context, *emptypb.Empty) (*GetNodeIPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeIP not implemented")
+}
+func (UnimplementedInfoServer) GetNetworkID(context.Context, *emptypb.Empty) (*GetNetworkIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkID not implemented")
+}
+func (UnimplementedInfoServer) GetNetworkName(context.Context, *emptypb.Empty) (*GetNetworkNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkName not implemented")
+}
+func (UnimplementedInfoServer) GetBlockchainID(context.Context, *GetBlockchainIDRequest) (*GetBlockchainIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchainID not implemented")
+}
+func (UnimplementedInfoServer) IsBootstrapped(context.Context, *IsBootstrappedRequest) (*IsBootstrappedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBootstrapped not implemented")
+}
+func (UnimplementedInfoServer) GetTxFee(context.Context, *emptypb.Empty) (*GetTxFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxFee not implemented")
+}
+func (UnimplementedInfoServer) Uptime(context.Context, *emptypb.Empty) (*UptimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uptime not implemented")
+}
+func (UnimplementedInfoServer) mustEmbedUnimplementedInfoServer() {}
+
+// UnsafeInfoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InfoServer will
+// result in compilation errors.
+type UnsafeInfoServer interface {
+	mustEmbedUnimplementedInfoServer()
+}
+
+func RegisterInfoServer(s grpc.ServiceRegistrar, srv InfoServer) {
+	s.RegisterService(&Info_ServiceDesc, srv)
+}
+
+func _Info_GetNodeVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNodeVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetNodeVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNodeVersion(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNodeID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNodeID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetNodeID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNodeID(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNodeIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNodeIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetNodeIP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNodeIP(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNetworkID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNetworkID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetNetworkID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNetworkID(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNetworkName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNetworkName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetNetworkName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNetworkName(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetBlockchainID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockchainIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetBlockchainID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetBlockchainID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetBlockchainID(ctx, req.(*GetBlockchainIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_IsBootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsBootstrappedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).IsBootstrapped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/IsBootstrapped",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).IsBootstrapped(ctx, req.(*IsBootstrappedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetTxFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetTxFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/GetTxFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetTxFee(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_Uptime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).Uptime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/info.Info/Uptime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).Uptime(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Info_ServiceDesc is the grpc.ServiceDesc for Info service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Info_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "info.Info",
+	HandlerType: (*InfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNodeVersion",
+			Handler:    _Info_GetNodeVersion_Handler,
+		},
+		{
+			MethodName: "GetNodeID",
+			Handler:    _Info_GetNodeID_Handler,
+		},
+		{
+			MethodName: "GetNodeIP",
+			Handler:    _Info_GetNodeIP_Handler,
+		},
+		{
+			MethodName: "GetNetworkID",
+			Handler:    _Info_GetNetworkID_Handler,
+		},
+		{
+			MethodName: "GetNetworkName",
+			Handler:    _Info_GetNetworkName_Handler,
+		},
+		{
+			MethodName: "GetBlockchainID",
+			Handler:    _Info_GetBlockchainID_Handler,
+		},
+		{
+			MethodName: "IsBootstrapped",
+			Handler:    _Info_IsBootstrapped_Handler,
+		},
+		{
+			MethodName: "GetTxFee",
+			Handler:    _Info_GetTxFee_Handler,
+		},
+		{
+			MethodName: "Uptime",
+			Handler:    _Info_Uptime_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "info/info.proto",
+}
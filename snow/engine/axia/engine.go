@@ -16,4 +16,8 @@ type Engine interface {
 	// GetVtx returns a vertex by its ID.
 	// Returns an error if unknown.
 	GetVtx(vtxID ids.ID) (axia.Vertex, error)
+
+	// Edge returns the IDs of the accepted vertices that have no accepted
+	// children.
+	Edge() []ids.ID
 }
@@ -194,6 +194,22 @@ func (_m *Engine) Disconnected(id ids.NodeID) error {
 	return r0
 }
 
+// Edge provides a mock function with given fields:
+func (_m *Engine) Edge() []ids.ID {
+	ret := _m.Called()
+
+	var r0 []ids.ID
+	if rf, ok := ret.Get(0).(func() []ids.ID); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ids.ID)
+		}
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: validatorID, requestID, containerID
 func (_m *Engine) Get(validatorID ids.NodeID, requestID uint32, containerID ids.ID) error {
 	ret := _m.Called(validatorID, requestID, containerID)
@@ -21,13 +21,16 @@ var (
 type EngineTest struct {
 	common.EngineTest
 
-	CantGetVtx bool
-	GetVtxF    func(vtxID ids.ID) (axia.Vertex, error)
+	CantGetVtx, CantEdge bool
+
+	GetVtxF func(vtxID ids.ID) (axia.Vertex, error)
+	EdgeF   func() []ids.ID
 }
 
 func (e *EngineTest) Default(cant bool) {
 	e.EngineTest.Default(cant)
 	e.CantGetVtx = false
+	e.CantEdge = false
 }
 
 func (e *EngineTest) GetVtx(vtxID ids.ID) (axia.Vertex, error) {
@@ -39,3 +42,13 @@ func (e *EngineTest) GetVtx(vtxID ids.ID) (axia.Vertex, error) {
 	}
 	return nil, errGetVtx
 }
+
+func (e *EngineTest) Edge() []ids.ID {
+	if e.EdgeF != nil {
+		return e.EdgeF()
+	}
+	if e.CantEdge && e.T != nil {
+		e.T.Fatalf("Unexpectedly called Edge")
+	}
+	return nil
+}
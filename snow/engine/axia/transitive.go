@@ -340,6 +340,10 @@ func (t *Transitive) GetVtx(vtxID ids.ID) (axia.Vertex, error) {
 	return t.Manager.GetVtx(vtxID)
 }
 
+func (t *Transitive) Edge() []ids.ID {
+	return t.Manager.Edge()
+}
+
 func (t *Transitive) attemptToIssueTxs() error {
 	err := t.errs.Err
 	if err != nil {
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"google.golang.org/grpc"
+)
+
+// GRPCService is a gRPC service that is served next to the HTTP handlers of an
+// API.
+type GRPCService struct {
+	LockOptions LockOption
+	// Name of the JSON-RPC service that this gRPC service mirrors. Calls of
+	// the gRPC method [Method] are authorized as calls of the JSON-RPC method
+	// [Name].[method].
+	Name string
+	Desc *grpc.ServiceDesc
+	Impl interface{}
+}
+
+// GRPCServiceCreator is implemented by VMs that expose gRPC services.
+type GRPCServiceCreator interface {
+	// Creates the gRPC services for custom chain network calls.
+	//
+	// The services are routed to by the same endpoint as the handlers returned
+	// by CreateHandlers, i.e. bc/[chain ID].
+	CreateGRPCServices() ([]*GRPCService, error)
+}
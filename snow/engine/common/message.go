@@ -24,6 +24,11 @@ const (
 
 	// StopVertex notifies a consensus that it has a pending stop vertex
 	StopVertex
+
+	// StateSyncRestart notifies the state syncer engine that the VM couldn't
+	// sync the state summary it accepted, and that a new summary must be
+	// selected.
+	StateSyncRestart
 )
 
 func (msg Message) String() string {
@@ -34,6 +39,8 @@ func (msg Message) String() string {
 		return "State Sync Done"
 	case StopVertex:
 		return "Pending Stop Vertex"
+	case StateSyncRestart:
+		return "State Sync Restart"
 	default:
 		return fmt.Sprintf("Unknown Message: %d", msg)
 	}
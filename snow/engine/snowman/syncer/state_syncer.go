@@ -476,11 +476,16 @@ func (ss *stateSyncer) AppRequestFailed(nodeID ids.NodeID, requestID uint32) err
 }
 
 func (ss *stateSyncer) Notify(msg common.Message) error {
-	if msg != common.StateSyncDone {
+	switch msg {
+	case common.StateSyncDone:
+		return ss.onDoneStateSyncing(ss.requestID)
+	case common.StateSyncRestart:
+		ss.Ctx.Log.Info("VM couldn't sync the selected state summary, restarting state sync")
+		return ss.restart()
+	default:
 		ss.Ctx.Log.Warn("unexpected message from the VM: %s", msg)
 		return nil
 	}
-	return ss.onDoneStateSyncing(ss.requestID)
 }
 
 func (ss *stateSyncer) Connected(nodeID ids.NodeID, nodeVersion version.Application) error {
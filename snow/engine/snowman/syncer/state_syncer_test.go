@@ -1306,3 +1306,48 @@ func TestStateSyncIsDoneOnceVMNotifies(t *testing.T) {
 	assert.NoError(syncer.Notify(common.StateSyncDone))
 	assert.True(stateSyncFullyDone)
 }
+
+func TestStateSyncIsRestartedOnceVMNotifies(t *testing.T) {
+	assert := assert.New(t)
+
+	vdrs := buildTestPeers(t)
+	startupAlpha := (3*vdrs.Weight() + 3) / 4
+
+	peers := tracker.NewPeers()
+	startup := tracker.NewStartup(peers, startupAlpha)
+	vdrs.RegisterCallbackListener(startup)
+
+	commonCfg := common.Config{
+		Ctx:                         snow.DefaultConsensusContextTest(),
+		Beacons:                     vdrs,
+		SampleK:                     vdrs.Len(),
+		Alpha:                       (vdrs.Weight() + 1) / 2,
+		StartupTracker:              startup,
+		RetryBootstrapWarnFrequency: 1,
+	}
+	syncer, _, sender := buildTestsObjects(t, &commonCfg)
+
+	contactedFrontiersProviders := ids.NewNodeIDSet(3)
+	sender.CantSendGetStateSummaryFrontier = true
+	sender.SendGetStateSummaryFrontierF = func(ss ids.NodeIDSet, u uint32) {
+		contactedFrontiersProviders.Union(ss)
+	}
+
+	for _, vdr := range vdrs.List() {
+		assert.NoError(syncer.Connected(vdr.ID(), version.CurrentApp))
+	}
+	assert.NotEmpty(contactedFrontiersProviders)
+
+	stateSyncFullyDone := false
+	syncer.onDoneStateSyncing = func(lastReqID uint32) error {
+		stateSyncFullyDone = true
+		return nil
+	}
+
+	// The frontiers are requested again, rather than moving on to
+	// bootstrapping.
+	contactedFrontiersProviders.Clear()
+	assert.NoError(syncer.Notify(common.StateSyncRestart))
+	assert.False(stateSyncFullyDone)
+	assert.NotEmpty(contactedFrontiersProviders)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/message"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 )
 
@@ -36,7 +37,7 @@ func TestQueue(t *testing.T) {
 	currentTime := time.Now()
 	u.clock.Set(currentTime)
 
-	mc, err := message.NewCreator(prometheus.NewRegistry(), true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(prometheus.NewRegistry(), compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(err)
 	mc.SetTime(currentTime)
 	msg1 := mc.InboundPut(ids.Empty,
@@ -59,6 +59,9 @@ type ChainRouter struct {
 
 	closeTimeout time.Duration
 	peers        map[ids.NodeID]version.Application
+	// node ID --> traffic that node sent to each chain
+	// invariant: only connected nodes have an entry
+	peerTraffic map[ids.NodeID]*chainTrafficCounter
 	// node ID --> chains that node is benched on
 	// invariant: if a node is benched on any chain, it is treated as disconnected on all chains
 	benched        map[ids.NodeID]ids.Set
@@ -102,6 +105,7 @@ func (cr *ChainRouter) Initialize(
 	cr.timedRequests = linkedhashmap.New()
 	cr.peers = make(map[ids.NodeID]version.Application)
 	cr.peers[nodeID] = version.CurrentApp
+	cr.peerTraffic = make(map[ids.NodeID]*chainTrafficCounter)
 	cr.healthConfig = healthConfig
 	cr.requestIDBytes = make([]byte, hashing.AddrLen+hashing.HashLen+wrappers.IntLen+wrappers.ByteLen) // Validator ID, Chain ID, Request ID, Msg Type
 
@@ -174,6 +178,8 @@ func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 	cr.lock.Lock()
 	defer cr.lock.Unlock()
 
+	cr.countTraffic(nodeID, chainID, msg.NumBytes())
+
 	// Get the chain, if it exists
 	chain, exists := cr.chains[chainID]
 	if !exists || !chain.IsValidator(nodeID) {
@@ -321,6 +327,7 @@ func (cr *ChainRouter) Disconnected(nodeID ids.NodeID) {
 	defer cr.lock.Unlock()
 
 	delete(cr.peers, nodeID)
+	delete(cr.peerTraffic, nodeID)
 	if _, benched := cr.benched[nodeID]; benched {
 		return
 	}
@@ -334,6 +341,19 @@ func (cr *ChainRouter) Disconnected(nodeID ids.NodeID) {
 	}
 }
 
+// ChainTraffic returns the messages and bytes that [nodeID] sent to each chain
+// since it connected
+func (cr *ChainRouter) ChainTraffic(nodeID ids.NodeID) map[string]ChainTraffic {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	counter, ok := cr.peerTraffic[nodeID]
+	if !ok {
+		return map[string]ChainTraffic{}
+	}
+	return counter.traffic()
+}
+
 // Benched routes an incoming notification that a validator was benched
 func (cr *ChainRouter) Benched(chainID ids.ID, nodeID ids.NodeID) {
 	cr.lock.Lock()
@@ -480,3 +500,33 @@ func (cr *ChainRouter) createRequestID(nodeID ids.NodeID, chainID ids.ID, reques
 	cr.requestIDBytes[hashing.AddrLen+hashing.HashLen+wrappers.IntLen] = byte(op)
 	return hashing.ComputeHash256Array(cr.requestIDBytes)
 }
+
+// countTraffic records that [nodeID] sent a message of [numBytes] to
+// [chainID]. Messages from nodes that aren't connected aren't counted. Messages
+// to chains that aren't registered are counted together, so that the number of
+// counts of a node stays bounded.
+//
+// Assumes [cr.lock] is held.
+func (cr *ChainRouter) countTraffic(nodeID ids.NodeID, chainID ids.ID, numBytes int) {
+	if _, connected := cr.peers[nodeID]; !connected {
+		return
+	}
+
+	counter, ok := cr.peerTraffic[nodeID]
+	if !ok {
+		counter = &chainTrafficCounter{
+			chains: make(map[ids.ID]*ChainTraffic),
+		}
+		cr.peerTraffic[nodeID] = counter
+	}
+	if _, registered := cr.chains[chainID]; !registered {
+		counter.unknown.add(numBytes)
+		return
+	}
+	t, ok := counter.chains[chainID]
+	if !ok {
+		t = &ChainTraffic{}
+		counter.chains[chainID] = t
+	}
+	t.add(numBytes)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/snow/networking/timeout"
 	"github.com/sankar-boro/axia-network-v2/snow/networking/tracker"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 	"github.com/sankar-boro/axia-network-v2/utils/math/meter"
 	"github.com/sankar-boro/axia-network-v2/utils/resource"
@@ -52,7 +53,7 @@ func TestShutdown(t *testing.T) {
 
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -148,7 +149,7 @@ func TestShutdownTimesOut(t *testing.T) {
 
 	chainRouter := ChainRouter{}
 
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID,
@@ -257,7 +258,7 @@ func TestRouterTimeout(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -381,7 +382,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(t, err)
 
 	assert.NoError(t, err)
@@ -494,7 +495,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -594,3 +595,81 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// the GetFailed message is sent
 	assert.Equal(t, 1, chainRouter.timedRequests.Len())
 }
+
+func TestRouterChainTraffic(t *testing.T) {
+	assert := assert.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+	go tm.Dispatch()
+
+	chainRouter := ChainRouter{}
+	mc, err := message.NewCreator(prometheus.NewRegistry(), compression.TypeGzip, "dummyNamespace", 10*time.Second)
+	assert.NoError(err)
+	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	vdrs := validators.NewSet()
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	assert.NoError(err)
+	handler, err := handler.New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+	)
+	assert.NoError(err)
+	chainRouter.AddChain(handler)
+
+	nodeID := ids.GenerateTestNodeID()
+	handleInbound := func(chainID ids.ID) uint64 {
+		outboundMsg, err := mc.Get(chainID, 1, time.Second, ids.Empty)
+		assert.NoError(err)
+		inboundMsg, err := mc.Parse(outboundMsg.Bytes(), nodeID, func() {})
+		assert.NoError(err)
+		chainRouter.HandleInbound(inboundMsg)
+		return uint64(len(outboundMsg.Bytes()))
+	}
+
+	// Messages from nodes that aren't connected aren't counted.
+	handleInbound(ctx.ChainID)
+	assert.Empty(chainRouter.ChainTraffic(nodeID))
+
+	chainRouter.Connected(nodeID, version.CurrentApp)
+	numBytes := handleInbound(ctx.ChainID)
+	numBytes += handleInbound(ctx.ChainID)
+
+	// Messages to chains that aren't registered are counted together.
+	numUnknownBytes := handleInbound(ids.GenerateTestID())
+	numUnknownBytes += handleInbound(ids.GenerateTestID())
+
+	assert.Equal(map[string]ChainTraffic{
+		ctx.ChainID.String(): {
+			MessagesReceived: 2,
+			BytesReceived:    numBytes,
+		},
+		UnknownChain: {
+			MessagesReceived: 2,
+			BytesReceived:    numUnknownBytes,
+		},
+	}, chainRouter.ChainTraffic(nodeID))
+
+	chainRouter.Disconnected(nodeID)
+	assert.Empty(chainRouter.ChainTraffic(nodeID))
+}
@@ -22,6 +22,7 @@ import (
 type Router interface {
 	ExternalHandler
 	InternalHandler
+	TrafficReporter
 
 	Initialize(
 		nodeID ids.NodeID,
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"github.com/sankar-boro/axia-network-v2/ids"
+)
+
+// UnknownChain is the name that the traffic a peer sent to chains that aren't
+// registered with the router is reported under, so that a peer can't add
+// counts by sending messages to arbitrary chain IDs.
+const UnknownChain = "unknown"
+
+// ChainTraffic counts the messages and bytes that a peer sent to a chain
+type ChainTraffic struct {
+	MessagesReceived uint64 `json:"messagesReceived"`
+	BytesReceived    uint64 `json:"bytesReceived"`
+}
+
+func (t *ChainTraffic) add(numBytes int) {
+	t.MessagesReceived++
+	t.BytesReceived += uint64(numBytes)
+}
+
+// TrafficReporter reports the traffic that peers sent to each chain
+type TrafficReporter interface {
+	// ChainTraffic returns the messages and bytes that [nodeID] sent to each
+	// chain since it connected, by chain ID. The traffic sent to chains that
+	// aren't registered is under [UnknownChain].
+	ChainTraffic(nodeID ids.NodeID) map[string]ChainTraffic
+}
+
+// chainTrafficCounter counts the traffic that a peer sent to each chain
+type chainTrafficCounter struct {
+	// chain ID --> traffic sent to that chain
+	// invariant: only chains that were registered have an entry
+	chains map[ids.ID]*ChainTraffic
+	// traffic sent to chains that aren't registered
+	unknown ChainTraffic
+}
+
+func (c *chainTrafficCounter) traffic() map[string]ChainTraffic {
+	traffic := make(map[string]ChainTraffic, len(c.chains)+1)
+	for chainID, t := range c.chains {
+		traffic[chainID.String()] = *t
+	}
+	if c.unknown.MessagesReceived > 0 {
+		traffic[UnknownChain] = c.unknown
+	}
+	return traffic
+}
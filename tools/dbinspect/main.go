@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// dbinspect reports on the contents of the database of a stopped node.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/sankar-boro/axia-network-v2/chains/inspect"
+	"github.com/sankar-boro/axia-network-v2/database/leveldb"
+	"github.com/sankar-boro/axia-network-v2/database/manager"
+	"github.com/sankar-boro/axia-network-v2/database/migrate"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
+	"github.com/sankar-boro/axia-network-v2/database/rocksdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/version"
+)
+
+const (
+	dbTypeKey          = "db-type"
+	dbDirKey           = "db-dir"
+	dbConfigKey        = "db-config-file"
+	chainIDsKey        = "chain-ids"
+	decodeLimitKey     = "decode-limit"
+	logLevelKey        = "log-level"
+	defaultDecodeLimit = 10
+)
+
+var (
+	errMissingDirectory    = errors.New("--db-dir must be provided")
+	errUnknownDatabaseType = errors.New("unknown database type")
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Printf("inspection failed: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := pflag.NewFlagSet("dbinspect", pflag.ContinueOnError)
+	dbType := fs.String(dbTypeKey, leveldb.Name, fmt.Sprintf("Database type. Must be one of %s, %s or %s", leveldb.Name, rocksdb.Name, pebble.Name))
+	dbDir := fs.String(dbDirKey, "", "Database directory of the network, i.e. the --db-dir of the node joined with the network name")
+	dbConfigFile := fs.String(dbConfigKey, "", "Path to the database config file")
+	chainIDStrs := fs.StringSlice(chainIDsKey, nil, "IDs of the chains to inspect. If empty, every chain is inspected")
+	decodeLimit := fs.Int(decodeLimitKey, defaultDecodeLimit, "Maximum number of values decoded per prefix")
+	logLevel := fs.String(logLevelKey, "info", "The log level")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dbDir == "" {
+		return errMissingDirectory
+	}
+
+	chainIDs := make([]ids.ID, len(*chainIDStrs))
+	for i, chainIDStr := range *chainIDStrs {
+		chainID, err := ids.FromString(chainIDStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse chain ID %q: %w", chainIDStr, err)
+		}
+		chainIDs[i] = chainID
+	}
+
+	level, err := logging.ToLevel(*logLevel)
+	if err != nil {
+		return err
+	}
+	log := logging.NewLogger(false, "", logging.NewWrappedCore(level, os.Stdout, logging.Plain.ConsoleEncoder()))
+
+	var dbConfig []byte
+	if *dbConfigFile != "" {
+		dbConfig, err = os.ReadFile(*dbConfigFile)
+		if err != nil {
+			return err
+		}
+	}
+	dbConfig, err = readOnlyConfig(dbConfig)
+	if err != nil {
+		return err
+	}
+
+	// The database manager creates the current database if it doesn't exist,
+	// so its existence is checked beforehand.
+	dir := migrate.Dir(*dbDir, *dbType)
+	currentDir := filepath.Join(dir, version.CurrentDatabase.String())
+	if _, err := os.Stat(currentDir); err != nil {
+		return fmt.Errorf("couldn't find the current database: %w", err)
+	}
+
+	var newManager func(string, []byte, logging.Logger, version.Version, string, prometheus.Registerer) (manager.Manager, error)
+	switch *dbType {
+	case leveldb.Name:
+		newManager = manager.NewLevelDB
+	case rocksdb.Name:
+		newManager = manager.NewRocksDB
+	case pebble.Name:
+		newManager = manager.NewPebbleDB
+	default:
+		return fmt.Errorf("%w: %q", errUnknownDatabaseType, *dbType)
+	}
+	dbManager, err := newManager(dir, dbConfig, log, version.CurrentDatabase, "db", prometheus.NewRegistry())
+	if err != nil {
+		return err
+	}
+
+	log.Info("inspecting the database at %s", currentDir)
+	err = inspect.Inspect(os.Stdout, dbManager.Current().Database, inspect.Config{
+		ChainIDs:    chainIDs,
+		DecodeLimit: *decodeLimit,
+	})
+	if closeErr := dbManager.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// readOnlyConfig returns [dbConfig] with the database opened read-only, so the
+// inspection never modifies the database. If the node is still running, the
+// databases that hold a lock on their directory fail to open.
+func readOnlyConfig(dbConfig []byte) ([]byte, error) {
+	config := make(map[string]interface{})
+	if len(dbConfig) > 0 {
+		if err := json.Unmarshal(dbConfig, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+	config["readOnly"] = true
+	return json.Marshal(config)
+}
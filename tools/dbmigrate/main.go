@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// dbmigrate copies the versioned databases of a stopped node from one
+// database backend into another.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/sankar-boro/axia-network-v2/database/leveldb"
+	"github.com/sankar-boro/axia-network-v2/database/migrate"
+	"github.com/sankar-boro/axia-network-v2/database/pebble"
+	"github.com/sankar-boro/axia-network-v2/database/rocksdb"
+	"github.com/sankar-boro/axia-network-v2/utils/logging"
+)
+
+const (
+	sourceDBTypeKey        = "source-db-type"
+	sourceDBDirKey         = "source-db-dir"
+	sourceDBConfigKey      = "source-db-config-file"
+	destinationDBTypeKey   = "dest-db-type"
+	destinationDBDirKey    = "dest-db-dir"
+	destinationDBConfigKey = "dest-db-config-file"
+	batchSizeKey           = "batch-size"
+	prefixLenKey           = "prefix-len"
+	skipVerifyKey          = "skip-verify"
+	logLevelKey            = "log-level"
+)
+
+var errMissingDirectory = errors.New("both --source-db-dir and --dest-db-dir must be provided")
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Printf("migration failed: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	dbTypes := fmt.Sprintf("%s, %s or %s", leveldb.Name, rocksdb.Name, pebble.Name)
+
+	fs := pflag.NewFlagSet("dbmigrate", pflag.ContinueOnError)
+	sourceType := fs.String(sourceDBTypeKey, leveldb.Name, fmt.Sprintf("Database type of the source. Must be one of %s", dbTypes))
+	sourceDir := fs.String(sourceDBDirKey, "", "Database directory of the source network, i.e. the --db-dir of the node joined with the network name")
+	sourceConfigFile := fs.String(sourceDBConfigKey, "", "Path to the database config file of the source")
+	destinationType := fs.String(destinationDBTypeKey, pebble.Name, fmt.Sprintf("Database type of the destination. Must be one of %s", dbTypes))
+	destinationDir := fs.String(destinationDBDirKey, "", "Database directory of the destination network, i.e. the --db-dir of the node joined with the network name")
+	destinationConfigFile := fs.String(destinationDBConfigKey, "", "Path to the database config file of the destination")
+	batchSize := fs.Int(batchSizeKey, migrate.DefaultBatchSize, "Number of bytes written between progress checkpoints")
+	prefixLen := fs.Int(prefixLenKey, migrate.DefaultPrefixLen, "Number of leading key bytes that checksums are grouped by")
+	skipVerify := fs.Bool(skipVerifyKey, false, "If true, the checksums of the copied databases aren't verified")
+	logLevel := fs.String(logLevelKey, "info", "The log level")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *sourceDir == "" || *destinationDir == "" {
+		return errMissingDirectory
+	}
+
+	level, err := logging.ToLevel(*logLevel)
+	if err != nil {
+		return err
+	}
+	log := logging.NewLogger(false, "", logging.NewWrappedCore(level, os.Stdout, logging.Plain.ConsoleEncoder()))
+
+	sourceConfig, err := readConfig(*sourceConfigFile)
+	if err != nil {
+		return err
+	}
+	destinationConfig, err := readConfig(*destinationConfigFile)
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.New(migrate.Config{
+		SourceType:        *sourceType,
+		SourceDir:         migrate.Dir(*sourceDir, *sourceType),
+		SourceConfig:      sourceConfig,
+		DestinationType:   *destinationType,
+		DestinationDir:    migrate.Dir(*destinationDir, *destinationType),
+		DestinationConfig: destinationConfig,
+		BatchSize:         *batchSize,
+		PrefixLen:         *prefixLen,
+		SkipVerify:        *skipVerify,
+	}, log)
+	if err != nil {
+		return err
+	}
+	return m.Run()
+}
+
+func readConfig(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(path)
+}
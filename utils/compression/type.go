@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errUnknownType = errors.New("unknown compression type")
+
+// Type is the type of compression that a Compressor performs
+type Type byte
+
+// Types are sent over the wire, so the values of existing types must not be
+// changed.
+const (
+	TypeNone Type = iota
+	TypeGzip
+	TypeZstd
+)
+
+// TypeFromString returns the type named [s]
+func TypeFromString(s string) (Type, error) {
+	switch strings.ToLower(s) {
+	case TypeNone.String():
+		return TypeNone, nil
+	case TypeGzip.String():
+		return TypeGzip, nil
+	case TypeZstd.String():
+		return TypeZstd, nil
+	default:
+		return TypeNone, fmt.Errorf("%w: %q", errUnknownType, s)
+	}
+}
+
+// Valid returns true if [t] is a known type
+func (t Type) Valid() bool {
+	return t <= TypeZstd
+}
+
+func (t Type) String() string {
+	switch t {
+	case TypeNone:
+		return "none"
+	case TypeGzip:
+		return "gzip"
+	case TypeZstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var _ Compressor = &zstdCompressor{}
+
+// zstdCompressor only encodes and decodes whole messages, which the encoder and
+// the decoder allow to be done concurrently, up to GOMAXPROCS at a time.
+type zstdCompressor struct {
+	maxSize int64
+
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (z *zstdCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("msg length (%d) > maximum msg length (%d)", len(msg), z.maxSize)
+	}
+	return z.encoder.EncodeAll(msg, nil), nil
+}
+
+// Decompress decompresses [msg].
+func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The decoder refuses to decode frames that claim to be larger than
+	// [z.maxSize], but frames may omit their size, so the size is checked
+	// again.
+	decompressed, err := z.decoder.DecodeAll(msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > z.maxSize {
+		return nil, fmt.Errorf("msg length > maximum msg length (%d)", z.maxSize)
+	}
+	return decompressed, nil
+}
+
+// NewZstdCompressor returns a new zstd Compressor that compresses messages of
+// at most [maxSize] bytes. If [dictionary] isn't empty, messages are compressed
+// with it, and compressed messages can only be decompressed by compressors that
+// use the same dictionary.
+func NewZstdCompressor(maxSize int64, dictionary []byte) (Compressor, error) {
+	encoderOptions := []zstd.EOption(nil)
+	decoderOptions := []zstd.DOption{
+		zstd.WithDecoderMaxMemory(uint64(maxSize)),
+	}
+	if len(dictionary) > 0 {
+		encoderOptions = append(encoderOptions, zstd.WithEncoderDict(dictionary))
+		decoderOptions = append(decoderOptions, zstd.WithDecoderDicts(dictionary))
+	}
+
+	encoder, err := zstd.NewWriter(nil, encoderOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create zstd encoder: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil, decoderOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create zstd decoder: %w", err)
+	}
+	return &zstdCompressor{
+		maxSize: maxSize,
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZstdCompressDecompress(t *testing.T) {
+	data := make([]byte, 4096)
+	for i := 0; i < len(data); i++ {
+		data[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+
+	compressor, err := NewZstdCompressor(2*units.MiB, nil)
+	assert.NoError(t, err)
+
+	dataCompressed, err := compressor.Compress(data)
+	assert.NoError(t, err)
+
+	dataDecompressed, err := compressor.Decompress(dataCompressed)
+	assert.NoError(t, err)
+	assert.EqualValues(t, data, dataDecompressed)
+
+	nonZstdData := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	_, err = compressor.Decompress(nonZstdData)
+	assert.Error(t, err)
+}
+
+func TestZstdSizeLimit(t *testing.T) {
+	compressor, err := NewZstdCompressor(units.KiB, nil)
+	assert.NoError(t, err)
+
+	_, err = compressor.Compress(make([]byte, units.KiB+1))
+	assert.Error(t, err)
+
+	// A compressor that allows larger messages can compress a message that is
+	// too large to be decompressed.
+	largeCompressor, err := NewZstdCompressor(2*units.KiB, nil)
+	assert.NoError(t, err)
+	compressed, err := largeCompressor.Compress(make([]byte, units.KiB+1))
+	assert.NoError(t, err)
+	_, err = compressor.Decompress(compressed)
+	assert.Error(t, err)
+}
+
+func TestZstdDictionary(t *testing.T) {
+	// A dictionary without entropy tables isn't valid
+	_, err := NewZstdCompressor(units.MiB, bytes.Repeat([]byte{1}, 64))
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
@@ -79,13 +80,7 @@ func (f *factory) makeLogger(config Config) (Logger, error) {
 	consoleCore := NewWrappedCore(config.DisplayLevel, os.Stdout, consoleEnc)
 	consoleCore.WriterDisabled = config.DisableWriterDisplaying
 
-	rw := &lumberjack.Logger{
-		Filename:   path.Join(config.Directory, config.LoggerName+".log"),
-		MaxSize:    config.MaxSize,  // megabytes
-		MaxAge:     config.MaxAge,   // days
-		MaxBackups: config.MaxFiles, // files
-		Compress:   config.Compress,
-	}
+	rw := NewRotatingWriter(config.RotatingWriterConfig, config.LoggerName)
 	fileCore := NewWrappedCore(config.LogLevel, rw, fileEnc)
 	prefix := config.LogFormat.WrapPrefix(config.MsgPrefix)
 
@@ -98,6 +93,18 @@ func (f *factory) makeLogger(config Config) (Logger, error) {
 	return l, nil
 }
 
+// NewRotatingWriter returns a writer that appends to the file [name].log in
+// the directory of [config]. The file is rotated as described by [config].
+func NewRotatingWriter(config RotatingWriterConfig, name string) io.WriteCloser {
+	return &lumberjack.Logger{
+		Filename:   path.Join(config.Directory, name+".log"),
+		MaxSize:    config.MaxSize,  // megabytes
+		MaxAge:     config.MaxAge,   // days
+		MaxBackups: config.MaxFiles, // files
+		Compress:   config.Compress,
+	}
+}
+
 func (f *factory) Make(name string) (Logger, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -22,12 +22,22 @@ type APIInterceptor interface {
 
 type contextKey int
 
-const requestTimestampKey contextKey = iota
+const (
+	requestTimestampKey contextKey = iota
+	batchedRequestKey
+)
 
 type apiInterceptor struct {
 	requestDurationCount *prometheus.CounterVec
 	requestDurationSum   *prometheus.GaugeVec
 	requestErrors        *prometheus.CounterVec
+	batchedRequests      *prometheus.CounterVec
+}
+
+// WithBatchedRequest returns a copy of [ctx] that marks the requests made with
+// it as calls of a JSON-RPC batch
+func WithBatchedRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchedRequestKey, true)
 }
 
 func NewAPIInterceptor(namespace string, registerer prometheus.Registerer) (APIInterceptor, error) {
@@ -54,17 +64,27 @@ func NewAPIInterceptor(namespace string, registerer prometheus.Registerer) (APII
 		},
 		[]string{"method"},
 	)
+	batchedRequests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_batched_count",
+			Help:      "Number of times this type of request was made as a call of a batch",
+		},
+		[]string{"method"},
+	)
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(requestDurationCount),
 		registerer.Register(requestDurationSum),
 		registerer.Register(requestErrors),
+		registerer.Register(batchedRequests),
 	)
 	return &apiInterceptor{
 		requestDurationCount: requestDurationCount,
 		requestDurationSum:   requestDurationSum,
 		requestErrors:        requestErrors,
+		batchedRequests:      batchedRequests,
 	}, errs.Err
 }
 
@@ -98,4 +118,11 @@ func (apr *apiInterceptor) AfterRequest(i *rpc.RequestInfo) {
 		})
 		errMetric.Inc()
 	}
+
+	if batched, _ := i.Request.Context().Value(batchedRequestKey).(bool); batched {
+		batchedMetric := apr.batchedRequests.With(prometheus.Labels{
+			"method": i.Method,
+		})
+		batchedMetric.Inc()
+	}
 }
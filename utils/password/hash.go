@@ -6,30 +6,156 @@ package password
 import (
 	"bytes"
 	"crypto/rand"
+	"errors"
+	"fmt"
 
 	"golang.org/x/crypto/argon2"
 )
 
+const (
+	// Argon2id is the version of hashes that are derived with Argon2id using
+	// the parameters recorded in the hash.
+	Argon2id Version = 1
+
+	// minMemoryPerThread is the minimum memory, in KiB, that Argon2 requires
+	// per thread.
+	minMemoryPerThread = 8
+
+	// maxTime, maxMemory and maxThreads bound the cost of deriving a hash so
+	// that parameters provided by a user can't exhaust the node.
+	maxTime    = 64
+	maxMemory  = 4 * 1024 * 1024 // 4 GiB
+	maxThreads = 64
+)
+
+var (
+	errZeroTime        = errors.New("time must be positive")
+	errZeroThreads     = errors.New("threads must be positive")
+	errTooLittleMemory = fmt.Errorf("memory must be at least %d KiB per thread", minMemoryPerThread)
+	errTooMuchTime     = fmt.Errorf("time must be at most %d", maxTime)
+	errTooMuchMemory   = fmt.Errorf("memory must be at most %d KiB", maxMemory)
+	errTooManyThreads  = fmt.Errorf("threads must be at most %d", maxThreads)
+
+	// LegacyParams are the parameters of every hash of the [LegacyHash]
+	// format.
+	LegacyParams = Params{
+		Time:    1,
+		Memory:  64 * 1024, // 64 MiB
+		Threads: 4,
+	}
+
+	// DefaultParams are the parameters that new hashes are derived with
+	// unless specified otherwise.
+	DefaultParams = Params{
+		Time:    3,
+		Memory:  64 * 1024, // 64 MiB
+		Threads: 4,
+	}
+)
+
+// Version of the format of a hash
+type Version uint16
+
+// Params of the Argon2id derivation of a hash
+type Params struct {
+	// Time is the number of passes over the memory
+	Time uint32 `serialize:"true" json:"time"`
+	// Memory is the size of the memory, in KiB
+	Memory uint32 `serialize:"true" json:"memory"`
+	// Threads is the number of threads
+	Threads uint8 `serialize:"true" json:"threads"`
+}
+
+// Verify returns an error if the parameters can't be used to derive a hash
+func (p Params) Verify() error {
+	switch {
+	case p.Time == 0:
+		return errZeroTime
+	case p.Time > maxTime:
+		return errTooMuchTime
+	case p.Threads == 0:
+		return errZeroThreads
+	case p.Threads > maxThreads:
+		return errTooManyThreads
+	case uint64(p.Memory) < minMemoryPerThread*uint64(p.Threads):
+		return errTooLittleMemory
+	case p.Memory > maxMemory:
+		return errTooMuchMemory
+	default:
+		return nil
+	}
+}
+
+// Exceeds returns true if deriving a hash with the parameters is more costly
+// than deriving it with [limit] in any dimension.
+func (p Params) Exceeds(limit Params) bool {
+	return p.Time > limit.Time || p.Memory > limit.Memory || p.Threads > limit.Threads
+}
+
 // Hash of a password
 type Hash struct {
+	Version  Version  `serialize:"true"` // The format of the hash
+	Params   Params   `serialize:"true"` // The parameters the hash was derived with
 	Password [32]byte `serialize:"true"` // The salted, hashed password
 	Salt     [16]byte `serialize:"true"` // The salt
 }
 
-// Set updates the password hash to be of the provided password
+// Set updates the password hash to be of the provided password, derived with
+// the default parameters
 func (h *Hash) Set(password string) error {
+	return h.SetWithParams(password, DefaultParams)
+}
+
+// SetWithParams updates the password hash to be of the provided password,
+// derived with [params]
+func (h *Hash) SetWithParams(password string, params Params) error {
+	if err := params.Verify(); err != nil {
+		return err
+	}
 	if _, err := rand.Read(h.Salt[:]); err != nil {
 		return err
 	}
+	h.Version = Argon2id
+	h.Params = params
 	// pw is the salted, hashed password
-	pw := argon2.IDKey([]byte(password), h.Salt[:], 1, 64*1024, 4, 32)
-	copy(h.Password[:], pw[:32])
+	pw := h.derive(password)
+	copy(h.Password[:], pw)
 	return nil
 }
 
 // Check returns true iff the provided password was the same as the last
 // password set.
 func (h *Hash) Check(password string) bool {
-	pw := argon2.IDKey([]byte(password), h.Salt[:], 1, 64*1024, 4, 32)
+	if h.Version != Argon2id || h.Params.Verify() != nil {
+		return false
+	}
+	pw := h.derive(password)
 	return bytes.Equal(pw, h.Password[:])
 }
+
+// NeedsUpgrade returns true if the hash should be re-derived, the next time
+// that its password is known, to be derived with [params].
+func (h *Hash) NeedsUpgrade(params Params) bool {
+	return h.Version != Argon2id || h.Params != params
+}
+
+func (h *Hash) derive(password string) []byte {
+	return argon2.IDKey([]byte(password), h.Salt[:], h.Params.Time, h.Params.Memory, h.Params.Threads, uint32(len(h.Password)))
+}
+
+// LegacyHash is the format of password hashes that were created before hashes
+// were versioned. Every legacy hash was derived with [LegacyParams].
+type LegacyHash struct {
+	Password [32]byte `serialize:"true"` // The salted, hashed password
+	Salt     [16]byte `serialize:"true"` // The salt
+}
+
+// Hash returns the versioned form of the legacy hash
+func (h *LegacyHash) Hash() Hash {
+	return Hash{
+		Version:  Argon2id,
+		Params:   LegacyParams,
+		Password: h.Password,
+		Salt:     h.Salt,
+	}
+}
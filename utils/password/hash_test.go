@@ -4,7 +4,10 @@
 package password
 
 import (
+	"crypto/rand"
 	"testing"
+
+	"golang.org/x/crypto/argon2"
 )
 
 func TestHash(t *testing.T) {
@@ -22,3 +25,95 @@ func TestHash(t *testing.T) {
 		t.Fatalf("Shouldn't have verified the password")
 	}
 }
+
+func TestHashWithParams(t *testing.T) {
+	params := Params{
+		Time:    1,
+		Memory:  8 * 1024,
+		Threads: 1,
+	}
+	h := Hash{}
+	if err := h.SetWithParams("heytherepal", params); err != nil {
+		t.Fatal(err)
+	}
+	if h.Params != params {
+		t.Fatalf("Should have recorded the parameters")
+	}
+	if !h.Check("heytherepal") {
+		t.Fatalf("Should have verified the password")
+	}
+	if h.Check("heytherepal!") {
+		t.Fatalf("Shouldn't have verified the password")
+	}
+	if h.NeedsUpgrade(params) {
+		t.Fatalf("Shouldn't need to be upgraded to the same parameters")
+	}
+	if !h.NeedsUpgrade(DefaultParams) {
+		t.Fatalf("Should need to be upgraded to different parameters")
+	}
+
+	if err := h.SetWithParams("heytherepal", Params{Time: 1, Memory: 7, Threads: 1}); err == nil {
+		t.Fatalf("Should have errored due to too little memory")
+	}
+}
+
+func TestLegacyHash(t *testing.T) {
+	legacy := LegacyHash{}
+	if _, err := rand.Read(legacy.Salt[:]); err != nil {
+		t.Fatal(err)
+	}
+	copy(legacy.Password[:], argon2.IDKey([]byte("heytherepal"), legacy.Salt[:], 1, 64*1024, 4, 32))
+
+	h := legacy.Hash()
+	if !h.Check("heytherepal") {
+		t.Fatalf("Should have verified the password")
+	}
+	if h.Check("heytherepal!") {
+		t.Fatalf("Shouldn't have verified the password")
+	}
+	if !h.NeedsUpgrade(DefaultParams) {
+		t.Fatalf("Should need to be upgraded to the default parameters")
+	}
+}
+
+func TestParamsVerify(t *testing.T) {
+	tests := []struct {
+		params Params
+		valid  bool
+	}{
+		{params: DefaultParams, valid: true},
+		{params: LegacyParams, valid: true},
+		{params: Params{Time: 0, Memory: 1024, Threads: 1}, valid: false},
+		{params: Params{Time: 1, Memory: 1024, Threads: 0}, valid: false},
+		{params: Params{Time: 1, Memory: 31, Threads: 4}, valid: false},
+		{params: Params{Time: 1, Memory: 32, Threads: 4}, valid: true},
+		{params: Params{Time: maxTime, Memory: maxMemory, Threads: maxThreads}, valid: true},
+		{params: Params{Time: maxTime + 1, Memory: 1024, Threads: 1}, valid: false},
+		{params: Params{Time: 1, Memory: maxMemory + 1, Threads: 1}, valid: false},
+		{params: Params{Time: 1, Memory: maxMemory, Threads: maxThreads + 1}, valid: false},
+	}
+	for _, test := range tests {
+		if err := test.params.Verify(); (err == nil) != test.valid {
+			t.Fatalf("Verify(%+v) returned %v", test.params, err)
+		}
+	}
+}
+
+func TestParamsExceeds(t *testing.T) {
+	limit := Params{Time: 3, Memory: 1024, Threads: 4}
+	tests := []struct {
+		params  Params
+		exceeds bool
+	}{
+		{params: limit, exceeds: false},
+		{params: Params{Time: 1, Memory: 512, Threads: 1}, exceeds: false},
+		{params: Params{Time: 4, Memory: 1024, Threads: 4}, exceeds: true},
+		{params: Params{Time: 3, Memory: 1025, Threads: 4}, exceeds: true},
+		{params: Params{Time: 3, Memory: 1024, Threads: 5}, exceeds: true},
+	}
+	for _, test := range tests {
+		if exceeds := test.params.Exceeds(limit); exceeds != test.exceeds {
+			t.Fatalf("Exceeds(%+v) returned %v", test.params, exceeds)
+		}
+	}
+}
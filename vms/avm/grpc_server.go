@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+
+	avmpb "github.com/sankar-boro/axia-network-v2/proto/pb/avm"
+)
+
+// Txs are exchanged with [service] hex encoded and are converted from and to
+// raw bytes over gRPC.
+const grpcEncoding = formatting.Hex
+
+var (
+	errUnexpectedEncodedType = errors.New("unexpected type of encoded value")
+
+	_ avmpb.AVMServer = &grpcServer{}
+)
+
+// grpcServer serves the avm API over gRPC by calling into [service]
+type grpcServer struct {
+	avmpb.UnsafeAVMServer
+	service *Service
+}
+
+func (s *grpcServer) IssueTx(_ context.Context, req *avmpb.IssueTxRequest) (*avmpb.IssueTxResponse, error) {
+	txStr, err := formatting.EncodeWithChecksum(grpcEncoding, req.Tx)
+	if err != nil {
+		return nil, err
+	}
+	reply := api.JSONTxID{}
+	args := &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: grpcEncoding,
+	}
+	if err := s.service.IssueTx(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	return &avmpb.IssueTxResponse{
+		TxId: reply.TxID[:],
+	}, nil
+}
+
+func (s *grpcServer) GetTx(_ context.Context, req *avmpb.GetTxRequest) (*avmpb.GetTxResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, err
+	}
+	reply := api.GetTxReply{}
+	args := &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: grpcEncoding,
+	}
+	if err := s.service.GetTx(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	txStr, ok := reply.Tx.(string)
+	if !ok {
+		return nil, errUnexpectedEncodedType
+	}
+	txBytes, err := formatting.Decode(reply.Encoding, txStr)
+	if err != nil {
+		return nil, err
+	}
+	return &avmpb.GetTxResponse{
+		Tx: txBytes,
+	}, nil
+}
+
+func (s *grpcServer) GetTxStatus(_ context.Context, req *avmpb.GetTxStatusRequest) (*avmpb.GetTxStatusResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, err
+	}
+	reply := GetTxStatusReply{}
+	if err := s.service.GetTxStatus(nil, &api.JSONTxID{TxID: txID}, &reply); err != nil {
+		return nil, err
+	}
+	return &avmpb.GetTxStatusResponse{
+		Status: reply.Status.String(),
+	}, nil
+}
+
+func (s *grpcServer) GetBalance(_ context.Context, req *avmpb.GetBalanceRequest) (*avmpb.GetBalanceResponse, error) {
+	reply := GetBalanceReply{}
+	args := &GetBalanceArgs{
+		Address:        req.Address,
+		AssetID:        req.AssetId,
+		IncludePartial: req.IncludePartial,
+	}
+	if err := s.service.GetBalance(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	utxoIDs := make([]*avmpb.UTXOID, len(reply.UTXOIDs))
+	for i, utxoID := range reply.UTXOIDs {
+		utxoIDs[i] = &avmpb.UTXOID{
+			TxId:        utxoID.TxID[:],
+			OutputIndex: utxoID.OutputIndex,
+		}
+	}
+	return &avmpb.GetBalanceResponse{
+		Balance: uint64(reply.Balance),
+		UtxoIds: utxoIDs,
+	}, nil
+}
+
+func (s *grpcServer) GetAssetDescription(_ context.Context, req *avmpb.GetAssetDescriptionRequest) (*avmpb.GetAssetDescriptionResponse, error) {
+	reply := GetAssetDescriptionReply{}
+	if err := s.service.GetAssetDescription(nil, &GetAssetDescriptionArgs{AssetID: req.AssetId}, &reply); err != nil {
+		return nil, err
+	}
+	return &avmpb.GetAssetDescriptionResponse{
+		AssetId:      reply.AssetID[:],
+		Name:         reply.Name,
+		Symbol:       reply.Symbol,
+		Denomination: uint32(reply.Denomination),
+	}, nil
+}
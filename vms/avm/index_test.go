@@ -475,6 +475,82 @@ func TestIndexingAllowIncomplete(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestReindexTransactions(t *testing.T) {
+	assert := assert.New(t)
+	genesisBytes := BuildGenesisTest(t)
+	issuer := make(chan common.Message, 1)
+	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
+	ctx := NewContext(t)
+	genesisTx := GetAXCTxFromGenesisTest(genesisBytes, t)
+	vm := setupTestVM(t, ctx, baseDBManager, genesisBytes, issuer, indexEnabledAvmConfig)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	ctx.Lock.Lock()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	txAssetID := axc.Asset{ID: genesisTx.ID()}
+
+	// Entries indexed before the reindex are removed, even those that aren't
+	// overwritten by the reindexed txs
+	_ = setupTestTxsInDB(t, vm.db, addr, txAssetID.ID, 5)
+
+	// Each tx spends the output of the previous one, so the UTXOs consumed by
+	// the reindexed txs have been spent
+	utxoID := axc.UTXOID{TxID: ids.GenerateTestID()}
+	txIDs := []ids.ID(nil)
+	for i := 0; i < 4; i++ {
+		tx := buildTX(utxoID, txAssetID, addr)
+		if err := signTX(vm.parser.Codec(), tx, key); err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(vm.state.PutTx(tx.ID(), tx))
+		txIDs = append(txIDs, tx.ID())
+		utxoID = axc.UTXOID{TxID: tx.ID()}
+	}
+	assert.NoError(vm.db.Commit())
+
+	assert.NoError(vm.StartReindex())
+	assert.True(vm.reindexingAddressTxs)
+	complete, err := database.GetBool(vm.db, []byte("complete"))
+	assert.NoError(err)
+	assert.False(complete)
+
+	// The UTXO consumed by the first tx is unknown
+	assert.Error(vm.ReindexTx(txIDs[0]))
+	assert.NoError(vm.ReindexTx(txIDs[1]))
+
+	// The reindex is resumed after a restart, and the last reindexed tx may be
+	// reindexed again
+	vm.addressTxsIndexer, err = index.NewIndexer(vm.db, ctx.Log, "", prometheus.NewRegistry(), true)
+	assert.NoError(err)
+	vm.reindexingAddressTxs = false
+	assert.NoError(vm.ResumeReindex())
+	assert.True(vm.reindexingAddressTxs)
+	for _, txID := range txIDs[1:] {
+		assert.NoError(vm.ReindexTx(txID))
+	}
+	assert.NoError(vm.FinishReindex())
+	assert.False(vm.reindexingAddressTxs)
+	complete, err = database.GetBool(vm.db, []byte("complete"))
+	assert.NoError(err)
+	assert.True(complete)
+
+	for i, txID := range txIDs[1:] {
+		assertIndexedTX(t, vm.db, uint64(i), addr, txAssetID.ID, txID)
+	}
+	assertLatestIdx(t, vm.db, addr, txAssetID.ID, 3)
+
+	assetPrefixDB := prefixdb.New(txAssetID.ID[:], prefixdb.New(addr[:], vm.db))
+	has, err := assetPrefixDB.Has(database.PackUInt64(3))
+	assert.NoError(err)
+	assert.False(has)
+}
+
 func buildPlatformUTXO(utxoID axc.UTXOID, txAssetID axc.Asset, addr ids.ShortID) *axc.UTXO {
 	return &axc.UTXO{
 		UTXOID: utxoID,
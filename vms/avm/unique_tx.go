@@ -142,9 +142,12 @@ func (tx *UniqueTx) Accept() error {
 	}
 
 	outputUTXOs := tx.UTXOs()
-	// index input and output UTXOs
-	if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
-		return fmt.Errorf("error indexing tx: %w", err)
+	// index input and output UTXOs, unless the index is being rebuilt, in
+	// which case the tx is indexed by the rebuild
+	if !tx.vm.reindexingAddressTxs {
+		if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
+			return fmt.Errorf("error indexing tx: %w", err)
+		}
 	}
 
 	// Remove spent utxos
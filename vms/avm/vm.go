@@ -12,10 +12,9 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/cache"
 	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/manager"
@@ -43,6 +42,8 @@ import (
 
 	safemath "github.com/sankar-boro/axia-network-v2/utils/math"
 	extensions "github.com/sankar-boro/axia-network-v2/vms/avm/fxs"
+
+	avmpb "github.com/sankar-boro/axia-network-v2/proto/pb/avm"
 )
 
 const (
@@ -59,7 +60,9 @@ var (
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
 
-	_ vertex.DAGVM = &VM{}
+	_ vertex.DAGVM              = &VM{}
+	_ common.GRPCServiceCreator = &VM{}
+	_ index.Reindexer           = &VM{}
 )
 
 type VM struct {
@@ -106,6 +109,9 @@ type VM struct {
 	axiawalletService AxiaWalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
+	// true if [addressTxsIndexer] is being rebuilt, in which case accepted
+	// txs are indexed by ReindexTx rather than when they're accepted
+	reindexingAddressTxs bool
 
 	uniqueTxs cache.Deduplicator
 }
@@ -294,7 +300,7 @@ func (vm *VM) Version() (string, error) {
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	codec := json.NewCodec()
 
-	rpcServer := rpc.NewServer()
+	rpcServer := schema.NewServer()
 	rpcServer.RegisterCodec(codec, "application/json")
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	rpcServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -304,7 +310,7 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		return nil, err
 	}
 
-	axiawalletServer := rpc.NewServer()
+	axiawalletServer := schema.NewServer()
 	axiawalletServer.RegisterCodec(codec, "application/json")
 	axiawalletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	axiawalletServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -319,8 +325,20 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	}, err
 }
 
+// CreateGRPCServices returns the gRPC services that mirror the handlers
+// returned by CreateHandlers
+func (vm *VM) CreateGRPCServices() ([]*common.GRPCService, error) {
+	return []*common.GRPCService{
+		{
+			Name: "avm",
+			Desc: &avmpb.AVM_ServiceDesc,
+			Impl: &grpcServer{service: &Service{vm: vm}},
+		},
+	}, nil
+}
+
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := schema.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -546,6 +564,77 @@ func (vm *VM) getUTXO(utxoID *axc.UTXOID) (*axc.UTXO, error) {
 	return parentUTXOs[int(inputIndex)], nil
 }
 
+// StartReindex starts rebuilding the address transaction index.
+// See index.Reindexer
+func (vm *VM) StartReindex() error {
+	if err := vm.addressTxsIndexer.StartReindex(); err != nil {
+		vm.db.Abort()
+		return err
+	}
+	vm.reindexingAddressTxs = true
+	return vm.db.Commit()
+}
+
+// ResumeReindex continues rebuilding the address transaction index where the
+// previous run stopped.
+// See index.Reindexer
+func (vm *VM) ResumeReindex() error {
+	if err := vm.addressTxsIndexer.ResumeReindex(); err != nil {
+		vm.db.Abort()
+		return err
+	}
+	vm.reindexingAddressTxs = true
+	return vm.db.Commit()
+}
+
+// ReindexTx adds the accepted tx [txID] to the address transaction index.
+// See index.Reindexer
+func (vm *VM) ReindexTx(txID ids.ID) error {
+	defer vm.db.Abort()
+
+	tx, err := vm.state.GetTx(txID)
+	if err != nil {
+		return fmt.Errorf("couldn't get tx %s: %w", txID, err)
+	}
+
+	// The input UTXOs may have been spent, so they're fetched from the txs
+	// that produced them
+	inputUTXOIDs := tx.InputUTXOs()
+	inputUTXOs := make([]*axc.UTXO, 0, len(inputUTXOIDs))
+	for _, utxoID := range inputUTXOIDs {
+		if utxoID.Symbolic() {
+			continue
+		}
+
+		inputTxID, inputIndex := utxoID.InputSource()
+		inputTx, err := vm.state.GetTx(inputTxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get tx %s that produced UTXO %s: %w", inputTxID, utxoID, err)
+		}
+		inputTxUTXOs := inputTx.UTXOs()
+		if uint32(len(inputTxUTXOs)) <= inputIndex {
+			return fmt.Errorf("%w: %s", errInvalidUTXO, utxoID)
+		}
+		inputUTXOs = append(inputUTXOs, inputTxUTXOs[inputIndex])
+	}
+
+	if err := vm.addressTxsIndexer.Accept(txID, inputUTXOs, tx.UTXOs()); err != nil {
+		return fmt.Errorf("error indexing tx %s: %w", txID, err)
+	}
+	return vm.db.Commit()
+}
+
+// FinishReindex marks the rebuilt address transaction index as complete.
+// See index.Reindexer
+func (vm *VM) FinishReindex() error {
+	if err := vm.addressTxsIndexer.FinishReindex(); err != nil {
+		vm.db.Abort()
+		return err
+	}
+	vm.reindexingAddressTxs = false
+	return vm.db.Commit()
+}
+
 func (vm *VM) getFx(val interface{}) (int, error) {
 	valType := reflect.TypeOf(val)
 	fx, exists := vm.typeToFxIndex[valType]
@@ -30,6 +30,10 @@ const (
 type UTXOState interface {
 	UTXOReader
 	UTXOWriter
+
+	// Flush drops all the cached UTXOs and address indices. It must be called
+	// after the database was modified without going through this UTXOState.
+	Flush()
 }
 
 // UTXOReader is a thin wrapper around a database to provide fetching of UTXOs.
@@ -210,6 +214,11 @@ func (s *utxoState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, err
 	return utxoIDs, iter.Error()
 }
 
+func (s *utxoState) Flush() {
+	s.utxoCache.Flush()
+	s.indexCache.Flush()
+}
+
 func (s *utxoState) getIndexDB(addr []byte) linkeddb.LinkedDB {
 	addrStr := string(addr)
 	if indexList, exists := s.indexCache.Get(addrStr); exists {
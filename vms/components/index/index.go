@@ -20,8 +20,13 @@ import (
 )
 
 var (
-	idxKey                         = []byte("idx")
-	idxCompleteKey                 = []byte("complete")
+	idxKey               = []byte("idx")
+	idxCompleteKey       = []byte("complete")
+	reindexingKey        = []byte("reindexing")
+	reindexGenerationKey = []byte("reindexGeneration")
+	// Key, in the database of an address and asset, of the generation of the
+	// reindex that last indexed a transaction of the address and asset
+	generationKey                  = []byte("generation")
 	errIndexingRequiredFromGenesis = errors.New("running would create incomplete index. Allow incomplete indices or re-sync from genesis with indexing enabled")
 	errCausesIncompleteIndex       = errors.New("running would create incomplete index. Allow incomplete indices or enable indexing")
 
@@ -29,6 +34,28 @@ var (
 	_ AddressTxsIndexer = &noIndexer{}
 )
 
+// Reindexer is implemented by VMs whose AddressTxsIndexer can be rebuilt from
+// the transactions they have accepted.
+type Reindexer interface {
+	// StartReindex starts rebuilding the index. Until FinishReindex is called,
+	// transactions accepted by the VM aren't indexed. Instead, ReindexTx must be
+	// called for every accepted transaction, in order of acceptance.
+	StartReindex() error
+
+	// ResumeReindex continues the reindex started in a previous run, or
+	// starts a new one if there isn't one to continue. ReindexTx must then be
+	// called for the accepted transactions that haven't been reindexed.
+	ResumeReindex() error
+
+	// ReindexTx indexes the accepted transaction [txID]. Reindexing the last
+	// reindexed transaction again has no effect.
+	ReindexTx(txID ids.ID) error
+
+	// FinishReindex marks the rebuilt index as complete and resumes indexing
+	// the transactions accepted by the VM.
+	FinishReindex() error
+}
+
 // AddressTxsIndexer maintains information about which transactions changed
 // the balances of which addresses. This includes both transactions that
 // increase and decrease an address's balance.
@@ -52,12 +79,31 @@ type AddressTxsIndexer interface {
 	// The length of the returned slice <= [pageSize].
 	// [cursor] is the offset to start reading from.
 	Read(address []byte, assetID ids.ID, cursor, pageSize uint64) ([]ids.ID, error)
+
+	// StartReindex causes the following calls to Accept to rebuild the index
+	// from scratch. Accept must then be called for every accepted transaction,
+	// in order of acceptance. The index is incomplete until FinishReindex is
+	// called.
+	StartReindex() error
+
+	// ResumeReindex continues the reindex started in a previous run, or starts
+	// a new one if there isn't one to continue.
+	ResumeReindex() error
+
+	// FinishReindex marks a rebuilt index as complete.
+	FinishReindex() error
 }
 
 type indexer struct {
 	log     logging.Logger
 	metrics metrics
 	db      database.Database
+	// True if the index is being rebuilt
+	reindexing bool
+	// Generation of the reindex in progress. The transactions indexed for an
+	// address and asset by an older generation are replaced once the first
+	// of its transactions is reindexed.
+	reindexGeneration uint64
 }
 
 // NewIndexer returns a new AddressTxsIndexer.
@@ -91,6 +137,7 @@ func NewIndexer(
 // |  [assetID]
 // |  |
 // |  | "idx" => 2 		Running transaction index key, represents the next index
+// |  | "generation" => 1	Generation of the reindex that last indexed a transaction
 // |  | "0"   => txID1
 // |  | "1"   => txID1
 // See interface documentation AddressTxsIndexer.Accept
@@ -129,6 +176,16 @@ func (i *indexer) Accept(txID ids.ID, inputUTXOs []*axc.UTXO, outputUTXOs []*axc
 		for assetID := range assetIDs {
 			assetPrefixDB := prefixdb.New(assetID[:], addressPrefixDB)
 
+			if i.reindexing {
+				reindexed, err := i.prepareReindex(assetPrefixDB, txID)
+				if err != nil {
+					return fmt.Errorf("failed to prepare reindex while indexing %s: %w", txID, err)
+				}
+				if reindexed {
+					continue
+				}
+			}
+
 			var idx uint64
 			idxBytes, err := assetPrefixDB.Get(idxKey)
 			switch err {
@@ -182,8 +239,9 @@ func (i *indexer) Read(address []byte, assetID ids.ID, cursor, pageSize uint64)
 
 	var txIDs []ids.ID
 	for uint64(len(txIDs)) < pageSize && iter.Next() {
-		if bytes.Equal(idxKey, iter.Key()) {
-			// This key has the next index to use, not a tx ID
+		if key := iter.Key(); bytes.Equal(idxKey, key) || bytes.Equal(generationKey, key) {
+			// This key has the next index to use or the reindex generation,
+			// not a tx ID
 			continue
 		}
 
@@ -199,6 +257,99 @@ func (i *indexer) Read(address []byte, assetID ids.ID, cursor, pageSize uint64)
 	return txIDs, nil
 }
 
+// prepareReindex returns true if [txID] is the last transaction reindexed for
+// the address and asset of [assetPrefixDB], which happens when the reindex was
+// interrupted and resumed. Otherwise, if [txID] is the first transaction of
+// the address and asset since the reindex started, the previously indexed
+// transactions are deleted.
+func (i *indexer) prepareReindex(assetPrefixDB database.Database, txID ids.ID) (bool, error) {
+	generation, err := database.GetUInt64(assetPrefixDB, generationKey)
+	switch {
+	case err == nil && generation == i.reindexGeneration:
+		idx, err := database.GetUInt64(assetPrefixDB, idxKey)
+		if err == database.ErrNotFound || idx == 0 {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		lastTxID, err := assetPrefixDB.Get(database.PackUInt64(idx - 1))
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(lastTxID, txID[:]), nil
+	case err != nil && err != database.ErrNotFound:
+		return false, err
+	}
+
+	if err := assetPrefixDB.DeleteRange(nil, nil); err != nil {
+		return false, err
+	}
+	return false, database.PutUInt64(assetPrefixDB, generationKey, i.reindexGeneration)
+}
+
+// StartReindex causes the following calls to Accept to rebuild the indexed
+// transactions. The transactions previously indexed for an address and asset
+// are deleted when the first transaction that changed its balance is accepted
+// again. Which addresses and assets were reindexed is tracked in the database
+// by the generation of the reindex, so the reindex can be resumed.
+// See AddressTxsIndexer
+func (i *indexer) StartReindex() error {
+	generation, err := database.GetUInt64(i.db, reindexGenerationKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	generation++
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		database.PutBool(i.db, idxCompleteKey, false),
+		database.PutBool(i.db, reindexingKey, true),
+		database.PutUInt64(i.db, reindexGenerationKey, generation),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+	i.reindexing = true
+	i.reindexGeneration = generation
+	return nil
+}
+
+// ResumeReindex continues the reindex that was in progress when the node
+// stopped, if any, so the transactions it reindexed are kept.
+// See AddressTxsIndexer
+func (i *indexer) ResumeReindex() error {
+	reindexing, err := database.GetBool(i.db, reindexingKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if !reindexing {
+		return i.StartReindex()
+	}
+	generation, err := database.GetUInt64(i.db, reindexGenerationKey)
+	if err != nil {
+		return err
+	}
+	i.reindexing = true
+	i.reindexGeneration = generation
+	return nil
+}
+
+// FinishReindex marks the rebuilt index as complete.
+// See AddressTxsIndexer
+func (i *indexer) FinishReindex() error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		database.PutBool(i.db, reindexingKey, false),
+		database.PutBool(i.db, idxCompleteKey, true),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+	i.reindexing = false
+	return nil
+}
+
 // checkIndexStatus checks the indexing status in the database, returning error if the state
 // with respect to provided parameters is invalid
 func checkIndexStatus(db database.KeyValueReaderWriter, enableIndexing, allowIncomplete bool) error {
@@ -249,3 +400,15 @@ func (i *noIndexer) Accept(ids.ID, []*axc.UTXO, []*axc.UTXO) error {
 func (i *noIndexer) Read([]byte, ids.ID, uint64, uint64) ([]ids.ID, error) {
 	return nil, nil
 }
+
+func (i *noIndexer) StartReindex() error {
+	return nil
+}
+
+func (i *noIndexer) ResumeReindex() error {
+	return nil
+}
+
+func (i *noIndexer) FinishReindex() error {
+	return nil
+}
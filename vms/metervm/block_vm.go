@@ -21,6 +21,8 @@ var (
 	_ block.BatchedChainVM       = &blockVM{}
 	_ block.HeightIndexedChainVM = &blockVM{}
 	_ block.StateSyncableVM      = &blockVM{}
+
+	_ common.GRPCServiceCreator = &blockVM{}
 )
 
 type blockVM struct {
@@ -146,3 +148,11 @@ func (vm *blockVM) LastAccepted() (ids.ID, error) {
 	vm.blockMetrics.lastAccepted.Observe(float64(end.Sub(start)))
 	return lastAcceptedID, err
 }
+
+func (vm *blockVM) CreateGRPCServices() ([]*common.GRPCService, error) {
+	creator, ok := vm.ChainVM.(common.GRPCServiceCreator)
+	if !ok {
+		return nil, nil
+	}
+	return creator.CreateGRPCServices()
+}
@@ -19,6 +19,8 @@ import (
 var (
 	_ vertex.DAGVM = &vertexVM{}
 	_ snowstorm.Tx = &meterTx{}
+
+	_ common.GRPCServiceCreator = &vertexVM{}
 )
 
 func NewVertexVM(vm vertex.DAGVM) vertex.DAGVM {
@@ -138,3 +140,11 @@ func (mtx *meterTx) Reject() error {
 	mtx.vm.vertexMetrics.reject.Observe(float64(end.Sub(start)))
 	return err
 }
+
+func (vm *vertexVM) CreateGRPCServices() ([]*common.GRPCService, error) {
+	creator, ok := vm.DAGVM.(common.GRPCServiceCreator)
+	if !ok {
+		return nil, nil
+	}
+	return creator.CreateGRPCServices()
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/status"
+)
+
+var (
+	// Prefix of the database of the address transaction index
+	addressTxsPrefix = []byte("addressTxs")
+	// Maps to the height of the last block whose txs were indexed
+	addressTxsHeightKey = []byte("height")
+
+	errIndexingWithStateSync = errors.New("address transaction indexing can't be enabled with state sync")
+)
+
+// indexAddressTxs indexes [txs], which are accepted by accepting the block at
+// [height], by the addresses whose balances they change. [onAcceptState] is
+// the state once the block is accepted. Must be called before [onAcceptState]
+// is applied to [vm.internalState].
+func (vm *VM) indexAddressTxs(height uint64, txs []*Tx, onAcceptState MutableState) error {
+	if !vm.chainConfig.IndexTransactions {
+		return nil
+	}
+
+	// The block may have been indexed before the node shut down without
+	// committing the block as accepted
+	lastHeight, err := database.GetUInt64(vm.addressTxsDB, addressTxsHeightKey)
+	switch {
+	case err == nil && lastHeight >= height:
+		return nil
+	case err != nil && err != database.ErrNotFound:
+		return fmt.Errorf("couldn't get height of the last indexed block: %w", err)
+	}
+
+	defer vm.addressTxsDB.Abort()
+
+	// UTXOs produced by the txs of the block, which may be consumed by the
+	// txs that follow them
+	produced := make(map[ids.ID]*axc.UTXO)
+	for _, tx := range txs {
+		// An aborted proposal tx doesn't change any balances, unless it
+		// removes a staker, in which case the stake is still returned
+		_, txStatus, err := onAcceptState.GetTx(tx.ID())
+		if err != nil {
+			return fmt.Errorf("couldn't get status of tx %s: %w", tx.ID(), err)
+		}
+		if _, ok := tx.UnsignedTx.(*UnsignedRewardValidatorTx); txStatus == status.Aborted && !ok {
+			continue
+		}
+
+		inputUTXOs, err := vm.addressTxsInputs(tx, produced)
+		if err != nil {
+			return err
+		}
+		outputUTXOs, err := vm.addressTxsOutputs(tx, onAcceptState)
+		if err != nil {
+			return err
+		}
+		for _, utxo := range outputUTXOs {
+			produced[utxo.InputID()] = utxo
+		}
+
+		if err := vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
+			return fmt.Errorf("error indexing tx %s: %w", tx.ID(), err)
+		}
+	}
+
+	if err := database.PutUInt64(vm.addressTxsDB, addressTxsHeightKey, height); err != nil {
+		return fmt.Errorf("couldn't put height of the last indexed block: %w", err)
+	}
+	return vm.addressTxsDB.Commit()
+}
+
+// addressTxsInputs returns the UTXOs consumed by [tx]. [produced] are the
+// UTXOs produced by the txs accepted in the same block before [tx].
+func (vm *VM) addressTxsInputs(tx *Tx, produced map[ids.ID]*axc.UTXO) ([]*axc.UTXO, error) {
+	var (
+		ins      []*axc.TransferableInput
+		imported []*axc.UTXO
+	)
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		ins = utx.Ins
+	case *UnsignedAddNominatorTx:
+		ins = utx.Ins
+	case *UnsignedAddAllychainValidatorTx:
+		ins = utx.Ins
+	case *UnsignedCreateChainTx:
+		ins = utx.Ins
+	case *UnsignedCreateAllychainTx:
+		ins = utx.Ins
+	case *UnsignedExportTx:
+		ins = utx.Ins
+	case *UnsignedImportTx:
+		ins = utx.Ins
+		// The imported UTXOs are removed from shared memory once the tx is
+		// accepted, so they're fetched on a best effort basis
+		utxoIDs := make([][]byte, len(utx.ImportedInputs))
+		for i, in := range utx.ImportedInputs {
+			utxoID := in.InputID()
+			utxoIDs[i] = utxoID[:]
+		}
+		allUTXOBytes, err := vm.ctx.SharedMemory.Get(utx.SourceChain, utxoIDs)
+		if err != nil {
+			vm.ctx.Log.Debug("couldn't get the UTXOs imported by tx %s: %s", tx.ID(), err)
+			break
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &axc.UTXO{}
+			if _, err := Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+			}
+			imported = append(imported, utxo)
+		}
+	}
+
+	utxos := make([]*axc.UTXO, 0, len(ins)+len(imported))
+	for _, in := range ins {
+		utxoID := in.InputID()
+		if utxo, ok := produced[utxoID]; ok {
+			utxos = append(utxos, utxo)
+			continue
+		}
+		utxo, err := vm.internalState.GetUTXO(utxoID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get UTXO %s consumed by tx %s: %w", utxoID, tx.ID(), err)
+		}
+		utxos = append(utxos, utxo)
+	}
+	return append(utxos, imported...), nil
+}
+
+// addressTxsOutputs returns the UTXOs produced by [tx]. The stake of a staker
+// is included when the staker is added, as well as when the staker is removed,
+// when the stake is returned along with any reward.
+func (vm *VM) addressTxsOutputs(tx *Tx, onAcceptState MutableState) ([]*axc.UTXO, error) {
+	txID := tx.ID()
+	var outs []*axc.TransferableOutput
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddNominatorTx:
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.Stake...)
+	case *UnsignedAddAllychainValidatorTx:
+		outs = utx.Outs
+	case *UnsignedCreateChainTx:
+		outs = utx.Outs
+	case *UnsignedCreateAllychainTx:
+		outs = utx.Outs
+	case *UnsignedExportTx:
+		outs = utx.Outs
+	case *UnsignedImportTx:
+		outs = utx.Outs
+	case *UnsignedRewardValidatorTx:
+		return vm.rewardUTXOs(utx, onAcceptState)
+	}
+
+	utxos := make([]*axc.UTXO, len(outs))
+	for i, out := range outs {
+		utxos[i] = &axc.UTXO{
+			UTXOID: axc.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(i),
+			},
+			Asset: axc.Asset{ID: out.AssetID()},
+			Out:   out.Output(),
+		}
+	}
+	return utxos, nil
+}
+
+// rewardUTXOs returns the UTXOs produced by removing the staker of [utx],
+// which are the returned stake and the rewards, if any.
+func (vm *VM) rewardUTXOs(utx *UnsignedRewardValidatorTx, onAcceptState MutableState) ([]*axc.UTXO, error) {
+	stakerTx, _, err := vm.internalState.GetTx(utx.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get staker tx %s: %w", utx.TxID, err)
+	}
+	var numOuts, numStake int
+	switch uStakerTx := stakerTx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		numOuts, numStake = len(uStakerTx.Outs), len(uStakerTx.Stake)
+	case *UnsignedAddNominatorTx:
+		numOuts, numStake = len(uStakerTx.Outs), len(uStakerTx.Stake)
+	default:
+		return nil, errWrongTxType
+	}
+
+	// The stake is returned after the outputs of the staker tx, followed by
+	// the reward of the staker and, for a nominator, of its validator
+	var utxos []*axc.UTXO
+	for i := numOuts; i < numOuts+numStake+2; i++ {
+		utxoID := axc.UTXOID{
+			TxID:        utx.TxID,
+			OutputIndex: uint32(i),
+		}
+		utxo, err := onAcceptState.GetUTXO(utxoID.InputID())
+		switch err {
+		case nil:
+			utxos = append(utxos, utxo)
+		case database.ErrNotFound:
+		default:
+			return nil, fmt.Errorf("couldn't get UTXO %s: %w", utxoID.InputID(), err)
+		}
+	}
+	return utxos, nil
+}
@@ -150,6 +150,10 @@ func (ab *AtomicBlock) Accept() error {
 		return errWrongTxType
 	}
 
+	if err := ab.vm.indexAddressTxs(ab.Height(), []*Tx{&ab.Tx}, ab.onAcceptState); err != nil {
+		return fmt.Errorf("failed to index tx of block %s: %w", blkID, err)
+	}
+
 	// Update the state of the chain in the database
 	ab.onAcceptState.Apply(ab.vm.internalState)
 
@@ -185,6 +189,7 @@ func (ab *AtomicBlock) Accept() error {
 		}
 	}
 
+	ab.vm.summaryBuilder.accepted(ab)
 	ab.free()
 	return nil
 }
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow/consensus/snowman"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+)
+
+func (vm *VM) GetAncestors(
+	blkID ids.ID,
+	maxBlocksNum int,
+	maxBlocksSize int,
+	maxBlocksRetrivalTime time.Duration,
+) ([][]byte, error) {
+	startTime := time.Now()
+	blk, err := vm.getBlock(blkID)
+	if err == database.ErrNotFound {
+		// Blocks below the height of a state summary were never fetched, so
+		// an empty response is returned to signal that the ancestors are
+		// unavailable.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ancestorsBytes := make([][]byte, 1, maxBlocksNum)
+	ancestorsBytes[0] = blk.Bytes()
+	ancestorsBytesLen := len(blk.Bytes()) + wrappers.IntLen
+	for numFetched := 1; numFetched < maxBlocksNum && time.Since(startTime) < maxBlocksRetrivalTime; numFetched++ {
+		if blk, err = vm.getBlock(blk.Parent()); err != nil {
+			break
+		}
+		blkBytes := blk.Bytes()
+		newLen := ancestorsBytesLen + len(blkBytes) + wrappers.IntLen
+		if newLen > maxBlocksSize {
+			break
+		}
+		ancestorsBytes = append(ancestorsBytes, blkBytes)
+		ancestorsBytesLen = newLen
+	}
+	return ancestorsBytes, nil
+}
+
+func (vm *VM) BatchedParseBlock(blks [][]byte) ([]snowman.Block, error) {
+	blocks := make([]snowman.Block, len(blks))
+	for i, blkBytes := range blks {
+		blk, err := vm.ParseBlock(blkBytes)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = blk
+	}
+	return blocks, nil
+}
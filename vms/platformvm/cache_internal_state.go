@@ -37,6 +37,7 @@ var (
 	allychainValidatorPrefix = []byte("allychainValidator")
 	validatorDiffsPrefix  = []byte("validatorDiffs")
 	blockPrefix           = []byte("block")
+	blockHeightPrefix     = []byte("blockHeight")
 	txPrefix              = []byte("tx")
 	rewardUTXOsPrefix     = []byte("rewardUTXOs")
 	utxoPrefix            = []byte("utxo")
@@ -44,10 +45,12 @@ var (
 	chainPrefix           = []byte("chain")
 	singletonPrefix       = []byte("singleton")
 
-	timestampKey     = []byte("timestamp")
-	currentSupplyKey = []byte("current supply")
-	lastAcceptedKey  = []byte("last accepted")
-	initializedKey   = []byte("initialized")
+	timestampKey       = []byte("timestamp")
+	currentSupplyKey   = []byte("current supply")
+	lastAcceptedKey    = []byte("last accepted")
+	initializedKey     = []byte("initialized")
+	stateSyncHeightKey = []byte("state sync height")
+	heightIndexKey     = []byte("height index checkpoint")
 
 	errWrongNetworkID = errors.New("tx has wrong network ID")
 
@@ -68,6 +71,11 @@ const (
 	rewardUTXOsCacheSize    = 2048
 	chainCacheSize          = 2048
 	chainDBCacheSize        = 2048
+
+	// heightIndexCommitFrequency is the number of blocks that are indexed by
+	// height before the index is committed.
+	heightIndexCommitFrequency = 1024
+	heightIndexLogFrequency    = 15 * time.Second
 )
 
 type InternalState interface {
@@ -93,6 +101,19 @@ type InternalState interface {
 	GetBlock(blockID ids.ID) (Block, error)
 	AddBlock(block Block)
 
+	// GetBlockIDAtHeight returns the ID of the accepted block at [height].
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+
+	// GetStateSyncHeight returns the height of the state summary this state
+	// was synced to, or 0 if the state was built by executing every block.
+	GetStateSyncHeight() uint64
+	SetStateSyncHeight(height uint64)
+
+	// Reload drops all the cached and uncommitted state and reloads the
+	// state from the database. It must be called after the database was
+	// modified underneath the state.
+	Reload() error
+
 	Abort()
 	Commit() error
 	CommitBatch() (database.Batch, error)
@@ -128,6 +149,8 @@ type InternalState interface {
  * |       '-- nodeID -> weightChange
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. blockHeights
+ * | '-- height -> blockID
  * |-. txs
  * | '-- txID -> tx bytes + tx status
  * |- rewardUTXOs
@@ -147,7 +170,8 @@ type InternalState interface {
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- stateSyncHeightKey -> stateSyncHeight
  */
 type internalStateImpl struct {
 	vm *VM
@@ -188,6 +212,8 @@ type internalStateImpl struct {
 	blockCache  cache.Cacher     // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
 
+	blockHeightDB database.Database
+
 	addedTxs map[ids.ID]*txStatusImpl // map of txID -> {*Tx, Status}
 	txCache  cache.Cacher             // cache of txID -> {*Tx, Status} if the entry is nil, it is not in the database
 	txDB     database.Database
@@ -210,10 +236,11 @@ type internalStateImpl struct {
 	chainDBCache cache.Cacher     // cache of allychainID -> linkedDB
 	chainDB      database.Database
 
-	originalTimestamp, timestamp         time.Time
-	originalCurrentSupply, currentSupply uint64
-	originalLastAccepted, lastAccepted   ids.ID
-	singletonDB                          database.Database
+	originalTimestamp, timestamp             time.Time
+	originalCurrentSupply, currentSupply     uint64
+	originalLastAccepted, lastAccepted       ids.ID
+	originalStateSyncHeight, stateSyncHeight uint64
+	singletonDB                              database.Database
 }
 
 type ValidatorWeightDiff struct {
@@ -284,6 +311,8 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 		addedBlocks: make(map[ids.ID]Block),
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
 
+		blockHeightDB: prefixdb.New(blockHeightPrefix, baseDB),
+
 		addedTxs: make(map[ids.ID]*txStatusImpl),
 		txDB:     prefixdb.New(txPrefix, baseDB),
 
@@ -405,9 +434,96 @@ func (st *internalStateImpl) sync(genesis []byte) error {
 			err,
 		)
 	}
+
+	if err := st.indexBlockHeights(); err != nil {
+		return fmt.Errorf(
+			"failed to index the accepted blocks by height: %w",
+			err,
+		)
+	}
 	return nil
 }
 
+// indexBlockHeights adds the accepted blocks that were persisted before the
+// height index existed to the height index. Blocks are walked from the last
+// accepted block back to the first block that is already indexed.
+//
+// The index is committed periodically along with a checkpoint, the highest
+// block that isn't indexed yet, from which an interrupted walk resumes.
+func (st *internalStateImpl) indexBlockHeights() error {
+	blkID, err := database.GetID(st.singletonDB, heightIndexKey)
+	resuming := err == nil
+	switch {
+	case err == database.ErrNotFound:
+		blkID = st.lastAccepted
+	case err != nil:
+		return err
+	default:
+		st.vm.ctx.Log.Info("resuming indexing the accepted blocks by height from %s", blkID)
+	}
+
+	var (
+		start       = time.Now()
+		lastLogTime = start
+		indexed     = 0
+	)
+	for {
+		blk, err := st.GetBlock(blkID)
+		if err == database.ErrNotFound {
+			// The state was synced, so older blocks aren't available.
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		height := blk.Height()
+		has, err := st.blockHeightDB.Has(database.PackUInt64(height))
+		if err != nil {
+			return err
+		}
+		if has {
+			break
+		}
+
+		// Keep the memory footprint bounded by committing the pending index
+		// entries. [blkID] isn't indexed yet, so it is the checkpoint.
+		if indexed > 0 && indexed%heightIndexCommitFrequency == 0 {
+			if err := database.PutID(st.singletonDB, heightIndexKey, blkID); err != nil {
+				return err
+			}
+			if err := st.Commit(); err != nil {
+				return err
+			}
+			st.vm.ctx.Log.Debug("indexed %d accepted blocks by height", indexed)
+		}
+
+		if err := database.PutID(st.blockHeightDB, database.PackUInt64(height), blkID); err != nil {
+			return err
+		}
+		indexed++
+
+		if now := time.Now(); now.Sub(lastLogTime) > heightIndexLogFrequency {
+			lastLogTime = now
+			st.vm.ctx.Log.Info("indexed %d accepted blocks by height, last height = %d", indexed, height)
+		}
+
+		if height == 0 {
+			break
+		}
+		blkID = blk.Parent()
+	}
+	if indexed == 0 && !resuming {
+		return nil
+	}
+
+	if err := st.singletonDB.Delete(heightIndexKey); err != nil {
+		return err
+	}
+	st.vm.ctx.Log.Info("indexed %d accepted blocks by height in %s", indexed, time.Since(start))
+	return st.Commit()
+}
+
 func NewInternalState(vm *VM, db database.Database, genesis []byte) (InternalState, error) {
 	is := newInternalStateDatabases(vm, db)
 	is.initCaches()
@@ -448,6 +564,9 @@ func (st *internalStateImpl) SetCurrentSupply(currentSupply uint64) { st.current
 func (st *internalStateImpl) GetLastAccepted() ids.ID             { return st.lastAccepted }
 func (st *internalStateImpl) SetLastAccepted(lastAccepted ids.ID) { st.lastAccepted = lastAccepted }
 
+func (st *internalStateImpl) GetStateSyncHeight() uint64       { return st.stateSyncHeight }
+func (st *internalStateImpl) SetStateSyncHeight(height uint64) { st.stateSyncHeight = height }
+
 func (st *internalStateImpl) GetAllychains() ([]*Tx, error) {
 	if st.cachedAllychains != nil {
 		return st.cachedAllychains, nil
@@ -673,6 +792,15 @@ func (st *internalStateImpl) AddBlock(block Block) {
 	st.addedBlocks[block.ID()] = block
 }
 
+func (st *internalStateImpl) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	for blkID, blk := range st.addedBlocks {
+		if blk.Status() == choices.Accepted && blk.Height() == height {
+			return blkID, nil
+		}
+	}
+	return database.GetID(st.blockHeightDB, database.PackUInt64(height))
+}
+
 func (st *internalStateImpl) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
 	return st.utxoState.UTXOIDs(addr, start, limit)
 }
@@ -787,6 +915,42 @@ func (st *internalStateImpl) Abort() {
 	st.baseDB.Abort()
 }
 
+func (st *internalStateImpl) Reload() error {
+	st.Abort()
+
+	st.addedCurrentStakers = nil
+	st.deletedCurrentStakers = nil
+	st.addedPendingStakers = nil
+	st.deletedPendingStakers = nil
+	st.uptimes = make(map[ids.NodeID]*currentValidatorState)
+	st.updatedUptimes = make(map[ids.NodeID]struct{})
+	st.addedBlocks = make(map[ids.ID]Block)
+	st.addedTxs = make(map[ids.ID]*txStatusImpl)
+	st.addedRewardUTXOs = make(map[ids.ID][]*axc.UTXO)
+	st.modifiedUTXOs = make(map[ids.ID]*axc.UTXO)
+	st.cachedAllychains = nil
+	st.addedAllychains = nil
+	st.addedChains = make(map[ids.ID][]*Tx)
+
+	st.validatorDiffsCache.Flush()
+	st.blockCache.Flush()
+	st.txCache.Flush()
+	st.rewardUTXOsCache.Flush()
+	st.chainCache.Flush()
+	st.chainDBCache.Flush()
+	st.utxoState.Flush()
+
+	// The linked lists cache their nodes, so they must be recreated.
+	st.currentValidatorList = linkeddb.NewDefault(st.currentValidatorBaseDB)
+	st.currentNominatorList = linkeddb.NewDefault(st.currentNominatorBaseDB)
+	st.currentAllychainValidatorList = linkeddb.NewDefault(st.currentAllychainValidatorBaseDB)
+	st.pendingValidatorList = linkeddb.NewDefault(st.pendingValidatorBaseDB)
+	st.pendingNominatorList = linkeddb.NewDefault(st.pendingNominatorBaseDB)
+	st.pendingAllychainValidatorList = linkeddb.NewDefault(st.pendingAllychainValidatorBaseDB)
+	st.allychainDB = linkeddb.NewDefault(st.allychainBaseDB)
+	return st.load()
+}
+
 func (st *internalStateImpl) Commit() error {
 	defer st.Abort()
 	batch, err := st.CommitBatch()
@@ -843,6 +1007,7 @@ func (st *internalStateImpl) Close() error {
 		st.currentValidatorsDB.Close(),
 		st.validatorsDB.Close(),
 		st.blockDB.Close(),
+		st.blockHeightDB.Close(),
 		st.txDB.Close(),
 		st.rewardUTXODB.Close(),
 		st.utxoDB.Close(),
@@ -1136,6 +1301,13 @@ func (st *internalStateImpl) writeBlocks() error {
 		if err := st.blockDB.Put(blkID[:], btxBytes); err != nil {
 			return err
 		}
+
+		if blk.Status() != choices.Accepted {
+			continue
+		}
+		if err := database.PutID(st.blockHeightDB, database.PackUInt64(blk.Height()), blkID); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1248,6 +1420,12 @@ func (st *internalStateImpl) writeSingletons() error {
 		}
 		st.originalLastAccepted = st.lastAccepted
 	}
+	if st.originalStateSyncHeight != st.stateSyncHeight {
+		if err := database.PutUInt64(st.singletonDB, stateSyncHeightKey, st.stateSyncHeight); err != nil {
+			return err
+		}
+		st.originalStateSyncHeight = st.stateSyncHeight
+	}
 	return nil
 }
 
@@ -1283,6 +1461,18 @@ func (st *internalStateImpl) loadSingletons() error {
 	st.originalLastAccepted = lastAccepted
 	st.lastAccepted = lastAccepted
 
+	stateSyncHeight, err := database.GetUInt64(st.singletonDB, stateSyncHeightKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		// The state was never synced.
+		stateSyncHeight = 0
+	default:
+		return err
+	}
+	st.originalStateSyncHeight = stateSyncHeight
+	st.stateSyncHeight = stateSyncHeight
+
 	return nil
 }
 
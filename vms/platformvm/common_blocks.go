@@ -305,6 +305,10 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 		return fmt.Errorf("failed to accept CommonBlock: %w", err)
 	}
 
+	if err := ddb.vm.indexAddressTxs(ddb.Height(), []*Tx{&parent.Tx}, ddb.onAcceptState); err != nil {
+		return fmt.Errorf("failed to index parent's tx: %w", err)
+	}
+
 	// Update the state of the chain in the database
 	ddb.onAcceptState.Apply(ddb.vm.internalState)
 	if err := ddb.vm.internalState.Commit(); err != nil {
@@ -320,6 +324,8 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 		}
 	}
 
+	ddb.vm.summaryBuilder.accepted(ddb.self)
+
 	// remove this block and its parent from memory
 	parent.free()
 	ddb.free()
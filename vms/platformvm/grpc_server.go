@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sankar-boro/axia-network-v2/api"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils/formatting"
+
+	platformpb "github.com/sankar-boro/axia-network-v2/proto/pb/platform"
+)
+
+// Txs and blocks are exchanged with [service] hex encoded and are converted
+// from and to raw bytes over gRPC.
+const grpcEncoding = formatting.Hex
+
+var (
+	errUnexpectedEncodedType = errors.New("unexpected type of encoded value")
+
+	_ platformpb.PlatformServer = &grpcServer{}
+)
+
+// grpcServer serves the platform API over gRPC by calling into [service]
+type grpcServer struct {
+	platformpb.UnsafePlatformServer
+	service *Service
+}
+
+func (s *grpcServer) GetHeight(context.Context, *emptypb.Empty) (*platformpb.GetHeightResponse, error) {
+	reply := GetHeightResponse{}
+	if err := s.service.GetHeight(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetHeightResponse{
+		Height: uint64(reply.Height),
+	}, nil
+}
+
+func (s *grpcServer) GetTimestamp(context.Context, *emptypb.Empty) (*platformpb.GetTimestampResponse, error) {
+	reply := GetTimestampReply{}
+	if err := s.service.GetTimestamp(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetTimestampResponse{
+		Timestamp: timestamppb.New(reply.Timestamp),
+	}, nil
+}
+
+func (s *grpcServer) GetCurrentSupply(context.Context, *emptypb.Empty) (*platformpb.GetCurrentSupplyResponse, error) {
+	reply := GetCurrentSupplyReply{}
+	if err := s.service.GetCurrentSupply(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetCurrentSupplyResponse{
+		Supply: uint64(reply.Supply),
+	}, nil
+}
+
+func (s *grpcServer) GetBalance(_ context.Context, req *platformpb.GetBalanceRequest) (*platformpb.GetBalanceResponse, error) {
+	reply := GetBalanceResponse{}
+	if err := s.service.GetBalance(nil, &GetBalanceRequest{Addresses: req.Addresses}, &reply); err != nil {
+		return nil, err
+	}
+	utxoIDs := make([]*platformpb.UTXOID, len(reply.UTXOIDs))
+	for i, utxoID := range reply.UTXOIDs {
+		utxoIDs[i] = &platformpb.UTXOID{
+			TxId:        utxoID.TxID[:],
+			OutputIndex: utxoID.OutputIndex,
+		}
+	}
+	return &platformpb.GetBalanceResponse{
+		Balance:            uint64(reply.Balance),
+		Unlocked:           uint64(reply.Unlocked),
+		LockedStakeable:    uint64(reply.LockedStakeable),
+		LockedNotStakeable: uint64(reply.LockedNotStakeable),
+		UtxoIds:            utxoIDs,
+	}, nil
+}
+
+func (s *grpcServer) IssueTx(_ context.Context, req *platformpb.IssueTxRequest) (*platformpb.IssueTxResponse, error) {
+	txStr, err := formatting.EncodeWithChecksum(grpcEncoding, req.Tx)
+	if err != nil {
+		return nil, err
+	}
+	reply := api.JSONTxID{}
+	args := &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: grpcEncoding,
+	}
+	if err := s.service.IssueTx(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.IssueTxResponse{
+		TxId: reply.TxID[:],
+	}, nil
+}
+
+func (s *grpcServer) GetTx(_ context.Context, req *platformpb.GetTxRequest) (*platformpb.GetTxResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, err
+	}
+	reply := api.GetTxReply{}
+	args := &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: grpcEncoding,
+	}
+	if err := s.service.GetTx(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	txBytes, err := decodeReply(reply.Tx, reply.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &platformpb.GetTxResponse{
+		Tx: txBytes,
+	}, nil
+}
+
+func (s *grpcServer) GetTxStatus(_ context.Context, req *platformpb.GetTxStatusRequest) (*platformpb.GetTxStatusResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, err
+	}
+	reply := GetTxStatusResponse{}
+	if err := s.service.GetTxStatus(nil, &GetTxStatusArgs{TxID: txID}, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetTxStatusResponse{
+		Status: reply.Status.String(),
+		Reason: reply.Reason,
+	}, nil
+}
+
+func (s *grpcServer) GetBlock(_ context.Context, req *platformpb.GetBlockRequest) (*platformpb.GetBlockResponse, error) {
+	blockID, err := ids.ToID(req.BlockId)
+	if err != nil {
+		return nil, err
+	}
+	reply := api.GetBlockResponse{}
+	args := &api.GetBlockArgs{
+		BlockID:  blockID,
+		Encoding: grpcEncoding,
+	}
+	if err := s.service.GetBlock(nil, args, &reply); err != nil {
+		return nil, err
+	}
+	blockBytes, err := decodeReply(reply.Block, reply.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &platformpb.GetBlockResponse{
+		Block: blockBytes,
+	}, nil
+}
+
+// decodeReply returns the bytes that [value], a string encoded with [enc], is
+// the encoding of.
+func decodeReply(value interface{}, enc formatting.Encoding) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, errUnexpectedEncodedType
+	}
+	return formatting.Decode(enc, str)
+}
@@ -0,0 +1,16 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/sankar-boro/axia-network-v2/ids"
+)
+
+// VerifyHeightIndex always succeeds, as the height index is completed when the
+// internal state is loaded.
+func (vm *VM) VerifyHeightIndex() error { return nil }
+
+func (vm *VM) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	return vm.internalState.GetBlockIDAtHeight(height)
+}
@@ -12,6 +12,8 @@ var _ Handler = NoopHandler{}
 
 type Handler interface {
 	HandleTx(nodeID ids.NodeID, requestID uint32, msg *Tx) error
+	HandleStateRequest(nodeID ids.NodeID, requestID uint32, msg *StateRequest) error
+	HandleStateResponse(nodeID ids.NodeID, requestID uint32, msg *StateResponse) error
 }
 
 type NoopHandler struct {
@@ -26,3 +28,21 @@ func (h NoopHandler) HandleTx(nodeID ids.NodeID, requestID uint32, _ *Tx) error
 	)
 	return nil
 }
+
+func (h NoopHandler) HandleStateRequest(nodeID ids.NodeID, requestID uint32, _ *StateRequest) error {
+	h.Log.Debug(
+		"dropping unexpected StateRequest message from %s with requestID %s",
+		nodeID,
+		requestID,
+	)
+	return nil
+}
+
+func (h NoopHandler) HandleStateResponse(nodeID ids.NodeID, requestID uint32, _ *StateResponse) error {
+	h.Log.Debug(
+		"dropping unexpected StateResponse message from %s with requestID %s",
+		nodeID,
+		requestID,
+	)
+	return nil
+}
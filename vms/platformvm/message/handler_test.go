@@ -13,7 +13,9 @@ import (
 )
 
 type CounterHandler struct {
-	Tx int
+	Tx            int
+	StateRequest  int
+	StateResponse int
 }
 
 func (h *CounterHandler) HandleTx(ids.NodeID, uint32, *Tx) error {
@@ -21,6 +23,16 @@ func (h *CounterHandler) HandleTx(ids.NodeID, uint32, *Tx) error {
 	return nil
 }
 
+func (h *CounterHandler) HandleStateRequest(ids.NodeID, uint32, *StateRequest) error {
+	h.StateRequest++
+	return nil
+}
+
+func (h *CounterHandler) HandleStateResponse(ids.NodeID, uint32, *StateResponse) error {
+	h.StateResponse++
+	return nil
+}
+
 func TestHandleTx(t *testing.T) {
 	assert := assert.New(t)
 
@@ -32,6 +44,28 @@ func TestHandleTx(t *testing.T) {
 	assert.Equal(1, handler.Tx)
 }
 
+func TestHandleStateRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := CounterHandler{}
+	msg := StateRequest{}
+
+	err := msg.Handle(&handler, ids.EmptyNodeID, 0)
+	assert.NoError(err)
+	assert.Equal(1, handler.StateRequest)
+}
+
+func TestHandleStateResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := CounterHandler{}
+	msg := StateResponse{}
+
+	err := msg.Handle(&handler, ids.EmptyNodeID, 0)
+	assert.NoError(err)
+	assert.Equal(1, handler.StateResponse)
+}
+
 func TestNoopHandler(t *testing.T) {
 	assert := assert.New(t)
 
@@ -41,4 +75,10 @@ func TestNoopHandler(t *testing.T) {
 
 	err := handler.HandleTx(ids.EmptyNodeID, 0, nil)
 	assert.NoError(err)
+
+	err = handler.HandleStateRequest(ids.EmptyNodeID, 0, nil)
+	assert.NoError(err)
+
+	err = handler.HandleStateResponse(ids.EmptyNodeID, 0, nil)
+	assert.NoError(err)
 }
@@ -11,6 +11,8 @@ import (
 
 var (
 	_ Message = &Tx{}
+	_ Message = &StateRequest{}
+	_ Message = &StateResponse{}
 
 	errUnexpectedCodecVersion = errors.New("unexpected codec version")
 )
@@ -43,6 +45,43 @@ func (msg *Tx) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) erro
 	return handler.HandleTx(nodeID, requestID, msg)
 }
 
+// StateRequest asks a peer for the key/value pairs of the platform chain
+// state at [Height]. The peer serves the namespaces in order, starting the
+// first one at [Start], until the response is full.
+type StateRequest struct {
+	message
+
+	Height     uint64     `serialize:"true"`
+	Namespaces [][][]byte `serialize:"true"`
+	Start      []byte     `serialize:"true"`
+}
+
+func (msg *StateRequest) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleStateRequest(nodeID, requestID, msg)
+}
+
+// StateChunk holds a sorted range of key/value pairs of one namespace. If
+// [Done] is false, the namespace contains more keys after the last key of
+// this chunk.
+type StateChunk struct {
+	Keys   [][]byte `serialize:"true"`
+	Values [][]byte `serialize:"true"`
+	Done   bool     `serialize:"true"`
+}
+
+// StateResponse answers a StateRequest with one chunk per served namespace,
+// in the order the namespaces were requested. An empty response means the
+// peer couldn't serve the requested height.
+type StateResponse struct {
+	message
+
+	Chunks []StateChunk `serialize:"true"`
+}
+
+func (msg *StateResponse) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleStateResponse(nodeID, requestID, msg)
+}
+
 func Parse(bytes []byte) (Message, error) {
 	var msg Message
 	version, err := c.Unmarshal(bytes, &msg)
@@ -33,6 +33,63 @@ func TestTx(t *testing.T) {
 	assert.Equal(tx, parsedMsg.Tx)
 }
 
+func TestStateRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	builtMsg := StateRequest{
+		Height: 1337,
+		Namespaces: [][][]byte{
+			{[]byte("tx")},
+			{[]byte("validators"), []byte("current"), []byte("validator")},
+		},
+		Start: utils.RandomBytes(32),
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	assert.NoError(err)
+	assert.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	assert.NoError(err)
+	assert.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*StateRequest)
+	assert.True(ok)
+
+	assert.Equal(builtMsg.Height, parsedMsg.Height)
+	assert.Equal(builtMsg.Namespaces, parsedMsg.Namespaces)
+	assert.Equal(builtMsg.Start, parsedMsg.Start)
+}
+
+func TestStateResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	builtMsg := StateResponse{
+		Chunks: []StateChunk{
+			{
+				Keys:   [][]byte{utils.RandomBytes(32)},
+				Values: [][]byte{utils.RandomBytes(units.KiB)},
+				Done:   true,
+			},
+			{
+				Keys:   [][]byte{utils.RandomBytes(32)},
+				Values: [][]byte{utils.RandomBytes(units.KiB)},
+			},
+		},
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	assert.NoError(err)
+	assert.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	assert.NoError(err)
+	assert.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*StateResponse)
+	assert.True(ok)
+
+	assert.Equal(builtMsg.Chunks, parsedMsg.Chunks)
+}
+
 func TestParseGibberish(t *testing.T) {
 	assert := assert.New(t)
 
@@ -254,6 +254,21 @@ func (mr *MockInternalStateMockRecorder) GetBlock(blockID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockInternalState)(nil).GetBlock), blockID)
 }
 
+// GetBlockIDAtHeight mocks base method.
+func (m *MockInternalState) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockIDAtHeight", height)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockIDAtHeight indicates an expected call of GetBlockIDAtHeight.
+func (mr *MockInternalStateMockRecorder) GetBlockIDAtHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockInternalState)(nil).GetBlockIDAtHeight), height)
+}
+
 // GetChains mocks base method.
 func (m *MockInternalState) GetChains(allychainID ids.ID) ([]*Tx, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +357,20 @@ func (mr *MockInternalStateMockRecorder) GetAllychains() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllychains", reflect.TypeOf((*MockInternalState)(nil).GetAllychains))
 }
 
+// GetStateSyncHeight mocks base method.
+func (m *MockInternalState) GetStateSyncHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStateSyncHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetStateSyncHeight indicates an expected call of GetStateSyncHeight.
+func (mr *MockInternalStateMockRecorder) GetStateSyncHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateSyncHeight", reflect.TypeOf((*MockInternalState)(nil).GetStateSyncHeight))
+}
+
 // GetTimestamp mocks base method.
 func (m *MockInternalState) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -432,6 +461,20 @@ func (mr *MockInternalStateMockRecorder) PendingStakerChainState() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStakerChainState", reflect.TypeOf((*MockInternalState)(nil).PendingStakerChainState))
 }
 
+// Reload mocks base method.
+func (m *MockInternalState) Reload() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockInternalStateMockRecorder) Reload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockInternalState)(nil).Reload))
+}
+
 // SetCurrentStakerChainState mocks base method.
 func (m *MockInternalState) SetCurrentStakerChainState(arg0 currentStakerChainState) {
 	m.ctrl.T.Helper()
@@ -492,6 +535,18 @@ func (mr *MockInternalStateMockRecorder) SetPendingStakerChainState(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingStakerChainState", reflect.TypeOf((*MockInternalState)(nil).SetPendingStakerChainState), arg0)
 }
 
+// SetStateSyncHeight mocks base method.
+func (m *MockInternalState) SetStateSyncHeight(height uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStateSyncHeight", height)
+}
+
+// SetStateSyncHeight indicates an expected call of SetStateSyncHeight.
+func (mr *MockInternalStateMockRecorder) SetStateSyncHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStateSyncHeight", reflect.TypeOf((*MockInternalState)(nil).SetStateSyncHeight), height)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockInternalState) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -44,20 +44,71 @@ func newNetwork(activationTime time.Time, appSender common.AppSender, vm *VM) *n
 }
 
 func (n *network) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+	n.vm.stateSyncer.handleResponse(nodeID, requestID, nil, true)
 	return nil
 }
 
+// AppRequest serves the state of the retained state summaries to syncing
+// peers.
 func (n *network) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, msgBytes []byte) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
-	return nil
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.log.Debug("dropping AppRequest message due to failing to parse message")
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.StateRequest)
+	if !ok {
+		n.log.Debug(
+			"dropping unexpected message from %s",
+			nodeID,
+		)
+		return nil
+	}
+	if len(msg.Namespaces) > maxNamespacesPerStateRequest {
+		n.log.Debug("dropping StateRequest from %s with %d namespaces", nodeID, len(msg.Namespaces))
+		return nil
+	}
+	for _, namespace := range msg.Namespaces {
+		if err := verifyStateNamespace(namespace); err != nil {
+			n.log.Debug("dropping StateRequest from %s: %s", nodeID, err)
+			return nil
+		}
+	}
+
+	chunks, err := n.vm.summaryBuilder.readState(msg)
+	if err != nil {
+		return err
+	}
+
+	response, err := message.Build(&message.StateResponse{
+		Chunks: chunks,
+	})
+	if err != nil {
+		return fmt.Errorf("AppRequest: failed to build StateResponse message with: %w", err)
+	}
+	return n.appSender.SendAppResponse(nodeID, requestID, response)
 }
 
 func (n *network) AppResponse(nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.log.Debug("dropping AppResponse message due to failing to parse message")
+		n.vm.stateSyncer.handleResponse(nodeID, requestID, nil, true)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.StateResponse)
+	if !ok {
+		n.log.Debug(
+			"dropping unexpected message from %s",
+			nodeID,
+		)
+		n.vm.stateSyncer.handleResponse(nodeID, requestID, nil, true)
+		return nil
+	}
+
+	n.vm.stateSyncer.handleResponse(nodeID, requestID, msg.Chunks, false)
 	return nil
 }
 
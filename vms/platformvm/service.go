@@ -1954,6 +1954,57 @@ func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, resp
 	return nil
 }
 
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID defaulted to AXC if omitted or left blank
+	AssetID string `json:"assetID"`
+}
+
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns list of transactions for a given address
+func (service *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetAddressTxs called with address=%s, assetID=%s, cursor=%d, pageSize=%d", args.Address, args.AssetID, args.Cursor, args.PageSize)
+	pageSize := uint64(args.PageSize)
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	// Parse to address
+	address, err := axc.ParseServiceAddress(service.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	assetID := service.vm.ctx.AXCAssetID
+	if args.AssetID != "" {
+		assetID, err = ids.FromString(args.AssetID)
+		if err != nil {
+			return fmt.Errorf("specified `assetID` is invalid: %w", err)
+		}
+	}
+
+	cursor := uint64(args.Cursor)
+	reply.TxIDs, err = service.vm.addressTxsIndexer.Read(address[:], assetID, cursor, pageSize)
+	if err != nil {
+		return err
+	}
+
+	// To get the next set of tx IDs, the user should provide this cursor.
+	reply.Cursor = json.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	Encoding formatting.Encoding `json:"encoding"`
@@ -14,6 +14,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sankar-boro/axia-network-v2/api"
@@ -27,8 +29,10 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/formatting"
 	"github.com/sankar-boro/axia-network-v2/utils/json"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
+	"github.com/sankar-boro/axia-network-v2/utils/password"
 	"github.com/sankar-boro/axia-network-v2/version"
 	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/components/index"
 	"github.com/sankar-boro/axia-network-v2/vms/platformvm/status"
 	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
 
@@ -63,7 +67,7 @@ func defaultService(t *testing.T) *Service {
 	vm, _, _ := defaultVM()
 	vm.ctx.Lock.Lock()
 	defer vm.ctx.Lock.Unlock()
-	ks := keystore.New(logging.NoLog{}, manager.NewMemDB(version.DefaultVersion1_0_0))
+	ks := keystore.New(logging.NoLog{}, manager.NewMemDB(version.DefaultVersion1_0_0), password.DefaultParams)
 	if err := ks.CreateUser(testUsername, testPassword); err != nil {
 		t.Fatal(err)
 	}
@@ -802,3 +806,89 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAddressTxs(t *testing.T) {
+	assert := assert.New(t)
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// The genesis block was accepted before indexing was enabled
+	var err error
+	service.vm.chainConfig.IndexTransactions = true
+	service.vm.addressTxsIndexer, err = index.NewIndexer(service.vm.addressTxsDB, logging.NoLog{}, "", prometheus.NewRegistry(), true)
+	assert.NoError(err)
+
+	// Accept an export tx in an atomic block
+	exportTx, err := service.vm.newExportTx(
+		100,
+		service.vm.ctx.SwapChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(service.vm.blockBuilder.AddUnverifiedTx(exportTx))
+	blk, err := service.vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	// Accept an add validator tx in a proposal block, staking to a new address
+	stakeAddr := ids.GenerateTestShortID()
+	addValidatorTx, err := service.vm.newAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(service.vm.clock.Time().Add(syncBound).Unix()),
+		uint64(service.vm.clock.Time().Add(syncBound).Add(defaultMinStakingDuration).Unix()),
+		ids.GenerateTestNodeID(),
+		stakeAddr,
+		0,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(service.vm.blockBuilder.AddUnverifiedTx(addValidatorTx))
+	blk, err = service.vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+	options, err := blk.(*ProposalBlock).Options()
+	assert.NoError(err)
+	commit := options[0].(*CommitBlock)
+	assert.NoError(commit.Verify())
+	assert.NoError(commit.Accept())
+
+	addr, err := service.vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	args := &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr},
+	}
+	reply := &GetAddressTxsReply{}
+	assert.NoError(service.GetAddressTxs(nil, args, reply))
+	assert.Equal([]ids.ID{exportTx.ID(), addValidatorTx.ID()}, reply.TxIDs)
+	assert.EqualValues(2, reply.Cursor)
+
+	// Pages are read from the cursor
+	args.Cursor = 1
+	args.PageSize = 1
+	reply = &GetAddressTxsReply{}
+	assert.NoError(service.GetAddressTxs(nil, args, reply))
+	assert.Equal([]ids.ID{addValidatorTx.ID()}, reply.TxIDs)
+	assert.EqualValues(2, reply.Cursor)
+
+	// The reward address doesn't receive anything until the staker is removed
+	addr, err = service.vm.FormatLocalAddress(stakeAddr)
+	assert.NoError(err)
+	args = &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr},
+	}
+	reply = &GetAddressTxsReply{}
+	assert.NoError(service.GetAddressTxs(nil, args, reply))
+	assert.Empty(reply.TxIDs)
+
+	args.PageSize = maxPageSize + 1
+	assert.Error(service.GetAddressTxs(nil, args, reply))
+}
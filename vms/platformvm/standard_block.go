@@ -194,6 +194,10 @@ func (sb *StandardBlock) Accept() error {
 		return fmt.Errorf("failed to accept CommonDecisionBlock: %w", err)
 	}
 
+	if err := sb.vm.indexAddressTxs(sb.Height(), sb.Txs, sb.onAcceptState); err != nil {
+		return fmt.Errorf("failed to index txs of block %s: %w", blkID, err)
+	}
+
 	// Update the state of the chain in the database
 	sb.onAcceptState.Apply(sb.vm.internalState)
 
@@ -220,6 +224,7 @@ func (sb *StandardBlock) Accept() error {
 		}
 	}
 
+	sb.vm.summaryBuilder.accepted(sb)
 	sb.free()
 	return nil
 }
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow/choices"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/snowman/block"
+	"github.com/sankar-boro/axia-network-v2/utils/hashing"
+)
+
+var (
+	_ block.StateSummary = &stateSummary{}
+
+	errWrongSummaryBlockType = errors.New("state summary block must be a decision block")
+)
+
+// stateSummary commits to the platform chain state right after [BlockBytes]
+// was accepted. The values of the singletons are carried by the summary, all
+// the other state is fetched from peers and verified against [StateHash].
+type stateSummary struct {
+	BlockBytes    []byte `serialize:"true"`
+	Timestamp     uint64 `serialize:"true"`
+	CurrentSupply uint64 `serialize:"true"`
+	StateHash     ids.ID `serialize:"true"`
+
+	id    ids.ID
+	bytes []byte
+	block Block
+	vm    *VM
+}
+
+func (s *stateSummary) ID() ids.ID     { return s.id }
+func (s *stateSummary) Height() uint64 { return s.block.Height() }
+func (s *stateSummary) Bytes() []byte  { return s.bytes }
+
+func (s *stateSummary) Accept() (bool, error) {
+	lastAccepted, err := s.vm.getBlock(s.vm.lastAcceptedID)
+	if err != nil {
+		return false, err
+	}
+
+	// If we have already executed up to or past this state summary, there is
+	// nothing to sync.
+	if lastAccepted.Height() >= s.Height() {
+		return false, nil
+	}
+	return true, s.vm.startStateSync(s)
+}
+
+func (s *stateSummary) timestamp() time.Time {
+	return time.Unix(int64(s.Timestamp), 0)
+}
+
+// initialize the non-serialized fields of the summary. The summary block is
+// marked as accepted, as the summary can only be built from, or synced to, an
+// accepted block.
+func (s *stateSummary) initialize(vm *VM, bytes []byte) error {
+	var blk Block
+	if _, err := Codec.Unmarshal(s.BlockBytes, &blk); err != nil {
+		return err
+	}
+	if _, ok := blk.(decision); !ok {
+		// The chain can only be resumed from a decision block, as the options
+		// of a proposal block require the state it was verified against.
+		return errWrongSummaryBlockType
+	}
+	if err := blk.initialize(vm, s.BlockBytes, choices.Accepted, blk); err != nil {
+		return err
+	}
+
+	s.id = hashing.ComputeHash256Array(bytes)
+	s.bytes = bytes
+	s.block = blk
+	s.vm = vm
+	return nil
+}
+
+func (vm *VM) newStateSummary(blk Block, timestamp time.Time, currentSupply uint64, stateHash ids.ID) (*stateSummary, error) {
+	summary := &stateSummary{
+		BlockBytes:    blk.Bytes(),
+		Timestamp:     uint64(timestamp.Unix()),
+		CurrentSupply: currentSupply,
+		StateHash:     stateHash,
+	}
+	bytes, err := Codec.Marshal(CodecVersion, summary)
+	if err != nil {
+		return nil, err
+	}
+	return summary, summary.initialize(vm, bytes)
+}
+
+func (vm *VM) parseStateSummary(bytes []byte) (*stateSummary, error) {
+	summary := &stateSummary{}
+	if _, err := Codec.Unmarshal(bytes, summary); err != nil {
+		return nil, err
+	}
+	return summary, summary.initialize(vm, bytes)
+}
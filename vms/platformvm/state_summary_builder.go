@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/nodb"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/message"
+)
+
+const (
+	// stateSummaryFrequency is the number of heights between the state
+	// summaries that are built. Every node builds its summaries at the same
+	// heights, so that the summary of one node can be voted on by the others.
+	stateSummaryFrequency = 1024
+
+	// maxStateSummaries is the number of the most recent state summaries whose
+	// state is retained, so that peers can keep fetching the state of a
+	// summary after newer blocks were accepted.
+	maxStateSummaries = 2
+)
+
+var _ database.Database = &snapshotDB{}
+
+// retainedStateSummary is a state summary along with a snapshot of the state
+// it commits to
+type retainedStateSummary struct {
+	summary  *stateSummary
+	snapshot database.Snapshot
+}
+
+// stateSummaryBuilder builds the state summaries of the first decision block
+// accepted at or after every multiple of [frequency]. The state hash of a
+// summary is calculated in the background from a snapshot of the state, so
+// that the state isn't locked while it's hashed.
+type stateSummaryBuilder struct {
+	vm *VM
+	// db is the database the internal state is built on
+	db database.Database
+	// frequency is the number of heights between summaries
+	frequency uint64
+
+	// nextHeight is the height of the next summary to build
+	// Invariant: only accessed while [vm.ctx.Lock] is held
+	nextHeight uint64
+	// building is true while a summary is being built
+	building utils.AtomicBool
+
+	lock sync.RWMutex
+	// the most recent summaries, by increasing height
+	summaries []*retainedStateSummary
+
+	closed chan struct{}
+	done   sync.WaitGroup
+}
+
+func newStateSummaryBuilder(vm *VM, db database.Database) *stateSummaryBuilder {
+	return &stateSummaryBuilder{
+		vm:        vm,
+		db:        db,
+		frequency: stateSummaryFrequency,
+		closed:    make(chan struct{}),
+	}
+}
+
+// accepted is called once [blk], a decision block, was accepted and the state
+// was committed. If a summary is due, a snapshot of the state is taken and the
+// summary is built in the background.
+//
+// Invariant: [vm.ctx.Lock] is held.
+func (b *stateSummaryBuilder) accepted(blk Block) {
+	height := blk.Height()
+	if b.nextHeight == 0 {
+		// The first summary is built at the next multiple of [frequency], so
+		// that it's built at the same height as the summaries of other nodes.
+		b.nextHeight = (height + b.frequency - 1) / b.frequency * b.frequency
+	}
+	if height < b.nextHeight {
+		return
+	}
+	b.nextHeight = (height/b.frequency + 1) * b.frequency
+	if b.building.GetValue() {
+		b.vm.ctx.Log.Warn("skipping the state summary at height %d as the previous summary is still being built", height)
+		return
+	}
+
+	snapshot, err := b.newSnapshot()
+	if err != nil {
+		b.vm.ctx.Log.Warn("couldn't take a snapshot of the state at height %d: %s", height, err)
+		return
+	}
+	timestamp := b.vm.internalState.GetTimestamp()
+	currentSupply := b.vm.internalState.GetCurrentSupply()
+
+	b.building.SetValue(true)
+	b.done.Add(1)
+	go b.build(blk, timestamp, currentSupply, snapshot)
+}
+
+func (b *stateSummaryBuilder) newSnapshot() (database.Snapshot, error) {
+	snapshotter, ok := b.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrSnapshotNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
+
+func (b *stateSummaryBuilder) build(blk Block, timestamp time.Time, currentSupply uint64, snapshot database.Snapshot) {
+	defer b.done.Done()
+	defer b.building.SetValue(false)
+
+	summary, err := b.newSummary(blk, timestamp, currentSupply, snapshot)
+	if err != nil {
+		snapshot.Release()
+		select {
+		case <-b.closed:
+		default:
+			b.vm.ctx.Log.Warn("couldn't build the state summary at height %d: %s", blk.Height(), err)
+		}
+		return
+	}
+	b.vm.ctx.Log.Debug("built state summary %s at height %d", summary.ID(), summary.Height())
+	b.add(summary, snapshot)
+}
+
+func (b *stateSummaryBuilder) newSummary(blk Block, timestamp time.Time, currentSupply uint64, snapshot database.Snapshot) (*stateSummary, error) {
+	db := &snapshotDB{snapshot: snapshot}
+	namespaces, err := stateNamespaces(db, blk.Height())
+	if err != nil {
+		return nil, err
+	}
+	stateHash, err := stateHash(db, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return b.vm.newStateSummary(blk, timestamp, currentSupply, stateHash)
+}
+
+// add [summary] as the most recent summary. [snapshot] is released once the
+// summary is no longer retained. Summaries that aren't above the most recent
+// summary are dropped.
+func (b *stateSummaryBuilder) add(summary *stateSummary, snapshot database.Snapshot) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	select {
+	case <-b.closed:
+		snapshot.Release()
+		return
+	default:
+	}
+
+	if len(b.summaries) > 0 && b.summaries[len(b.summaries)-1].summary.Height() >= summary.Height() {
+		snapshot.Release()
+		return
+	}
+
+	b.summaries = append(b.summaries, &retainedStateSummary{
+		summary:  summary,
+		snapshot: snapshot,
+	})
+	if len(b.summaries) > maxStateSummaries {
+		b.summaries[0].snapshot.Release()
+		b.summaries[0] = nil
+		b.summaries = b.summaries[1:]
+	}
+}
+
+// addSynced retains [summary], whose state was just synced into the database,
+// as the most recent summary.
+//
+// Invariant: [vm.ctx.Lock] is held.
+func (b *stateSummaryBuilder) addSynced(summary *stateSummary) error {
+	snapshot, err := b.newSnapshot()
+	if err != nil {
+		return err
+	}
+	b.nextHeight = (summary.Height()/b.frequency + 1) * b.frequency
+	b.add(summary, snapshot)
+	return nil
+}
+
+// last returns the most recent summary, or [database.ErrNotFound] if no
+// summary was built yet.
+func (b *stateSummaryBuilder) last() (*stateSummary, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if len(b.summaries) == 0 {
+		return nil, database.ErrNotFound
+	}
+	return b.summaries[len(b.summaries)-1].summary, nil
+}
+
+// get returns the summary at [height], or [database.ErrNotFound] if it isn't
+// retained.
+func (b *stateSummaryBuilder) get(height uint64) (*stateSummary, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for _, retained := range b.summaries {
+		if retained.summary.Height() == height {
+			return retained.summary, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+// readState reads the state requested by [msg] from the snapshot of the
+// summary at the requested height. No chunks are returned if that summary
+// isn't retained.
+func (b *stateSummaryBuilder) readState(msg *message.StateRequest) ([]message.StateChunk, error) {
+	// The lock is held while reading so that the snapshot isn't released.
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for _, retained := range b.summaries {
+		if retained.summary.Height() == msg.Height {
+			return readStateChunks(
+				&snapshotDB{snapshot: retained.snapshot},
+				msg.Namespaces,
+				msg.Start,
+				maxStateResponseSize,
+			)
+		}
+	}
+	return nil, nil
+}
+
+// shutdown waits for the summary being built, if any, and releases the
+// retained snapshots.
+func (b *stateSummaryBuilder) shutdown() {
+	b.lock.Lock()
+	close(b.closed)
+	for _, retained := range b.summaries {
+		retained.snapshot.Release()
+	}
+	b.summaries = nil
+	b.lock.Unlock()
+
+	b.done.Wait()
+}
+
+// snapshotDB exposes a snapshot as a database, so that the state can be read
+// from the snapshot the same way it's read from the database. Writes fail with
+// [database.ErrClosed].
+type snapshotDB struct {
+	nodb.Database
+	snapshot database.Snapshot
+}
+
+func (db *snapshotDB) Has(key []byte) (bool, error) { return db.snapshot.Has(key) }
+
+func (db *snapshotDB) Get(key []byte) ([]byte, error) { return db.snapshot.Get(key) }
+
+func (db *snapshotDB) NewIterator() database.Iterator { return db.snapshot.NewIterator() }
+
+func (db *snapshotDB) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.snapshot.NewIteratorWithStart(start)
+}
+
+func (db *snapshotDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.snapshot.NewIteratorWithPrefix(prefix)
+}
+
+func (db *snapshotDB) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.snapshot.NewIteratorWithStartAndPrefix(start, prefix)
+}
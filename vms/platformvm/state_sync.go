@@ -0,0 +1,295 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/linkeddb"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils"
+	"github.com/sankar-boro/axia-network-v2/utils/constants"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/message"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/status"
+)
+
+const (
+	// validatorDiffsSyncWindow is the number of heights, up to and including
+	// the height of a state summary, whose validator weight diffs are synced.
+	// Validator sets can't be calculated for heights older than this window
+	// on a node that was state synced.
+	validatorDiffsSyncWindow = 256
+
+	// maxStateResponseSize is the maximum number of key/value bytes served in
+	// a single StateResponse.
+	maxStateResponseSize = 256 * units.KiB
+
+	maxStateNamespaceLen       = 3
+	maxStateNamespacePrefixLen = 64
+)
+
+var (
+	// utxoStatePrefix is the prefix used by axc.UTXOState to store the UTXOs
+	// in [utxoPrefix].
+	utxoStatePrefix = []byte("utxo")
+
+	utxoStateNamespace = [][]byte{utxoPrefix, utxoStatePrefix}
+
+	// validatorDiffsNamespace holds the validator weight diffs of every height
+	validatorDiffsNamespace = [][]byte{validatorsPrefix, validatorDiffsPrefix}
+
+	// staticStateNamespaces are the namespaces of the platform chain state
+	// that exist independently of the content of the state. They are synced
+	// first, as the remaining namespaces are derived from them.
+	staticStateNamespaces = [][][]byte{
+		{txPrefix},
+		{validatorsPrefix, currentPrefix, validatorPrefix},
+		{validatorsPrefix, currentPrefix, nominatorPrefix},
+		{validatorsPrefix, currentPrefix, allychainValidatorPrefix},
+		{validatorsPrefix, pendingPrefix, validatorPrefix},
+		{validatorsPrefix, pendingPrefix, nominatorPrefix},
+		{validatorsPrefix, pendingPrefix, allychainValidatorPrefix},
+		{allychainPrefix},
+		utxoStateNamespace,
+	}
+
+	errInvalidStateNamespace = errors.New("invalid state namespace")
+	errInvalidStateChunk     = errors.New("invalid state chunk")
+)
+
+// namespaceDB returns the database of [namespace] within [db]. [db] must be
+// the database that the internal state was built on, or a database with the
+// same layout.
+func namespaceDB(db database.Database, namespace [][]byte) database.Database {
+	if len(namespace) == 0 {
+		return db
+	}
+
+	// The internal state is built on a versiondb, so the first prefix must not
+	// be merged with any prefix of [db].
+	nsDB := prefixdb.NewNested(namespace[0], db)
+	for _, prefix := range namespace[1:] {
+		nsDB = prefixdb.New(prefix, nsDB)
+	}
+	return nsDB
+}
+
+// stateNamespaces returns all the namespaces that make up the platform chain
+// state at [height], in the order they are synced. The static namespaces of
+// [db] must already be populated.
+func stateNamespaces(db database.Database, height uint64) ([][][]byte, error) {
+	lowestDiffHeight := uint64(1)
+	if height > validatorDiffsSyncWindow {
+		lowestDiffHeight = height - validatorDiffsSyncWindow + 1
+	}
+	return stateNamespacesFrom(db, lowestDiffHeight, height)
+}
+
+// stateNamespacesFrom returns the namespaces of the platform chain state at
+// [height], including the validator weight diffs of every height from
+// [lowestDiffHeight].
+func stateNamespacesFrom(db database.Database, lowestDiffHeight, height uint64) ([][][]byte, error) {
+	allychainIDs := []ids.ID{constants.PrimaryNetworkID}
+	allychainList := linkeddb.NewDefault(namespaceDB(db, [][]byte{allychainPrefix}))
+	allychainIt := allychainList.NewIterator()
+	defer allychainIt.Release()
+	for allychainIt.Next() {
+		allychainID, err := ids.ToID(allychainIt.Key())
+		if err != nil {
+			return nil, err
+		}
+		allychainIDs = append(allychainIDs, allychainID)
+	}
+	if err := allychainIt.Error(); err != nil {
+		return nil, err
+	}
+	ids.SortIDs(allychainIDs)
+
+	namespaces := make([][][]byte, len(staticStateNamespaces), len(staticStateNamespaces)+len(allychainIDs))
+	copy(namespaces, staticStateNamespaces)
+
+	for _, allychainID := range allychainIDs {
+		allychainID := allychainID
+		namespaces = append(namespaces, [][]byte{chainPrefix, allychainID[:]})
+	}
+
+	// Only stakers can have been rewarded.
+	txIt := namespaceDB(db, [][]byte{txPrefix}).NewIterator()
+	defer txIt.Release()
+	for txIt.Next() {
+		stx := stateTx{}
+		if _, err := GenesisCodec.Unmarshal(txIt.Value(), &stx); err != nil {
+			return nil, err
+		}
+		if stx.Status != status.Committed {
+			continue
+		}
+
+		tx := Tx{}
+		if _, err := GenesisCodec.Unmarshal(stx.Tx, &tx); err != nil {
+			return nil, err
+		}
+		switch tx.UnsignedTx.(type) {
+		case *UnsignedAddValidatorTx, *UnsignedAddNominatorTx:
+			namespaces = append(namespaces, [][]byte{rewardUTXOsPrefix, utils.CopyBytes(txIt.Key())})
+		}
+	}
+	if err := txIt.Error(); err != nil {
+		return nil, err
+	}
+
+	for diffHeight := lowestDiffHeight; diffHeight <= height; diffHeight++ {
+		for _, allychainID := range allychainIDs {
+			prefixBytes, err := GenesisCodec.Marshal(CodecVersion, heightWithAllychain{
+				Height:      diffHeight,
+				AllychainID: allychainID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			namespaces = append(namespaces, [][]byte{validatorsPrefix, validatorDiffsPrefix, prefixBytes})
+		}
+	}
+	return namespaces, nil
+}
+
+// stateHash returns the hash of all the key/value pairs in [namespaces].
+func stateHash(db database.Database, namespaces [][][]byte) (ids.ID, error) {
+	hasher := sha256.New()
+	lenBytes := make([]byte, 4)
+	write := func(b []byte) {
+		binary.BigEndian.PutUint32(lenBytes, uint32(len(b)))
+		_, _ = hasher.Write(lenBytes)
+		_, _ = hasher.Write(b)
+	}
+
+	for _, namespace := range namespaces {
+		for _, prefix := range namespace {
+			write(prefix)
+		}
+
+		it := namespaceDB(db, namespace).NewIterator()
+		for it.Next() {
+			write(it.Key())
+			write(it.Value())
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return ids.ID{}, err
+		}
+	}
+
+	var hash ids.ID
+	copy(hash[:], hasher.Sum(nil))
+	return hash, nil
+}
+
+// readStateChunks reads the key/value pairs of [namespaces] in order, starting
+// the first namespace at [start], until [maxSize] bytes were read.
+func readStateChunks(db database.Database, namespaces [][][]byte, start []byte, maxSize int) ([]message.StateChunk, error) {
+	chunks := make([]message.StateChunk, 0, len(namespaces))
+	size := 0
+	for i, namespace := range namespaces {
+		if i > 0 {
+			start = nil
+		}
+
+		chunk := message.StateChunk{Done: true}
+		it := namespaceDB(db, namespace).NewIteratorWithStart(start)
+		for it.Next() {
+			key := it.Key()
+			value := it.Value()
+			if len(chunk.Keys) > 0 && size+len(key)+len(value) > maxSize {
+				chunk.Done = false
+				break
+			}
+
+			chunk.Keys = append(chunk.Keys, utils.CopyBytes(key))
+			chunk.Values = append(chunk.Values, utils.CopyBytes(value))
+			size += len(key) + len(value)
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+
+		chunks = append(chunks, chunk)
+		if !chunk.Done || size >= maxSize {
+			break
+		}
+	}
+	return chunks, nil
+}
+
+// verifyStateNamespace verifies that [namespace], received from a peer, only
+// refers to the platform chain state.
+func verifyStateNamespace(namespace [][]byte) error {
+	if len(namespace) == 0 || len(namespace) > maxStateNamespaceLen {
+		return errInvalidStateNamespace
+	}
+	for _, prefix := range namespace {
+		if len(prefix) > maxStateNamespacePrefixLen {
+			return errInvalidStateNamespace
+		}
+	}
+
+	switch first := namespace[0]; {
+	case bytes.Equal(first, txPrefix),
+		bytes.Equal(first, validatorsPrefix),
+		bytes.Equal(first, allychainPrefix),
+		bytes.Equal(first, utxoPrefix),
+		bytes.Equal(first, chainPrefix),
+		bytes.Equal(first, rewardUTXOsPrefix):
+		return nil
+	default:
+		return errInvalidStateNamespace
+	}
+}
+
+// verifyStateChunk verifies that the keys of [chunk] are sorted and start at
+// or after [start].
+func verifyStateChunk(chunk *message.StateChunk, start []byte) error {
+	if len(chunk.Keys) != len(chunk.Values) {
+		return errInvalidStateChunk
+	}
+	if !chunk.Done && len(chunk.Keys) == 0 {
+		return errInvalidStateChunk
+	}
+	previous := start
+	for i, key := range chunk.Keys {
+		if previous != nil && bytes.Compare(key, previous) < 0 {
+			return errInvalidStateChunk
+		}
+		if i > 0 && bytes.Equal(key, previous) {
+			return errInvalidStateChunk
+		}
+		previous = key
+	}
+	return nil
+}
+
+// namespaceKey returns a key that uniquely identifies [namespace]
+func namespaceKey(namespace [][]byte) string {
+	key := make([]byte, 0, len(namespace)*(1+maxStateNamespacePrefixLen))
+	for _, prefix := range namespace {
+		key = append(key, byte(len(prefix)))
+		key = append(key, prefix...)
+	}
+	return string(key)
+}
+
+// successor returns the smallest key that sorts after [key].
+func successor(key []byte) []byte {
+	next := make([]byte, len(key)+1)
+	copy(next, key)
+	return next
+}
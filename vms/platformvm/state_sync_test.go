@@ -0,0 +1,457 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/memdb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow/consensus/snowman"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
+	"github.com/sankar-boro/axia-network-v2/utils/crypto"
+	"github.com/sankar-boro/axia-network-v2/version"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/message"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/status"
+)
+
+func TestStateSync(t *testing.T) {
+	assert := assert.New(t)
+
+	server, _, serverSender := defaultVM()
+	client, _, clientSender := defaultVM()
+	defer func() {
+		server.ctx.Lock.Lock()
+		assert.NoError(server.Shutdown())
+		server.ctx.Lock.Unlock()
+
+		client.ctx.Lock.Lock()
+		assert.NoError(client.Shutdown())
+		client.ctx.Lock.Unlock()
+	}()
+
+	// Only the server learns about the new chain.
+	server.summaryBuilder.frequency = 1
+	server.summaryBuilder.nextHeight = 0
+	tx, blk := acceptCreateChainBlock(t, server, "name")
+	server.summaryBuilder.done.Wait()
+
+	serverNodeID := ids.GenerateTestNodeID()
+	clientNodeID := ids.GenerateTestNodeID()
+	clientSender.SendAppRequestF = func(_ ids.NodeIDSet, requestID uint32, request []byte) error {
+		go func() {
+			err := server.AppRequest(clientNodeID, requestID, time.Time{}, request)
+			assert.NoError(err)
+		}()
+		return nil
+	}
+	serverSender.SendAppResponseF = func(_ ids.NodeID, requestID uint32, response []byte) error {
+		return client.AppResponse(serverNodeID, requestID, response)
+	}
+	toEngine := make(chan common.Message, 1)
+	client.toEngine = toEngine
+	assert.NoError(client.Connected(serverNodeID, version.CurrentApp))
+
+	serverSummary, err := server.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(blk.Height(), serverSummary.Height())
+
+	summary, err := client.ParseStateSummary(serverSummary.Bytes())
+	assert.NoError(err)
+	assert.Equal(serverSummary.ID(), summary.ID())
+
+	client.ctx.Lock.Lock()
+	accepted, err := summary.Accept()
+	client.ctx.Lock.Unlock()
+	assert.NoError(err)
+	assert.True(accepted)
+
+	select {
+	case msg := <-toEngine:
+		assert.Equal(common.StateSyncDone, msg)
+	case <-time.After(10 * time.Second):
+		t.Fatal("state sync didn't finish")
+	}
+
+	client.ctx.Lock.Lock()
+	defer client.ctx.Lock.Unlock()
+
+	assert.Equal(blk.ID(), client.lastAcceptedID)
+	assert.Equal(blk.Height(), client.internalState.GetStateSyncHeight())
+	assert.Equal(server.internalState.GetTimestamp(), client.internalState.GetTimestamp())
+	assert.Equal(server.internalState.GetCurrentSupply(), client.internalState.GetCurrentSupply())
+
+	_, txStatus, err := client.internalState.GetTx(tx.ID())
+	assert.NoError(err)
+	assert.Equal(status.Committed, txStatus)
+
+	blkID, err := client.GetBlockIDAtHeight(blk.Height())
+	assert.NoError(err)
+	assert.Equal(blk.ID(), blkID)
+
+	clientSummary, err := client.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(serverSummary.ID(), clientSummary.ID())
+
+	ongoing, err := client.GetOngoingSyncStateSummary()
+	assert.Error(err)
+	assert.Nil(ongoing)
+}
+
+func TestStateSyncShutdownBeforeSwap(t *testing.T) {
+	assert := assert.New(t)
+
+	server, _, serverSender := defaultVM()
+	client, _, clientSender := defaultVM()
+	defer func() {
+		server.ctx.Lock.Lock()
+		assert.NoError(server.Shutdown())
+		server.ctx.Lock.Unlock()
+	}()
+
+	server.summaryBuilder.frequency = 1
+	server.summaryBuilder.nextHeight = 0
+	acceptCreateChainBlock(t, server, "name")
+	server.summaryBuilder.done.Wait()
+
+	serverNodeID := ids.GenerateTestNodeID()
+	clientNodeID := ids.GenerateTestNodeID()
+	clientSender.SendAppRequestF = func(_ ids.NodeIDSet, requestID uint32, request []byte) error {
+		go func() {
+			err := server.AppRequest(clientNodeID, requestID, time.Time{}, request)
+			assert.NoError(err)
+		}()
+		return nil
+	}
+	serverSender.SendAppResponseF = func(_ ids.NodeID, requestID uint32, response []byte) error {
+		return client.AppResponse(serverNodeID, requestID, response)
+	}
+	toEngine := make(chan common.Message, 1)
+	client.toEngine = toEngine
+	assert.NoError(client.Connected(serverNodeID, version.CurrentApp))
+
+	serverSummary, err := server.GetLastStateSummary()
+	assert.NoError(err)
+	summary, err := client.ParseStateSummary(serverSummary.Bytes())
+	assert.NoError(err)
+
+	// Hold the lock until the state was fetched and verified, so that the
+	// sync is blocked on acquiring the lock when the VM is shut down.
+	client.ctx.Lock.Lock()
+	accepted, err := summary.Accept()
+	assert.NoError(err)
+	assert.True(accepted)
+
+	for {
+		progress, err := client.stateSyncer.getProgress()
+		assert.NoError(err)
+		if progress.phase == stateSyncSwapping {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- client.Shutdown()
+	}()
+	select {
+	case err := <-shutdown:
+		assert.NoError(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("shutdown deadlocked with the state sync")
+	}
+	client.ctx.Lock.Unlock()
+
+	select {
+	case msg := <-toEngine:
+		t.Fatalf("unexpected message %s", msg)
+	default:
+	}
+}
+
+func TestStateSummaryRetention(t *testing.T) {
+	assert := assert.New(t)
+
+	server, _, serverSender := defaultVM()
+	client, _, clientSender := defaultVM()
+	defer func() {
+		server.ctx.Lock.Lock()
+		assert.NoError(server.Shutdown())
+		server.ctx.Lock.Unlock()
+
+		client.ctx.Lock.Lock()
+		assert.NoError(client.Shutdown())
+		client.ctx.Lock.Unlock()
+	}()
+
+	server.summaryBuilder.frequency = 1
+	server.summaryBuilder.nextHeight = 0
+	_, blk := acceptCreateChainBlock(t, server, "chain0")
+	server.summaryBuilder.done.Wait()
+	summary, err := server.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(blk.Height(), summary.Height())
+
+	// The state of the summary is still served once a newer block was
+	// accepted.
+	acceptCreateChainBlock(t, server, "chain1")
+	server.summaryBuilder.done.Wait()
+	allychainID := testAllychain1.ID()
+	request := &message.StateRequest{
+		Height:     summary.Height(),
+		Namespaces: [][][]byte{{chainPrefix, allychainID[:]}},
+	}
+	chunks, err := server.summaryBuilder.readState(request)
+	assert.NoError(err)
+	assert.Len(chunks, 1)
+	liveChunks, err := readStateChunks(server.summaryBuilder.db, request.Namespaces, nil, maxStateResponseSize)
+	assert.NoError(err)
+	assert.Len(liveChunks, 1)
+	assert.Less(len(chunks[0].Keys), len(liveChunks[0].Keys))
+
+	// The summary is no longer retained once enough newer summaries were
+	// built.
+	for i := 2; i <= maxStateSummaries; i++ {
+		acceptCreateChainBlock(t, server, fmt.Sprintf("chain%d", i))
+		server.summaryBuilder.done.Wait()
+	}
+	_, err = server.GetStateSummary(summary.Height())
+	assert.ErrorIs(err, database.ErrNotFound)
+	chunks, err = server.summaryBuilder.readState(request)
+	assert.NoError(err)
+	assert.Empty(chunks)
+
+	// A client syncing to the summary gives up on it, so that the engine can
+	// select a new summary.
+	serverNodeID := ids.GenerateTestNodeID()
+	clientNodeID := ids.GenerateTestNodeID()
+	clientSender.SendAppRequestF = func(_ ids.NodeIDSet, requestID uint32, request []byte) error {
+		go func() {
+			err := server.AppRequest(clientNodeID, requestID, time.Time{}, request)
+			assert.NoError(err)
+		}()
+		return nil
+	}
+	serverSender.SendAppResponseF = func(_ ids.NodeID, requestID uint32, response []byte) error {
+		return client.AppResponse(serverNodeID, requestID, response)
+	}
+	toEngine := make(chan common.Message, 1)
+	client.toEngine = toEngine
+	assert.NoError(client.Connected(serverNodeID, version.CurrentApp))
+
+	clientSummary, err := client.ParseStateSummary(summary.Bytes())
+	assert.NoError(err)
+	client.ctx.Lock.Lock()
+	accepted, err := clientSummary.Accept()
+	client.ctx.Lock.Unlock()
+	assert.NoError(err)
+	assert.True(accepted)
+
+	select {
+	case msg := <-toEngine:
+		assert.Equal(common.StateSyncRestart, msg)
+	case <-time.After(20 * time.Second):
+		t.Fatal("state sync didn't restart")
+	}
+
+	ongoing, err := client.GetOngoingSyncStateSummary()
+	assert.Error(err)
+	assert.Nil(ongoing)
+}
+
+// acceptCreateChainBlock issues a tx creating a chain named [name] in
+// [testAllychain1] and accepts the block that includes it.
+func acceptCreateChainBlock(t *testing.T, vm *VM, name string) (*Tx, snowman.Block) {
+	assert := assert.New(t)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	assert.NoError(vm.SetPreference(vm.lastAcceptedID))
+	tx, err := vm.newCreateChainTx(
+		testAllychain1.ID(),
+		nil,
+		ids.ID{'t', 'e', 's', 't', 'v', 'm'},
+		nil,
+		name,
+		[]*crypto.PrivateKeySECP256K1R{testAllychain1ControlKeys[0], testAllychain1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+	return tx, blk
+}
+
+func TestGetBlockIDAtHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lastAccepted, err := vm.getBlock(vm.lastAcceptedID)
+	assert.NoError(err)
+
+	for blk := lastAccepted; ; {
+		blkID, err := vm.GetBlockIDAtHeight(blk.Height())
+		assert.NoError(err)
+		assert.Equal(blk.ID(), blkID)
+
+		if blk.Height() == 0 {
+			break
+		}
+		blk, err = vm.getBlock(blk.Parent())
+		assert.NoError(err)
+	}
+
+	_, err = vm.GetBlockIDAtHeight(lastAccepted.Height() + 1)
+	assert.Error(err)
+}
+
+func TestIndexBlockHeights(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	defer func() {
+		vm.ctx.Lock.Lock()
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	acceptCreateChainBlock(t, vm, "chain0")
+	acceptCreateChainBlock(t, vm, "chain1")
+	acceptCreateChainBlock(t, vm, "chain2")
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	st := vm.internalState.(*internalStateImpl)
+	lastAccepted, err := vm.getBlock(vm.lastAcceptedID)
+	assert.NoError(err)
+	blkIDs := make([]ids.ID, lastAccepted.Height()+1)
+	for blk := lastAccepted; ; {
+		blkIDs[blk.Height()] = blk.ID()
+		if blk.Height() == 0 {
+			break
+		}
+		blk, err = vm.getBlock(blk.Parent())
+		assert.NoError(err)
+	}
+
+	checkIndexed := func() {
+		for height, blkID := range blkIDs {
+			indexedID, err := vm.GetBlockIDAtHeight(uint64(height))
+			assert.NoError(err)
+			assert.Equal(blkID, indexedID)
+		}
+		has, err := st.singletonDB.Has(heightIndexKey)
+		assert.NoError(err)
+		assert.False(has)
+	}
+	unindex := func(heights int) {
+		for height := 0; height < heights; height++ {
+			assert.NoError(st.blockHeightDB.Delete(database.PackUInt64(uint64(height))))
+		}
+	}
+
+	// The height index didn't exist.
+	unindex(len(blkIDs))
+	assert.NoError(st.Commit())
+	assert.NoError(st.indexBlockHeights())
+	checkIndexed()
+
+	// Indexing was interrupted after the blocks above the checkpoint were
+	// committed.
+	checkpoint := len(blkIDs) - 2
+	unindex(checkpoint + 1)
+	assert.NoError(database.PutID(st.singletonDB, heightIndexKey, blkIDs[checkpoint]))
+	assert.NoError(st.Commit())
+	assert.NoError(st.indexBlockHeights())
+	checkIndexed()
+}
+
+func TestReadStateChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	txDB := namespaceDB(db, [][]byte{txPrefix})
+	allychainDB := namespaceDB(db, [][]byte{allychainPrefix})
+	for _, key := range [][]byte{{1}, {2}, {3}} {
+		assert.NoError(txDB.Put(key, []byte{0, 0, 0}))
+	}
+	assert.NoError(allychainDB.Put([]byte{4}, []byte{0}))
+
+	namespaces := [][][]byte{{txPrefix}, {allychainPrefix}}
+
+	// The first response stops in the middle of the first namespace.
+	chunks, err := readStateChunks(db, namespaces, nil, 8)
+	assert.NoError(err)
+	assert.Len(chunks, 1)
+	assert.False(chunks[0].Done)
+	assert.Equal([][]byte{{1}, {2}}, chunks[0].Keys)
+	assert.NoError(verifyStateChunk(&chunks[0], nil))
+
+	// The second response finishes both namespaces.
+	start := successor(chunks[0].Keys[1])
+	chunks, err = readStateChunks(db, namespaces, start, 8)
+	assert.NoError(err)
+	assert.Len(chunks, 2)
+	assert.True(chunks[0].Done)
+	assert.Equal([][]byte{{3}}, chunks[0].Keys)
+	assert.True(chunks[1].Done)
+	assert.Equal([][]byte{{4}}, chunks[1].Keys)
+	assert.NoError(verifyStateChunk(&chunks[0], start))
+	assert.NoError(verifyStateChunk(&chunks[1], nil))
+
+	// Keys before the requested start are rejected.
+	assert.ErrorIs(verifyStateChunk(&message.StateChunk{
+		Keys:   [][]byte{{1}},
+		Values: [][]byte{{0}},
+		Done:   true,
+	}, start), errInvalidStateChunk)
+}
+
+func TestStateHash(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	namespaces := [][][]byte{{txPrefix}, {allychainPrefix}}
+	emptyHash, err := stateHash(db, namespaces)
+	assert.NoError(err)
+
+	// Moving a key to a different namespace changes the hash.
+	assert.NoError(namespaceDB(db, namespaces[0]).Put([]byte{1}, []byte{2}))
+	hash0, err := stateHash(db, namespaces)
+	assert.NoError(err)
+	assert.NotEqual(emptyHash, hash0)
+
+	assert.NoError(namespaceDB(db, namespaces[0]).Delete([]byte{1}))
+	assert.NoError(namespaceDB(db, namespaces[1]).Put([]byte{1}, []byte{2}))
+	hash1, err := stateHash(db, namespaces)
+	assert.NoError(err)
+	assert.NotEqual(hash0, hash1)
+}
+
+func TestVerifyStateNamespace(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(verifyStateNamespace([][]byte{txPrefix}))
+	assert.NoError(verifyStateNamespace(utxoStateNamespace))
+	assert.ErrorIs(verifyStateNamespace(nil), errInvalidStateNamespace)
+	assert.ErrorIs(verifyStateNamespace([][]byte{blockPrefix}), errInvalidStateNamespace)
+	assert.ErrorIs(verifyStateNamespace([][]byte{stateSyncPrefix}), errInvalidStateNamespace)
+}
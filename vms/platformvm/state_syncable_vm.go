@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/snowman/block"
+)
+
+func (vm *VM) StateSyncEnabled() (bool, error) {
+	return vm.chainConfig.StateSyncEnabled, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
+	summary, err := vm.stateSyncer.getOngoingSummary()
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// GetLastStateSummary returns the most recent state summary that was built.
+// Summaries are only built every [stateSummaryFrequency] heights.
+func (vm *VM) GetLastStateSummary() (block.StateSummary, error) {
+	summary, err := vm.summaryBuilder.last()
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	summary, err := vm.parseStateSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// GetStateSummary only serves the most recent summaries, as the state at
+// older heights isn't kept.
+func (vm *VM) GetStateSummary(height uint64) (block.StateSummary, error) {
+	summary, err := vm.summaryBuilder.get(height)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (vm *VM) startStateSync(summary *stateSummary) error {
+	vm.ctx.Log.Info("starting state sync to height %d", summary.Height())
+	return vm.stateSyncer.start(summary)
+}
+
+// resumeStateSyncSwap completes a state sync that was interrupted after the
+// synced state was verified.
+func (vm *VM) resumeStateSyncSwap() error {
+	summary, err := vm.stateSyncer.getOngoingSummary()
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	progress, err := vm.stateSyncer.getProgress()
+	if err != nil {
+		return err
+	}
+	if progress.phase != stateSyncSwapping {
+		return nil
+	}
+
+	vm.ctx.Log.Info("completing state sync to height %d", summary.Height())
+	vm.stateSyncer.summary = summary
+	vm.stateSyncer.progress = progress
+	return vm.applyStateSync(vm.stateSyncer)
+}
+
+// finishStateSync replaces the platform chain state with the verified state
+// fetched by [s] and resumes the chain from the summary block.
+//
+// Invariant: [vm.ctx.Lock] is held.
+func (vm *VM) finishStateSync(s *stateSyncer) error {
+	if err := vm.applyStateSync(s); err != nil {
+		return err
+	}
+	if err := vm.updateValidators(); err != nil {
+		return err
+	}
+	// Chains that were created by txs in the synced state must be started.
+	if err := vm.initBlockchains(); err != nil {
+		return err
+	}
+	return vm.SetPreference(vm.lastAcceptedID)
+}
+
+func (vm *VM) applyStateSync(s *stateSyncer) error {
+	vm.internalState.Abort()
+	if err := s.swap(); err != nil {
+		return err
+	}
+	if err := vm.internalState.Reload(); err != nil {
+		return err
+	}
+
+	summary := s.summary
+	blk := summary.block
+	vm.internalState.AddBlock(blk)
+	vm.internalState.SetLastAccepted(blk.ID())
+	vm.internalState.SetHeight(blk.Height())
+	vm.internalState.SetTimestamp(summary.timestamp())
+	vm.internalState.SetCurrentSupply(summary.CurrentSupply)
+	vm.internalState.SetStateSyncHeight(blk.Height())
+	if err := vm.internalState.Commit(); err != nil {
+		return err
+	}
+	if err := s.clear(); err != nil {
+		return err
+	}
+
+	vm.lastAcceptedID = blk.ID()
+	vm.currentBlocks = make(map[ids.ID]Block)
+
+	// The synced state can be served to peers right away.
+	if err := vm.summaryBuilder.addSynced(summary); err != nil {
+		vm.ctx.Log.Warn("couldn't retain the synced state summary: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,700 @@
+// Copyright (C) 2019-2021, Axia Systems, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sankar-boro/axia-network-v2/database"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
+	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/snow/engine/common"
+	"github.com/sankar-boro/axia-network-v2/utils/sampler"
+	"github.com/sankar-boro/axia-network-v2/utils/units"
+	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
+	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/platformvm/message"
+)
+
+const (
+	// maxNamespacesPerStateRequest is the maximum number of namespaces asked
+	// for in a single StateRequest. Most of the dynamic namespaces are small,
+	// so requesting many of them at once saves round trips.
+	maxNamespacesPerStateRequest = 64
+
+	stateRequestTimeout = 10 * time.Second
+	maxStateSyncBackoff = time.Minute
+
+	// maxStateSyncFailedRounds is the number of consecutive rounds in which
+	// every connected peer failed to serve the summary before the summary is
+	// abandoned. Peers only serve the few most recent summaries, so the
+	// summary is likely no longer served by anyone.
+	maxStateSyncFailedRounds = 3
+
+	// stateSyncBatchSize is the number of bytes written to the database before
+	// the pending writes are flushed.
+	stateSyncBatchSize = 4 * units.MiB
+)
+
+// phases of an ongoing state sync, persisted along with its progress
+const (
+	stateSyncFetching byte = iota
+	stateSyncSwapping
+)
+
+var (
+	stateSyncPrefix = []byte("stateSync")
+
+	stateSyncSummaryKey  = []byte("summary")
+	stateSyncProgressKey = []byte("progress")
+
+	errNoStateSyncPeers  = errors.New("no peers to state sync from")
+	errStaleStateSummary = errors.New("no peer serves the state of the summary")
+	errStateSyncShutdown = errors.New("state sync shut down")
+	errStateHashMismatch = errors.New("synced state doesn't match the summary")
+)
+
+type stateSyncProgress struct {
+	phase     byte
+	namespace uint64
+	start     []byte
+}
+
+type stateResponse struct {
+	nodeID    ids.NodeID
+	requestID uint32
+	chunks    []message.StateChunk
+	failed    bool
+}
+
+// stateSyncer fetches the state committed to by a state summary from peers
+// into a staging area, verifies it against the summary and finally moves it
+// into the platform chain state.
+type stateSyncer struct {
+	vm      *VM
+	summary *stateSummary
+
+	// db is the database the internal state is built on
+	db database.Database
+	// stagingDB holds the state while it is being fetched
+	stagingDB database.Database
+
+	namespaces [][][]byte
+	progress   stateSyncProgress
+
+	lock sync.Mutex
+	// peers that are currently connected
+	peers ids.NodeIDSet
+	// ID of the outstanding request, if any
+	requestID uint32
+	// node the outstanding request was sent to
+	requestNodeID ids.NodeID
+	responses     chan stateResponse
+
+	closed chan struct{}
+	done   sync.WaitGroup
+}
+
+func newStateSyncer(vm *VM, db database.Database) *stateSyncer {
+	return &stateSyncer{
+		vm:        vm,
+		db:        db,
+		stagingDB: prefixdb.NewNested(stateSyncPrefix, db),
+		peers:     ids.NodeIDSet{},
+		responses: make(chan stateResponse, 1),
+		closed:    make(chan struct{}),
+	}
+}
+
+// getOngoingSummary returns the summary that was being synced to, if any.
+func (s *stateSyncer) getOngoingSummary() (*stateSummary, error) {
+	summaryBytes, err := s.stagingDB.Get(stateSyncSummaryKey)
+	if err != nil {
+		return nil, err // includes database.ErrNotFound
+	}
+	return s.vm.parseStateSummary(summaryBytes)
+}
+
+func (s *stateSyncer) getProgress() (stateSyncProgress, error) {
+	progressBytes, err := s.stagingDB.Get(stateSyncProgressKey)
+	if err != nil {
+		return stateSyncProgress{}, err
+	}
+
+	p := wrappers.Packer{Bytes: progressBytes}
+	progress := stateSyncProgress{
+		phase:     p.UnpackByte(),
+		namespace: p.UnpackLong(),
+		start:     p.UnpackBytes(),
+	}
+	if len(progress.start) == 0 {
+		progress.start = nil
+	}
+	return progress, p.Err
+}
+
+func (s *stateSyncer) putProgress(db database.KeyValueWriter) error {
+	p := wrappers.Packer{MaxSize: 1 + wrappers.LongLen + wrappers.IntLen + len(s.progress.start)}
+	p.PackByte(s.progress.phase)
+	p.PackLong(s.progress.namespace)
+	p.PackBytes(s.progress.start)
+	if p.Err != nil {
+		return p.Err
+	}
+	return db.Put(stateSyncProgressKey, p.Bytes)
+}
+
+// start syncing to [summary]. If [summary] was already being synced to, the
+// sync resumes from the persisted progress.
+func (s *stateSyncer) start(summary *stateSummary) error {
+	ongoing, err := s.getOngoingSummary()
+	switch {
+	case err == nil && ongoing.ID() == summary.ID():
+		progress, err := s.getProgress()
+		if err != nil {
+			return err
+		}
+		s.progress = progress
+	case err == nil || err == database.ErrNotFound:
+		// The staging area may hold the state of a different summary.
+		if err := s.clear(); err != nil {
+			return err
+		}
+		s.progress = stateSyncProgress{}
+		s.namespaces = nil
+		batch := s.stagingDB.NewBatch()
+		if err := batch.Put(stateSyncSummaryKey, summary.Bytes()); err != nil {
+			return err
+		}
+		if err := s.putProgress(batch); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	s.summary = summary
+	s.vm.ctx.Log.Info(
+		"state syncing to summary %s at height %d",
+		summary.ID(),
+		summary.Height(),
+	)
+
+	s.done.Add(1)
+	go s.run()
+	return nil
+}
+
+func (s *stateSyncer) run() {
+	defer s.done.Done()
+
+	for {
+		err := s.sync()
+		if err == nil {
+			break
+		}
+		if err == errStateSyncShutdown {
+			return
+		}
+		if err == errStaleStateSummary {
+			// The engine selects a new summary to sync to.
+			s.vm.ctx.Log.Warn(
+				"no peer serves the state of summary %s at height %d, restarting state sync",
+				s.summary.ID(),
+				s.summary.Height(),
+			)
+			if err := s.clear(); err != nil {
+				s.vm.ctx.Log.Error("failed to clear the state sync staging area: %s", err)
+				return
+			}
+			s.notify(common.StateSyncRestart)
+			return
+		}
+
+		// The staging area is reset so that a faulty peer can't leave the
+		// sync stuck on state that can never be verified.
+		s.vm.ctx.Log.Error("state sync to %s failed, restarting: %s", s.summary.ID(), err)
+		s.progress = stateSyncProgress{}
+		s.namespaces = nil
+		if err := s.putProgress(s.stagingDB); err != nil {
+			s.vm.ctx.Log.Error("failed to reset the state sync progress: %s", err)
+			return
+		}
+	}
+
+	s.vm.ctx.Log.Info("finished state syncing to height %d", s.summary.Height())
+	s.notify(common.StateSyncDone)
+}
+
+func (s *stateSyncer) notify(msg common.Message) {
+	select {
+	case s.vm.toEngine <- msg:
+	case <-s.closed:
+	}
+}
+
+// sync fetches, verifies and commits the state of the summary.
+func (s *stateSyncer) sync() error {
+	if s.progress.phase == stateSyncFetching {
+		if err := s.fetch(); err != nil {
+			return err
+		}
+
+		hash, err := stateHash(s.stagingDB, s.namespaces)
+		if err != nil {
+			return err
+		}
+		if hash != s.summary.StateHash {
+			return fmt.Errorf("%w: expected %s but got %s", errStateHashMismatch, s.summary.StateHash, hash)
+		}
+
+		s.progress = stateSyncProgress{phase: stateSyncSwapping}
+		if err := s.putProgress(s.stagingDB); err != nil {
+			return err
+		}
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	// The VM may have been shut down while the lock was being acquired. The
+	// swap is resumed from the persisted progress on the next start.
+	select {
+	case <-s.closed:
+		return errStateSyncShutdown
+	default:
+	}
+	return s.vm.finishStateSync(s)
+}
+
+// fetch the state of the summary into the staging area.
+func (s *stateSyncer) fetch() error {
+	backoff := time.Duration(0)
+	failedPeers := ids.NodeIDSet{}
+	failedRounds := 0
+	for {
+		if err := s.loadNamespaces(); err != nil {
+			return err
+		}
+		if s.progress.namespace >= uint64(len(s.namespaces)) {
+			return nil
+		}
+
+		nodeID, err := s.samplePeer(failedPeers)
+		if err == errNoStateSyncPeers {
+			// Every peer failed to serve the summary. Either no peer is
+			// connected yet, or the peers no longer retain the summary.
+			if failedPeers.Len() > 0 {
+				failedRounds++
+				if failedRounds >= maxStateSyncFailedRounds {
+					return errStaleStateSummary
+				}
+			}
+			failedPeers.Clear()
+			backoff = nextStateSyncBackoff(backoff)
+			s.vm.ctx.Log.Info("no peer could serve state at height %d, retrying in %s", s.summary.Height(), backoff)
+			select {
+			case <-time.After(backoff):
+				continue
+			case <-s.closed:
+				return errStateSyncShutdown
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		numRequested, chunks, err := s.request(nodeID)
+		if err != nil {
+			return err
+		}
+		if len(chunks) == 0 {
+			s.vm.ctx.Log.Debug("%s couldn't serve state at height %d", nodeID, s.summary.Height())
+			failedPeers.Add(nodeID)
+			continue
+		}
+		if len(chunks) > numRequested {
+			s.vm.ctx.Log.Debug("dropping unrequested state chunks from %s", nodeID)
+			failedPeers.Add(nodeID)
+			continue
+		}
+		if err := s.write(chunks); err != nil {
+			if err == errInvalidStateChunk {
+				s.vm.ctx.Log.Debug("dropping invalid state chunks from %s: %s", nodeID, err)
+				failedPeers.Add(nodeID)
+				continue
+			}
+			return err
+		}
+		backoff = 0
+		failedRounds = 0
+	}
+}
+
+// loadNamespaces populates the namespaces to sync. The dynamic namespaces can
+// only be determined once the static namespaces were fetched.
+func (s *stateSyncer) loadNamespaces() error {
+	switch {
+	case len(s.namespaces) > len(staticStateNamespaces):
+		return nil
+	case s.progress.phase == stateSyncFetching && s.progress.namespace < uint64(len(staticStateNamespaces)):
+		s.namespaces = staticStateNamespaces
+		return nil
+	}
+
+	namespaces, err := stateNamespaces(s.stagingDB, s.summary.Height())
+	if err != nil {
+		return err
+	}
+	s.namespaces = namespaces
+	return nil
+}
+
+func (s *stateSyncer) samplePeer(failedPeers ids.NodeIDSet) (ids.NodeID, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	peers := make([]ids.NodeID, 0, s.peers.Len())
+	for nodeID := range s.peers {
+		if !failedPeers.Contains(nodeID) {
+			peers = append(peers, nodeID)
+		}
+	}
+	if len(peers) == 0 {
+		return ids.EmptyNodeID, errNoStateSyncPeers
+	}
+
+	sampler := sampler.NewUniform()
+	if err := sampler.Initialize(uint64(len(peers))); err != nil {
+		return ids.EmptyNodeID, err
+	}
+	index, err := sampler.Next()
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return peers[index], nil
+}
+
+// request the next namespaces from [nodeID]. Returns the number of requested
+// namespaces and the received chunks, or no chunks if the request failed.
+func (s *stateSyncer) request(nodeID ids.NodeID) (int, []message.StateChunk, error) {
+	last := s.progress.namespace + maxNamespacesPerStateRequest
+	if numNamespaces := uint64(len(s.namespaces)); last > numNamespaces {
+		last = numNamespaces
+	}
+	namespaces := s.namespaces[s.progress.namespace:last]
+	msgBytes, err := message.Build(&message.StateRequest{
+		Height:     s.summary.Height(),
+		Namespaces: namespaces,
+		Start:      s.progress.start,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.lock.Lock()
+	s.requestID++
+	requestID := s.requestID
+	s.requestNodeID = nodeID
+	// Drop a response that arrived after its request timed out.
+	select {
+	case <-s.responses:
+	default:
+	}
+	s.lock.Unlock()
+
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	if err := s.vm.appSender.SendAppRequest(nodeIDs, requestID, msgBytes); err != nil {
+		return 0, nil, err
+	}
+
+	timeout := time.NewTimer(stateRequestTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case response := <-s.responses:
+			if response.requestID != requestID {
+				continue // late response to a previous request
+			}
+			if response.failed {
+				return len(namespaces), nil, nil
+			}
+			return len(namespaces), response.chunks, nil
+		case <-timeout.C:
+			s.lock.Lock()
+			s.requestNodeID = ids.EmptyNodeID
+			s.lock.Unlock()
+			return len(namespaces), nil, nil
+		case <-s.closed:
+			return 0, nil, errStateSyncShutdown
+		}
+	}
+}
+
+// write [chunks] into the staging area and advance the progress.
+func (s *stateSyncer) write(chunks []message.StateChunk) error {
+	for i := range chunks {
+		start := s.progress.start
+		if i > 0 {
+			start = nil
+		}
+		if err := verifyStateChunk(&chunks[i], start); err != nil {
+			return err
+		}
+	}
+
+	batch := s.stagingDB.NewBatch()
+	for _, chunk := range chunks {
+		nsDB := namespaceDB(s.stagingDB, s.namespaces[s.progress.namespace])
+		if s.progress.start == nil {
+			// The namespace may contain keys of a previous attempt.
+			if err := database.Clear(nsDB, nsDB); err != nil {
+				return err
+			}
+		}
+
+		nsBatch := nsDB.NewBatch()
+		for i, key := range chunk.Keys {
+			if err := nsBatch.Put(key, chunk.Values[i]); err != nil {
+				return err
+			}
+		}
+		if err := nsBatch.Write(); err != nil {
+			return err
+		}
+
+		if chunk.Done {
+			s.progress.namespace++
+			s.progress.start = nil
+		} else {
+			s.progress.start = successor(chunk.Keys[len(chunk.Keys)-1])
+		}
+	}
+	if err := s.putProgress(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// swap replaces the platform chain state with the state in the staging area.
+// Namespaces of the previous state that aren't part of the synced state are
+// removed.
+//
+// Invariant: [vm.ctx.Lock] is held and the internal state has no uncommitted
+// changes.
+func (s *stateSyncer) swap() error {
+	if err := s.loadNamespaces(); err != nil {
+		return err
+	}
+	staleNamespaces, err := s.staleNamespaces()
+	if err != nil {
+		return err
+	}
+
+	vdb := versiondb.New(s.db)
+	// The UTXOs are re-inserted, rather than copied, so that their address
+	// index is rebuilt.
+	utxoState := axc.NewUTXOState(prefixdb.NewNested(utxoPrefix, vdb), GenesisCodec)
+
+	pending := 0
+	flush := func(size int) error {
+		pending += size
+		if pending < stateSyncBatchSize {
+			return nil
+		}
+		pending = 0
+		utxoState.Flush()
+		return vdb.Commit()
+	}
+
+	deleteLive := func(namespace [][]byte) error {
+		isUTXOs := isNamespace(namespace, utxoStateNamespace)
+		writeDB := namespaceDB(vdb, namespace)
+
+		liveIt := namespaceDB(s.db, namespace).NewIterator()
+		defer liveIt.Release()
+		for liveIt.Next() {
+			var err error
+			if isUTXOs {
+				var utxoID ids.ID
+				utxoID, err = ids.ToID(liveIt.Key())
+				if err == nil {
+					err = utxoState.DeleteUTXO(utxoID)
+				}
+			} else {
+				err = writeDB.Delete(liveIt.Key())
+			}
+			if err == nil {
+				err = flush(len(liveIt.Key()))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return liveIt.Error()
+	}
+
+	for _, namespace := range staleNamespaces {
+		if err := deleteLive(namespace); err != nil {
+			return err
+		}
+	}
+
+	for _, namespace := range s.namespaces {
+		if err := deleteLive(namespace); err != nil {
+			return err
+		}
+
+		isUTXOs := isNamespace(namespace, utxoStateNamespace)
+		writeDB := namespaceDB(vdb, namespace)
+		stagedIt := namespaceDB(s.stagingDB, namespace).NewIterator()
+		for stagedIt.Next() {
+			key := stagedIt.Key()
+			value := stagedIt.Value()
+			if isUTXOs {
+				var utxoID ids.ID
+				utxoID, err = ids.ToID(key)
+				if err == nil {
+					utxo := &axc.UTXO{}
+					if _, err = GenesisCodec.Unmarshal(value, utxo); err == nil {
+						err = utxoState.PutUTXO(utxoID, utxo)
+					}
+				}
+			} else {
+				err = writeDB.Put(key, value)
+			}
+			if err == nil {
+				err = flush(len(key) + len(value))
+			}
+			if err != nil {
+				stagedIt.Release()
+				return err
+			}
+		}
+		err = stagedIt.Error()
+		stagedIt.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return vdb.Commit()
+}
+
+// staleNamespaces returns the namespaces of the live state that aren't part of
+// the synced state. The validator weight diffs are always removed, as the
+// synced diffs don't connect to the diffs of the live state.
+func (s *stateSyncer) staleNamespaces() ([][][]byte, error) {
+	synced := make(map[string]struct{}, len(s.namespaces))
+	for _, namespace := range s.namespaces {
+		synced[namespaceKey(namespace)] = struct{}{}
+	}
+
+	// The live namespaces are listed without any validator weight diffs.
+	liveNamespaces, err := stateNamespacesFrom(s.db, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	stale := [][][]byte{validatorDiffsNamespace}
+	for _, namespace := range liveNamespaces {
+		if _, ok := synced[namespaceKey(namespace)]; !ok {
+			stale = append(stale, namespace)
+		}
+	}
+	return stale, nil
+}
+
+// clear removes the staging area.
+func (s *stateSyncer) clear() error {
+	return database.Clear(s.stagingDB, s.stagingDB)
+}
+func (s *stateSyncer) connected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.peers.Add(nodeID)
+}
+
+func (s *stateSyncer) disconnected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.peers.Remove(nodeID)
+	if s.requestNodeID == nodeID {
+		s.deliver(stateResponse{
+			nodeID:    nodeID,
+			requestID: s.requestID,
+			failed:    true,
+		})
+	}
+}
+
+func (s *stateSyncer) handleResponse(nodeID ids.NodeID, requestID uint32, chunks []message.StateChunk, failed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if requestID != s.requestID || nodeID != s.requestNodeID {
+		return
+	}
+	s.deliver(stateResponse{
+		nodeID:    nodeID,
+		requestID: requestID,
+		chunks:    chunks,
+		failed:    failed,
+	})
+}
+
+// deliver [response] to the outstanding request without blocking.
+//
+// Invariant: [s.lock] is held.
+func (s *stateSyncer) deliver(response stateResponse) {
+	s.requestNodeID = ids.EmptyNodeID
+	select {
+	case s.responses <- response:
+	default:
+	}
+}
+
+// shutdown stops the sync and waits for it to return.
+//
+// Invariant: [vm.ctx.Lock] is held. It is released while waiting, as the sync
+// acquires it to commit the synced state.
+func (s *stateSyncer) shutdown() {
+	close(s.closed)
+
+	s.vm.ctx.Lock.Unlock()
+	s.done.Wait()
+	s.vm.ctx.Lock.Lock()
+}
+
+func nextStateSyncBackoff(backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return time.Second
+	}
+	backoff *= 2
+	if backoff > maxStateSyncBackoff {
+		return maxStateSyncBackoff
+	}
+	return backoff
+}
+
+func isNamespace(namespace, expected [][]byte) bool {
+	if len(namespace) != len(expected) {
+		return false
+	}
+	for i, prefix := range namespace {
+		if !bytes.Equal(prefix, expected[i]) {
+			return false
+		}
+	}
+	return true
+}
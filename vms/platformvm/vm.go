@@ -8,16 +8,19 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
+	stdjson "encoding/json"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sankar-boro/axia-network-v2/api/schema"
 	"github.com/sankar-boro/axia-network-v2/cache"
 	"github.com/sankar-boro/axia-network-v2/chains"
 	"github.com/sankar-boro/axia-network-v2/codec"
 	"github.com/sankar-boro/axia-network-v2/codec/linearcodec"
 	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/manager"
+	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
+	"github.com/sankar-boro/axia-network-v2/database/versiondb"
 	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/snow"
 	"github.com/sankar-boro/axia-network-v2/snow/choices"
@@ -35,11 +38,14 @@ import (
 	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
 	"github.com/sankar-boro/axia-network-v2/version"
 	"github.com/sankar-boro/axia-network-v2/vms/components/axc"
+	"github.com/sankar-boro/axia-network-v2/vms/components/index"
 	"github.com/sankar-boro/axia-network-v2/vms/platformvm/fx"
 	"github.com/sankar-boro/axia-network-v2/vms/platformvm/reward"
 	"github.com/sankar-boro/axia-network-v2/vms/secp256k1fx"
 
 	safemath "github.com/sankar-boro/axia-network-v2/utils/math"
+
+	platformpb "github.com/sankar-boro/axia-network-v2/proto/pb/platform"
 )
 
 const (
@@ -63,12 +69,29 @@ var (
 	errStartAfterEndTime = errors.New("start time is after the end time")
 	errWrongCacheType    = errors.New("unexpectedly cached type")
 
-	_ block.ChainVM        = &VM{}
-	_ validators.Connector = &VM{}
-	_ secp256k1fx.VM       = &VM{}
-	_ validators.State     = &VM{}
+	_ block.ChainVM              = &VM{}
+	_ block.StateSyncableVM      = &VM{}
+	_ block.HeightIndexedChainVM = &VM{}
+	_ block.BatchedChainVM       = &VM{}
+	_ validators.Connector       = &VM{}
+	_ secp256k1fx.VM             = &VM{}
+	_ validators.State           = &VM{}
+	_ common.GRPCServiceCreator  = &VM{}
 )
 
+// ChainConfig is the platform chain configuration provided in the chain
+// config file.
+type ChainConfig struct {
+	// StateSyncEnabled makes the node sync the platform chain state from its
+	// peers, rather than executing every block, when it is far behind.
+	StateSyncEnabled bool `json:"state-sync-enabled"`
+	// IndexTransactions makes the node index accepted txs by the addresses
+	// whose balances they change. It can't be combined with state sync, as
+	// the txs of the synced blocks are never executed.
+	IndexTransactions    bool `json:"index-transactions"`
+	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
+}
+
 type VM struct {
 	Factory
 	metrics
@@ -91,12 +114,23 @@ type VM struct {
 
 	internalState InternalState
 
+	addressTxsDB      *versiondb.Database
+	addressTxsIndexer index.AddressTxsIndexer
+
 	// ID of the preferred block
 	preferred ids.ID
 
 	// ID of the last accepted block
 	lastAcceptedID ids.ID
 
+	chainConfig ChainConfig
+	toEngine    chan<- common.Message
+
+	// Fetches the state of a state summary from peers
+	stateSyncer *stateSyncer
+	// Builds the state summaries served to peers
+	summaryBuilder *stateSummaryBuilder
+
 	fx            fx.Fx
 	codecRegistry codec.Registry
 
@@ -130,6 +164,16 @@ func (vm *VM) Initialize(
 ) error {
 	ctx.Log.Verbo("initializing platform chain")
 
+	if len(configBytes) > 0 {
+		if err := stdjson.Unmarshal(configBytes, &vm.chainConfig); err != nil {
+			return err
+		}
+		ctx.Log.Info("VM config initialized %+v", vm.chainConfig)
+	}
+	if vm.chainConfig.IndexTransactions && vm.chainConfig.StateSyncEnabled {
+		return errIndexingWithStateSync
+	}
+
 	registerer := prometheus.NewRegistry()
 	if err := ctx.Metrics.Register(registerer); err != nil {
 		return err
@@ -150,6 +194,9 @@ func (vm *VM) Initialize(
 
 	vm.ctx = ctx
 	vm.dbManager = dbManager
+	vm.toEngine = toEngine
+	vm.stateSyncer = newStateSyncer(vm, dbManager.Current().Database)
+	vm.summaryBuilder = newStateSummaryBuilder(vm, dbManager.Current().Database)
 
 	vm.codecRegistry = linearcodec.NewDefault()
 	if err := vm.fx.Initialize(vm); err != nil {
@@ -174,6 +221,34 @@ func (vm *VM) Initialize(
 	}
 	vm.internalState = is
 
+	// A state sync that was interrupted while the synced state was being moved
+	// into place must be completed before the state can be used.
+	if err := vm.resumeStateSyncSwap(); err != nil {
+		return fmt.Errorf(
+			"failed to complete the state sync: %w",
+			err,
+		)
+	}
+
+	vm.addressTxsDB = versiondb.New(prefixdb.New(addressTxsPrefix, vm.dbManager.Current().Database))
+	// use no op impl when disabled in config
+	if vm.chainConfig.IndexTransactions {
+		ctx.Log.Info("address transaction indexing is enabled")
+		vm.addressTxsIndexer, err = index.NewIndexer(vm.addressTxsDB, ctx.Log, "", registerer, vm.chainConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
+		}
+	} else {
+		ctx.Log.Info("address transaction indexing is disabled")
+		vm.addressTxsIndexer, err = index.NewNoIndexer(vm.addressTxsDB, vm.chainConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
+		}
+	}
+	if err := vm.addressTxsDB.Commit(); err != nil {
+		return err
+	}
+
 	// Initialize the utility to track validator uptimes
 	vm.uptimeManager = uptime.NewManager(is)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &ctx.Lock, vm.uptimeManager)
@@ -326,6 +401,8 @@ func (vm *VM) Shutdown() error {
 	}
 
 	vm.blockBuilder.Shutdown()
+	vm.stateSyncer.shutdown()
+	vm.summaryBuilder.shutdown()
 
 	if vm.bootstrapped.GetValue() {
 		primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
@@ -414,7 +491,7 @@ func (vm *VM) Version() (string, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := schema.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.apiRequestMetrics.InterceptRequest)
@@ -430,11 +507,23 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	}, nil
 }
 
+// CreateGRPCServices returns the gRPC services that mirror the handlers
+// returned by CreateHandlers
+func (vm *VM) CreateGRPCServices() ([]*common.GRPCService, error) {
+	return []*common.GRPCService{
+		{
+			Name: "core",
+			Desc: &platformpb.Platform_ServiceDesc,
+			Impl: &grpcServer{service: &Service{vm: vm}},
+		},
+	}, nil
+}
+
 // CreateStaticHandlers returns a map where:
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := schema.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(&StaticService{}, "core"); err != nil {
@@ -450,10 +539,12 @@ func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
 }
 
 func (vm *VM) Connected(vdrID ids.NodeID, _ version.Application) error {
+	vm.stateSyncer.connected(vdrID)
 	return vm.uptimeManager.Connect(vdrID)
 }
 
 func (vm *VM) Disconnected(vdrID ids.NodeID) error {
+	vm.stateSyncer.disconnected(vdrID)
 	if err := vm.uptimeManager.Disconnect(vdrID); err != nil {
 		return err
 	}
@@ -488,6 +579,12 @@ func (vm *VM) GetValidatorSet(height uint64, allychainID ids.ID) (map[ids.NodeID
 	if lastAcceptedHeight < height {
 		return nil, database.ErrNotFound
 	}
+	// A state synced node only has the validator weight diffs of the heights
+	// right before the state summary.
+	if stateSyncHeight := vm.internalState.GetStateSyncHeight(); stateSyncHeight > validatorDiffsSyncWindow &&
+		height < stateSyncHeight-validatorDiffsSyncWindow {
+		return nil, database.ErrNotFound
+	}
 
 	// get the start time to track metrics
 	startTime := vm.Clock().Time()
@@ -35,6 +35,7 @@ import (
 	"github.com/sankar-boro/axia-network-v2/snow/networking/timeout"
 	"github.com/sankar-boro/axia-network-v2/snow/uptime"
 	"github.com/sankar-boro/axia-network-v2/snow/validators"
+	"github.com/sankar-boro/axia-network-v2/utils/compression"
 	"github.com/sankar-boro/axia-network-v2/utils/constants"
 	"github.com/sankar-boro/axia-network-v2/utils/crypto"
 	"github.com/sankar-boro/axia-network-v2/utils/formatting"
@@ -2033,7 +2034,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 
 	chainRouter := &router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, compression.TypeGzip, "dummyNamespace", 10*time.Second)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, timeoutManager, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
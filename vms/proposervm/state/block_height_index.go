@@ -11,8 +11,8 @@ import (
 	"github.com/sankar-boro/axia-network-v2/database/prefixdb"
 	"github.com/sankar-boro/axia-network-v2/database/versiondb"
 	"github.com/sankar-boro/axia-network-v2/ids"
+	"github.com/sankar-boro/axia-network-v2/utils"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
-	"github.com/sankar-boro/axia-network-v2/utils/wrappers"
 )
 
 const (
@@ -114,68 +114,51 @@ func (hi *heightIndex) SetIndexHasReset() error {
 }
 
 func (hi *heightIndex) ResetHeightIndex(log logging.Logger, baseDB versiondb.Commitable) error {
-	var (
-		itHeight   = hi.heightDB.NewIterator()
-		itMetadata = hi.metadataDB.NewIterator()
-	)
-	defer func() {
-		itHeight.Release()
-		itMetadata.Release()
-	}()
-
 	// clear height cache
 	hi.heightsCache.Flush()
 
-	// clear heightDB
-	deleteCount := 0
-	processingStart := time.Now()
-	for itHeight.Next() {
-		if err := hi.heightDB.Delete(itHeight.Key()); err != nil {
+	// clear heightDB with range deletions of at most deleteBatchSize entries,
+	// committing after each of them so that databases that don't support range
+	// tombstones don't have to delete the whole index at once
+	var (
+		start           []byte
+		deleteCount     int
+		processingStart = time.Now()
+	)
+	for {
+		limit, numKeys, err := hi.nextDeleteLimit(start)
+		if err != nil {
 			return err
 		}
-
-		deleteCount++
-		if deleteCount%deleteBatchSize == 0 {
-			if err := hi.Commit(); err != nil {
-				return err
-			}
-			if err := baseDB.Commit(); err != nil {
-				return err
-			}
-
-			log.Info("Deleted %d height entries", deleteCount)
-
-			// every deleteBatchSize ops, sleep to avoid clogging the node on this
-			processingDuration := time.Since(processingStart)
-			// Sleep [sleepDurationMultiplier]x (5x) the amount of time we spend processing the block
-			// to ensure the indexing does not bottleneck the node.
-			time.Sleep(processingDuration * sleepDurationMultiplier)
-			processingStart = time.Now()
-
-			if err := itHeight.Error(); err != nil {
-				return err
-			}
-
-			// release iterator so underlying db does not hold on to the previous state
-			itHeight.Release()
-			itHeight = hi.heightDB.NewIterator()
+		if err := hi.heightDB.DeleteRange(start, limit); err != nil {
+			return err
 		}
-	}
-
-	// clear metadataDB
-	for itMetadata.Next() {
-		if err := hi.metadataDB.Delete(itMetadata.Key()); err != nil {
+		if err := hi.Commit(); err != nil {
+			return err
+		}
+		if err := baseDB.Commit(); err != nil {
 			return err
 		}
+		deleteCount += numKeys
+		if limit == nil {
+			break
+		}
+
+		log.Info("Deleted %d height entries", deleteCount)
+
+		// every deleteBatchSize ops, sleep to avoid clogging the node on this
+		processingDuration := time.Since(processingStart)
+		// Sleep [sleepDurationMultiplier]x (5x) the amount of time we spend processing the block
+		// to ensure the indexing does not bottleneck the node.
+		time.Sleep(processingDuration * sleepDurationMultiplier)
+		processingStart = time.Now()
+
+		start = limit
 	}
 
-	errs := wrappers.Errs{}
-	errs.Add(
-		itHeight.Error(),
-		itMetadata.Error(),
-	)
-	if errs.Errored() {
-		return errs.Err
+	// clear metadataDB
+	if err := hi.metadataDB.DeleteRange(nil, nil); err != nil {
+		return err
 	}
 
 	if err := hi.SetIndexHasReset(); err != nil {
@@ -188,6 +171,23 @@ func (hi *heightIndex) ResetHeightIndex(log logging.Logger, baseDB versiondb.Com
 	return baseDB.Commit()
 }
 
+// nextDeleteLimit returns the key that follows the first deleteBatchSize
+// height entries at or after [start], or nil if there are fewer entries, along
+// with the number of entries before it.
+func (hi *heightIndex) nextDeleteLimit(start []byte) ([]byte, int, error) {
+	it := hi.heightDB.NewIteratorWithStart(start)
+	defer it.Release()
+
+	numKeys := 0
+	for it.Next() {
+		if numKeys == deleteBatchSize {
+			return utils.CopyBytes(it.Key()), numKeys, nil
+		}
+		numKeys++
+	}
+	return nil, numKeys, it.Error()
+}
+
 func (hi *heightIndex) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
 	if blkIDIntf, found := hi.heightsCache.Get(height); found {
 		res, _ := blkIDIntf.(ids.ID)
@@ -8,8 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/sankar-boro/axia-network-v2/database"
 	"github.com/sankar-boro/axia-network-v2/database/memdb"
 	"github.com/sankar-boro/axia-network-v2/database/versiondb"
+	"github.com/sankar-boro/axia-network-v2/ids"
 	"github.com/sankar-boro/axia-network-v2/utils/logging"
 )
 
@@ -22,9 +24,47 @@ func TestHasIndexReset(t *testing.T) {
 	wasReset, err := s.HasIndexReset()
 	a.NoError(err)
 	a.False(wasReset)
+	for height := uint64(0); height < 10; height++ {
+		a.NoError(s.SetBlockIDAtHeight(height, ids.GenerateTestID()))
+	}
+	a.NoError(s.SetForkHeight(5))
+	a.NoError(vdb.Commit())
 	err = s.ResetHeightIndex(logging.NoLog{}, vdb)
 	a.NoError(err)
 	wasReset, err = s.HasIndexReset()
 	a.NoError(err)
 	a.True(wasReset)
+	_, err = s.GetBlockIDAtHeight(3)
+	a.Equal(database.ErrNotFound, err)
+	_, err = s.GetForkHeight()
+	a.Equal(database.ErrNotFound, err)
+}
+
+func TestResetHeightIndexInBatches(t *testing.T) {
+	a := assert.New(t)
+
+	db := memdb.New()
+	vdb := versiondb.New(db)
+	s := New(vdb)
+	numHeights := uint64(2*deleteBatchSize + 1)
+	for height := uint64(0); height < numHeights; height++ {
+		a.NoError(s.SetBlockIDAtHeight(height, ids.GenerateTestID()))
+	}
+	a.NoError(s.SetCheckpoint(ids.GenerateTestID()))
+	a.NoError(vdb.Commit())
+
+	a.NoError(s.ResetHeightIndex(logging.NoLog{}, vdb))
+	for _, height := range []uint64{0, deleteBatchSize - 1, deleteBatchSize, numHeights - 1} {
+		_, err := s.GetBlockIDAtHeight(height)
+		a.Equal(database.ErrNotFound, err)
+	}
+	_, err := s.GetCheckpoint()
+	a.Equal(database.ErrNotFound, err)
+
+	// Only the reset marker is left in the underlying database.
+	it := db.NewIterator()
+	defer it.Release()
+	a.True(it.Next())
+	a.False(it.Next())
+	a.NoError(it.Error())
 }
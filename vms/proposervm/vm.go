@@ -43,6 +43,8 @@ var (
 	_ block.HeightIndexedChainVM = &VM{}
 	_ block.StateSyncableVM      = &VM{}
 
+	_ common.GRPCServiceCreator = &VM{}
+
 	dbPrefix = []byte("proposervm")
 )
 
@@ -263,6 +265,14 @@ func (vm *VM) LastAccepted() (ids.ID, error) {
 	return lastAccepted, err
 }
 
+func (vm *VM) CreateGRPCServices() ([]*common.GRPCService, error) {
+	creator, ok := vm.ChainVM.(common.GRPCServiceCreator)
+	if !ok {
+		return nil, nil
+	}
+	return creator.CreateGRPCServices()
+}
+
 func (vm *VM) repair(indexerState state.State) error {
 	// check and possibly rebuild height index
 	if vm.hVM == nil {
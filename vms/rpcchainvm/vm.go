@@ -18,7 +18,7 @@ import (
 
 // protocolVersion should be bumped anytime changes are made which require
 // the plugin vm to upgrade to latest axia release to be compatible.
-const protocolVersion = 15
+const protocolVersion = 16
 
 var (
 	// Handshake is a common handshake that is shared by plugin and host.